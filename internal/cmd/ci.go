@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// ciEnvironments maps environment variables set by CI systems to their names,
+// generic CI variable goes last so specific systems are reported by name
+var ciEnvironments = []struct {
+	env  string
+	name string
+}{
+	{"GITHUB_ACTIONS", "GitHub Actions"},
+	{"GITLAB_CI", "GitLab CI"},
+	{"BITBUCKET_BUILD_NUMBER", "Bitbucket Pipelines"},
+	{"TF_BUILD", "Azure Pipelines"},
+	{"CODEBUILD_BUILD_ID", "AWS CodeBuild"},
+	{"CIRCLECI", "CircleCI"},
+	{"BUILDKITE", "Buildkite"},
+	{"JENKINS_URL", "Jenkins"},
+	{"TEAMCITY_VERSION", "TeamCity"},
+	{"TRAVIS", "Travis CI"},
+	{"DRONE", "Drone"},
+	{"CI", "CI"},
+}
+
+// detectCI returns name of CI system the tool runs in, empty outside of CI
+func detectCI() string {
+	for _, ci := range ciEnvironments {
+		if value := os.Getenv(ci.env); value != "" && value != "false" && value != "0" {
+			return ci.name
+		}
+	}
+	return ""
+}
+
+// isCI reports whether CI mode is on, --ci flag overrides detection in both directions
+func isCI() bool {
+	if viper.IsSet("ci") {
+		return viper.GetBool("ci")
+	}
+	return detectCI() != ""
+}
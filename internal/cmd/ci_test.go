@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// clearCIEnv hides CI environment the tests themselves may run in
+func clearCIEnv(t *testing.T) {
+	t.Helper()
+	for _, ci := range ciEnvironments {
+		t.Setenv(ci.env, "")
+	}
+}
+
+// bindTestFlags binds commit flags parsed from args, like bindSettings does without config files
+func bindTestFlags(t *testing.T, args ...string) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	cmd := &cobra.Command{Use: "commit"}
+	bindCommitFlags(cmd)
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatalf("failed to parse flags %v: %v", args, err)
+	}
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		t.Fatalf("failed to bind flags: %v", err)
+	}
+}
+
+func TestDetectCI(t *testing.T) {
+	for _, ci := range ciEnvironments {
+		t.Run(ci.env, func(t *testing.T) {
+			clearCIEnv(t)
+			t.Setenv(ci.env, "true")
+			if got := detectCI(); got != ci.name {
+				t.Errorf("detectCI() = %q, want %q", got, ci.name)
+			}
+		})
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{name: "no ci", want: ""},
+		{name: "disabled by false", env: map[string]string{"CI": "false"}, want: ""},
+		{name: "disabled by zero", env: map[string]string{"CI": "0"}, want: ""},
+		{name: "specific system wins", env: map[string]string{"CI": "true", "GITLAB_CI": "true"}, want: "GitLab CI"},
+		{name: "jenkins url", env: map[string]string{"JENKINS_URL": "https://ci.example.com/"}, want: "Jenkins"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearCIEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if got := detectCI(); got != tt.want {
+				t.Errorf("detectCI() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSettings_CI(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		args      []string
+		wantCI    bool
+		wantYes   bool
+		wantFirst bool
+		wantAuto  bool
+	}{
+		{
+			name: "outside of ci",
+		},
+		{
+			name:      "detected ci",
+			env:       map[string]string{"GITHUB_ACTIONS": "true"},
+			wantCI:    true,
+			wantYes:   true,
+			wantFirst: true,
+		},
+		{
+			name:      "ci flag outside of ci",
+			args:      []string{"--ci"},
+			wantCI:    true,
+			wantYes:   true,
+			wantFirst: true,
+		},
+		{
+			name: "ci disabled by flag",
+			env:  map[string]string{"GITHUB_ACTIONS": "true"},
+			args: []string{"--ci=false"},
+		},
+		{
+			name:      "explicit auto keeps its confirmation",
+			env:       map[string]string{"CI": "true"},
+			args:      []string{"--auto"},
+			wantCI:    true,
+			wantFirst: true,
+			wantAuto:  true,
+		},
+		{
+			name:    "explicit first is kept",
+			env:     map[string]string{"CI": "true"},
+			args:    []string{"--first=false"},
+			wantCI:  true,
+			wantYes: true,
+		},
+		{
+			name:    "explicit yes outside of ci",
+			args:    []string{"--yes"},
+			wantYes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearCIEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			bindTestFlags(t, tt.args...)
+
+			if got := isCI(); got != tt.wantCI {
+				t.Errorf("isCI() = %v, want %v", got, tt.wantCI)
+			}
+			settings := newSettings()
+			if settings.Yes != tt.wantYes || settings.First != tt.wantFirst || settings.Auto != tt.wantAuto {
+				t.Errorf(
+					"newSettings() yes = %v, first = %v, auto = %v, want %v, %v, %v",
+					settings.Yes, settings.First, settings.Auto, tt.wantYes, tt.wantFirst, tt.wantAuto,
+				)
+			}
+		})
+	}
+}
@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -16,13 +19,21 @@ import (
 
 	"github.com/hasansino/commit/internal/cmdutil"
 	"github.com/hasansino/commit/pkg/commit"
+	"github.com/hasansino/commit/pkg/commit/modules"
+	"github.com/hasansino/commit/pkg/commit/ui"
 )
 
 const envPrefix = "COMMIT"
 
+// Exit codes are a contract for scripts and hooks wrapping the tool
 const (
-	exitOK    = 0
-	exitError = 1
+	exitOK              = 0
+	exitError           = 1
+	exitNothingToCommit = 2
+	exitCanceled        = 3
+	exitProviderFailure = 4
+	exitGitFailure      = 5
+	exitPushFailure     = 6
 )
 
 func NewCommitCommand(ctx context.Context, f *cmdutil.Factory) *cobra.Command {
@@ -31,31 +42,19 @@ func NewCommitCommand(ctx context.Context, f *cmdutil.Factory) *cobra.Command {
 		Short: "Commit helper tool",
 		Long:  `Commit helper tool`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return viper.BindPFlags(cmd.Flags())
+			return bindSettings(cmd)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			settings := &commit.Settings{
-				Providers:          viper.GetStringSlice("providers"),
-				Timeout:            viper.GetDuration("timeout"),
-				CustomPrompt:       viper.GetString("prompt"),
-				First:              viper.GetBool("first"),
-				Auto:               viper.GetBool("auto"),
-				DryRun:             viper.GetBool("dry-run"),
-				ExcludePatterns:    viper.GetStringSlice("exclude"),
-				IncludePatterns:    viper.GetStringSlice("include-only"),
-				MultiLine:          viper.GetBool("multi-line"),
-				Push:               viper.GetBool("push"),
-				Tag:                viper.GetString("tag"),
-				UseGlobalGitignore: viper.GetBool("use-global-gitignore"),
-				MaxDiffSizeBytes:   viper.GetInt("max-diff-size-bytes"),
-				JiraTaskPosition:   viper.GetString("jira-task-position"),
-				JiraTaskStyle:      viper.GetString("jira-task-style"),
+			if helpAll, _ := cmd.Flags().GetBool("help-all"); helpAll {
+				return printHelpTree(cmd.OutOrStdout(), cmd)
 			}
+			settings := newSettings()
 			initLogging(f.Options().LogLevel)
 			return runCommitCommand(f, settings)
 		},
-		SilenceUsage:  true,
-		SilenceErrors: false,
+		SilenceUsage: true,
+		// errors are printed by Execute, expected outcomes are only reported by exit code
+		SilenceErrors: true,
 		CompletionOptions: cobra.CompletionOptions{
 			DisableDefaultCmd: true,
 			HiddenDefaultCmd:  true,
@@ -72,42 +71,27 @@ func NewCommitCommand(ctx context.Context, f *cmdutil.Factory) *cobra.Command {
 	viper.AutomaticEnv()
 
 	f.BindFlags(cmd.PersistentFlags())
+	cmd.PersistentFlags().String("config", "",
+		"Configuration file used instead of repository .commit.yaml, merged over ~/.config/commit/config.yaml.")
 
-	flags := cmd.Flags()
+	bindCommitFlags(cmd)
 
-	flags.StringSlice("providers", []string{},
-		"Providers to use, leave empty for all (claude|openai|gemini).")
-	flags.Duration("timeout", 5*time.Second,
-		"API timeout.")
-	flags.String("prompt", "",
-		"Custom prompt template.")
-	flags.Bool("first", false,
-		"Use first received message and discard others.")
-	flags.Bool("auto", false,
-		"Auto-commit with first and fastest response from provider.")
-	flags.Bool("dry-run", false,
-		"Show what would be committed without committing.")
-	flags.StringSlice("exclude", nil,
-		"Exclude patterns, when staging changes.")
-	flags.StringSlice("include-only", nil,
-		"Only include specific patterns, when staging changes.")
-	flags.Bool("multi-line", false,
-		"Use multi-line commit messages.")
-	flags.Bool("push", false,
-		"Push after committing.")
-	flags.String("tag", "",
-		"Create and increment semver tag part (major|minor|patch).")
-	flags.Bool("use-global-gitignore", true,
-		"Use global gitignore.")
-	flags.Int("max-diff-size-bytes", 64*1024,
-		"Maximum diff size in bytes to include in prompts.")
-	flags.String("jira-task-position", "none",
-		"Jira task position in commit message: prefix, infix, suffix, or none.")
-	flags.String(
-		"jira-task-style", "none", "Jira task style: brackets, parens , plain-colon, or plain.",
-	)
+	cmd.Flags().Bool("help-all", false,
+		"Print long help of all commands.")
 
 	cmd.AddCommand(newVersionCommand())
+	cmd.AddCommand(newManCommand())
+	cmd.AddCommand(newSplitCommand(f))
+	cmd.AddCommand(newRewordCommand(f))
+	cmd.AddCommand(newHistoryCommand(f))
+	cmd.AddCommand(newInitCommand(f))
+	cmd.AddCommand(newPrepareCommitMsgCommand(f))
+	cmd.AddCommand(newPRDescribeCommand(f))
+	cmd.AddCommand(newReviewCommand(f))
+	cmd.AddCommand(newExplainCommand(f))
+	cmd.AddCommand(newUndoCommand(f))
+	cmd.AddCommand(newDaemonCommand(f))
+	cmd.AddCommand(newRPCCommand(f))
 
 	return cmd
 }
@@ -119,20 +103,58 @@ func Execute() int {
 	factory := cmdutil.NewFactory(ctx)
 	cmd := NewCommitCommand(ctx, factory)
 
-	var execErr error
-	cmd, execErr = cmd.ExecuteContextC(ctx)
+	executed, err := cmd.ExecuteContextC(ctx)
+	if err == nil {
+		return exitOK
+	}
 
-	if execErr != nil {
-		if cmd != nil && cmd.SilenceErrors {
-			return exitOK
+	code := exitCode(err)
+	if code != exitNothingToCommit && code != exitCanceled {
+		if executed == nil {
+			executed = cmd
 		}
-		return exitError
+		executed.PrintErrln(executed.ErrPrefix(), err.Error())
 	}
 
-	return exitOK
+	return code
+}
+
+// exitCode maps outcome of a run to exit code
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, commit.ErrNothingToCommit):
+		return exitNothingToCommit
+	case errors.Is(err, commit.ErrCanceled):
+		return exitCanceled
+	case errors.Is(err, commit.ErrPush):
+		return exitPushFailure
+	case errors.Is(err, commit.ErrGit):
+		return exitGitFailure
+	case errors.Is(err, commit.ErrProvider):
+		return exitProviderFailure
+	default:
+		return exitError
+	}
 }
 
+// initLogging sets default logger, --quiet and --verbose override log level:
+// quiet leaves errors only, verbose enables debug logs, git commands are traced by settings
 func initLogging(level string) {
+	initLoggingTo(os.Stdout, level)
+}
+
+// initLoggingTo is initLogging with logs written to w, commands speaking a protocol
+// over stdout log to stderr
+func initLoggingTo(w io.Writer, level string) {
+	switch {
+	case viper.GetBool("quiet"):
+		level = "error"
+	case viper.GetBool("verbose"):
+		level = "debug"
+	}
+
 	var slogLevel slog.Level
 	switch level {
 	case "debug":
@@ -151,9 +173,15 @@ func initLogging(level string) {
 		AddSource:  false,
 		Level:      slogLevel,
 		TimeFormat: time.TimeOnly,
+		NoColor:    isAccessible(),
 	}
 
-	logger := slog.New(tint.NewHandler(os.Stdout, loggerOpts))
+	logger := slog.New(tint.NewHandler(w, loggerOpts))
+
+	// CI logs are read by machines as often as by people, logfmt suits both
+	if isCI() {
+		logger = slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slogLevel}))
+	}
 
 	// Any call to log.* will be redirected to slog.Error.
 	// Because of that, we need to agree to use `log` package only for errors.
@@ -161,6 +189,366 @@ func initLogging(level string) {
 
 	// for both 'log' and 'slog'
 	slog.SetDefault(logger)
+
+	if name := detectCI(); name != "" && isCI() {
+		slog.Debug("CI environment detected, interactive mode disabled", "ci", name)
+	}
+}
+
+// isAccessible reports whether accessible mode is requested by flag or environment
+func isAccessible() bool {
+	return viper.GetBool("accessible") || ui.DetectAccessible()
+}
+
+// newSettings builds commit settings from flags, environment and defaults
+func newSettings() *commit.Settings {
+	settings := &commit.Settings{
+		Providers:          viper.GetStringSlice("providers"),
+		Timeout:            viper.GetDuration("timeout"),
+		MaxConcurrent:      viper.GetInt("max-concurrent-providers"),
+		Models:             getPairs("models"),
+		CustomPrompt:       viper.GetString("prompt"),
+		First:              viper.GetBool("first"),
+		Auto:               viper.GetBool("auto"),
+		DryRun:             viper.GetBool("dry-run"),
+		Copy:               viper.GetBool("copy"),
+		History:            viper.GetBool("history"),
+		SuggestionCache:    viper.GetBool("suggestion-cache"),
+		Accessible:         isAccessible(),
+		RememberUI:         viper.GetBool("remember-ui"),
+		CostThreshold:      viper.GetFloat64("cost-threshold"),
+		ExcludePatterns:    viper.GetStringSlice("exclude"),
+		IncludePatterns:    viper.GetStringSlice("include-only"),
+		MultiLine:          viper.GetBool("multi-line"),
+		Push:               viper.GetBool("push"),
+		PushRemote:         viper.GetString("push-remote"),
+		ForceWithLease:     viper.GetBool("force-with-lease"),
+		SetUpstream:        viper.GetBool("set-upstream"),
+		PullRebase:         viper.GetBool("pull-rebase-before-push"),
+		RollbackCommit:     viper.GetBool("rollback-commit"),
+		CreatePR:           viper.GetBool("create-pr") || viper.GetBool("create-mr"),
+		PRBase:             viper.GetString("pr-base"),
+		PRDraft:            viper.GetBool("pr-draft"),
+		PRLabels:           viper.GetStringSlice("pr-labels"),
+		MRSquash:           viper.GetBool("mr-squash"),
+		MRRemoveSource:     viper.GetBool("mr-remove-source-branch"),
+		PlatformHosts:      getPairs("platform-hosts"),
+		Tag:                viper.GetString("tag"),
+		UseGlobalGitignore: viper.GetBool("use-global-gitignore"),
+		MaxDiffSizeBytes:   viper.GetInt("max-diff-size-bytes"),
+		NewFileHeadLines:   viper.GetInt("new-file-head-lines"),
+		MapReduce:          viper.GetBool("map-reduce"),
+		SummaryModels:      getPairs("summary-models"),
+		JiraTaskPosition:   viper.GetString("jira-task-position"),
+		JiraTaskStyle:      viper.GetString("jira-task-style"),
+		JiraEnrich:         viper.GetBool("jira-enrich"),
+		JiraValidate:       viper.GetString("jira-validate"),
+		JiraComment:        viper.GetBool("jira-comment"),
+		JiraTransition:     viper.GetString("jira-transition"),
+		ChatWebhooks:       getList("chat-webhook"),
+		ChatNotifyOn:       viper.GetString("chat-notify-on"),
+		Webhooks:           getList("webhook"),
+		WebhookEvents:      getList("webhook-events"),
+		WebhookSecret:      viper.GetString("webhook-secret"),
+		AzureWorkItem:      viper.GetString("azure-work-item"),
+		TicketPattern:      viper.GetString("ticket-pattern"),
+		TicketFormat:       viper.GetString("ticket-format"),
+		TicketPosition:     viper.GetString("ticket-position"),
+		InferScope:         viper.GetString("infer-scope"),
+		DepsMessage:        viper.GetBool("deps-message"),
+		DetectBreaking:     viper.GetBool("detect-breaking"),
+		CoAuthors:          getList("co-authors"),
+		Pairing:            viper.GetBool("pairing"),
+		SignOff:            viper.GetBool("signoff"),
+		Trailers:           getList("trailers"),
+		CloseIssues:        viper.GetString("close-issues"),
+		ExecModules:        getList("exec-modules"),
+		PluginDir:          pluginDir(),
+		MessageTemplate:    viper.GetString("message-template"),
+		TranslateTo:        viper.GetString("translate-to"),
+		TranslateMode:      viper.GetString("translate-mode"),
+		ASCIIOnly:          viper.GetBool("ascii-only"),
+		BannedWords:        getList("banned-words"),
+		BannedWordsAction:  viper.GetString("banned-words-action"),
+		SubjectCase:        viper.GetString("subject-case"),
+		StripPeriod:        viper.GetBool("strip-period"),
+		Imperative:         viper.GetString("imperative"),
+		SubjectLimit:       viper.GetInt("subject-limit"),
+		SubjectOverflow:    viper.GetString("subject-overflow"),
+		BodyWidth:          viper.GetInt("body-width"),
+		NoVerify:           viper.GetBool("no-verify"),
+		SubmoduleLog:       viper.GetBool("submodule-log"),
+		ScopeDir:           viper.GetString("scope-dir"),
+		ReleaseNotes:       viper.GetBool("release-notes"),
+		TagPrefix:          viper.GetString("tag-prefix"),
+		TagPattern:         viper.GetString("tag-pattern"),
+		Author:             viper.GetString("author"),
+		Date:               viper.GetString("date"),
+		AllowEmpty:         viper.GetBool("allow-empty"),
+		RecentCommits:      viper.GetInt("recent-commits"),
+		BranchDiff:         viper.GetBool("branch-diff"),
+		BranchBase:         viper.GetString("branch-base"),
+		StashUnrelated:     viper.GetBool("stash-unrelated"),
+		Deepen:             viper.GetBool("deepen"),
+		LargeBinaryBytes:   viper.GetInt64("large-binary-threshold"),
+		AbortOnLargeBinary: viper.GetBool("abort-on-large-binary"),
+		RepoPath:           viper.GetString("repo"),
+		DiffAlgorithm:      viper.GetString("diff-algorithm"),
+		RenameThreshold:    viper.GetInt("find-renames"),
+		NoFunctionContext:  !viper.GetBool("function-context"),
+		CompressDiff:       viper.GetBool("compress-diff"),
+		PureGo:             viper.GetBool("pure-go"),
+		TraceGit:           viper.GetBool("verbose"),
+		ConflictMarkers:    viper.GetString("conflict-markers"),
+		Notes:              viper.GetBool("notes"),
+		Message:            viper.GetString("message"),
+		DiffFile:           viper.GetString("diff-file"),
+		OutputFile:         viper.GetString("output-file"),
+		PrintOnly:          viper.GetBool("print-only"),
+		Yes:                viper.GetBool("yes"),
+		Quiet:              viper.GetBool("quiet"),
+	}
+
+	// zero threshold of settings means default one, the flag disables rename detection with it
+	if settings.RenameThreshold == 0 {
+		settings.RenameThreshold = -1
+	}
+
+	// there is nobody to answer prompts in CI, bubbletea cannot render there either
+	if isCI() {
+		if !settings.Auto {
+			settings.Yes = true
+		}
+		if !viper.IsSet("first") {
+			settings.First = true
+		}
+	}
+
+	return settings
+}
+
+// bindCommitFlags defines flags shared by commands which create commits
+func bindCommitFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	flags.StringSlice("providers", []string{},
+		"Providers to use, leave empty for all (claude|openai|gemini).")
+	flags.Duration("timeout", 5*time.Second,
+		"API timeout.")
+	flags.Int("max-concurrent-providers", 0,
+		"Maximum provider requests in flight at once, e.g. 1 for proxies or local servers, 0 is unlimited.")
+	flags.String("models", "",
+		"Comma-separated provider=model pairs overriding *_MODEL env, e.g. openai=gpt-4.1-mini.")
+	flags.String("prompt", "",
+		"Custom prompt template.")
+	flags.Bool("first", false,
+		"Use first received message and discard others.")
+	flags.Bool("auto", false,
+		"Auto-commit with first and fastest response from provider.")
+	flags.Bool("dry-run", false,
+		"Show what would be committed without committing.")
+	flags.BoolP("quiet", "q", false,
+		"Print only final message or commit hash, log errors only.")
+	flags.Bool("verbose", false,
+		"Log debug output including git commands and provider metadata.")
+	cmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	flags.BoolP("yes", "y", false,
+		"Pick top-ranked suggestion and proceed without interactive mode, ranked by --providers order and latency.")
+	flags.StringP("message", "m", "",
+		"Commit this message instead of generating one, modules, trailers and commit flow still apply.")
+	flags.Bool("copy", false,
+		"Copy message to clipboard instead of committing.")
+	flags.String("output-file", "",
+		"Write final message to this file instead of committing, e.g. for hooks and editor plugins.")
+	flags.Bool("print-only", false,
+		"Print final message to stdout instead of committing.")
+	flags.Bool("ci", false,
+		"Non-interactive mode for CI: pick first suggestion, no TUI, plain logs. "+
+			"Detected from CI environment variables, --ci=false disables.")
+	flags.Bool("accessible", false,
+		"Screen reader friendly mode: linear prompts, no full screen UI, colors or animation. "+
+			"Also enabled by ACCESSIBLE env.")
+	flags.Float64("cost-threshold", 0,
+		"Ask for confirmation when estimated cost of provider requests exceeds this many USD, 0 disables.")
+	flags.Bool("history", false,
+		"Store suggestions per repository, to recall them after aborted runs.")
+	flags.Bool("suggestion-cache", false,
+		"Reuse suggestions of previous run without providers when staged changes and options are unchanged, "+
+			"requires --history.")
+	flags.Bool("remember-ui", false,
+		"Remember options (push, tag, sign-off) and layout of interactive mode per repository.")
+	flags.StringSlice("exclude", nil,
+		"Exclude patterns, when staging changes.")
+	flags.StringSlice("include-only", nil,
+		"Only include specific patterns, when staging changes.")
+	flags.Bool("multi-line", false,
+		"Use multi-line commit messages.")
+	flags.Bool("push", false,
+		"Push after committing.")
+	flags.String("push-remote", "origin",
+		"Remote to push commits and tags to.")
+	flags.Bool("force-with-lease", false,
+		"Push with --force-with-lease.")
+	flags.Bool("set-upstream", false,
+		"Set upstream when pushing a branch without one.")
+	flags.Bool("pull-rebase-before-push", false,
+		"Rebase onto remote branch before pushing when it has new commits.")
+	flags.Bool("rollback-commit", false,
+		"Undo local commit when creating tag or pushing fails.")
+	flags.Bool("create-pr", false,
+		"Create pull/merge request with generated title and description after push.")
+	flags.String("pr-base", "",
+		"Branch created pull request targets, defaults to default branch of push remote.")
+	flags.Bool("create-mr", false,
+		"Same as --create-pr, for GitLab merge requests.")
+	flags.Bool("mr-squash", false,
+		"Squash commits of created GitLab merge request when it is merged.")
+	flags.Bool("mr-remove-source-branch", false,
+		"Delete source branch of created GitLab merge request when it is merged.")
+	flags.String("platform-hosts", "",
+		"Comma-separated host=platform pairs for instances not named after platform, e.g. git.corp.com=gitlab.")
+	flags.Bool("pr-draft", false,
+		"Create pull request as draft.")
+	flags.StringSlice("pr-labels", nil,
+		"Labels of created pull request.")
+	flags.String("tag", "",
+		"Create and increment semver tag part (major|minor|patch|prerelease|auto).")
+	flags.String("tag-prefix", "v",
+		"Prefix of semver tags, e.g. release- or app/v.")
+	flags.String("tag-pattern", "",
+		"Glob to list existing tags, defaults to tag prefix followed by *.")
+	flags.Bool("deepen", false,
+		"Fetch full history of a shallow clone when tagging or branch diff needs it.")
+	flags.Int64("large-binary-threshold", 1024*1024,
+		"Warn about staged binary files larger than this many bytes which are not tracked by Git LFS, 0 disables.")
+	flags.Bool("abort-on-large-binary", false,
+		"Abort instead of warning about large binary files not tracked by Git LFS.")
+	flags.String("conflict-markers", "refuse",
+		"Handling of conflict markers left in staged changes (refuse|warn|ignore).")
+	flags.String("repo", "",
+		"Path to repository worktree, defaults to GIT_WORK_TREE or current directory.")
+	flags.Bool("stash-unrelated", false,
+		"Stash changes not selected for commit and restore them afterwards.")
+	flags.Bool("use-global-gitignore", true,
+		"Use global gitignore.")
+	flags.Int("max-diff-size-bytes", 64*1024,
+		"Maximum diff size in bytes to include in prompts.")
+	flags.String("diff-file", "",
+		"Generate and print message of diff read from this file instead of staged changes, - reads stdin.")
+	flags.String("diff-algorithm", "patience",
+		"Diff algorithm for prompts (myers|minimal|patience|histogram).")
+	flags.Int("find-renames", 50,
+		"Similarity percentage to detect renames in diffs, 0 disables rename detection.")
+	flags.Bool("function-context", true,
+		"Show whole function around changes in staged diff.")
+	flags.Bool("compress-diff", false,
+		"Drop whitespace-only hunks, index lines and long runs of unchanged lines from diffs in prompts.")
+	flags.Bool("pure-go", false,
+		"Use go-git instead of git binary for diffs, tags, push and conflicts, enabled when git is missing.")
+	flags.Bool("map-reduce", false,
+		"Summarize chunks of diffs over --max-diff-size-bytes separately, then generate message from summaries.")
+	flags.String("summary-models", "",
+		"Comma-separated provider=model pairs summarizing chunks with --map-reduce, e.g. openai=gpt-4.1-nano.")
+	flags.Int("new-file-head-lines", 40,
+		"New files longer than this are summarized (head and declarations) in prompts, 0 disables.")
+	flags.String("jira-task-position", "none",
+		"Jira task position in commit message: prefix, infix, suffix, or none.")
+	flags.String(
+		"jira-task-style", "none", "Jira task style: brackets, parens , plain-colon, or plain.",
+	)
+	flags.Bool("jira-enrich", false,
+		"Fetch Jira issue detected in branch name and add its summary to prompt.")
+	flags.String("jira-validate", "off",
+		"Check Jira issue detected in branch name exists and is in progress (refuse|warn|off).")
+	flags.String("chat-webhook", "",
+		"Comma-separated Slack or Teams incoming webhook urls notified about commits.")
+	flags.String("chat-notify-on", "push",
+		"Commits chat webhooks are notified about (commit|push|tag).")
+	flags.String("webhook", "",
+		"Comma-separated urls receiving JSON payload of lifecycle events.")
+	flags.String("webhook-events", "",
+		"Comma-separated lifecycle events firing webhooks, empty fires all "+
+			"(suggestions-generated|commit-created|tag-created|pushed).")
+	flags.String("webhook-secret", "",
+		"Key signing webhook payloads with HMAC-SHA256 in X-Commit-Signature-256 header.")
+	flags.Bool("jira-comment", false,
+		"Comment Jira issue detected in branch name with created commit.")
+	flags.String("jira-transition", "",
+		"Move Jira issue detected in branch name to this status after commit, e.g. \"In Review\".")
+	flags.String("azure-work-item", "none",
+		"Add Azure Boards work item (AB#1234) from branch name to commit message: subject, footer, or none.")
+	flags.String("ticket-pattern", "",
+		"Regex detecting ticket in branch name, uses group named ticket, first group or whole match.")
+	flags.String("ticket-format", modules.DefaultTicketFormat,
+		"Go template rendering detected ticket, fields: .Ticket, .Branch.")
+	flags.String("ticket-position", "footer",
+		"Ticket position in commit message: prefix, suffix, or footer.")
+	flags.String("infer-scope", "off",
+		"Infer conventional commit scope from staged paths: missing (add when absent), override, or off.")
+	flags.Bool("deps-message", true,
+		"Generate message of dependency-only changes from version delta, without providers.")
+	flags.Bool("detect-breaking", false,
+		"Mark commits removing or changing exported Go API as breaking changes.")
+	flags.String("co-authors", "",
+		"Comma-separated co-authors as \"Name <email>\" or .pairs initials, added as Co-authored-by trailers.")
+	flags.Bool("pairing", false,
+		"Add co-authors of active git-duet or git-together pair.")
+	flags.Bool("signoff", false,
+		"Add Signed-off-by trailer of committer identity.")
+	flags.String("trailers", "",
+		"Comma-separated trailers in \"Key: value\" form added to every commit message.")
+	flags.String("message-template", "",
+		"Go template file every commit message is rendered with, see Message Template in README.")
+	flags.String("translate-to", "",
+		"Language to translate commit messages into with providers, e.g. German, empty disables.")
+	flags.String("translate-mode", "replace",
+		"Translation placement (replace|bilingual), bilingual keeps original message followed by translation.")
+	flags.Bool("ascii-only", false,
+		"Transliterate accented letters and strip emoji and other non-ASCII characters from commit messages.")
+	flags.String("banned-words", "",
+		"Comma-separated words prohibited in commit messages, in addition to default profanity list.")
+	flags.String("banned-words-action", "off",
+		"Handling of banned words in commit messages (mask|reject|off).")
+	flags.String("plugin-dir", "",
+		"Directory of WASM plugin modules, defaults to ~/.config/commit/plugins.")
+	flags.String("exec-modules", "",
+		"Comma-separated executables transforming prompt and message, see External modules in README.")
+	flags.String("close-issues", "off",
+		"Keyword closing issues referenced by branch name or subject on GitHub/GitLab, e.g. closes, or off.")
+	flags.String("subject-case", "keep",
+		"Case of subject first letter: lower, sentence, or keep.")
+	flags.Bool("strip-period", false,
+		"Remove trailing period from subject.")
+	flags.String("imperative", "off",
+		"Imperative mood enforcement of subject: heuristic, ai (heuristic checked by providers), or off.")
+	flags.Int("subject-limit", 0,
+		"Maximum subject length, e.g. 50, 0 disables.")
+	flags.String("subject-overflow", "truncate",
+		"Handling of subject words past --subject-limit: truncate, or wrap into body.")
+	flags.Int("body-width", 0,
+		"Hard-wrap body lines at this column, e.g. 72, 0 disables.")
+	flags.Bool("no-verify", false,
+		"Skip pre-commit and commit-msg hooks.")
+	flags.Bool("notes", false,
+		"Record provider, model, prompt hash and token usage in git notes (refs/notes/commit-ai).")
+	flags.Bool("submodule-log", false,
+		"Include commit log of updated submodules in prompts.")
+	flags.Int("recent-commits", 0,
+		"Number of recent commit subjects to include in prompts as style reference, 0 disables.")
+	flags.Bool("release-notes", false,
+		"Generate tag annotation with release notes from commits since previous tag.")
+	flags.Bool("branch-diff", false,
+		"Print message summarizing whole branch against its base instead of committing staged changes.")
+	flags.String("branch-base", "",
+		"Base ref for --branch-diff, defaults to default branch of push remote.")
+	flags.Bool("allow-empty", false,
+		"Create commit even when there are no changes, e.g. to trigger CI.")
+	flags.String("author", "",
+		"Override commit author, in \"Name <email>\" form.")
+	flags.String("date", "",
+		"Override commit author date.")
+	flags.String("scope-dir", "",
+		"Only commit changes inside this directory and use its name as conventional commit scope.")
 }
 
 func runCommitCommand(f *cmdutil.Factory, settings *commit.Settings) error {
@@ -171,5 +559,28 @@ func runCommitCommand(f *cmdutil.Factory, settings *commit.Settings) error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize commit service: %w", err)
 	}
+	defer service.Close(context.Background())
 	return service.Execute(f.Context())
 }
+
+// pluginDir returns directory of WASM plugins, the one of user configuration when none is given
+func pluginDir() string {
+	if dir := viper.GetString("plugin-dir"); dir != "" {
+		return dir
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, ".config", "commit", "plugins")
+	}
+	return ""
+}
+
+// splitList splits comma-separated flag value, keeping spaces inside items, e.g. in names
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
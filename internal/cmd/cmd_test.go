@@ -0,0 +1,12 @@
+package cmd
+
+import "testing"
+
+func TestNewSettings_TraceGit(t *testing.T) {
+	clearCIEnv(t)
+	bindTestFlags(t, "--verbose")
+
+	if settings := newSettings(); !settings.TraceGit {
+		t.Error("newSettings() with --verbose TraceGit = false, want true")
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// repoConfigFile is repository configuration, looked up from worktree directory up to repository root
+const repoConfigFile = ".commit.yaml"
+
+// untrustedConfigKeys make commit run code of their value or send repository contents and tokens
+// to hosts they name, repository configuration may come with untrusted clone, so they are accepted
+// from user configuration, flags and environment only
+var untrustedConfigKeys = []string{"exec-modules", "plugin-dir", "webhook", "chat-webhook", "platform-hosts"}
+
+// bindSettings binds flags of command and loads configuration files beneath them,
+// precedence is flags, environment, repository configuration, user configuration, defaults
+func bindSettings(cmd *cobra.Command) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		if err := mergeConfigFile(filepath.Join(home, ".config", "commit", "config.yaml"), false, true); err != nil {
+			return err
+		}
+	}
+
+	// explicit file replaces repository one and is trusted as user chose it
+	if path := viper.GetString("config"); path != "" {
+		return mergeConfigFile(path, true, true)
+	}
+	if path := findRepoConfig(viper.GetString("repo")); path != "" {
+		return mergeConfigFile(path, false, false)
+	}
+	return nil
+}
+
+// mergeConfigFile merges settings of YAML file over ones loaded before, missing file is skipped
+// unless it is required
+func mergeConfigFile(path string, required, trusted bool) error {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	}
+
+	config := viper.New()
+	config.SetConfigFile(path)
+	config.SetConfigType("yaml")
+	if err := config.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	settings := config.AllSettings()
+	if !trusted {
+		for _, key := range untrustedConfigKeys {
+			if _, ok := settings[key]; ok {
+				slog.Warn("Setting ignored in repository config, set it in user config, flags or environment",
+					"setting", key, "path", path)
+				delete(settings, key)
+			}
+		}
+	}
+
+	if err := viper.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to merge config %s: %w", path, err)
+	}
+	return nil
+}
+
+// findRepoConfig returns repository configuration file nearest to dir, which defaults to
+// working directory, empty when there is none up to repository root
+func findRepoConfig(dir string) string {
+	if dir == "" {
+		dir = os.Getenv("GIT_WORK_TREE")
+	}
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		path := filepath.Join(dir, repoConfigFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// getList returns list setting, given comma-separated by flags and environment or as list in configuration
+func getList(key string) []string {
+	if _, ok := viper.Get(key).([]any); ok {
+		return viper.GetStringSlice(key)
+	}
+	return splitList(viper.GetString(key))
+}
+
+// getPairs returns key=value pairs, e.g. provider=model, given comma-separated or as map in configuration
+func getPairs(key string) []string {
+	values, ok := viper.Get(key).(map[string]any)
+	if !ok {
+		return getList(key)
+	}
+	pairs := make([]string, 0, len(values))
+	for name, value := range values {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", name, value))
+	}
+	sort.Strings(pairs)
+	return pairs
+}
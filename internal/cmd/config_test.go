@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestMergeConfigFile_UntrustedKeys(t *testing.T) {
+	config := `exec-modules: ./run.sh
+plugin-dir: ./plugins
+webhook: https://attacker.example.com/hook
+chat-webhook: https://attacker.example.com/chat
+platform-hosts:
+  attacker.example.com: github
+timeout: 30s
+`
+	path := filepath.Join(t.TempDir(), repoConfigFile)
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		trusted bool
+	}{
+		{name: "repository config", trusted: false},
+		{name: "user config", trusted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+
+			if err := mergeConfigFile(path, true, tt.trusted); err != nil {
+				t.Fatalf("mergeConfigFile() error = %v", err)
+			}
+			for _, key := range untrustedConfigKeys {
+				if got := viper.IsSet(key); got != tt.trusted {
+					t.Errorf("viper.IsSet(%q) = %v, want %v", key, got, tt.trusted)
+				}
+			}
+			if !viper.IsSet("timeout") {
+				t.Error("viper.IsSet(\"timeout\") = false, want true")
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newDaemonCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Serve message generation of current repository over local socket",
+		Long: `Serve message generation of current repository over HTTP on a unix socket, for editors and scripts.
+Provider clients and their connections stay open between requests, staged changes are watched
+and their diff is prepared before a request comes.
+
+POST /generate with {} generates suggestions of staged changes, {"diff": "..."} of given diff,
+GET /health reports daemon is running. Socket defaults to .git/commit-daemon.sock, e.g.:
+
+  curl --unix-socket .git/commit-daemon.sock -d '{}' http://commit/generate
+
+Over TCP every request must carry bearer token, generated on start into .git/commit-daemon.token:
+
+  curl -H "Authorization: Bearer $(cat .git/commit-daemon.token)" -d '{}' http://127.0.0.1:7420/generate`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initLogging(f.Options().LogLevel)
+			return runDaemonCommand(
+				f, newSettings(),
+				viper.GetString("socket"), viper.GetString("listen"), viper.GetDuration("watch-interval"),
+			)
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	cmd.Flags().String("socket", "",
+		"Unix socket to listen on, defaults to commit-daemon.sock in git directory.")
+	cmd.Flags().String("listen", "",
+		"TCP address to listen on instead of unix socket, e.g. 127.0.0.1:7420, "+
+			"requests need bearer token from commit-daemon.token in git directory.")
+	cmd.Flags().Duration("watch-interval", 2*time.Second,
+		"Interval of checking staged changes to prepare their diff, 0 disables watching.")
+
+	return cmd
+}
+
+func runDaemonCommand(
+	f *cmdutil.Factory, settings *commit.Settings, socket, address string, watchInterval time.Duration,
+) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+
+	var (
+		listener net.Listener
+		token    string
+	)
+	if address != "" {
+		tokenPath, err := service.DaemonTokenPath()
+		if err != nil {
+			return err
+		}
+		if token, err = writeDaemonToken(tokenPath); err != nil {
+			return err
+		}
+		defer func() { _ = os.Remove(tokenPath) }()
+
+		listener, err = net.Listen("tcp", address)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", address, err)
+		}
+		slog.Info("Daemon requests need bearer token", "token_file", tokenPath)
+	} else {
+		if socket == "" {
+			if socket, err = service.DaemonSocketPath(); err != nil {
+				return err
+			}
+		}
+		if conn, err := net.Dial("unix", socket); err == nil {
+			_ = conn.Close()
+			return fmt.Errorf("daemon is already running on %s", socket)
+		}
+		// socket of a daemon which did not exit cleanly would fail listening
+		if err := os.Remove(socket); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+		listener, err = listenPrivateSocket(socket)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.Remove(socket) }()
+	}
+
+	return service.Serve(f.Context(), listener, watchInterval, token)
+}
+
+// writeDaemonToken generates bearer token and writes it to file readable by current user only,
+// token of a previous daemon is replaced
+func writeDaemonToken(path string) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := hex.EncodeToString(secret)
+
+	// file is created anew, permissions of existing one would be kept
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to remove stale token: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token file: %w", err)
+	}
+	if _, err := file.WriteString(token + "\n"); err != nil {
+		_ = file.Close()
+		return "", fmt.Errorf("failed to write token file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write token file: %w", err)
+	}
+	return token, nil
+}
+
+// listenPrivateSocket listens on unix socket accessible by current user only, suggestions are
+// generated with api keys of user. Socket is created in a private directory and moved into place
+// once restricted, so it is never reachable with permissions given by umask
+func listenPrivateSocket(socket string) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(socket), ".commit-daemon-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socket, err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	if err := os.Rename(path, socket); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to move socket to %s: %w", socket, err)
+	}
+	// socket was moved, it is removed by caller
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	return listener, nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newExplainCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <ref>",
+		Short: "Explain an existing commit in plain language",
+		Long: `Explain an existing commit in plain language from its message and diff,
+e.g. to understand old commits with uninformative messages. Nothing is changed.`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initLogging(f.Options().LogLevel)
+			return runExplainCommand(f, newSettings(), args[0])
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	return cmd
+}
+
+func runExplainCommand(f *cmdutil.Factory, settings *commit.Settings, ref string) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+	return service.Explain(f.Context(), ref)
+}
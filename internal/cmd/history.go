@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newHistoryCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List suggestions generated in current repository",
+		Long: `List suggestions generated in current repository with --history, newest first.
+Suggestions generated for the same staged changes can be recalled in interactive mode with h.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initLogging(f.Options().LogLevel)
+			settings := newSettings()
+			settings.History = true
+			return runHistoryCommand(f, settings, cmd.OutOrStdout(), viper.GetInt("limit"), viper.GetBool("clear"))
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	cmd.Flags().Int("limit", 10,
+		"Number of generations to list, 0 lists all.")
+	cmd.Flags().Bool("clear", false,
+		"Remove history of current repository.")
+
+	return cmd
+}
+
+func runHistoryCommand(f *cmdutil.Factory, settings *commit.Settings, out io.Writer, limit int, clear bool) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+
+	if clear {
+		return service.ClearHistory(f.Context())
+	}
+
+	entries, err := service.History(f.Context())
+	if err != nil {
+		return err
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	for i, entry := range entries {
+		if i > 0 {
+			_, _ = fmt.Fprintln(out)
+		}
+		branch := entry.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+		_, _ = fmt.Fprintf(out, "%s  %s  diff %.8s\n", entry.Time.Local().Format(time.DateTime), branch, entry.DiffHash)
+
+		providers := make([]string, 0, len(entry.Suggestions))
+		for provider := range entry.Suggestions {
+			providers = append(providers, provider)
+		}
+		sort.Strings(providers)
+		for _, provider := range providers {
+			message := strings.TrimSpace(entry.Suggestions[provider])
+			subject, _, _ := strings.Cut(message, "\n")
+			_, _ = fmt.Fprintf(out, "  %-8s %s\n", provider, subject)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+	"github.com/hasansino/commit/pkg/commit/modules"
+)
+
+// initConfig is repository configuration written by init, fields are in file order
+type initConfig struct {
+	Providers        []string `yaml:"providers,omitempty"`
+	MultiLine        bool     `yaml:"multi-line,omitempty"`
+	SubjectCase      string   `yaml:"subject-case,omitempty"`
+	SubjectLimit     int      `yaml:"subject-limit,omitempty"`
+	StripPeriod      bool     `yaml:"strip-period,omitempty"`
+	Imperative       string   `yaml:"imperative,omitempty"`
+	InferScope       string   `yaml:"infer-scope,omitempty"`
+	JiraTaskPosition string   `yaml:"jira-task-position,omitempty"`
+	JiraTaskStyle    string   `yaml:"jira-task-style,omitempty"`
+}
+
+func newInitCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create repository configuration interactively",
+		Long: `Create repository configuration interactively.
+Asks for providers, conventional commit preferences and Jira style, writes .commit.yaml to repository root
+and optionally installs prepare-commit-msg hook filling message of plain git commit.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initLogging(f.Options().LogLevel)
+			return runInitCommand(f, newSettings(), cmd.InOrStdin(), cmd.OutOrStdout(), viper.GetBool("force"))
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	cmd.Flags().Bool("force", false,
+		"Overwrite existing .commit.yaml without asking.")
+
+	return cmd
+}
+
+func runInitCommand(f *cmdutil.Factory, settings *commit.Settings, in io.Reader, out io.Writer, force bool) error {
+	root, err := findRepoRoot(settings.RepoPath)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(root, repoConfigFile)
+	p := &prompter{in: bufio.NewReader(in), out: out}
+
+	if _, err := os.Stat(path); err == nil && !force {
+		overwrite, err := p.confirm(fmt.Sprintf("%s already exists, overwrite it?", path), false)
+		if err != nil || !overwrite {
+			return err
+		}
+	}
+
+	var config initConfig
+	providers, err := p.ask("Providers, comma-separated (claude, openai, gemini), empty for all available", "")
+	if err != nil {
+		return err
+	}
+	config.Providers = splitList(strings.ToLower(providers))
+	for _, provider := range config.Providers {
+		if !slices.Contains([]string{"claude", "openai", "gemini"}, provider) {
+			return fmt.Errorf("invalid provider: %s (must be claude, openai or gemini)", provider)
+		}
+	}
+
+	if config.MultiLine, err = p.confirm("Multi-line messages with body?", false); err != nil {
+		return err
+	}
+	if config.SubjectCase, err = p.choose("Subject case", []string{
+		string(modules.SubjectCaseKeep), string(modules.SubjectCaseLower), string(modules.SubjectCaseSentence),
+	}); err != nil {
+		return err
+	}
+	limit, err := p.ask("Maximum subject length, 0 disables", "0")
+	if err != nil {
+		return err
+	}
+	if config.SubjectLimit, err = strconv.Atoi(limit); err != nil || config.SubjectLimit < 0 {
+		return fmt.Errorf("invalid subject limit: %s (must be non-negative number)", limit)
+	}
+	if config.StripPeriod, err = p.confirm("Remove trailing period from subject?", false); err != nil {
+		return err
+	}
+	if config.Imperative, err = p.choose("Imperative mood enforcement", []string{
+		string(modules.ImperativeModeOff), string(modules.ImperativeModeHeuristic), string(modules.ImperativeModeAI),
+	}); err != nil {
+		return err
+	}
+	if config.InferScope, err = p.choose("Infer scope from staged paths", []string{
+		string(modules.ScopeInferenceOff),
+		string(modules.ScopeInferenceMissing), string(modules.ScopeInferenceOverride),
+	}); err != nil {
+		return err
+	}
+	if config.JiraTaskPosition, err = p.choose("Jira task position", []string{
+		string(modules.JiraTaskPositionNone), string(modules.JiraTaskPositionPrefix),
+		string(modules.JiraTaskPositionInfix), string(modules.JiraTaskPositionSuffix),
+	}); err != nil {
+		return err
+	}
+	if config.JiraTaskPosition != string(modules.JiraTaskPositionNone) {
+		if config.JiraTaskStyle, err = p.choose("Jira task style", []string{
+			string(modules.JiraTaskStyleBrackets), string(modules.JiraTaskStyleParens),
+			string(modules.JiraTaskStylePlainColon), string(modules.JiraTaskStylePlain),
+		}); err != nil {
+			return err
+		}
+	}
+	installHook, err := p.confirm("Install prepare-commit-msg hook filling message of plain git commit?", false)
+	if err != nil {
+		return err
+	}
+
+	var data bytes.Buffer
+	encoder := yaml.NewEncoder(&data)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.WriteFile(path, data.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	_, _ = fmt.Fprintf(out, "Wrote %s\n", path)
+
+	if !installHook {
+		return nil
+	}
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+	hookPath, err := service.InstallPrepareCommitMsgHook(f.Context())
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "Installed %s\n", hookPath)
+	return nil
+}
+
+// findRepoRoot returns worktree root containing dir, which defaults to working directory
+func findRepoRoot(dir string) (string, error) {
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve repository path: %w", err)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to find repository root: %w", err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("not a git repository")
+		}
+		dir = parent
+	}
+}
+
+// prompter asks questions line by line, empty answer takes default
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask returns answer to question, io.EOF when input is closed
+func (p *prompter) ask(question, def string) (string, error) {
+	if def != "" {
+		_, _ = fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		_, _ = fmt.Fprintf(p.out, "%s: ", question)
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// choose asks until one of choices is answered, first one is default
+func (p *prompter) choose(question string, choices []string) (string, error) {
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s (%s)", question, strings.Join(choices, "|")), choices[0])
+		if err != nil {
+			return "", err
+		}
+		if answer = strings.ToLower(answer); slices.Contains(choices, answer) {
+			return answer, nil
+		}
+		_, _ = fmt.Fprintf(p.out, "Unknown choice %q.\n", answer)
+	}
+}
+
+// confirm asks yes or no question
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	answer, err := p.ask(fmt.Sprintf("%s (%s)", question, hint), "")
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "":
+		return def, nil
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+
+	"github.com/hasansino/commit/internal/version"
+)
+
+func newManCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: "Generate man pages",
+		Long: `Generate man pages of all commands into a directory, one page per command,
+for packagers shipping documentation together with the binary.`,
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, _ := cmd.Flags().GetString("dir")
+			return runManCommand(cmd.Root(), dir)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("dir", "man",
+		"Directory man pages are written to, created when missing.")
+
+	return cmd
+}
+
+func runManCommand(root *cobra.Command, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create man directory: %w", err)
+	}
+
+	root.DisableAutoGenTag = true
+	header := &doc.GenManHeader{
+		Title:   strings.ToUpper(root.Name()),
+		Section: "1",
+		Source:  root.Name() + " " + version.GetVersion(),
+		Manual:  "User Commands",
+	}
+	if err := doc.GenManTree(root, header, dir); err != nil {
+		return fmt.Errorf("failed to generate man pages: %w", err)
+	}
+	return nil
+}
+
+// printHelpTree writes long help of command and all its visible subcommands, depth first
+func printHelpTree(w io.Writer, cmd *cobra.Command) error {
+	title := cmd.CommandPath()
+	if _, err := fmt.Fprintf(w, "%s\n%s\n\n", title, strings.Repeat("=", len(title))); err != nil {
+		return err
+	}
+
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	if _, err := fmt.Fprintf(w, "%s\n\n%s\n", description, cmd.UsageString()); err != nil {
+		return err
+	}
+
+	for _, sub := range cmd.Commands() {
+		if !sub.IsAvailableCommand() || sub.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		if err := printHelpTree(w, sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newPRDescribeCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pr-describe",
+		Short: "Generate pull request title and description from branch changes",
+		Long: `Generate pull request title and description from diff of current branch against its base,
+which is default branch of the remote unless --branch-base is set.
+Result is printed, copied to clipboard with --copy or posted to GitHub or GitLab with --post.
+Posting requires GITHUB_TOKEN (or GH_TOKEN) or GITLAB_TOKEN and the branch pushed to the remote.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initLogging(f.Options().LogLevel)
+			return runPRDescribeCommand(f, newSettings(), viper.GetBool("post"))
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	cmd.Flags().Bool("post", false,
+		"Create pull or merge request via GitHub or GitLab API instead of printing.")
+
+	return cmd
+}
+
+func runPRDescribeCommand(f *cmdutil.Factory, settings *commit.Settings, post bool) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+	return service.DescribePullRequest(f.Context(), post)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newPrepareCommitMsgCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prepare-commit-msg <file> [source] [sha]",
+		Short: "Fill message file of plain git commit, used by prepare-commit-msg hook",
+		Long: `Fill message file of plain git commit with generated suggestion of staged changes.
+Used by prepare-commit-msg hook installed with commit init, messages given by -m, -F, templates,
+merges and amends are left alone.`,
+		Args: cobra.RangeArgs(1, 3),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 && args[1] != "" {
+				return nil
+			}
+			initLogging(f.Options().LogLevel)
+			return runPrepareCommitMsgCommand(f, newSettings(), args[0])
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	return cmd
+}
+
+func runPrepareCommitMsgCommand(f *cmdutil.Factory, settings *commit.Settings, messageFile string) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+	return service.PrepareCommitMessage(f.Context(), messageFile)
+}
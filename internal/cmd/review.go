@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newReviewCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "Review staged changes and report potential problems",
+		Long: `Review staged changes, or whole branch with --branch-diff, and report potential bugs,
+missing tests and style issues found by providers. Nothing is staged or committed.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initLogging(f.Options().LogLevel)
+			return runReviewCommand(f, newSettings())
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	return cmd
+}
+
+func runReviewCommand(f *cmdutil.Factory, settings *commit.Settings) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+	return service.Review(f.Context())
+}
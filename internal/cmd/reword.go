@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newRewordCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reword [ref]",
+		Short: "Regenerate message of an existing commit",
+		Long: `Regenerate message of an existing commit from its diff and rewrite it.
+HEAD is amended, older commits are rewritten with rebase. Defaults to HEAD.`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref := "HEAD"
+			if len(args) > 0 {
+				ref = args[0]
+			}
+			initLogging(f.Options().LogLevel)
+			return runRewordCommand(f, newSettings(), ref, viper.GetBool("force"))
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	cmd.Flags().Bool("force", false,
+		"Allow rewording commits which are already pushed to a remote.")
+
+	return cmd
+}
+
+func runRewordCommand(f *cmdutil.Factory, settings *commit.Settings, ref string, force bool) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+	return service.Reword(f.Context(), ref, force)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newRPCCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rpc",
+		Short: "Serve editor plugins with JSON-RPC over stdin and stdout",
+		Long: `Serve editor plugins (VS Code, Neovim) with JSON-RPC 2.0 over stdin and stdout, one message per line.
+Logs are written to stderr, so stdout carries responses only.
+
+Methods:
+  generate         {"diff"?, "branch"?}, suggestions of staged changes or of given diff
+  listSuggestions  suggestions generated last, while staged changes are unchanged
+  commit           {"message", "provider"?, "push"?, "tag"?}, commits staged changes
+  shutdown         stops serving, as does closing stdin
+
+e.g.:
+
+  {"jsonrpc": "2.0", "id": 1, "method": "generate"}`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initLoggingTo(os.Stderr, f.Options().LogLevel)
+			return runRPCCommand(f, newSettings())
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	return cmd
+}
+
+func runRPCCommand(f *cmdutil.Factory, settings *commit.Settings) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+
+	return service.ServeRPC(f.Context(), os.Stdin, os.Stdout)
+}
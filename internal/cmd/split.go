@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newSplitCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "split",
+		Short: "Split staged changes into multiple logical commits",
+		Long: `Split staged changes into multiple logical commits.
+Providers group changed files into independent change sets and propose a message for each,
+the plan is shown for confirmation before commits are created.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initLogging(f.Options().LogLevel)
+			return runSplitCommand(f, newSettings())
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	return cmd
+}
+
+func runSplitCommand(f *cmdutil.Factory, settings *commit.Settings) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+	return service.Split(f.Context())
+}
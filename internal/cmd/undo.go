@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hasansino/commit/internal/cmdutil"
+	"github.com/hasansino/commit/pkg/commit"
+)
+
+func newUndoCommand(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Undo last commit created by this tool keeping its changes staged",
+		Long: `Soft-reset last commit keeping its changes staged, so message can be regenerated
+with different options. Only commits with generation note (written with --notes) are undone,
+commits without it or already pushed are rejected unless --force is set.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return bindSettings(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initLogging(f.Options().LogLevel)
+			return runUndoCommand(f, newSettings(), viper.GetBool("force"))
+		},
+		SilenceUsage: true,
+	}
+
+	bindCommitFlags(cmd)
+
+	cmd.Flags().Bool("force", false,
+		"Undo commits without generation note or already pushed to a remote.")
+
+	return cmd
+}
+
+func runUndoCommand(f *cmdutil.Factory, settings *commit.Settings, force bool) error {
+	service, err := commit.NewCommitService(
+		settings,
+		commit.WithLogger(slog.Default()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize commit service: %w", err)
+	}
+	defer service.Close(context.Background())
+	return service.Undo(f.Context(), force)
+}
@@ -3,46 +3,119 @@ package commit
 import (
 	"context"
 	"time"
+
+	"github.com/hasansino/commit/pkg/commit/modules"
+	"github.com/hasansino/commit/pkg/commit/ui"
 )
 
 //go:generate mockgen -source $GOFILE -package mocks -destination mocks/mocks.go
 
 type providerAccessor interface {
 	Name() string
+	Model() string
 	IsAvailable() bool
 	Ask(ctx context.Context, prompt string) ([]string, error)
+	Usage() (inputTokens int64, outputTokens int64)
 	SetTimeout(timeout time.Duration)
 }
 
 type moduleAccessor interface {
 	Name() string
-	TransformPrompt(ctx context.Context, prompt string) (string, bool, error)
+	TransformPrompt(ctx context.Context, branch, prompt string) (string, bool, error)
 	TransformCommitMessage(ctx context.Context, branch, message string) (string, bool, error)
 }
 
+// promptContributorAccessor is implemented by modules whose prompt transformation only appends
+// context independent of other modules, e.g. fetched over network, so they can run concurrently
+type promptContributorAccessor interface {
+	PromptContext(ctx context.Context, branch string) (string, bool, error)
+}
+
+type taskValidatorAccessor interface {
+	ValidateTask(ctx context.Context, branch string) error
+}
+
+// commitNotifierAccessor is told about created commits, e.g. to update issue tracker
+type commitNotifierAccessor interface {
+	Name() string
+	NotifyCommit(ctx context.Context, event modules.CommitEvent) error
+}
+
+// suggestionsNotifierAccessor is implemented by notifiers also told about generated suggestions
+type suggestionsNotifierAccessor interface {
+	NotifySuggestions(ctx context.Context, event modules.SuggestionsEvent) error
+}
+
 type gitOperationsAccessor interface {
 	IsGitRepository() bool
 	GetRepoState() (string, error)
 	HasConflicts() (bool, []string, error)
 	GetConflictedFiles() ([]string, error)
 	UnstageAll() error
-	StageFiles(excludePatterns, includePatterns []string, useGlobalGitignore bool) ([]string, error)
-	GetStagedDiff(maxSizeBytes int) (string, error)
+	StageFiles(excludePatterns, includePatterns []string, useGlobalGitignore bool, scopeDir string) ([]string, error)
+	StagePaths(paths []string) error
+	StashUnstaged() (bool, error)
+	RestoreStash() error
+	GetStagedDiff(maxSizeBytes int, newFileHeadLines int) (string, error)
+	GetStagedFiles() ([]string, error)
+	GetIndexHash() (string, error)
+	GetGitDir() (string, error)
+	GetLargeBinaries(thresholdBytes int64) (map[string]int64, error)
+	GetConflictMarkers() ([]string, error)
+	GetSubmoduleSummary(includeLog bool) (string, error)
 	GetCurrentBranch() (string, error)
-	CreateCommit(message string) error
-	Push() (string, error)
-	GetLatestTag() (string, error)
-	IncrementVersion(currentTag, incrementType string) (string, error)
+	GetCommitTemplate() (string, error)
+	GetRecentCommits(n int) ([]string, error)
+	CreateCommit(message string, noVerify, allowEmpty bool, author, date string) error
+	ResolveRewordTarget(ref string) (string, error)
+	IsCommitPushed(sha string) (bool, error)
+	GetCommitDiff(sha string, maxSizeBytes int) (string, []string, error)
+	ResolveCommit(ref string) (string, error)
+	GetCommitMessage(sha string) (string, error)
+	GetBranchDiff(base, remote string, maxSizeBytes int) (string, []string, error)
+	RewordCommit(sha, message string, noVerify bool) error
+	GetRemoteDivergence(remote string) (int, int, error)
+	HasRemoteBranch(remote, branch string) bool
+	GetRemoteURL(remoteName string) (string, error)
+	GetDefaultBranch(remote string) string
+	PullRebase(remote string) error
+	Push(remote string, forceWithLease, setUpstream bool) (string, error)
+	IsShallowRepository() (bool, error)
+	Deepen(remote string) error
+	GetLatestTag(prefix, pattern string) (string, error)
+	GetCommitsSince(tag string) ([]string, error)
+	IncrementVersion(currentTag, incrementType, prefix string) (string, error)
 	CreateTag(tag, message string) error
-	PushTag(tag string) error
+	PushTag(tag, remote string) error
+	DeleteTag(tag string) error
+	UndoLastCommit() error
+	InstallHook(name, script string) (string, error)
+	AddNote(notesRef, message string) error
+	GetNote(notesRef, sha string) string
+	PushNotes(notesRef, remote string) error
 }
 
 type aiServiceAccessor interface {
 	NumProviders() int
+	Ask(ctx context.Context, providers []string, prompt string, first bool) (map[string]string, error)
 	GenerateCommitMessages(
 		ctx context.Context,
 		diff, branch string, files []string,
-		providers []string, customPrompt string,
+		providers []string, customPrompt, commitTemplate string,
+		recentCommits []string,
 		first bool, multiLine bool,
+		transformPrompt func(ctx context.Context, prompt string) string,
 	) (map[string]string, error)
+	BuildPrompt(
+		diff, branch string, files []string,
+		customPrompt, commitTemplate string,
+		recentCommits []string,
+		multiLine bool,
+	) string
+	EstimateRequests(providers []string, prompt string) []ui.CostEstimate
+	GenerationMetadata(provider string) map[string]string
+	GenerationStats(provider string) (ui.ProviderStats, bool)
+	ProviderNames(requested []string) []string
+	SetProgress(progress ui.ProgressFunc)
+	CancelProvider(provider string)
 }
@@ -2,9 +2,14 @@ package commit
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +17,7 @@ import (
 	"github.com/hasansino/commit/pkg/commit/providers/claude"
 	"github.com/hasansino/commit/pkg/commit/providers/gemini"
 	"github.com/hasansino/commit/pkg/commit/providers/openai"
+	"github.com/hasansino/commit/pkg/commit/ui"
 
 	_ "embed"
 )
@@ -25,39 +31,140 @@ var promptFormatSingle string
 //go:embed prompt-format-multi.md
 var promptFormatMulti string
 
+//go:embed prompt-template.md
+var promptTemplateSingle string
+
+//go:embed prompt-template-multi.md
+var promptTemplateMulti string
+
+//go:embed prompt-history.md
+var promptHistory string
+
 type aiService struct {
-	logger    *slog.Logger
-	timeout   time.Duration
-	providers map[string]providerAccessor
+	logger        *slog.Logger
+	timeout       time.Duration
+	maxConcurrent int // requests in flight at once, 0 is unlimited
+	providers     map[string]providerAccessor
+
+	mu          sync.Mutex
+	generations map[string]generationInfo // of latest generated commit messages, by provider
+	progress    ui.ProgressFunc           // nil when nobody observes requests
+	calls       map[*providerCall]struct{}
+}
+
+// providerCall is state of one fan out of prompt to providers, calls in flight at once,
+// e.g. summaries of diff chunks, do not share it
+type providerCall struct {
+	cancels     map[string]context.CancelFunc // cancel requests in flight, by provider
+	generations map[string]generationInfo     // of responses, by provider
+}
+
+// generationInfo describes latest response of a provider, for auditing
+type generationInfo struct {
+	model        string
+	promptHash   string
+	inputTokens  int64
+	outputTokens int64
+	latency      time.Duration
 }
 
-func newAIService(logger *slog.Logger, timeout time.Duration) *aiService {
+// configurableProvider is a provider which is tuned before its first request
+type configurableProvider interface {
+	providerAccessor
+	SetModel(model string)
+}
+
+// providerFactories construct providers by name, sdk clients are created on first request
+var providerFactories = []struct {
+	name string
+	new  func() configurableProvider
+}{
+	{"openai", func() configurableProvider { return openai.NewOpenAI() }},
+	{"claude", func() configurableProvider { return claude.NewClaude() }},
+	{"gemini", func() configurableProvider { return gemini.NewGemini() }},
+}
+
+// newAIService sets up available providers, models override ones configured by environment, by provider,
+// maxConcurrent limits requests in flight at once, 0 is unlimited,
+// only requested providers are constructed, empty requested means all of them
+func newAIService(
+	logger *slog.Logger, timeout time.Duration, models map[string]string, maxConcurrent int, requested []string,
+) *aiService {
 	providerList := make(map[string]providerAccessor)
 
-	if openaiProvider := openai.NewOpenAI(); openaiProvider.IsAvailable() {
-		openaiProvider.SetTimeout(timeout)
-		providerList[openaiProvider.Name()] = openaiProvider
-	}
-	if claudeProvider := claude.NewClaude(); claudeProvider.IsAvailable() {
-		claudeProvider.SetTimeout(timeout)
-		providerList[claudeProvider.Name()] = claudeProvider
-	}
-	if geminiProvider := gemini.NewGemini(); geminiProvider.IsAvailable() {
-		geminiProvider.SetTimeout(timeout)
-		providerList[geminiProvider.Name()] = geminiProvider
+	for _, factory := range providerFactories {
+		if len(requested) > 0 && !containsFold(requested, factory.name) {
+			continue
+		}
+		provider := factory.new()
+		if !provider.IsAvailable() {
+			continue
+		}
+		provider.SetTimeout(timeout)
+		provider.SetModel(models[provider.Name()])
+		providerList[provider.Name()] = provider
 	}
 
 	return &aiService{
-		logger:    logger,
-		timeout:   timeout,
-		providers: providerList,
+		logger:        logger,
+		timeout:       timeout,
+		maxConcurrent: maxConcurrent,
+		providers:     providerList,
+	}
+}
+
+// containsFold reports whether list contains value, ignoring case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
 	}
+	return false
 }
 
 func (s *aiService) NumProviders() int {
 	return len(s.providers)
 }
 
+// ProviderNames returns sorted names of requested providers which are available
+func (s *aiService) ProviderNames(requested []string) []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.FilterProviders(requested) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetProgress sets observer of provider requests, nil removes it
+func (s *aiService) SetProgress(progress ui.ProgressFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress = progress
+}
+
+// CancelProvider cancels requests in flight to provider, others continue
+func (s *aiService) CancelProvider(provider string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for call := range s.calls {
+		if cancel, ok := call.cancels[provider]; ok {
+			cancel()
+		}
+	}
+}
+
+// reportProgress notifies observer about status of provider request
+func (s *aiService) reportProgress(provider string, status ui.ProviderStatus) {
+	s.mu.Lock()
+	progress := s.progress
+	s.mu.Unlock()
+	if progress != nil {
+		progress(provider, status)
+	}
+}
+
 func (s *aiService) FilterProviders(requested []string) map[string]providerAccessor {
 	if len(requested) == 0 {
 		return s.providers
@@ -74,8 +181,10 @@ func (s *aiService) FilterProviders(requested []string) map[string]providerAcces
 func (s *aiService) GenerateCommitMessages(
 	ctx context.Context,
 	diff, branch string, files []string,
-	providers []string, customPrompt string,
+	providers []string, customPrompt, commitTemplate string,
+	recentCommits []string,
 	first bool, multiLine bool,
+	transformPrompt func(ctx context.Context, prompt string) string,
 ) (map[string]string, error) {
 	// passed from --providers(-p) flag
 	activeProviders := s.FilterProviders(providers)
@@ -83,13 +192,123 @@ func (s *aiService) GenerateCommitMessages(
 		return nil, fmt.Errorf("no ai providers available")
 	}
 
-	var prompt string
+	prompt := s.BuildPrompt(diff, branch, files, customPrompt, commitTemplate, recentCommits, multiLine)
+	if transformPrompt != nil {
+		prompt = transformPrompt(ctx, prompt)
+	}
+
+	messages, generations := s.askProviders(ctx, activeProviders, prompt, first)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.generations == nil {
+		s.generations = make(map[string]generationInfo)
+	}
+	maps.Copy(s.generations, generations)
+
+	return messages, nil
+}
+
+// BuildPrompt renders prompt of commit message generation, custom prompt replaces default one
+func (s *aiService) BuildPrompt(
+	diff, branch string, files []string,
+	customPrompt, commitTemplate string,
+	recentCommits []string,
+	multiLine bool,
+) string {
 	if len(customPrompt) > 0 {
-		prompt = s.buildCustomPrompt(customPrompt, diff, branch, files)
-	} else {
-		prompt = s.buildPrompt(diff, branch, files, multiLine)
+		return s.buildCustomPrompt(customPrompt, diff, branch, files, commitTemplate, recentCommits)
+	}
+	return s.buildPrompt(diff, branch, files, commitTemplate, recentCommits, multiLine)
+}
+
+// EstimateRequests returns approximate token usage and cost of sending prompt to requested providers,
+// sorted by provider name
+func (s *aiService) EstimateRequests(providers []string, prompt string) []ui.CostEstimate {
+	activeProviders := s.FilterProviders(providers)
+	inputTokens := estimateTokens(prompt)
+
+	estimates := make([]ui.CostEstimate, 0, len(activeProviders))
+	for _, name := range s.ProviderNames(providers) {
+		model := activeProviders[name].Model()
+		estimate := ui.CostEstimate{
+			Provider:     name,
+			Model:        model,
+			InputTokens:  inputTokens,
+			OutputTokens: estimatedOutputTokens,
+			Cost:         -1,
+		}
+		if cost, ok := estimateCost(model, inputTokens, estimatedOutputTokens); ok {
+			estimate.Cost = cost
+		}
+		estimates = append(estimates, estimate)
+	}
+	return estimates
+}
+
+// Ask sends an arbitrary prompt to requested providers and returns cleaned up responses
+func (s *aiService) Ask(
+	ctx context.Context,
+	providers []string, prompt string,
+	first bool,
+) (map[string]string, error) {
+	activeProviders := s.FilterProviders(providers)
+	if len(activeProviders) == 0 {
+		return nil, fmt.Errorf("no ai providers available")
+	}
+	messages, _ := s.askProviders(ctx, activeProviders, prompt, first)
+	return messages, nil
+}
+
+// GenerationMetadata describes latest commit message of provider: model, prompt hash and token usage,
+// nil when provider did not generate one yet
+func (s *aiService) GenerationMetadata(provider string) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, ok := s.generations[provider]
+	if !ok {
+		return nil
+	}
+
+	return map[string]string{
+		"provider":      provider,
+		"model":         info.model,
+		"prompt_sha256": info.promptHash,
+		"input_tokens":  strconv.FormatInt(info.inputTokens, 10),
+		"output_tokens": strconv.FormatInt(info.outputTokens, 10),
+	}
+}
+
+// GenerationStats returns latency, token usage and estimated cost of latest commit message of provider
+func (s *aiService) GenerationStats(provider string) (ui.ProviderStats, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, ok := s.generations[provider]
+	if !ok {
+		return ui.ProviderStats{}, false
 	}
 
+	stats := ui.ProviderStats{
+		Latency:      info.latency,
+		InputTokens:  info.inputTokens,
+		OutputTokens: info.outputTokens,
+		Cost:         -1,
+	}
+	if cost, ok := estimateCost(info.model, info.inputTokens, info.outputTokens); ok {
+		stats.Cost = cost
+	}
+	return stats, true
+}
+
+// askProviders fans out prompt to all given providers concurrently, returns responses
+// and generation details of them, by provider
+func (s *aiService) askProviders(
+	ctx context.Context,
+	activeProviders map[string]providerAccessor, prompt string,
+	first bool,
+) (map[string]string, map[string]generationInfo) {
 	type providerResponse struct {
 		Name    string
 		Message string
@@ -97,27 +316,83 @@ func (s *aiService) GenerateCommitMessages(
 		Err     error
 	}
 
+	promptSum := sha256.Sum256([]byte(prompt))
+	promptHash := hex.EncodeToString(promptSum[:])
+
 	commonCtx, commonCtxCancel := context.WithCancel(ctx)
 
+	call := &providerCall{
+		cancels:     make(map[string]context.CancelFunc, len(activeProviders)),
+		generations: make(map[string]generationInfo, len(activeProviders)),
+	}
+	s.mu.Lock()
+	if s.calls == nil {
+		s.calls = make(map[*providerCall]struct{})
+	}
+	s.calls[call] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.calls, call)
+		s.mu.Unlock()
+	}()
+
 	wg := &sync.WaitGroup{}
 	resultChan := make(chan providerResponse, len(activeProviders))
 
+	// proxies and local servers may not cope with parallel requests, others wait for a free slot
+	var slots chan struct{}
+	if s.maxConcurrent > 0 && s.maxConcurrent < len(activeProviders) {
+		slots = make(chan struct{}, s.maxConcurrent)
+	}
+
 	for _, provider := range activeProviders {
 		wg.Add(1)
 		go func(ctx context.Context, provider providerAccessor) {
 			defer wg.Done()
 
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			s.mu.Lock()
+			call.cancels[provider.Name()] = cancel
+			s.mu.Unlock()
+			defer func() {
+				s.mu.Lock()
+				delete(call.cancels, provider.Name())
+				s.mu.Unlock()
+			}()
+			s.reportProgress(provider.Name(), ui.StatusPending)
+
+			// timeout starts when request is sent, not while it waits for a free slot
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+					defer func() { <-slots }()
+				case <-ctx.Done():
+					s.reportProgress(provider.Name(), ui.StatusCanceled)
+					resultChan <- providerResponse{Name: provider.Name(), Err: ctx.Err()}
+					return
+				}
+			}
+
 			s.logger.DebugContext(
 				ctx, "Requesting message from provider",
 				"provider", provider.Name(),
 			)
 
-			ctx, cancel := context.WithTimeout(ctx, s.timeout)
-			defer cancel()
+			ctx, cancelTimeout := context.WithTimeout(ctx, s.timeout)
+			defer cancelTimeout()
 
 			now := time.Now()
 
+			inputBefore, outputBefore := provider.Usage()
 			messages, err := provider.Ask(ctx, prompt)
+			if errors.Is(err, context.Canceled) {
+				s.reportProgress(provider.Name(), ui.StatusCanceled)
+			} else if err != nil || len(messages) == 0 {
+				s.reportProgress(provider.Name(), ui.StatusFailed)
+			}
 			if err != nil {
 				if !errors.Is(err, context.Canceled) {
 					s.logger.ErrorContext(
@@ -147,23 +422,57 @@ func (s *aiService) GenerateCommitMessages(
 				return
 			}
 
+			inputAfter, outputAfter := provider.Usage()
+			s.mu.Lock()
+			call.generations[provider.Name()] = generationInfo{
+				model:        provider.Model(),
+				promptHash:   promptHash,
+				inputTokens:  inputAfter - inputBefore,
+				outputTokens: outputAfter - outputBefore,
+				latency:      time.Since(now),
+			}
+			s.mu.Unlock()
+			s.reportProgress(provider.Name(), ui.StatusDone)
+
+			s.logger.DebugContext(
+				ctx, "Provider responded",
+				"provider", provider.Name(),
+				"model", provider.Model(),
+				"prompt_sha256", promptHash,
+				"input_tokens", inputAfter-inputBefore,
+				"output_tokens", outputAfter-outputBefore,
+				"latency", time.Since(now).String(),
+			)
+
 			resultChan <- providerResponse{
 				Name:    provider.Name(),
 				Message: s.cleanupMessage(messages[0]),
+				Time:    time.Since(now),
 			}
 		}(commonCtx, provider)
 	}
 
 	results := make(map[string]string)
 
-	// we want first fastest response
+	// we want first fastest valid response, requests still in flight are canceled right away
 	if first {
-		msg := <-resultChan
-		results[msg.Name] = msg.Message
+		for range activeProviders {
+			result := <-resultChan
+			if result.Err != nil || result.Message == "" {
+				continue
+			}
+			results[result.Name] = result.Message
+			s.logger.DebugContext(
+				ctx, "First response received, canceling other providers",
+				"provider", result.Name,
+				"time", result.Time.String(),
+			)
+			break
+		}
 		commonCtxCancel()
 		wg.Wait()
 		close(resultChan)
-		return results, nil
+		return results, call.generations
 	}
 
 	wg.Wait()
@@ -186,7 +495,7 @@ func (s *aiService) GenerateCommitMessages(
 		)
 	}
 
-	return results, nil
+	return results, call.generations
 }
 
 func (s *aiService) cleanupMessage(message string) string {
@@ -206,11 +515,25 @@ func (s *aiService) cleanupMessage(message string) string {
 	return message
 }
 
-func (s *aiService) buildPrompt(diff, branch string, files []string, multiLine bool) string {
+func (s *aiService) buildPrompt(
+	diff, branch string, files []string,
+	commitTemplate string, recentCommits []string,
+	multiLine bool,
+) string {
 	injectFormat := promptFormatSingle
 	if multiLine {
 		injectFormat = promptFormatMulti
 	}
+	if len(commitTemplate) > 0 {
+		injectTemplate := promptTemplateSingle
+		if multiLine {
+			injectTemplate = promptTemplateMulti
+		}
+		injectFormat += "\n" + strings.ReplaceAll(injectTemplate, "{template}", commitTemplate)
+	}
+	if len(recentCommits) > 0 {
+		injectFormat += "\n" + strings.ReplaceAll(promptHistory, "{history}", formatRecentCommits(recentCommits))
+	}
 	result := defaultPrompt
 	result = strings.ReplaceAll(result, "{format}", injectFormat)
 	result = strings.ReplaceAll(result, "{branch}", branch)
@@ -219,9 +542,23 @@ func (s *aiService) buildPrompt(diff, branch string, files []string, multiLine b
 	return result
 }
 
-func (s *aiService) buildCustomPrompt(prompt string, diff, branch string, files []string) string {
+func (s *aiService) buildCustomPrompt(
+	prompt, diff, branch string, files []string,
+	commitTemplate string, recentCommits []string,
+) string {
 	result := strings.ReplaceAll(prompt, "{branch}", branch)
+	result = strings.ReplaceAll(result, "{template}", commitTemplate)
+	result = strings.ReplaceAll(result, "{history}", formatRecentCommits(recentCommits))
 	result = strings.ReplaceAll(result, "{files}", strings.Join(files, ", "))
 	result = strings.ReplaceAll(result, "{diff}", diff)
 	return result
 }
+
+// formatRecentCommits renders commit subjects as a markdown list
+func formatRecentCommits(commits []string) string {
+	lines := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		lines = append(lines, "- "+commit)
+	}
+	return strings.Join(lines, "\n")
+}
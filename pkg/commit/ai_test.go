@@ -2,20 +2,27 @@ package commit
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
+	"math"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"go.uber.org/mock/gomock"
 
 	"github.com/hasansino/commit/pkg/commit/mocks"
+	"github.com/hasansino/commit/pkg/commit/ui"
 )
 
 func TestAIService_NumProviders(t *testing.T) {
 	logger := slog.New(slog.DiscardHandler)
-	service := newAIService(logger, 30*time.Second)
+	service := newAIService(logger, 30*time.Second, nil, 0, nil)
 
 	numProviders := service.NumProviders()
 
@@ -42,6 +49,34 @@ func TestAIService_NumProviders(t *testing.T) {
 	}
 }
 
+func TestNewAIService_RequestedProviders(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "test")
+	t.Setenv("ANTHROPIC_API_KEY", "test")
+	t.Setenv("GEMINI_API_KEY", "test")
+	logger := slog.New(slog.DiscardHandler)
+
+	tests := []struct {
+		name      string
+		requested []string
+		want      []string
+	}{
+		{name: "all when none requested", requested: nil, want: []string{"claude", "gemini", "openai"}},
+		{name: "only requested", requested: []string{"openai"}, want: []string{"openai"}},
+		{name: "case insensitive", requested: []string{"Claude", "GEMINI"}, want: []string{"claude", "gemini"}},
+		{name: "unknown", requested: []string{"unknown"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newAIService(logger, 30*time.Second, nil, 0, tt.requested)
+			got := service.ProviderNames(nil)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("providers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAIService_FilterProviders(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -139,7 +174,7 @@ func TestAIService_buildPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := service.buildPrompt(diff, branch, files, tt.multiLine)
+			result := service.buildPrompt(diff, branch, files, "", nil, tt.multiLine)
 
 			if result == "" {
 				t.Error("buildPrompt() returned empty string")
@@ -173,6 +208,43 @@ func TestAIService_buildPrompt(t *testing.T) {
 	}
 }
 
+func TestAIService_buildPrompt_CommitTemplate(t *testing.T) {
+	service := &aiService{}
+
+	template := "Why:\n\nWhat:"
+
+	withTemplate := service.buildPrompt("diff", "main", []string{"test.go"}, template, nil, true)
+	if !strings.Contains(withTemplate, template) {
+		t.Error("buildPrompt() did not include commit template")
+	}
+	if strings.Contains(withTemplate, "{template}") {
+		t.Error("buildPrompt() did not replace {template} placeholder")
+	}
+
+	withoutTemplate := service.buildPrompt("diff", "main", []string{"test.go"}, "", nil, true)
+	if strings.Contains(withoutTemplate, "# Commit Template") {
+		t.Error("buildPrompt() included commit template section without template")
+	}
+}
+
+func TestAIService_buildPrompt_RecentCommits(t *testing.T) {
+	service := &aiService{}
+	history := []string{"feat(ui): ✨ add dark mode", "fix(api): handle timeouts"}
+
+	withHistory := service.buildPrompt("diff", "main", []string{"test.go"}, "", history, false)
+	if !strings.Contains(withHistory, "- feat(ui): ✨ add dark mode\n- fix(api): handle timeouts") {
+		t.Error("buildPrompt() did not include recent commits")
+	}
+	if strings.Contains(withHistory, "{history}") {
+		t.Error("buildPrompt() did not replace {history} placeholder")
+	}
+
+	withoutHistory := service.buildPrompt("diff", "main", []string{"test.go"}, "", nil, false)
+	if strings.Contains(withoutHistory, "# Commit History") {
+		t.Error("buildPrompt() included commit history section without commits")
+	}
+}
+
 func TestAIService_buildCustomPrompt(t *testing.T) {
 	service := &aiService{}
 
@@ -216,7 +288,7 @@ func TestAIService_buildCustomPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := service.buildCustomPrompt(tt.customPrompt, tt.diff, tt.branch, tt.files)
+			result := service.buildCustomPrompt(tt.customPrompt, tt.diff, tt.branch, tt.files, "", nil)
 
 			if result == "" && tt.customPrompt != "" {
 				t.Error("buildCustomPrompt() returned empty string for non-empty prompt")
@@ -245,6 +317,9 @@ func TestAIService_GenerateCommitMessages(t *testing.T) {
 
 	mockProvider := mocks.NewMockproviderAccessor(ctrl)
 	mockProvider.EXPECT().Name().Return("testprovider").AnyTimes()
+	mockProvider.EXPECT().Model().Return("test-model").AnyTimes()
+	mockProvider.EXPECT().Usage().Return(int64(100), int64(20))
+	mockProvider.EXPECT().Usage().Return(int64(220), int64(50))
 	mockProvider.EXPECT().Ask(gomock.Any(), gomock.Any()).Return([]string{"test commit message"}, nil)
 
 	service := &aiService{
@@ -262,7 +337,7 @@ func TestAIService_GenerateCommitMessages(t *testing.T) {
 	providers := []string{"testprovider"}
 
 	messages, err := service.GenerateCommitMessages(
-		ctx, diff, branch, files, providers, "", false, false,
+		ctx, diff, branch, files, providers, "", "", nil, false, false, nil,
 	)
 
 	if err != nil {
@@ -276,6 +351,204 @@ func TestAIService_GenerateCommitMessages(t *testing.T) {
 	if messages["testprovider"] != "test commit message" {
 		t.Errorf("GenerateCommitMessages() = %q, want %q", messages["testprovider"], "test commit message")
 	}
+
+	metadata := service.GenerationMetadata("testprovider")
+	if metadata["model"] != "test-model" || metadata["input_tokens"] != "120" || metadata["output_tokens"] != "30" {
+		t.Errorf("GenerationMetadata() = %v, want test-model with 120 input and 30 output tokens", metadata)
+	}
+	if len(metadata["prompt_sha256"]) != 64 {
+		t.Errorf("GenerationMetadata() prompt hash = %q, want sha256 hex", metadata["prompt_sha256"])
+	}
+	if service.GenerationMetadata("unknown") != nil {
+		t.Errorf("GenerationMetadata() of unknown provider should be nil")
+	}
+
+	stats, ok := service.GenerationStats("testprovider")
+	if !ok || stats.InputTokens != 120 || stats.OutputTokens != 30 || stats.Cost >= 0 {
+		t.Errorf("GenerationStats() = %+v, %v, want 120 input, 30 output tokens and unknown cost", stats, ok)
+	}
+}
+
+func TestAIService_CancelProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fastProvider := mocks.NewMockproviderAccessor(ctrl)
+	fastProvider.EXPECT().Name().Return("fast").AnyTimes()
+	fastProvider.EXPECT().Model().Return("fast-model").AnyTimes()
+	fastProvider.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
+	fastProvider.EXPECT().Ask(gomock.Any(), gomock.Any()).Return([]string{"fast message"}, nil)
+
+	slowProvider := mocks.NewMockproviderAccessor(ctrl)
+	slowProvider.EXPECT().Name().Return("slow").AnyTimes()
+	slowProvider.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
+	slowProvider.EXPECT().Ask(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ string) ([]string, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	)
+
+	service := &aiService{
+		logger:  slog.New(slog.DiscardHandler),
+		timeout: 30 * time.Second,
+		providers: map[string]providerAccessor{
+			"fast": fastProvider,
+			"slow": slowProvider,
+		},
+	}
+
+	if names := service.ProviderNames(nil); strings.Join(names, ",") != "fast,slow" {
+		t.Errorf("ProviderNames() = %v, want [fast slow]", names)
+	}
+
+	var mu sync.Mutex
+	statuses := make(map[string]ui.ProviderStatus)
+	service.SetProgress(func(provider string, status ui.ProviderStatus) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses[provider] = status
+		// user cancels slow provider as soon as it is requested
+		if provider == "slow" && status == ui.StatusPending {
+			service.CancelProvider("slow")
+		}
+	})
+
+	messages, err := service.Ask(context.Background(), nil, "prompt", false)
+	if err != nil {
+		t.Fatalf("Ask() unexpected error = %v", err)
+	}
+	if len(messages) != 1 || messages["fast"] != "fast message" {
+		t.Errorf("Ask() = %v, want only fast message", messages)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if statuses["fast"] != ui.StatusDone || statuses["slow"] != ui.StatusCanceled {
+		t.Errorf("statuses = %v, want fast done and slow canceled", statuses)
+	}
+}
+
+func TestAIService_OverlappingCalls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan string, 2)
+	releaseGenerate := make(chan struct{})
+
+	provider := mocks.NewMockproviderAccessor(ctrl)
+	provider.EXPECT().Name().Return("shared").AnyTimes()
+	provider.EXPECT().Model().Return("model").AnyTimes()
+	provider.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
+	provider.EXPECT().Ask(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, prompt string) ([]string, error) {
+			if strings.Contains(prompt, "diff --git a/generated.go") {
+				started <- "generate"
+				<-releaseGenerate
+				return []string{"feat: generated"}, nil
+			}
+			started <- "ask"
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	).Times(2)
+
+	service := &aiService{
+		logger:    slog.New(slog.DiscardHandler),
+		timeout:   30 * time.Second,
+		providers: map[string]providerAccessor{"shared": provider},
+	}
+
+	generated := make(chan map[string]string, 1)
+	go func() {
+		messages, _ := service.GenerateCommitMessages(
+			context.Background(), "diff --git a/generated.go", "main", []string{"generated.go"},
+			nil, "", "", nil, false, false, nil,
+		)
+		generated <- messages
+	}()
+	if got := <-started; got != "generate" {
+		t.Fatalf("first request = %s, want generate", got)
+	}
+
+	asked := make(chan map[string]string, 1)
+	go func() {
+		messages, _ := service.Ask(context.Background(), nil, "prompt", false)
+		asked <- messages
+	}()
+	if got := <-started; got != "ask" {
+		t.Fatalf("second request = %s, want ask", got)
+	}
+
+	// completed call must not drop cancel of the one still in flight
+	close(releaseGenerate)
+	if messages := <-generated; messages["shared"] != "feat: generated" {
+		t.Errorf("GenerateCommitMessages() = %v, want generated message", messages)
+	}
+	service.CancelProvider("shared")
+	select {
+	case messages := <-asked:
+		if len(messages) != 0 {
+			t.Errorf("Ask() = %v, want no messages of canceled provider", messages)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("CancelProvider() did not cancel request of overlapping call")
+	}
+
+	metadata := service.GenerationMetadata("shared")
+	if metadata == nil {
+		t.Fatal("GenerationMetadata() = nil, want metadata of generated message")
+	}
+	prompt := service.BuildPrompt("diff --git a/generated.go", "main", []string{"generated.go"}, "", "", nil, false)
+	promptSum := sha256.Sum256([]byte(prompt))
+	if metadata["prompt_sha256"] != hex.EncodeToString(promptSum[:]) {
+		t.Errorf("GenerationMetadata() prompt hash = %q, want hash of generation prompt", metadata["prompt_sha256"])
+	}
+}
+
+func TestAIService_EstimateRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	knownProvider := mocks.NewMockproviderAccessor(ctrl)
+	knownProvider.EXPECT().Name().Return("openai").AnyTimes()
+	knownProvider.EXPECT().Model().Return("gpt-4o-mini").AnyTimes()
+
+	unknownProvider := mocks.NewMockproviderAccessor(ctrl)
+	unknownProvider.EXPECT().Name().Return("local").AnyTimes()
+	unknownProvider.EXPECT().Model().Return("llama-3").AnyTimes()
+
+	service := &aiService{
+		logger: slog.New(slog.DiscardHandler),
+		providers: map[string]providerAccessor{
+			"openai": knownProvider,
+			"local":  unknownProvider,
+		},
+	}
+
+	estimates := service.EstimateRequests(nil, strings.Repeat("a", 4000))
+	if len(estimates) != 2 {
+		t.Fatalf("EstimateRequests() returned %d estimates, want 2", len(estimates))
+	}
+
+	local, openai := estimates[0], estimates[1]
+	if local.Provider != "local" || openai.Provider != "openai" {
+		t.Errorf("EstimateRequests() order = %s, %s, want local, openai", local.Provider, openai.Provider)
+	}
+	if openai.InputTokens != 1000 || openai.OutputTokens != estimatedOutputTokens {
+		t.Errorf("EstimateRequests() tokens = %d→%d, want 1000→%d",
+			openai.InputTokens, openai.OutputTokens, estimatedOutputTokens)
+	}
+	if want := (1000*0.15 + estimatedOutputTokens*0.60) / 1e6; math.Abs(openai.Cost-want) > 1e-12 {
+		t.Errorf("EstimateRequests() cost = %v, want %v", openai.Cost, want)
+	}
+	if local.Cost >= 0 {
+		t.Errorf("EstimateRequests() cost of unknown model = %v, want negative", local.Cost)
+	}
+
+	if filtered := service.EstimateRequests([]string{"openai"}, "prompt"); len(filtered) != 1 {
+		t.Errorf("EstimateRequests() with filter returned %d estimates, want 1", len(filtered))
+	}
 }
 
 func TestAIService_GenerateCommitMessages_NoProviders(t *testing.T) {
@@ -292,7 +565,7 @@ func TestAIService_GenerateCommitMessages_NoProviders(t *testing.T) {
 	providers := []string{"nonexistent"}
 
 	_, err := service.GenerateCommitMessages(
-		ctx, diff, branch, files, providers, "", false, false,
+		ctx, diff, branch, files, providers, "", "", nil, false, false, nil,
 	)
 
 	if err == nil {
@@ -311,10 +584,14 @@ func TestAIService_GenerateCommitMessages_FirstMode(t *testing.T) {
 
 	mockProvider1 := mocks.NewMockproviderAccessor(ctrl)
 	mockProvider1.EXPECT().Name().Return("provider1").AnyTimes()
+	mockProvider1.EXPECT().Model().Return("test-model").AnyTimes()
+	mockProvider1.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
 	mockProvider1.EXPECT().Ask(gomock.Any(), gomock.Any()).Return([]string{"first message"}, nil).AnyTimes()
 
 	mockProvider2 := mocks.NewMockproviderAccessor(ctrl)
 	mockProvider2.EXPECT().Name().Return("provider2").AnyTimes()
+	mockProvider2.EXPECT().Model().Return("test-model").AnyTimes()
+	mockProvider2.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
 	mockProvider2.EXPECT().Ask(gomock.Any(), gomock.Any()).Return([]string{"second message"}, nil).AnyTimes()
 
 	service := &aiService{
@@ -333,7 +610,7 @@ func TestAIService_GenerateCommitMessages_FirstMode(t *testing.T) {
 	providers := []string{}
 
 	messages, err := service.GenerateCommitMessages(
-		ctx, diff, branch, files, providers, "", true, false, // first = true
+		ctx, diff, branch, files, providers, "", "", nil, true, false, nil, // first = true
 	)
 
 	if err != nil {
@@ -358,12 +635,134 @@ func TestAIService_GenerateCommitMessages_FirstMode(t *testing.T) {
 	}
 }
 
+func TestAIService_GenerateCommitMessages_FirstModeCancelsOthers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failingProvider := mocks.NewMockproviderAccessor(ctrl)
+	failingProvider.EXPECT().Name().Return("failing").AnyTimes()
+	failingProvider.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
+	failingProvider.EXPECT().Ask(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("provider error"))
+
+	fastProvider := mocks.NewMockproviderAccessor(ctrl)
+	fastProvider.EXPECT().Name().Return("fast").AnyTimes()
+	fastProvider.EXPECT().Model().Return("fast-model").AnyTimes()
+	fastProvider.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
+	fastProvider.EXPECT().Ask(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ string) ([]string, error) {
+			time.Sleep(20 * time.Millisecond) // failing provider answers first
+			return []string{"fast message"}, nil
+		},
+	)
+
+	var slowCanceled atomic.Bool
+	slowProvider := mocks.NewMockproviderAccessor(ctrl)
+	slowProvider.EXPECT().Name().Return("slow").AnyTimes()
+	slowProvider.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
+	slowProvider.EXPECT().Ask(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ string) ([]string, error) {
+			select {
+			case <-ctx.Done():
+				slowCanceled.Store(true)
+				return nil, ctx.Err()
+			case <-time.After(10 * time.Second):
+				return []string{"slow message"}, nil
+			}
+		},
+	)
+
+	service := &aiService{
+		logger:  slog.New(slog.DiscardHandler),
+		timeout: 30 * time.Second,
+		providers: map[string]providerAccessor{
+			"failing": failingProvider,
+			"fast":    fastProvider,
+			"slow":    slowProvider,
+		},
+	}
+
+	goroutines := runtime.NumGoroutine()
+	start := time.Now()
+
+	messages, err := service.Ask(context.Background(), nil, "prompt", true)
+	if err != nil {
+		t.Fatalf("Ask() unexpected error = %v", err)
+	}
+	if len(messages) != 1 || messages["fast"] != "fast message" {
+		t.Errorf("Ask() = %v, want only fast message", messages)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Ask() took %s, slow provider was not canceled", elapsed)
+	}
+	if !slowCanceled.Load() {
+		t.Error("slow provider was not canceled")
+	}
+
+	// provider goroutines are done when Ask returns, give runtime a moment to reap them
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if leaked := runtime.NumGoroutine() - goroutines; leaked > 0 {
+		t.Errorf("%d goroutines leaked", leaked)
+	}
+}
+
+func TestAIService_Ask_MaxConcurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var inFlight, maxInFlight atomic.Int32
+	providers := make(map[string]providerAccessor)
+	for _, name := range []string{"one", "two", "three"} {
+		provider := mocks.NewMockproviderAccessor(ctrl)
+		provider.EXPECT().Name().Return(name).AnyTimes()
+		provider.EXPECT().Model().Return("model").AnyTimes()
+		provider.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
+		provider.EXPECT().Ask(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ string) ([]string, error) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					highest := maxInFlight.Load()
+					if current <= highest || maxInFlight.CompareAndSwap(highest, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				return []string{name + " message"}, nil
+			},
+		)
+		providers[name] = provider
+	}
+
+	service := &aiService{
+		logger:        slog.New(slog.DiscardHandler),
+		timeout:       30 * time.Second,
+		maxConcurrent: 1,
+		providers:     providers,
+	}
+
+	messages, err := service.Ask(context.Background(), nil, "prompt", false)
+	if err != nil {
+		t.Fatalf("Ask() unexpected error = %v", err)
+	}
+	if len(messages) != 3 {
+		t.Errorf("Ask() = %v, want messages of all providers", messages)
+	}
+	if got := maxInFlight.Load(); got != 1 {
+		t.Errorf("requests in flight = %d, want 1", got)
+	}
+}
+
 func TestAIService_GenerateCommitMessages_ContextCancellation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockProvider := mocks.NewMockproviderAccessor(ctrl)
 	mockProvider.EXPECT().Name().Return("testprovider").AnyTimes()
+	mockProvider.EXPECT().Model().Return("test-model").AnyTimes()
+	mockProvider.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
 	mockProvider.EXPECT().Ask(gomock.Any(), gomock.Any()).DoAndReturn(
 		func(ctx context.Context, prompt string) ([]string, error) {
 			// Simulate slow provider that gets cancelled
@@ -393,7 +792,7 @@ func TestAIService_GenerateCommitMessages_ContextCancellation(t *testing.T) {
 	providers := []string{"testprovider"}
 
 	messages, err := service.GenerateCommitMessages(
-		ctx, diff, branch, files, providers, "", false, false,
+		ctx, diff, branch, files, providers, "", "", nil, false, false, nil,
 	)
 
 	if err != nil {
@@ -412,6 +811,8 @@ func TestAIService_GenerateCommitMessages_ProviderError(t *testing.T) {
 
 	mockProvider := mocks.NewMockproviderAccessor(ctrl)
 	mockProvider.EXPECT().Name().Return("errorprovider").AnyTimes()
+	mockProvider.EXPECT().Model().Return("test-model").AnyTimes()
+	mockProvider.EXPECT().Usage().Return(int64(0), int64(0)).AnyTimes()
 	mockProvider.EXPECT().Ask(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("provider error"))
 
 	service := &aiService{
@@ -429,7 +830,7 @@ func TestAIService_GenerateCommitMessages_ProviderError(t *testing.T) {
 	providers := []string{"errorprovider"}
 
 	messages, err := service.GenerateCommitMessages(
-		ctx, diff, branch, files, providers, "", false, false,
+		ctx, diff, branch, files, providers, "", "", nil, false, false, nil,
 	)
 
 	if err != nil {
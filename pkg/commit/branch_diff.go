@@ -0,0 +1,105 @@
+package commit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hasansino/commit/pkg/commit/ui"
+)
+
+// SummarizeBranch generates a single message describing all changes of current branch,
+// e.g. for squash-merge commits or pull request titles, and prints it without committing
+func (s *Service) SummarizeBranch(ctx context.Context) error {
+	if err := s.checkRepository(ctx); err != nil {
+		return err
+	}
+
+	if err := s.ensureFullHistory(ctx, "branch diff"); err != nil {
+		return err
+	}
+
+	diff, files, err := s.gitOps.GetBranchDiff(
+		s.settings.BranchBase, s.settings.PushRemote,
+		s.settings.MaxDiffSizeBytes,
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get branch diff", "error", err)
+		return classify(ErrGit, fmt.Errorf("failed to get diff: %w", err))
+	}
+
+	if strings.TrimSpace(diff) == "" {
+		s.logger.WarnContext(ctx, "Branch has no changes against its base")
+		return ErrNothingToCommit
+	}
+
+	branch, promptBranch, err := s.currentBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	commitTemplate, err := s.gitOps.GetCommitTemplate()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load commit template", "error", err)
+	}
+
+	confirmed, err := s.confirmCost(ctx, diff, promptBranch, files, commitTemplate, nil)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		s.logger.WarnContext(ctx, "Generation canceled by user")
+		return ErrCanceled
+	}
+
+	s.logger.DebugContext(ctx, "Requesting branch summaries...", "files", len(files))
+
+	messages, err := s.aiService.GenerateCommitMessages(
+		ctx,
+		diff, promptBranch, files,
+		s.settings.Providers, s.settings.CustomPrompt, commitTemplate,
+		nil,
+		s.settings.First, s.settings.MultiLine,
+		s.promptTransformer(branch),
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to generate commit messages", "error", err)
+		return classify(ErrProvider, fmt.Errorf("failed to generate suggestions: %w", err))
+	}
+
+	var message string
+
+	if s.settings.Auto {
+		message = s.getRandomMessage(messages)
+	} else if s.settings.Yes {
+		message = s.topRankedMessage(ctx, messages)
+	} else {
+		uiModel, err := ui.RenderInteractiveUI(ctx, messages, nil, s.accessibleOptions()...)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				s.logger.WarnContext(ctx, "Interactive mode canceled by user")
+				return ErrCanceled
+			}
+			s.logger.ErrorContext(ctx, "Failed to enter interactive mode", "error", err)
+			return fmt.Errorf("failed to run interactive ui: %w", err)
+		}
+		message = uiModel.GetFinalChoice()
+	}
+
+	if len(message) == 0 {
+		s.logger.WarnContext(ctx, "No commit message provided")
+		return fmt.Errorf("no commit message provided")
+	}
+
+	message, err = s.applyModules(ctx, branch, message)
+	if err != nil {
+		return err
+	}
+	message = strings.TrimSpace(message)
+
+	// printed as is, so it can be piped into merge or pull request tooling
+	fmt.Println(message)
+
+	return nil
+}
@@ -5,20 +5,47 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"sync"
+
+	"github.com/atotto/clipboard"
 
 	"github.com/hasansino/commit/pkg/commit/modules"
 	"github.com/hasansino/commit/pkg/commit/ui"
+	"github.com/hasansino/commit/pkg/gitops"
 )
 
 const defaultRepoPath = "."
 
+// defaultTagPrefix is used when Settings.TagPrefix is empty
+const defaultTagPrefix = "v"
+
+// emptyCommitDiff replaces the diff in prompts when --allow-empty commit has no changes
+const emptyCommitDiff = "(no file changes: this is an intentionally empty commit, e.g. to trigger CI)"
+
+// userMessageProvider names message provided with --message among suggestions
+const userMessageProvider = "user"
+
 type Service struct {
 	logger    *slog.Logger
 	settings  *Settings
 	gitOps    gitOperationsAccessor
 	aiService aiServiceAccessor
 	modules   []moduleAccessor
+	validator taskValidatorAccessor // nil unless task validation is enabled
+	notifiers []commitNotifierAccessor
+
+	summarizer      aiServiceAccessor          // providers summarizing chunks of huge diffs, nil to use aiService
+	dependencyFiles modules.ChangedFilesSource // nil unless dependency bump messages are enabled
+	history         *historyStore              // nil unless history is enabled
+	prefs           *prefsStore                // nil unless interactive mode preferences are remembered
+	gitConfig       modules.ConfigSource       // identity of Signed-off-by trailer
+	repoless        bool                       // diff file is described outside of any repository
+	scopeDir        string                     // scope directory relative to repository root, empty for whole tree
+	plugins         *modules.WASMPlugins       // nil unless plugin directory is given
 }
 
 func NewCommitService(settings *Settings, opts ...Option) (*Service, error) {
@@ -39,13 +66,52 @@ func NewCommitService(settings *Settings, opts ...Option) (*Service, error) {
 		svc.logger = slog.New(slog.DiscardHandler)
 	}
 
-	git, err := newGitOperations(defaultRepoPath)
+	// --repo takes precedence over GIT_WORK_TREE, like git -C does
+	repoPath := settings.RepoPath
+	if repoPath == "" {
+		repoPath = os.Getenv("GIT_WORK_TREE")
+	}
+	if repoPath == "" {
+		repoPath = defaultRepoPath
+	}
+
+	pureGo := settings.PureGo
+	if _, err := exec.LookPath("git"); err != nil && !pureGo {
+		svc.logger.Warn("Git binary not found, falling back to go-git", "error", err)
+		pureGo = true
+	}
+
+	platformHosts, _ := parsePlatformHosts(settings.PlatformHosts) // validated with settings
+
+	gitOptions := []gitops.Option{
+		gitops.WithDiffOptions(newDiffOptions(settings)),
+		gitops.WithPureGo(pureGo),
+		gitops.WithTrace(settings.TraceGit),
+		gitops.WithPlatformHosts(platformHosts),
+	}
+	git, err := gitops.Open(repoPath, gitOptions...)
+	if errors.Is(err, gitops.ErrNotRepository) && settings.DiffFile != "" {
+		// Supplied diff needs no repository, it only adds context when there is one
+		svc.logger.Debug("Not in a git repository, diff is described without repository context")
+		git, err = gitops.OpenEmpty(gitOptions...)
+		svc.repoless = true
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize git operations: %w", err)
 	}
 
 	svc.gitOps = git
-	svc.aiService = newAIService(svc.logger, settings.Timeout)
+	svc.gitConfig = git.GetConfigValue
+	models, _ := parseModels(settings.Models) // validated with settings
+	svc.aiService = newAIService(
+		svc.logger, settings.Timeout, models, settings.MaxConcurrent, settings.Providers,
+	)
+	if len(settings.SummaryModels) > 0 {
+		summaryModels, _ := parseModels(settings.SummaryModels) // validated with settings
+		svc.summarizer = newAIService(
+			svc.logger, settings.Timeout, summaryModels, settings.MaxConcurrent, settings.Providers,
+		)
+	}
 
 	// Parse Jira task position
 	var jiraPosition modules.JiraTaskPosition
@@ -73,137 +139,502 @@ func NewCommitService(settings *Settings, opts ...Option) (*Service, error) {
 		jiraStyle = modules.JiraTaskStylePlain
 	}
 
-	svc.modules = append(svc.modules, modules.NewJIRATaskDetector(jiraPosition, jiraStyle))
+	// normalization goes first, other modules decorate normalized message
+	if settings.SubjectCase != "" && settings.SubjectCase != string(modules.SubjectCaseKeep) ||
+		settings.StripPeriod ||
+		settings.Imperative != "" && settings.Imperative != string(modules.ImperativeModeOff) {
+		svc.modules = append(svc.modules, modules.NewMessageNormalizer(
+			modules.SubjectCase(settings.SubjectCase), settings.StripPeriod,
+			modules.ImperativeMode(settings.Imperative), svc.rewriteImperative,
+		))
+	}
 
-	return svc, nil
-}
+	if settings.ScopeDir != "" {
+		scopeDir, err := git.ResolveScopeDir(settings.ScopeDir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scope directory: %w", err)
+		}
+		if scopeDir != "" {
+			absScopeDir, err := filepath.Abs(settings.ScopeDir)
+			if err != nil {
+				return nil, fmt.Errorf("invalid scope directory: %w", err)
+			}
+			svc.modules = append(svc.modules, modules.NewScopeInjector(deriveScopeName(absScopeDir)))
+		}
+		svc.scopeDir = scopeDir
+	} else if settings.InferScope != "" && settings.InferScope != string(modules.ScopeInferenceOff) {
+		// staged paths are relative to worktree root
+		svc.modules = append(svc.modules, modules.NewScopeInferrer(
+			git.Root(), modules.ScopeInference(settings.InferScope), git.GetFilteredStagedFiles,
+		))
+	}
 
-func (s *Service) Execute(ctx context.Context) error {
-	if s.aiService.NumProviders() == 0 {
-		s.logger.WarnContext(ctx, "No providers configured")
-		return fmt.Errorf("no api keys found in environment")
+	jiraDetector := modules.NewJIRATaskDetector(jiraPosition, jiraStyle)
+	jiraValidate := settings.JiraValidate != "" && settings.JiraValidate != "off"
+	jiraCommitActions := settings.JiraComment || settings.JiraTransition != ""
+	if settings.JiraEnrich || jiraValidate || jiraCommitActions {
+		jiraClient := modules.NewJiraClient()
+		switch {
+		case jiraClient.IsAvailable():
+			jiraClient.SetTimeout(settings.Timeout)
+			jiraDetector.WithClient(jiraClient, settings.JiraEnrich)
+			if jiraValidate {
+				svc.validator = jiraDetector
+			}
+			if jiraCommitActions {
+				jiraDetector.WithCommitActions(settings.JiraComment, settings.JiraTransition)
+				svc.notifiers = append(svc.notifiers, jiraDetector)
+			}
+		case jiraValidate && settings.JiraValidate == "refuse":
+			return nil, fmt.Errorf("jira validation requires JIRA_URL and JIRA_API_TOKEN")
+		default:
+			svc.logger.Warn("Jira integration requires JIRA_URL and JIRA_API_TOKEN, skipping")
+		}
 	}
+	svc.modules = append(svc.modules, jiraDetector)
 
-	if !s.gitOps.IsGitRepository() {
-		return fmt.Errorf("not a git repository")
+	if len(settings.ChatWebhooks) > 0 {
+		chatNotifier := modules.NewChatNotifier(settings.ChatWebhooks, settings.ChatNotifyOn)
+		chatNotifier.SetTimeout(settings.Timeout)
+		svc.notifiers = append(svc.notifiers, chatNotifier)
+	}
+	if len(settings.Webhooks) > 0 {
+		webhooks := modules.NewLifecycleWebhooks(settings.Webhooks, settings.WebhookEvents, settings.WebhookSecret)
+		webhooks.SetTimeout(settings.Timeout)
+		svc.notifiers = append(svc.notifiers, webhooks)
 	}
 
-	repoStateStr, err := s.gitOps.GetRepoState()
-	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to get repository state", "error", err)
-		return fmt.Errorf("failed to get repository state: %w", err)
+	// Parse Azure Boards work item placement
+	var azurePlacement modules.AzureWorkItemPlacement
+	switch strings.ToLower(settings.AzureWorkItem) {
+	case string(modules.AzureWorkItemPlacementSubject):
+		azurePlacement = modules.AzureWorkItemPlacementSubject
+	case string(modules.AzureWorkItemPlacementFooter):
+		azurePlacement = modules.AzureWorkItemPlacementFooter
+	default:
+		azurePlacement = modules.AzureWorkItemPlacementNone
 	}
 
-	if repoStateStr != RepoStateNormal {
-		s.logger.ErrorContext(ctx, "Repository not in normal state", "state", repoStateStr)
-		return fmt.Errorf("repository is in %s state, cannot create commit", repoStateStr)
+	svc.modules = append(svc.modules, modules.NewAzureWorkItemLinker(azurePlacement))
+
+	if settings.TicketPattern != "" {
+		ticketDetector, err := modules.NewTicketDetector(
+			settings.TicketPattern, settings.TicketFormat,
+			modules.TicketPosition(strings.ToLower(settings.TicketPosition)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize ticket detector: %w", err)
+		}
+		svc.modules = append(svc.modules, ticketDetector)
 	}
 
-	hasConflicts, _, err := s.gitOps.HasConflicts()
-	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to check for conflicts", "error", err)
-		return fmt.Errorf("failed to check for conflicts: %w", err)
+	if settings.DepsMessage {
+		svc.dependencyFiles = func() ([]modules.ChangedFile, error) {
+			return git.GetStagedFileContents(isDependencyFile)
+		}
 	}
 
-	if hasConflicts {
-		s.logger.ErrorContext(ctx, "Unresolved conflicts detected")
-		return fmt.Errorf("unresolved conflicts detected")
+	if settings.DetectBreaking {
+		svc.modules = append(svc.modules, modules.NewBreakingChangeDetector(git.GetStagedGoFiles))
 	}
 
-	s.logger.DebugContext(ctx, "Unstaging all files...")
+	if settings.CloseIssues != "" && settings.CloseIssues != "off" {
+		issueCloser, err := newIssueCloser(git, settings.PushRemote, settings.CloseIssues)
+		if err != nil {
+			return nil, err
+		}
+		if issueCloser != nil {
+			svc.modules = append(svc.modules, issueCloser)
+		} else {
+			svc.logger.Warn("Issue closing keywords are supported on GitHub and GitLab remotes only, skipping")
+		}
+	}
 
-	if err := s.gitOps.UnstageAll(); err != nil {
-		s.logger.ErrorContext(ctx, "Failed to unstage files", "error", err)
-		return fmt.Errorf("failed to unstage files: %w", err)
+	if len(settings.CoAuthors) > 0 || settings.Pairing {
+		// .pairs is looked up in worktree root first
+		pairsFiles := []string{filepath.Join(git.Root(), ".pairs")}
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			pairsFiles = append(pairsFiles, filepath.Join(homeDir, ".pairs"))
+		}
+		svc.modules = append(svc.modules, modules.NewCoAuthorDetector(
+			settings.CoAuthors, settings.Pairing, git.GetConfigValue, pairsFiles,
+		))
 	}
 
-	s.logger.DebugContext(ctx, "Staging files...")
+	// external modules and template are resolved before plugins are loaded,
+	// so invalid settings do not leave plugin runtime open
+	execModules := make([]moduleAccessor, 0, len(settings.ExecModules))
+	for _, path := range settings.ExecModules {
+		// resolved before entering worktree root, relative paths are given from current directory
+		resolved, err := exec.LookPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid external module: %w", err)
+		}
+		if resolved, err = filepath.Abs(resolved); err != nil {
+			return nil, fmt.Errorf("invalid external module: %w", err)
+		}
+		execModules = append(execModules, modules.NewExternalModule(resolved))
+	}
 
-	stagedFiles, err := s.gitOps.StageFiles(
-		s.settings.ExcludePatterns,
-		s.settings.IncludePatterns,
-		s.settings.UseGlobalGitignore,
-	)
+	var templateRewriter *modules.TemplateRewriter
+	if settings.MessageTemplate != "" {
+		text, err := os.ReadFile(settings.MessageTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message template: %w", err)
+		}
+		templateRewriter, err = modules.NewTemplateRewriter(string(text))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.PluginDir != "" {
+		var cacheDir string
+		if userCacheDir, err := os.UserCacheDir(); err == nil {
+			cacheDir = filepath.Join(userCacheDir, "commit", "plugins")
+		}
+		plugins, err := modules.LoadWASMModules(
+			context.Background(), settings.PluginDir, modules.WithCompilationCacheDir(cacheDir),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load plugins: %w", err)
+		}
+		svc.plugins = plugins
+		for _, plugin := range plugins.Modules {
+			svc.modules = append(svc.modules, plugin)
+		}
+	}
+
+	svc.modules = append(svc.modules, execModules...)
+
+	// template gives final shape to message decorated by modules above
+	if templateRewriter != nil {
+		svc.modules = append(svc.modules, templateRewriter)
+	}
+
+	if settings.TranslateTo != "" {
+		svc.modules = append(svc.modules, modules.NewMessageTranslator(
+			settings.TranslateTo, modules.TranslationMode(settings.TranslateMode), svc.translateMessage,
+		))
+	}
+
+	if settings.ASCIIOnly {
+		svc.modules = append(svc.modules, modules.NewASCIIFilter())
+	}
+
+	// banned words are checked after all modules which could add them
+	if settings.BannedWordsAction != "" && settings.BannedWordsAction != string(modules.BannedWordActionOff) {
+		svc.modules = append(svc.modules, modules.NewBannedWordFilter(
+			modules.BannedWordAction(settings.BannedWordsAction), settings.BannedWords,
+		))
+	}
+
+	// formatting goes last, after other modules added their parts to the message
+	if settings.SubjectLimit > 0 || settings.BodyWidth > 0 {
+		svc.modules = append(svc.modules, modules.NewMessageWrapper(
+			settings.SubjectLimit, settings.BodyWidth,
+			modules.SubjectOverflow(settings.SubjectOverflow),
+		))
+	}
+
+	if userCacheDir, err := os.UserCacheDir(); err == nil {
+		if settings.History {
+			svc.history = newHistoryStore(filepath.Join(userCacheDir, "commit", "history"), git.Root())
+		}
+		if settings.RememberUI {
+			svc.prefs = newPrefsStore(filepath.Join(userCacheDir, "commit", "ui"), git.Root())
+		}
+	}
+
+	return svc, nil
+}
+
+// Close releases resources held by service, e.g. runtime of WASM plugins
+func (s *Service) Close(ctx context.Context) error {
+	if err := s.plugins.Close(ctx); err != nil {
+		return fmt.Errorf("failed to close plugins: %w", err)
+	}
+	return nil
+}
+
+// newDiffOptions returns diff options of settings, zero settings keep diffs tuned
+// like they were before options were configurable
+func newDiffOptions(settings *Settings) gitops.DiffOptions {
+	options := gitops.DefaultDiffOptions
+	if settings.DiffAlgorithm != "" {
+		options.Algorithm = settings.DiffAlgorithm
+	}
+	if settings.RenameThreshold != 0 {
+		options.RenameThreshold = max(settings.RenameThreshold, 0)
+	}
+	options.FunctionContext = !settings.NoFunctionContext
+	options.Compress = settings.CompressDiff
+	return options
+}
+
+func (s *Service) Execute(ctx context.Context) error {
+	if s.settings.BranchDiff {
+		return s.SummarizeBranch(ctx)
+	}
+
+	if s.settings.DiffFile != "" {
+		return s.GenerateFromDiff(ctx)
+	}
+
+	if err := s.checkRepository(ctx); err != nil {
+		return err
+	}
+
+	stagedFiles, err := s.stageChanges(ctx)
 	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to stage files", "error", err)
-		return fmt.Errorf("failed to stage files: %w", err)
+		return classify(ErrGit, err)
 	}
 
-	if len(stagedFiles) == 0 {
+	if len(stagedFiles) == 0 && !s.settings.AllowEmpty {
 		s.logger.WarnContext(ctx, "No files to commit")
-		return nil
+		return ErrNothingToCommit
+	}
+
+	if err := s.checkLargeBinaries(ctx); err != nil {
+		return err
+	}
+
+	if err := s.checkConflictMarkers(ctx); err != nil {
+		return err
+	}
+
+	// Keep unrelated work out of the way of hooks and commit, until we are done
+	if s.settings.StashUnrelated {
+		stashed, err := s.gitOps.StashUnstaged()
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to stash unrelated changes", "error", err)
+			return fmt.Errorf("failed to stash unrelated changes: %w", err)
+		}
+		if stashed {
+			s.logger.DebugContext(ctx, "Stashed unrelated worktree changes")
+			defer s.restoreStash(ctx)
+		}
 	}
 
 	s.logger.DebugContext(ctx, "Getting staged diff...")
 
-	diff, err := s.gitOps.GetStagedDiff(s.settings.MaxDiffSizeBytes)
+	diff, err := s.gitOps.GetStagedDiff(s.settings.MaxDiffSizeBytes, s.settings.NewFileHeadLines)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Failed to get staged diff", "error", err)
-		return fmt.Errorf("failed to get diff: %w", err)
+		return classify(ErrGit, fmt.Errorf("failed to get diff: %w", err))
 	}
 
 	if strings.TrimSpace(diff) == "" {
-		s.logger.WarnContext(ctx, "No changes staged for commit")
-		return nil
+		if !s.settings.AllowEmpty {
+			s.logger.WarnContext(ctx, "No changes staged for commit")
+			return ErrNothingToCommit
+		}
+		s.logger.InfoContext(ctx, "No changes staged, creating empty commit")
+		diff = emptyCommitDiff
+	} else if s.settings.MapReduce && strings.Contains(diff, gitops.DiffTruncatedMarker) {
+		// diff was cut even without context, summaries of its chunks describe all of it
+		if diff, err = s.reduceDiff(ctx, diff); err != nil {
+			return err
+		}
 	}
 
-	branch, err := s.gitOps.GetCurrentBranch()
+	// Submodule bumps are opaque in the diff, describe them explicitly
+	submoduleSummary, err := s.gitOps.GetSubmoduleSummary(s.settings.SubmoduleLog)
 	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to get current branch", "error", err)
-		return fmt.Errorf("failed to get current branch: %w", err)
+		s.logger.WarnContext(ctx, "Failed to summarize submodule changes", "error", err)
+	} else if submoduleSummary != "" {
+		diff = submoduleSummary + "\n\n" + diff
 	}
 
-	s.logger.DebugContext(ctx, "Requesting commit messages...")
+	branch, promptBranch, err := s.currentBranch(ctx)
+	if err != nil {
+		return classify(ErrGit, err)
+	}
 
-	messages, err := s.aiService.GenerateCommitMessages(
-		ctx,
-		diff, branch, stagedFiles,
-		s.settings.Providers, s.settings.CustomPrompt,
-		s.settings.First, s.settings.MultiLine,
-	)
+	if err := s.validateTask(ctx, branch); err != nil {
+		return err
+	}
+
+	// message of user skips generation, but goes through the same modules and commit flow
+	if s.settings.Message != "" {
+		s.logger.DebugContext(ctx, "Using provided message, generation skipped")
+		return s.processCommitMessages(ctx, map[string]string{userMessageProvider: s.settings.Message}, branch)
+	}
+
+	// dependency bumps are described by version delta better than by providers
+	if message := s.dependencyBumpMessage(ctx, stagedFiles); message != "" {
+		s.logger.InfoContext(ctx, "Only dependencies changed, message generated without providers")
+		return s.processCommitMessages(ctx, map[string]string{dependencyBumpProvider: message}, branch)
+	}
+
+	commitTemplate, err := s.gitOps.GetCommitTemplate()
 	if err != nil {
-		s.logger.ErrorContext(ctx, "Failed to generate commit messages", "error", err)
-		return fmt.Errorf("failed to generate suggestions: %w", err)
+		// template is only guidance for providers, do not fail the commit
+		s.logger.WarnContext(ctx, "Failed to load commit template", "error", err)
+	}
+
+	var recentCommits []string
+	if s.settings.RecentCommits > 0 {
+		// history is only style guidance for providers, do not fail the commit
+		recentCommits, err = s.gitOps.GetRecentCommits(s.settings.RecentCommits)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Failed to load recent commits", "error", err)
+		}
 	}
 
-	return s.processCommitMessages(ctx, messages, branch)
+	// generation is repeated from interactive mode, optionally with extra instruction of user
+	generate := func(ctx context.Context, instruction string) (map[string]string, error) {
+		messages, err := s.aiService.GenerateCommitMessages(
+			ctx,
+			diff, promptBranch, stagedFiles,
+			s.settings.Providers, s.settings.CustomPrompt, commitTemplate,
+			recentCommits,
+			s.settings.First, s.settings.MultiLine,
+			s.instructedPromptTransformer(branch, instruction),
+		)
+		if err == nil {
+			s.recordHistory(ctx, branch, diff, messages)
+			s.notifySuggestions(ctx, branch, messages)
+		}
+		return messages, err
+	}
+
+	// files toggled in interactive mode are restaged, next generation uses new diff
+	restage := func(ctx context.Context, files []string) (string, error) {
+		restagedDiff, err := s.restageFiles(ctx, files)
+		if err != nil {
+			return "", err
+		}
+		if submoduleSummary != "" {
+			restagedDiff = submoduleSummary + "\n\n" + restagedDiff
+		}
+		diff, stagedFiles = restagedDiff, files
+		return diff, nil
+	}
+	if s.settings.StashUnrelated {
+		// unstaged files would collide with stashed changes when stash is restored
+		restage = nil
+	}
+
+	// suggestions of aborted runs for the same changes can be recalled
+	previous := s.previousSuggestions(ctx, diff)
+
+	// unchanged staged content and options restore suggestions of previous run, e.g. aborted one
+	messages := s.cachedSuggestions(ctx, branch)
+	if len(messages) > 0 {
+		s.logger.InfoContext(ctx, "Staged changes unchanged since previous run, reusing its suggestions")
+	} else {
+		confirmed, err := s.confirmCost(ctx, diff, promptBranch, stagedFiles, commitTemplate, recentCommits)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			s.logger.WarnContext(ctx, "Generation canceled by user")
+			return ErrCanceled
+		}
+
+		s.logger.DebugContext(ctx, "Requesting commit messages...")
+
+		messages, err = s.generateWithProgress(ctx, generate)
+		if errors.Is(err, context.Canceled) {
+			s.logger.WarnContext(ctx, "Generation canceled by user")
+			return ErrCanceled
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to generate commit messages", "error", err)
+			return classify(ErrProvider, fmt.Errorf("failed to generate suggestions: %w", err))
+		}
+	}
+
+	return s.processCommitMessages(
+		ctx, messages, branch,
+		ui.WithRegenerate(generate), ui.WithDiff(diff), ui.WithFiles(stagedFiles, restage),
+		ui.WithStats(s.aiService.GenerationStats), ui.WithHistory(previous), ui.WithMerge(s.mergeMessages),
+	)
 }
 
-// processCommitMessages handles the commit message selection and commit creation
-func (s *Service) processCommitMessages(ctx context.Context, messages map[string]string, branch string) error {
+// processCommitMessages handles the commit message selection and commit creation,
+// uiOptions extend interactive mode
+func (s *Service) processCommitMessages(
+	ctx context.Context, messages map[string]string, branch string, uiOptions ...ui.Option,
+) error {
 	var commitMessage string
 
-	if s.settings.Auto {
+	// provided message is final, there is nothing to choose from
+	if s.settings.Auto || s.settings.Message != "" {
 		commitMessage = s.getRandomMessage(messages)
 		if commitMessage == "" {
 			s.logger.WarnContext(ctx, "No valid suggestions available for auto-commit")
-			return fmt.Errorf("no valid suggestions available for auto-commit")
+			return classify(ErrProvider, fmt.Errorf("no valid suggestions available for auto-commit"))
 		}
 		s.logger.DebugContext(ctx, "Auto-selected commit message", "message", commitMessage)
+	} else if s.settings.Yes {
+		commitMessage = s.topRankedMessage(ctx, messages)
+		if commitMessage == "" {
+			s.logger.WarnContext(ctx, "No valid suggestions available")
+			return classify(ErrProvider, fmt.Errorf("no valid suggestions available"))
+		}
 	} else {
 		s.logger.DebugContext(ctx, "Using interactive mode...")
 
-		uiModel, err := ui.RenderInteractiveUI(
-			ctx,
-			messages,
-			map[string]bool{
-				ui.CheckboxIDDryRun:         s.settings.DryRun,
-				ui.CheckboxIDPush:           !s.settings.DryRun && s.settings.Push,
-				ui.CheckboxIDCreateTagMajor: !s.settings.DryRun && s.settings.Tag == "major",
-				ui.CheckboxIDCreateTagMinor: !s.settings.DryRun && s.settings.Tag == "minor",
-				ui.CheckboxIDCreateTagPatch: !s.settings.DryRun && s.settings.Tag == "patch",
-			},
+		// options remembered from earlier runs are preselected along with those enabled by flags
+		prefs := s.loadPreferences(ctx)
+		tag := s.settings.Tag
+		if tag == "" {
+			tag = prefs.Tag
+		}
+
+		checkboxes := map[string]bool{
+			ui.CheckboxIDDryRun:         s.settings.DryRun,
+			ui.CheckboxIDPush:           !s.settings.DryRun && (s.settings.Push || prefs.Push),
+			ui.CheckboxIDCreateTagMajor: !s.settings.DryRun && tag == "major",
+			ui.CheckboxIDCreateTagMinor: !s.settings.DryRun && tag == "minor",
+			ui.CheckboxIDCreateTagPatch: !s.settings.DryRun && tag == "patch",
+			ui.CheckboxIDCreateTagAuto:  !s.settings.DryRun && tag == "auto",
+			ui.CheckboxIDCreateTagPre:   !s.settings.DryRun && tag == "prerelease",
+			ui.CheckboxIDSignOff:        s.settings.SignOff || prefs.SignOff,
+		}
+		if len(s.settings.Trailers) > 0 {
+			checkboxes[ui.CheckboxIDTrailers] = true
+		}
+		// there is no branch to push on detached HEAD
+		if branch == "" {
+			delete(checkboxes, ui.CheckboxIDPush)
+		} else {
+			remote := s.settings.PushRemote
+			if remote == "" {
+				remote = gitops.DefaultRemote
+			}
+			uiOptions = append(uiOptions, ui.WithPushTarget(ui.PushTarget{
+				Remote:    remote,
+				Branch:    branch,
+				NewBranch: !s.gitOps.HasRemoteBranch(remote, branch),
+			}))
+		}
+
+		if preview := s.tagPreviewer(ctx); preview != nil {
+			uiOptions = append(uiOptions, ui.WithTagPreview(preview))
+		}
+
+		uiOptions = append(uiOptions,
+			ui.WithLayout(ui.Layout{ShowDiff: prefs.ShowDiff, ShowHistory: prefs.ShowHistory}),
 		)
+		uiOptions = append(uiOptions, s.accessibleOptions()...)
+		uiModel, err := ui.RenderInteractiveUI(ctx, messages, checkboxes, uiOptions...)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
 				s.logger.WarnContext(ctx, "Interactive mode canceled by user")
-				return nil
+				return ErrCanceled
 			}
 			s.logger.ErrorContext(ctx, "Failed to enter interactive mode", "error", err)
 			return fmt.Errorf("failed to run interactive ui: %w", err)
 		}
 
 		commitMessage = uiModel.GetFinalChoice()
+		messages = uiModel.GetSuggestions()
+		if uiModel.IsCopied() {
+			s.settings.Copy = true
+		}
 
 		// override flags if user interacted with checkboxes
 		s.settings.DryRun = uiModel.GetCheckboxValue(ui.CheckboxIDDryRun)
@@ -219,6 +650,19 @@ func (s *Service) processCommitMessages(ctx context.Context, messages map[string
 		if uiModel.GetCheckboxValue(ui.CheckboxIDCreateTagPatch) {
 			s.settings.Tag = "patch"
 		}
+		if uiModel.GetCheckboxValue(ui.CheckboxIDCreateTagAuto) {
+			s.settings.Tag = "auto"
+		}
+		if uiModel.GetCheckboxValue(ui.CheckboxIDCreateTagPre) {
+			s.settings.Tag = "prerelease"
+		}
+
+		s.settings.SignOff = uiModel.GetCheckboxValue(ui.CheckboxIDSignOff)
+		if !uiModel.GetCheckboxValue(ui.CheckboxIDTrailers) {
+			s.settings.Trailers = nil
+		}
+
+		s.savePreferences(ctx, prefs, uiModel, branch != "")
 	}
 
 	if len(commitMessage) == 0 {
@@ -226,6 +670,202 @@ func (s *Service) processCommitMessages(ctx context.Context, messages map[string
 		return fmt.Errorf("no commit message provided")
 	}
 
+	// modules change the message, provider has to be found before them
+	provider := providerOf(messages, commitMessage)
+
+	commitMessage, err := s.applyModules(ctx, branch, commitMessage)
+	if err != nil {
+		return err
+	}
+	commitMessage = s.appendTrailers(ctx, branch, commitMessage)
+
+	commitMessage = strings.Trim(commitMessage, "\n")
+	commitMessage = strings.TrimSpace(commitMessage)
+
+	if s.settings.Copy {
+		if err := clipboard.WriteAll(commitMessage); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to copy commit message to clipboard", "error", err)
+			return fmt.Errorf("failed to copy to clipboard: %w", err)
+		}
+		s.logger.InfoContext(ctx, "Commit message copied to clipboard, nothing committed", "message", commitMessage)
+		return nil
+	}
+
+	if handled, err := s.outputMessage(ctx, commitMessage); handled {
+		return err
+	}
+
+	if s.settings.DryRun {
+		s.logger.WarnContext(ctx, "Dry run enabled, no side effects created")
+		s.logger.InfoContext(ctx, "Final commit message", "message", commitMessage)
+		if s.settings.Quiet {
+			fmt.Println(commitMessage)
+		}
+		return nil
+	}
+
+	published, err := s.publishCommit(ctx, commitMessage, provider)
+	if err != nil {
+		return err
+	}
+	s.notifyCommit(ctx, commitMessage, published)
+
+	// hash is the only output of quiet mode, so scripts can refer to created commit
+	if s.settings.Quiet {
+		if sha, err := s.gitOps.ResolveCommit("HEAD"); err == nil {
+			fmt.Println(sha)
+		}
+	}
+
+	return nil
+}
+
+// checkRepository verifies that providers are configured and repository is ready for a commit,
+// providers are not needed when message is provided
+func (s *Service) checkRepository(ctx context.Context) error {
+	if s.aiService.NumProviders() == 0 && s.settings.Message == "" {
+		s.logger.WarnContext(ctx, "No providers configured")
+		return classify(ErrProvider, fmt.Errorf("no api keys found in environment"))
+	}
+
+	if !s.gitOps.IsGitRepository() {
+		return classify(ErrGit, fmt.Errorf("not a git repository"))
+	}
+
+	repoStateStr, err := s.gitOps.GetRepoState()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get repository state", "error", err)
+		return classify(ErrGit, fmt.Errorf("failed to get repository state: %w", err))
+	}
+
+	if repoStateStr != gitops.RepoStateNormal {
+		s.logger.ErrorContext(ctx, "Repository not in normal state", "state", repoStateStr)
+		return classify(ErrGit, fmt.Errorf("repository is in %s state, cannot create commit", repoStateStr))
+	}
+
+	hasConflicts, _, err := s.gitOps.HasConflicts()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to check for conflicts", "error", err)
+		return classify(ErrGit, fmt.Errorf("failed to check for conflicts: %w", err))
+	}
+
+	if hasConflicts {
+		s.logger.ErrorContext(ctx, "Unresolved conflicts detected")
+		return classify(ErrGit, fmt.Errorf("unresolved conflicts detected"))
+	}
+
+	return nil
+}
+
+// accessibleOptions switch interactive mode to linear prompts when accessible mode is on
+func (s *Service) accessibleOptions() []ui.Option {
+	if !s.settings.Accessible {
+		return nil
+	}
+	return []ui.Option{ui.WithAccessible(os.Stdin, os.Stdout)}
+}
+
+// stageChanges resets the index and stages files according to settings
+func (s *Service) stageChanges(ctx context.Context) ([]string, error) {
+	s.logger.DebugContext(ctx, "Unstaging all files...")
+
+	if err := s.gitOps.UnstageAll(); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to unstage files", "error", err)
+		return nil, fmt.Errorf("failed to unstage files: %w", err)
+	}
+
+	s.logger.DebugContext(ctx, "Staging files...")
+
+	stagedFiles, err := s.gitOps.StageFiles(
+		s.settings.ExcludePatterns,
+		s.settings.IncludePatterns,
+		s.settings.UseGlobalGitignore,
+		s.scopeDir,
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to stage files", "error", err)
+		return nil, fmt.Errorf("failed to stage files: %w", err)
+	}
+
+	return stagedFiles, nil
+}
+
+// restageFiles stages exactly given files and returns their diff
+func (s *Service) restageFiles(ctx context.Context, files []string) (string, error) {
+	s.logger.DebugContext(ctx, "Restaging files...", "files", len(files))
+
+	if err := s.gitOps.UnstageAll(); err != nil {
+		return "", fmt.Errorf("failed to unstage files: %w", err)
+	}
+	if err := s.gitOps.StagePaths(files); err != nil {
+		return "", fmt.Errorf("failed to stage files: %w", err)
+	}
+
+	diff, err := s.gitOps.GetStagedDiff(s.settings.MaxDiffSizeBytes, s.settings.NewFileHeadLines)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %w", err)
+	}
+	return diff, nil
+}
+
+// currentBranch returns branch name for modules and branch description for prompts,
+// on detached HEAD branch is empty, description names the commit and push is disabled
+func (s *Service) currentBranch(ctx context.Context) (string, string, error) {
+	branch, err := s.gitOps.GetCurrentBranch()
+	if err == nil {
+		return branch, branch, nil
+	}
+
+	var detached *gitops.DetachedHeadError
+	if !errors.As(err, &detached) {
+		s.logger.ErrorContext(ctx, "Failed to get current branch", "error", err)
+		return "", "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	if s.settings.Push {
+		s.logger.WarnContext(ctx, "HEAD is detached, push disabled", "commit", gitops.ShortSHA(detached.SHA))
+		s.settings.Push = false
+	} else {
+		s.logger.DebugContext(ctx, "HEAD is detached", "commit", gitops.ShortSHA(detached.SHA))
+	}
+
+	return "", "detached at " + gitops.ShortSHA(detached.SHA), nil
+}
+
+// appendTrailers adds Signed-off-by and configured trailers, they are chosen per invocation
+// so they are not part of modules
+func (s *Service) appendTrailers(ctx context.Context, branch, commitMessage string) string {
+	trailers := s.settings.Trailers
+	if s.settings.SignOff {
+		if identity := s.signOffIdentity(); identity != "" {
+			trailers = append(trailers[:len(trailers):len(trailers)], "Signed-off-by: "+identity)
+		} else {
+			s.logger.WarnContext(ctx, "Cannot sign off commit, user.name or user.email is not configured")
+		}
+	}
+	if len(trailers) == 0 {
+		return commitMessage
+	}
+
+	commitMessage, _, _ = modules.NewTrailerAppender(trailers).TransformCommitMessage(ctx, branch, commitMessage)
+	return commitMessage
+}
+
+// signOffIdentity returns committer as Name <email>, empty when not configured
+func (s *Service) signOffIdentity() string {
+	if s.gitConfig == nil {
+		return ""
+	}
+	name, email := s.gitConfig("user.name"), s.gitConfig("user.email")
+	if name == "" || email == "" {
+		return ""
+	}
+	return name + " <" + email + ">"
+}
+
+// applyModules runs commit message transformations of all modules in order,
+// failing modules are skipped unless they reject the message
+func (s *Service) applyModules(ctx context.Context, branch, commitMessage string) (string, error) {
 	for _, module := range s.modules {
 		var (
 			updatedMessage string
@@ -236,6 +876,10 @@ func (s *Service) processCommitMessages(ctx context.Context, messages map[string
 		s.logger.DebugContext(ctx, "Running module", "name", module.Name())
 
 		updatedMessage, workDone, err = module.TransformCommitMessage(ctx, branch, commitMessage)
+		if errors.Is(err, modules.ErrMessageRejected) {
+			s.logger.ErrorContext(ctx, "Commit message rejected", "module", module.Name(), "error", err)
+			return "", err
+		}
 		if err != nil {
 			s.logger.ErrorContext(
 				ctx, "Failed to transform commit message",
@@ -265,72 +909,94 @@ func (s *Service) processCommitMessages(ctx context.Context, messages map[string
 		// ----
 	}
 
-	commitMessage = strings.Trim(commitMessage, "\n")
-	commitMessage = strings.TrimSpace(commitMessage)
+	return commitMessage, nil
+}
 
-	if !s.settings.DryRun {
-		if err := s.gitOps.CreateCommit(commitMessage); err != nil {
-			s.logger.ErrorContext(ctx, "Failed to create commit", "error", err)
-			return fmt.Errorf("failed to create commit: %w", err)
-		}
-		s.logger.InfoContext(
-			ctx, "Commit created",
-			"commit_message", commitMessage,
-		)
+// promptTransformer binds prompt transformations of modules to current branch
+func (s *Service) promptTransformer(branch string) func(ctx context.Context, prompt string) string {
+	return func(ctx context.Context, prompt string) string {
+		return s.applyPromptModules(ctx, branch, prompt)
+	}
+}
 
-		if s.settings.Push {
-			mrURL, err := s.gitOps.Push()
-			if err != nil {
-				s.logger.ErrorContext(ctx, "Failed to push to remote", "error", err)
-				return fmt.Errorf("failed to push: %w", err)
-			}
-			s.logger.InfoContext(ctx, "Successfully pushed to remote")
+// instructedPromptTransformer appends extra instruction of user to prompt transformed by modules
+func (s *Service) instructedPromptTransformer(
+	branch, instruction string,
+) func(ctx context.Context, prompt string) string {
+	transform := s.promptTransformer(branch)
+	if instruction == "" {
+		return transform
+	}
+	return func(ctx context.Context, prompt string) string {
+		return transform(ctx, prompt) + "\n\n# Additional instruction\n\n" + instruction
+	}
+}
 
-			if mrURL != "" {
-				s.logger.InfoContext(ctx, "Create merge/pull request", "url", mrURL)
+// applyPromptModules runs prompt transformations of all modules in order,
+// failing module leaves prompt untouched; consecutive prompt contributors run concurrently
+// and their additions are appended in module order, as if they ran one by one
+func (s *Service) applyPromptModules(ctx context.Context, branch, prompt string) string {
+	for i := 0; i < len(s.modules); {
+		end := i
+		for end < len(s.modules) {
+			if _, ok := s.modules[end].(promptContributorAccessor); !ok {
+				break
 			}
+			end++
+		}
+		if end > i {
+			prompt += s.collectPromptContext(ctx, branch, s.modules[i:end])
+			i = end
+			continue
 		}
 
-		if s.settings.Tag != "" {
-			latestTag, err := s.gitOps.GetLatestTag()
-			if err != nil {
-				s.logger.ErrorContext(ctx, "Failed to get latest tag", "error", err)
-				return fmt.Errorf("failed to get latest tag: %w", err)
-			}
+		module := s.modules[i]
+		i++
+		updatedPrompt, workDone, err := module.TransformPrompt(ctx, branch, prompt)
+		if err != nil {
+			s.logger.WarnContext(
+				ctx, "Failed to transform prompt",
+				"module", module.Name(),
+				"error", err,
+			)
+			continue
+		}
+		if !workDone {
+			continue
+		}
 
-			if latestTag == "" {
-				s.logger.WarnContext(ctx, "No existing tags found, will create first tag")
-			} else {
-				s.logger.InfoContext(ctx, "Latest tag found", "tag", latestTag)
-			}
+		s.logger.DebugContext(ctx, "Transformed prompt", "module", module.Name())
+		prompt = updatedPrompt
+	}
+
+	return prompt
+}
 
-			newTag, err := s.gitOps.IncrementVersion(latestTag, s.settings.Tag)
+// collectPromptContext runs prompt contributors concurrently, additions are joined in module order
+func (s *Service) collectPromptContext(ctx context.Context, branch string, contributors []moduleAccessor) string {
+	additions := make([]string, len(contributors))
+	var wg sync.WaitGroup
+	for i, module := range contributors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addition, workDone, err := module.(promptContributorAccessor).PromptContext(ctx, branch)
 			if err != nil {
-				s.logger.ErrorContext(ctx, "Failed to increment version", "error", err)
-				return fmt.Errorf("failed to increment version: %w", err)
+				s.logger.WarnContext(
+					ctx, "Failed to transform prompt",
+					"module", module.Name(),
+					"error", err,
+				)
+				return
 			}
-
-			if err := s.gitOps.CreateTag(newTag, commitMessage); err != nil {
-				s.logger.ErrorContext(ctx, "Failed to create tag", "tag", newTag, "error", err)
-				return fmt.Errorf("failed to create tag %s: %w", newTag, err)
+			if workDone {
+				s.logger.DebugContext(ctx, "Transformed prompt", "module", module.Name())
+				additions[i] = addition
 			}
-
-			s.logger.InfoContext(ctx, "Tag created", "tag", newTag)
-
-			if s.settings.Push {
-				if err := s.gitOps.PushTag(newTag); err != nil {
-					s.logger.ErrorContext(ctx, "Failed to push tag", "tag", newTag, "error", err)
-					return fmt.Errorf("failed to push tag %s: %w", newTag, err)
-				}
-				s.logger.InfoContext(ctx, "Tag pushed to remote", "tag", newTag)
-			}
-		}
-	} else {
-		s.logger.WarnContext(ctx, "Dry run enabled, no side effects created")
-		s.logger.InfoContext(ctx, "Final commit message", "message", commitMessage)
+		}()
 	}
-
-	return nil
+	wg.Wait()
+	return strings.Join(additions, "")
 }
 
 func (s *Service) getRandomMessage(messages map[string]string) string {
@@ -340,3 +1006,102 @@ func (s *Service) getRandomMessage(messages map[string]string) string {
 	}
 	return ""
 }
+
+// checkLargeBinaries warns about large staged binaries which are not tracked by Git LFS,
+// and refuses to continue when configured so
+func (s *Service) checkLargeBinaries(ctx context.Context) error {
+	if s.settings.LargeBinaryBytes <= 0 {
+		return nil
+	}
+
+	large, err := s.gitOps.GetLargeBinaries(s.settings.LargeBinaryBytes)
+	if err != nil {
+		// only a safety net, do not block the commit on it
+		s.logger.WarnContext(ctx, "Failed to check for large binaries", "error", err)
+		return nil
+	}
+
+	if len(large) == 0 {
+		return nil
+	}
+
+	for file, size := range large {
+		s.logger.WarnContext(
+			ctx, "Large binary file is not tracked by Git LFS",
+			"file", file,
+			"size_bytes", size,
+		)
+	}
+
+	if s.settings.AbortOnLargeBinary {
+		return fmt.Errorf("%d large binary files staged outside of Git LFS", len(large))
+	}
+
+	return nil
+}
+
+// checkConflictMarkers looks for conflict markers left in staged changes after manual resolution,
+// before they are sent to providers and committed
+func (s *Service) checkConflictMarkers(ctx context.Context) error {
+	if s.settings.ConflictMarkers == "" || s.settings.ConflictMarkers == "ignore" {
+		return nil
+	}
+
+	markers, err := s.gitOps.GetConflictMarkers()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to check for conflict markers", "error", err)
+		return fmt.Errorf("failed to check for conflict markers: %w", err)
+	}
+
+	if len(markers) == 0 {
+		return nil
+	}
+
+	for _, location := range markers {
+		s.logger.WarnContext(ctx, "Conflict marker in staged changes", "location", location)
+	}
+
+	if s.settings.ConflictMarkers == "refuse" {
+		return fmt.Errorf(
+			"%d conflict markers found in staged changes, resolve them or rerun with --conflict-markers=warn",
+			len(markers),
+		)
+	}
+
+	return nil
+}
+
+// validateTask checks issue tracker task the branch belongs to, before spending time on providers
+func (s *Service) validateTask(ctx context.Context, branch string) error {
+	if s.validator == nil || branch == "" {
+		return nil
+	}
+
+	err := s.validator.ValidateTask(ctx, branch)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, modules.ErrJiraIssueNotFound), errors.Is(err, modules.ErrJiraIssueNotInProgress):
+		s.logger.WarnContext(ctx, "Commit does not belong to active task", "branch", branch, "error", err)
+		if s.settings.JiraValidate == "refuse" {
+			return fmt.Errorf("%w, rerun with --jira-validate=warn to commit anyway", err)
+		}
+	default:
+		// tracker being unreachable is no reason to block the commit
+		s.logger.WarnContext(ctx, "Failed to validate task", "branch", branch, "error", err)
+	}
+
+	return nil
+}
+
+// restoreStash brings back unrelated changes stashed before commit
+func (s *Service) restoreStash(ctx context.Context) {
+	if err := s.gitOps.RestoreStash(); err != nil {
+		s.logger.ErrorContext(
+			ctx, "Failed to restore unrelated changes, run 'git stash pop' manually",
+			"error", err,
+		)
+		return
+	}
+	s.logger.DebugContext(ctx, "Restored unrelated worktree changes")
+}
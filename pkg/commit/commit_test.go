@@ -4,15 +4,26 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"go.uber.org/mock/gomock"
 
 	"github.com/hasansino/commit/pkg/commit/mocks"
+	"github.com/hasansino/commit/pkg/commit/modules"
+	"github.com/hasansino/commit/pkg/commit/ui"
+	"github.com/hasansino/commit/pkg/gitops"
 )
 
 func TestNewCommitService(t *testing.T) {
+	// NewCommitService enters worktree root, restore working directory afterwards
+	t.Chdir(".")
+
 	tests := []struct {
 		name        string
 		settings    *Settings
@@ -228,7 +239,7 @@ func TestService_Execute_NoProviders(t *testing.T) {
 	}
 
 	// Mock git operations to avoid actual git calls
-	mockGitOps := &gitOperations{}
+	mockGitOps := &gitops.Operations{}
 
 	service := &Service{
 		logger:    slog.New(slog.DiscardHandler),
@@ -386,40 +397,252 @@ func (a *testGitOperationsAdapter) UnstageAll() error {
 func (a *testGitOperationsAdapter) StageFiles(
 	excludePatterns, includePatterns []string,
 	useGlobalGitignore bool,
+	scopeDir string,
 ) ([]string, error) {
-	return a.gitOps.StageFiles(excludePatterns, includePatterns, useGlobalGitignore)
+	return a.gitOps.StageFiles(excludePatterns, includePatterns, useGlobalGitignore, scopeDir)
+}
+
+func (a *testGitOperationsAdapter) StagePaths(paths []string) error {
+	return a.gitOps.StagePaths(paths)
+}
+
+func (a *testGitOperationsAdapter) GetStagedDiff(maxSize int, newFileHeadLines int) (string, error) {
+	return a.gitOps.GetStagedDiff(maxSize, newFileHeadLines)
 }
 
-func (a *testGitOperationsAdapter) GetStagedDiff(maxSize int) (string, error) {
-	return a.gitOps.GetStagedDiff(maxSize)
+func (a *testGitOperationsAdapter) GetSubmoduleSummary(includeLog bool) (string, error) {
+	return a.gitOps.GetSubmoduleSummary(includeLog)
 }
 
 func (a *testGitOperationsAdapter) GetCurrentBranch() (string, error) {
 	return a.gitOps.GetCurrentBranch()
 }
 
-func (a *testGitOperationsAdapter) CreateCommit(message string) error {
-	return a.gitOps.CreateCommit(message)
+func (a *testGitOperationsAdapter) GetCommitTemplate() (string, error) {
+	return a.gitOps.GetCommitTemplate()
+}
+
+func (a *testGitOperationsAdapter) GetRecentCommits(n int) ([]string, error) {
+	return a.gitOps.GetRecentCommits(n)
+}
+
+func (a *testGitOperationsAdapter) GetBranchDiff(base, remote string, maxSizeBytes int) (string, []string, error) {
+	return a.gitOps.GetBranchDiff(base, remote, maxSizeBytes)
+}
+
+func (a *testGitOperationsAdapter) StashUnstaged() (bool, error) {
+	return a.gitOps.StashUnstaged()
+}
+
+func (a *testGitOperationsAdapter) RestoreStash() error {
+	return a.gitOps.RestoreStash()
+}
+
+func (a *testGitOperationsAdapter) IsShallowRepository() (bool, error) {
+	return a.gitOps.IsShallowRepository()
+}
+
+func (a *testGitOperationsAdapter) Deepen(remote string) error {
+	return a.gitOps.Deepen(remote)
+}
+
+func (a *testGitOperationsAdapter) GetLargeBinaries(thresholdBytes int64) (map[string]int64, error) {
+	return a.gitOps.GetLargeBinaries(thresholdBytes)
+}
+
+func (a *testGitOperationsAdapter) GetConflictMarkers() ([]string, error) {
+	return a.gitOps.GetConflictMarkers()
+}
+
+func (a *testGitOperationsAdapter) AddNote(notesRef, message string) error {
+	return a.gitOps.AddNote(notesRef, message)
+}
+
+func (a *testGitOperationsAdapter) GetNote(notesRef, sha string) string {
+	return a.gitOps.GetNote(notesRef, sha)
+}
+
+func (a *testGitOperationsAdapter) PushNotes(notesRef, remote string) error {
+	return a.gitOps.PushNotes(notesRef, remote)
+}
+
+func (a *testGitOperationsAdapter) CreateCommit(message string, noVerify, allowEmpty bool, author, date string) error {
+	return a.gitOps.CreateCommit(message, noVerify, allowEmpty, author, date)
+}
+
+func (a *testGitOperationsAdapter) GetCommitsSince(tag string) ([]string, error) {
+	return a.gitOps.GetCommitsSince(tag)
+}
+
+func (a *testGitOperationsAdapter) ResolveRewordTarget(ref string) (string, error) {
+	return a.gitOps.ResolveRewordTarget(ref)
+}
+
+func (a *testGitOperationsAdapter) IsCommitPushed(sha string) (bool, error) {
+	return a.gitOps.IsCommitPushed(sha)
+}
+
+func (a *testGitOperationsAdapter) GetCommitDiff(sha string, maxSizeBytes int) (string, []string, error) {
+	return a.gitOps.GetCommitDiff(sha, maxSizeBytes)
+}
+
+func (a *testGitOperationsAdapter) ResolveCommit(ref string) (string, error) {
+	return a.gitOps.ResolveCommit(ref)
+}
+
+func (a *testGitOperationsAdapter) GetCommitMessage(sha string) (string, error) {
+	return a.gitOps.GetCommitMessage(sha)
+}
+
+func (a *testGitOperationsAdapter) RewordCommit(sha, message string, noVerify bool) error {
+	return a.gitOps.RewordCommit(sha, message, noVerify)
+}
+
+func (a *testGitOperationsAdapter) GetRemoteDivergence(remote string) (int, int, error) {
+	return a.gitOps.GetRemoteDivergence(remote)
+}
+
+func (a *testGitOperationsAdapter) GetStagedFiles() ([]string, error) {
+	return a.gitOps.GetStagedFiles()
+}
+
+func (a *testGitOperationsAdapter) GetIndexHash() (string, error) {
+	return a.gitOps.GetIndexHash()
+}
+
+func (a *testGitOperationsAdapter) GetGitDir() (string, error) {
+	return a.gitOps.GetGitDir()
+}
+
+func (a *testGitOperationsAdapter) InstallHook(name, script string) (string, error) {
+	return a.gitOps.InstallHook(name, script)
+}
+
+func (a *testGitOperationsAdapter) HasRemoteBranch(remote, branch string) bool {
+	return a.gitOps.HasRemoteBranch(remote, branch)
+}
+
+func (a *testGitOperationsAdapter) GetRemoteURL(remoteName string) (string, error) {
+	return a.gitOps.GetRemoteURL(remoteName)
+}
+
+func (a *testGitOperationsAdapter) GetDefaultBranch(remote string) string {
+	return a.gitOps.GetDefaultBranch(remote)
+}
+
+func (a *testGitOperationsAdapter) PullRebase(remote string) error {
+	return a.gitOps.PullRebase(remote)
 }
 
-func (a *testGitOperationsAdapter) Push() (string, error) {
-	return a.gitOps.Push()
+func (a *testGitOperationsAdapter) Push(remote string, forceWithLease, setUpstream bool) (string, error) {
+	return a.gitOps.Push(remote, forceWithLease, setUpstream)
 }
 
-func (a *testGitOperationsAdapter) GetLatestTag() (string, error) {
-	return a.gitOps.GetLatestTag()
+func (a *testGitOperationsAdapter) GetLatestTag(prefix, pattern string) (string, error) {
+	return a.gitOps.GetLatestTag(prefix, pattern)
 }
 
-func (a *testGitOperationsAdapter) IncrementVersion(currentTag, incrementType string) (string, error) {
-	return a.gitOps.IncrementVersion(currentTag, incrementType)
+func (a *testGitOperationsAdapter) IncrementVersion(currentTag, incrementType, prefix string) (string, error) {
+	return a.gitOps.IncrementVersion(currentTag, incrementType, prefix)
 }
 
 func (a *testGitOperationsAdapter) CreateTag(tag, message string) error {
 	return a.gitOps.CreateTag(tag, message)
 }
 
-func (a *testGitOperationsAdapter) PushTag(tag string) error {
-	return a.gitOps.PushTag(tag)
+func (a *testGitOperationsAdapter) DeleteTag(tag string) error {
+	return a.gitOps.DeleteTag(tag)
+}
+
+func (a *testGitOperationsAdapter) UndoLastCommit() error {
+	return a.gitOps.UndoLastCommit()
+}
+
+func (a *testGitOperationsAdapter) PushTag(tag, remote string) error {
+	return a.gitOps.PushTag(tag, remote)
+}
+
+// newTestRepository initializes repository in a temporary directory and enters it,
+// identity is configured in repository as service reads it from there
+func newTestRepository(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	gitInTestRepository(t, dir, "init", "-q", "-b", "main")
+	gitInTestRepository(t, dir, "config", "user.name", "Test")
+	gitInTestRepository(t, dir, "config", "user.email", "test@example.com")
+	gitInTestRepository(t, dir, "config", "commit.gpgsign", "false")
+	t.Chdir(dir)
+	return dir
+}
+
+func gitInTestRepository(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestService_Execute_Repository(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T, dir string)
+		settings Settings
+		wantHead string // abbreviated name of HEAD after commit
+	}{
+		{
+			name:     "unborn branch",
+			setup:    func(t *testing.T, dir string) {},
+			wantHead: "main",
+		},
+		{
+			name: "detached head",
+			setup: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("readme\n"), 0644); err != nil {
+					t.Fatalf("Failed to write README.md: %v", err)
+				}
+				gitInTestRepository(t, dir, "add", "README.md")
+				gitInTestRepository(t, dir, "commit", "-q", "--no-verify", "-m", "docs: readme")
+				gitInTestRepository(t, dir, "checkout", "-q", "--detach")
+			},
+			// push needs a branch, it is disabled instead of failing
+			settings: Settings{Push: true},
+			wantHead: "HEAD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newTestRepository(t)
+			tt.setup(t, dir)
+			if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+				t.Fatalf("Failed to write main.go: %v", err)
+			}
+
+			settings := tt.settings
+			settings.Auto = true
+			settings.Timeout = 30 * time.Second
+			settings.MaxDiffSizeBytes = 64 * 1024
+			service, err := NewCommitService(&settings)
+			if err != nil {
+				t.Fatalf("NewCommitService() error = %v", err)
+			}
+			service.aiService = &simpleTestAdapter{hasProviders: true, commitMsg: "feat: add main"}
+
+			if err := service.Execute(context.Background()); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if subject := gitInTestRepository(t, dir, "log", "-1", "--format=%s"); subject != "feat: add main" {
+				t.Errorf("HEAD subject = %q, want %q", subject, "feat: add main")
+			}
+			if head := gitInTestRepository(t, dir, "rev-parse", "--abbrev-ref", "HEAD"); head != tt.wantHead {
+				t.Errorf("HEAD = %q, want %q", head, tt.wantHead)
+			}
+		})
+	}
 }
 
 // Simplified adapter for testing AI service
@@ -436,11 +659,27 @@ func (s *simpleTestAdapter) NumProviders() int {
 	return 0
 }
 
+func (s *simpleTestAdapter) Ask(
+	ctx context.Context,
+	providers []string, prompt string,
+	first bool,
+) (map[string]string, error) {
+	if s.genErr != nil {
+		return nil, s.genErr
+	}
+	if s.commitMsg != "" {
+		return map[string]string{"test": s.commitMsg}, nil
+	}
+	return map[string]string{}, nil
+}
+
 func (s *simpleTestAdapter) GenerateCommitMessages(
 	ctx context.Context,
 	diff, branch string, files []string,
-	providers []string, customPrompt string,
+	providers []string, customPrompt, commitTemplate string,
+	recentCommits []string,
 	first bool, multiLine bool,
+	transformPrompt func(ctx context.Context, prompt string) string,
 ) (map[string]string, error) {
 	if s.genErr != nil {
 		return nil, s.genErr
@@ -451,6 +690,294 @@ func (s *simpleTestAdapter) GenerateCommitMessages(
 	return map[string]string{}, nil
 }
 
+func (s *simpleTestAdapter) BuildPrompt(
+	diff, branch string, files []string,
+	customPrompt, commitTemplate string,
+	recentCommits []string,
+	multiLine bool,
+) string {
+	return diff
+}
+
+func (s *simpleTestAdapter) EstimateRequests(_ []string, _ string) []ui.CostEstimate {
+	return nil
+}
+
+func (s *simpleTestAdapter) GenerationMetadata(provider string) map[string]string {
+	if provider != "test" {
+		return nil
+	}
+	return map[string]string{"provider": provider, "model": "test-model"}
+}
+
+func (s *simpleTestAdapter) GenerationStats(_ string) (ui.ProviderStats, bool) {
+	return ui.ProviderStats{}, false
+}
+
+func (s *simpleTestAdapter) ProviderNames(_ []string) []string {
+	return []string{"test"}
+}
+
+func (s *simpleTestAdapter) SetProgress(_ ui.ProgressFunc) {}
+
+func (s *simpleTestAdapter) CancelProvider(_ string) {}
+
+func TestService_ValidateTask(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		branch      string
+		validateErr error
+		wantErr     bool
+	}{
+		{name: "active task", mode: "refuse", branch: "feature/TASK-1"},
+		{name: "closed task refused", mode: "refuse", branch: "feature/TASK-1",
+			validateErr: modules.ErrJiraIssueNotInProgress, wantErr: true},
+		{name: "unknown task refused", mode: "refuse", branch: "feature/TASK-1",
+			validateErr: modules.ErrJiraIssueNotFound, wantErr: true},
+		{name: "closed task warned", mode: "warn", branch: "feature/TASK-1",
+			validateErr: modules.ErrJiraIssueNotInProgress},
+		{name: "tracker unreachable", mode: "refuse", branch: "feature/TASK-1",
+			validateErr: errors.New("connection refused")},
+		{name: "detached head", mode: "refuse", branch: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			validator := mocks.NewMocktaskValidatorAccessor(ctrl)
+			if tt.branch != "" {
+				validator.EXPECT().ValidateTask(gomock.Any(), tt.branch).Return(tt.validateErr)
+			}
+
+			service := &Service{
+				logger:    slog.New(slog.DiscardHandler),
+				settings:  &Settings{JiraValidate: tt.mode},
+				validator: validator,
+			}
+
+			err := service.validateTask(context.Background(), tt.branch)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_PromptTransformer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failing := mocks.NewMockmoduleAccessor(ctrl)
+	failing.EXPECT().Name().Return("failing").AnyTimes()
+	failing.EXPECT().TransformPrompt(gomock.Any(), "feature/TASK-1", "PROMPT").
+		Return("", false, errors.New("jira unavailable"))
+
+	enriching := mocks.NewMockmoduleAccessor(ctrl)
+	enriching.EXPECT().Name().Return("enriching").AnyTimes()
+	enriching.EXPECT().TransformPrompt(gomock.Any(), "feature/TASK-1", "PROMPT").
+		Return("PROMPT\nTask context", true, nil)
+
+	service := &Service{
+		logger:  slog.New(slog.DiscardHandler),
+		modules: []moduleAccessor{failing, enriching},
+	}
+
+	got := service.promptTransformer("feature/TASK-1")(context.Background(), "PROMPT")
+	if got != "PROMPT\nTask context" {
+		t.Errorf("promptTransformer() = %q, want %q", got, "PROMPT\nTask context")
+	}
+}
+
+// testPromptContributor adds its context only when all contributors of its batch run at once
+type testPromptContributor struct {
+	name    string
+	context string
+	started *sync.WaitGroup
+}
+
+func (c *testPromptContributor) Name() string { return c.name }
+
+func (c *testPromptContributor) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (c *testPromptContributor) TransformCommitMessage(_ context.Context, _, message string) (string, bool, error) {
+	return message, false, nil
+}
+
+func (c *testPromptContributor) PromptContext(_ context.Context, _ string) (string, bool, error) {
+	c.started.Done()
+	done := make(chan struct{})
+	go func() {
+		c.started.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return c.context, true, nil
+	case <-time.After(time.Second):
+		return "", false, errors.New("contributors did not run concurrently")
+	}
+}
+
+func TestService_PromptTransformer_Contributors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var batch sync.WaitGroup
+	batch.Add(2)
+	first := &testPromptContributor{name: "first", context: "\nfirst", started: &batch}
+	second := &testPromptContributor{name: "second", context: "\nsecond", started: &batch}
+
+	var last sync.WaitGroup
+	last.Add(1)
+	third := &testPromptContributor{name: "third", context: "\nthird", started: &last}
+
+	// sequential module sees additions of contributors before it, in module order
+	rewriting := mocks.NewMockmoduleAccessor(ctrl)
+	rewriting.EXPECT().Name().Return("rewriting").AnyTimes()
+	rewriting.EXPECT().TransformPrompt(gomock.Any(), "main", "PROMPT\nfirst\nsecond").
+		Return("REWRITTEN", true, nil)
+
+	service := &Service{
+		logger:  slog.New(slog.DiscardHandler),
+		modules: []moduleAccessor{first, second, rewriting, third},
+	}
+
+	got := service.promptTransformer("main")(context.Background(), "PROMPT")
+	if got != "REWRITTEN\nthird" {
+		t.Errorf("promptTransformer() = %q, want %q", got, "REWRITTEN\nthird")
+	}
+}
+
+func TestService_instructedPromptTransformer(t *testing.T) {
+	service := &Service{logger: slog.New(slog.DiscardHandler)}
+
+	if got := service.instructedPromptTransformer("main", "")(context.Background(), "PROMPT"); got != "PROMPT" {
+		t.Errorf("instructedPromptTransformer() = %q, want prompt unchanged", got)
+	}
+
+	got := service.instructedPromptTransformer("main", "mention the migration")(context.Background(), "PROMPT")
+	if got != "PROMPT\n\n# Additional instruction\n\nmention the migration" {
+		t.Errorf("instructedPromptTransformer() = %q, want instruction appended", got)
+	}
+}
+
+func TestService_tagPreviewer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	git := mocks.NewMockgitOperationsAccessor(ctrl)
+	git.EXPECT().GetLatestTag("v", "").Return("v1.4.2", nil)
+	git.EXPECT().GetCommitsSince("v1.4.2").Return([]string{"fix: typo"}, nil)
+	git.EXPECT().IncrementVersion("v1.4.2", gomock.Any(), "v").DoAndReturn((&gitops.Operations{}).IncrementVersion).
+		AnyTimes()
+
+	service := &Service{
+		logger:   slog.New(slog.DiscardHandler),
+		settings: &Settings{}, // empty prefix defaults to v
+		gitOps:   git,
+	}
+
+	preview := service.tagPreviewer(context.Background())
+	if preview == nil {
+		t.Fatal("tagPreviewer() = nil, want preview")
+	}
+
+	tests := []struct {
+		increment string
+		message   string
+		want      string
+	}{
+		{increment: "patch", want: "v1.4.3"},
+		{increment: "minor", want: "v1.5.0"},
+		{increment: "major", want: "v2.0.0"},
+		{increment: "prerelease", want: "v1.4.3-rc.1"},
+		{increment: "auto", message: "fix: crash", want: "v1.4.3"},
+		{increment: "auto", message: "feat: export", want: "v1.5.0"},
+		{increment: "auto", message: "feat!: drop v1 api", want: "v2.0.0"},
+	}
+	for _, tt := range tests {
+		if got := preview(tt.increment, tt.message); got != tt.want {
+			t.Errorf("preview(%q, %q) = %q, want %q", tt.increment, tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestService_appendTrailers(t *testing.T) {
+	config := func(key string) string {
+		return map[string]string{"user.name": "Jane Doe", "user.email": "jane@example.com"}[key]
+	}
+
+	tests := []struct {
+		name      string
+		settings  *Settings
+		gitConfig modules.ConfigSource
+		want      string
+	}{
+		{
+			name:     "nothing configured",
+			settings: &Settings{},
+			want:     "feat: add export",
+		},
+		{
+			name:      "sign-off",
+			settings:  &Settings{SignOff: true},
+			gitConfig: config,
+			want:      "feat: add export\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:      "configured trailers before sign-off",
+			settings:  &Settings{SignOff: true, Trailers: []string{"Reviewed-by: Team"}},
+			gitConfig: config,
+			want:      "feat: add export\n\nReviewed-by: Team\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:      "sign-off without identity",
+			settings:  &Settings{SignOff: true},
+			gitConfig: func(string) string { return "" },
+			want:      "feat: add export",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &Service{
+				logger:    slog.New(slog.DiscardHandler),
+				settings:  tt.settings,
+				gitConfig: tt.gitConfig,
+			}
+			if got := service.appendTrailers(context.Background(), "main", "feat: add export"); got != tt.want {
+				t.Errorf("appendTrailers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_applyModules_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failing := mocks.NewMockmoduleAccessor(ctrl)
+	failing.EXPECT().Name().Return("failing").AnyTimes()
+	failing.EXPECT().TransformCommitMessage(gomock.Any(), "main", "feat: damn").
+		Return("", false, errors.New("plugin crashed"))
+
+	service := &Service{
+		logger: slog.New(slog.DiscardHandler),
+		modules: []moduleAccessor{
+			failing,
+			modules.NewBannedWordFilter(modules.BannedWordActionReject, nil),
+		},
+	}
+
+	_, err := service.applyModules(context.Background(), "main", "feat: damn")
+	if !errors.Is(err, modules.ErrMessageRejected) {
+		t.Errorf("applyModules() error = %v, want ErrMessageRejected", err)
+	}
+}
+
 // Integration test helpers for testing with actual modules
 func TestService_ModuleIntegration(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -574,7 +1101,7 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(errors.New("unstage error"))
 			},
@@ -589,12 +1116,14 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{}, nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{}, nil)
 			},
-			wantErr: false,
+			wantErr:     true,
+			errContains: "nothing to commit",
 		},
 		{
 			name: "empty diff",
@@ -604,13 +1133,15 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("  ", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("  ", nil)
 			},
-			wantErr: false,
+			wantErr:     true,
+			errContains: "nothing to commit",
 		},
 		{
 			name: "get current branch error",
@@ -620,11 +1151,13 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("", errors.New("branch error"))
 			},
 			wantErr:     true,
@@ -638,12 +1171,15 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true, genErr: errors.New("ai error")},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
 			},
 			wantErr:     true,
 			errContains: "failed to generate suggestions",
@@ -657,12 +1193,15 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: ""},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
 			},
 			wantErr:     true,
 			errContains: "no valid suggestions available for auto-commit",
@@ -677,12 +1216,15 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
 			},
 			wantErr: false,
 		},
@@ -696,13 +1238,16 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("main", nil)
-				git.EXPECT().CreateCommit("test commit").Return(errors.New("commit error"))
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(errors.New("commit error"))
 			},
 			wantErr:     true,
 			errContains: "failed to create commit",
@@ -717,13 +1262,209 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "provided message without providers",
+			settings: &Settings{
+				Timeout:  30 * time.Second,
+				Message:  "fix: correct typo",
+				Trailers: []string{"Reviewed-by: Team"},
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: false},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().CreateCommit("fix: correct typo\n\nReviewed-by: Team", false, false, "", "").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "quiet commit resolves hash",
+			settings: &Settings{
+				Timeout: 30 * time.Second,
+				Auto:    true,
+				Quiet:   true,
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().ResolveCommit("HEAD").Return("0123456789abcdef", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "conflict markers refused",
+			settings: &Settings{
+				Timeout:         30 * time.Second,
+				Auto:            true,
+				ConflictMarkers: "refuse",
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetConflictMarkers().Return([]string{"file.go:3"}, nil)
+			},
+			wantErr:     true,
+			errContains: "conflict markers found in staged changes",
+		},
+		{
+			name: "conflict markers only warned",
+			settings: &Settings{
+				Timeout:         30 * time.Second,
+				Auto:            true,
+				ConflictMarkers: "warn",
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetConflictMarkers().Return([]string{"file.go:3"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "generation note written and pushed",
+			settings: &Settings{
+				Timeout: 30 * time.Second,
+				Auto:    true,
+				Notes:   true,
+				Push:    true,
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().AddNote(notesRef, "Generated-By: commit\nProvider: test\nModel: test-model").Return(nil)
+				git.EXPECT().GetRemoteDivergence(gomock.Any()).Return(0, 0, nil)
+				git.EXPECT().Push(gomock.Any(), false, false).Return("", nil)
+				git.EXPECT().PushNotes(notesRef, gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "generation note not written for rolled back commit",
+			settings: &Settings{
+				Timeout:        30 * time.Second,
+				Auto:           true,
+				Notes:          true,
+				Push:           true,
+				RollbackCommit: true,
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("main", nil)
-				git.EXPECT().CreateCommit("test commit").Return(nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetRemoteDivergence(gomock.Any()).Return(0, 0, nil)
+				git.EXPECT().Push(gomock.Any(), false, false).Return("", errors.New("rejected"))
+				git.EXPECT().UndoLastCommit().Return(nil)
+			},
+			wantErr:     true,
+			errContains: "failed to push",
+		},
+		{
+			name: "empty commit allowed",
+			settings: &Settings{
+				Timeout:    30 * time.Second,
+				Auto:       true,
+				AllowEmpty: true,
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, true, "", "").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "detached head disables push",
+			settings: &Settings{
+				Timeout: 30 * time.Second,
+				Auto:    true,
+				Push:    true,
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("", &gitops.DetachedHeadError{SHA: "0123456789abcdef"})
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
 			},
 			wantErr: false,
 		},
@@ -738,19 +1479,23 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("main", nil)
-				git.EXPECT().CreateCommit("test commit").Return(nil)
-				git.EXPECT().Push().Return("https://github.com/user/repo/pull/new", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetRemoteDivergence("").Return(0, 0, nil)
+				git.EXPECT().Push("", false, false).Return("https://github.com/user/repo/pull/new", nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "push error",
+			name: "push aborted when remote diverged",
 			settings: &Settings{
 				Timeout: 30 * time.Second,
 				Auto:    true,
@@ -760,38 +1505,156 @@ func TestService_Execute(t *testing.T) {
 			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("main", nil)
-				git.EXPECT().CreateCommit("test commit").Return(nil)
-				git.EXPECT().Push().Return("", errors.New("push error"))
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetRemoteDivergence("").Return(1, 2, nil)
 			},
 			wantErr:     true,
-			errContains: "failed to push",
+			errContains: "remote branch has 2 new commit(s)",
 		},
 		{
-			name: "tag creation success",
+			name: "pull rebase before push",
+			settings: &Settings{
+				Timeout:    30 * time.Second,
+				Auto:       true,
+				DryRun:     false,
+				Push:       true,
+				PullRebase: true,
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetRemoteDivergence("").Return(1, 2, nil)
+				git.EXPECT().PullRebase("").Return(nil)
+				git.EXPECT().Push("", false, false).Return("", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "push error",
 			settings: &Settings{
 				Timeout: 30 * time.Second,
 				Auto:    true,
 				DryRun:  false,
-				Tag:     "patch",
+				Push:    true,
 			},
 			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("main", nil)
-				git.EXPECT().CreateCommit("test commit").Return(nil)
-				git.EXPECT().GetLatestTag().Return("v1.0.0", nil)
-				git.EXPECT().IncrementVersion("v1.0.0", "patch").Return("v1.0.1", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetRemoteDivergence("").Return(0, 0, nil)
+				git.EXPECT().Push("", false, false).Return("", errors.New("push error"))
+			},
+			wantErr:     true,
+			errContains: "failed to push",
+		},
+		{
+			name: "tag creation success",
+			settings: &Settings{
+				Timeout:   30 * time.Second,
+				Auto:      true,
+				DryRun:    false,
+				Tag:       "patch",
+				TagPrefix: "v",
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().IsShallowRepository().Return(false, nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetLatestTag("v", "").Return("v1.0.0", nil)
+				git.EXPECT().IncrementVersion("v1.0.0", "patch", "v").Return("v1.0.1", nil)
+				git.EXPECT().CreateTag("v1.0.1", "test commit").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tag refused in shallow clone",
+			settings: &Settings{
+				Timeout:   30 * time.Second,
+				Auto:      true,
+				Tag:       "patch",
+				TagPrefix: "v",
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().IsShallowRepository().Return(true, nil)
+			},
+			wantErr:     true,
+			errContains: "shallow clone",
+		},
+		{
+			name: "tag in shallow clone deepens history",
+			settings: &Settings{
+				Timeout:   30 * time.Second,
+				Auto:      true,
+				Tag:       "patch",
+				TagPrefix: "v",
+				Deepen:    true,
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().IsShallowRepository().Return(true, nil)
+				git.EXPECT().Deepen("").Return(nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetLatestTag("v", "").Return("v1.0.0", nil)
+				git.EXPECT().IncrementVersion("v1.0.0", "patch", "v").Return("v1.0.1", nil)
 				git.EXPECT().CreateTag("v1.0.1", "test commit").Return(nil)
 			},
 			wantErr: false,
@@ -799,30 +1662,135 @@ func TestService_Execute(t *testing.T) {
 		{
 			name: "tag creation and push",
 			settings: &Settings{
-				Timeout: 30 * time.Second,
-				Auto:    true,
-				DryRun:  false,
-				Tag:     "minor",
-				Push:    true,
+				Timeout:   30 * time.Second,
+				Auto:      true,
+				DryRun:    false,
+				Tag:       "minor",
+				TagPrefix: "v",
+				Push:      true,
 			},
 			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
 			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
 				git.EXPECT().IsGitRepository().Return(true)
-				git.EXPECT().GetRepoState().Return(RepoStateNormal, nil)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
 				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
 				git.EXPECT().UnstageAll().Return(nil)
-				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"file.go"}, nil)
-				git.EXPECT().GetStagedDiff(gomock.Any()).Return("diff content", nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
 				git.EXPECT().GetCurrentBranch().Return("main", nil)
-				git.EXPECT().CreateCommit("test commit").Return(nil)
-				git.EXPECT().Push().Return("", nil)
-				git.EXPECT().GetLatestTag().Return("v1.0.0", nil)
-				git.EXPECT().IncrementVersion("v1.0.0", "minor").Return("v1.1.0", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().IsShallowRepository().Return(false, nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetRemoteDivergence("").Return(0, 0, nil)
+				git.EXPECT().Push("", false, false).Return("", nil)
+				git.EXPECT().GetLatestTag("v", "").Return("v1.0.0", nil)
+				git.EXPECT().IncrementVersion("v1.0.0", "minor", "v").Return("v1.1.0", nil)
 				git.EXPECT().CreateTag("v1.1.0", "test commit").Return(nil)
-				git.EXPECT().PushTag("v1.1.0").Return(nil)
+				git.EXPECT().PushTag("v1.1.0", "").Return(nil)
 			},
 			wantErr: false,
 		},
+		{
+			name: "tag push failure rolls back local tag",
+			settings: &Settings{
+				Timeout:        30 * time.Second,
+				Auto:           true,
+				Tag:            "minor",
+				TagPrefix:      "v",
+				Push:           true,
+				RollbackCommit: true,
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().IsShallowRepository().Return(false, nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetRemoteDivergence("").Return(0, 0, nil)
+				git.EXPECT().GetLatestTag("v", "").Return("v1.0.0", nil)
+				git.EXPECT().IncrementVersion("v1.0.0", "minor", "v").Return("v1.1.0", nil)
+				git.EXPECT().CreateTag("v1.1.0", "test commit").Return(nil)
+				git.EXPECT().Push("", false, false).Return("", nil)
+				git.EXPECT().PushTag("v1.1.0", "").Return(errors.New("tag push error"))
+				git.EXPECT().DeleteTag("v1.1.0").Return(nil)
+			},
+			wantErr:     true,
+			errContains: "failed to push tag",
+		},
+		{
+			name: "push failure rolls back tag and commit",
+			settings: &Settings{
+				Timeout:        30 * time.Second,
+				Auto:           true,
+				Tag:            "patch",
+				TagPrefix:      "v",
+				Push:           true,
+				RollbackCommit: true,
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().IsShallowRepository().Return(false, nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetRemoteDivergence("").Return(0, 0, nil)
+				git.EXPECT().GetLatestTag("v", "").Return("v1.0.0", nil)
+				git.EXPECT().IncrementVersion("v1.0.0", "patch", "v").Return("v1.0.1", nil)
+				git.EXPECT().CreateTag("v1.0.1", "test commit").Return(nil)
+				git.EXPECT().Push("", false, false).Return("", errors.New("push error"))
+				git.EXPECT().DeleteTag("v1.0.1").Return(nil)
+				git.EXPECT().UndoLastCommit().Return(nil)
+			},
+			wantErr:     true,
+			errContains: "failed to push",
+		},
+		{
+			name: "tag creation failure keeps commit without rollback",
+			settings: &Settings{
+				Timeout:   30 * time.Second,
+				Auto:      true,
+				Tag:       "patch",
+				TagPrefix: "v",
+			},
+			aiAdapter: &simpleTestAdapter{hasProviders: true, commitMsg: "test commit"},
+			setupMocks: func(git *mocks.MockgitOperationsAccessor) {
+				git.EXPECT().IsGitRepository().Return(true)
+				git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+				git.EXPECT().HasConflicts().Return(false, []string{}, nil)
+				git.EXPECT().UnstageAll().Return(nil)
+				git.EXPECT().StageFiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]string{"file.go"}, nil)
+				git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff content", nil)
+				git.EXPECT().GetSubmoduleSummary(gomock.Any()).Return("", nil)
+				git.EXPECT().GetCurrentBranch().Return("main", nil)
+				git.EXPECT().GetCommitTemplate().Return("", nil)
+				git.EXPECT().IsShallowRepository().Return(false, nil)
+				git.EXPECT().CreateCommit("test commit", false, false, "", "").Return(nil)
+				git.EXPECT().GetLatestTag("v", "").Return("v1.0.0", nil)
+				git.EXPECT().IncrementVersion("v1.0.0", "patch", "v").Return("v1.0.1", nil)
+				git.EXPECT().CreateTag("v1.0.1", "test commit").Return(errors.New("tag exists"))
+			},
+			wantErr:     true,
+			errContains: "failed to create tag",
+		},
 	}
 
 	for _, tt := range tests {
@@ -860,3 +1828,100 @@ func TestService_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestService_PrepareCommitMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	git := mocks.NewMockgitOperationsAccessor(ctrl)
+	git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return("diff --git a/main.go b/main.go", nil)
+	git.EXPECT().GetStagedFiles().Return([]string{"main.go"}, nil)
+	git.EXPECT().GetCurrentBranch().Return("main", nil)
+	git.EXPECT().GetCommitTemplate().Return("", nil)
+
+	service := &Service{
+		logger:    slog.New(slog.DiscardHandler),
+		settings:  &Settings{Trailers: []string{"Reviewed-by: Team"}},
+		gitOps:    &testGitOperationsAdapter{gitOps: git},
+		aiService: &simpleTestAdapter{hasProviders: true, commitMsg: "feat: add main"},
+	}
+
+	messageFile := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(messageFile, []byte("\n# Please enter the commit message\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.PrepareCommitMessage(context.Background(), messageFile); err != nil {
+		t.Fatalf("PrepareCommitMessage() unexpected error = %v", err)
+	}
+
+	content, err := os.ReadFile(messageFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "feat: add main\n\nReviewed-by: Team\n\n# Please enter the commit message\n"
+	if string(content) != want {
+		t.Errorf("PrepareCommitMessage() wrote %q, want %q", content, want)
+	}
+}
+
+func TestService_Undo(t *testing.T) {
+	tests := []struct {
+		name     string
+		note     string
+		pushed   bool
+		force    bool
+		wantUndo bool
+		wantErr  bool
+	}{
+		{
+			name:     "generated commit",
+			note:     "Generated-By: commit\nProvider: claude",
+			wantUndo: true,
+		},
+		{
+			name:    "commit without note",
+			wantErr: true,
+		},
+		{
+			name:     "commit without note forced",
+			force:    true,
+			wantUndo: true,
+		},
+		{
+			name:    "pushed generated commit",
+			note:    "Generated-By: commit",
+			pushed:  true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			git := mocks.NewMockgitOperationsAccessor(ctrl)
+			git.EXPECT().IsGitRepository().Return(true)
+			git.EXPECT().GetRepoState().Return(gitops.RepoStateNormal, nil)
+			git.EXPECT().ResolveCommit("HEAD").Return("0123456789abcdef", nil)
+			git.EXPECT().GetNote(notesRef, "0123456789abcdef").Return(tt.note)
+			git.EXPECT().IsCommitPushed("0123456789abcdef").Return(tt.pushed, nil).AnyTimes()
+			git.EXPECT().GetCommitMessage("0123456789abcdef").Return("feat: add main", nil).AnyTimes()
+			if tt.wantUndo {
+				git.EXPECT().UndoLastCommit().Return(nil)
+			}
+
+			service := &Service{
+				logger:   slog.New(slog.DiscardHandler),
+				settings: &Settings{},
+				gitOps:   &testGitOperationsAdapter{gitOps: git},
+			}
+
+			err := service.Undo(context.Background(), tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Undo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
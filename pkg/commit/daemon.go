@@ -0,0 +1,268 @@
+package commit
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+const (
+	daemonSocketName     = "commit-daemon.sock"  // default socket, in git directory of served repository
+	daemonTokenName      = "commit-daemon.token" // bearer token of daemon listening on TCP, next to socket
+	daemonMaxRequestSize = 16 << 20
+)
+
+// DaemonRequest asks daemon for suggestions, empty diff means staged changes of served repository
+type DaemonRequest struct {
+	Diff   string `json:"diff,omitempty"`
+	Branch string `json:"branch,omitempty"`
+}
+
+// DaemonResponse carries suggestions by provider with modules and trailers applied, or error
+type DaemonResponse struct {
+	Suggestions map[string]string `json:"suggestions,omitempty"`
+	Cached      bool              `json:"cached,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// daemon serves generation requests of one repository, requests are answered one at a time,
+// so concurrent ones for the same staged changes are served from cache
+type daemon struct {
+	service *Service
+
+	mu          sync.Mutex
+	indexHash   string
+	diff        string            // staged diff, prepared when index changes
+	files       []string          // staged files
+	suggestions map[string]string // of staged diff, dropped when index changes
+}
+
+// DaemonSocketPath returns default socket of daemon serving current repository
+func (s *Service) DaemonSocketPath() (string, error) {
+	gitDir, err := s.gitOps.GetGitDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve git directory: %w", err)
+	}
+	return filepath.Join(gitDir, daemonSocketName), nil
+}
+
+// DaemonTokenPath returns file with bearer token of daemon serving current repository over TCP
+func (s *Service) DaemonTokenPath() (string, error) {
+	gitDir, err := s.gitOps.GetGitDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve git directory: %w", err)
+	}
+	return filepath.Join(gitDir, daemonTokenName), nil
+}
+
+// Serve answers generation requests on listener until ctx is done, provider clients and their
+// connections are reused between requests, staged changes are polled every watchInterval
+// so their diff is ready before a request comes. Requests must carry token as bearer token,
+// empty token is for unix sockets, which are protected by their permissions
+func (s *Service) Serve(ctx context.Context, listener net.Listener, watchInterval time.Duration, token string) error {
+	if s.aiService.NumProviders() == 0 {
+		s.logger.WarnContext(ctx, "No providers configured")
+		return fmt.Errorf("no api keys found in environment")
+	}
+
+	d := &daemon{service: s}
+	d.mu.Lock()
+	d.refresh(ctx)
+	d.mu.Unlock()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", d.handleHealth)
+	mux.HandleFunc("POST /generate", d.handleGenerate)
+
+	server := &http.Server{
+		Handler:           authorizeDaemon(token, mux),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go d.watch(ctx, watchInterval)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	s.logger.InfoContext(ctx, "Daemon listening", "address", listener.Addr().String())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("daemon failed: %w", err)
+	}
+	return nil
+}
+
+// authorizeDaemon rejects requests without token and requests of browsers, which send Origin,
+// so pages opened by user can not reach daemon with help of DNS rebinding
+func authorizeDaemon(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") != "" {
+			writeDaemonResponse(w, http.StatusForbidden, DaemonResponse{Error: "cross-origin requests are not allowed"})
+			return
+		}
+		if token != "" {
+			bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeDaemonResponse(w, http.StatusUnauthorized, DaemonResponse{Error: "invalid or missing token"})
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// watch refreshes staged diff every interval until ctx is done
+func (d *daemon) watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.mu.Lock()
+			d.refresh(ctx)
+			d.mu.Unlock()
+		}
+	}
+}
+
+// refresh prepares diff of staged changes when index changed, must be called with mu held
+func (d *daemon) refresh(ctx context.Context) {
+	s := d.service
+	indexHash, err := s.gitOps.GetIndexHash()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to hash staged changes", "error", err)
+		return
+	}
+	if indexHash == d.indexHash {
+		return
+	}
+
+	diff, err := s.gitOps.GetStagedDiff(s.settings.MaxDiffSizeBytes, s.settings.NewFileHeadLines)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to get staged diff", "error", err)
+		return
+	}
+	files, err := s.gitOps.GetStagedFiles()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to get staged files", "error", err)
+		return
+	}
+
+	s.logger.DebugContext(ctx, "Staged changes updated", "files", len(files))
+	d.indexHash, d.diff, d.files, d.suggestions = indexHash, diff, files, nil
+}
+
+func (d *daemon) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeDaemonResponse(w, http.StatusOK, DaemonResponse{})
+}
+
+func (d *daemon) handleGenerate(w http.ResponseWriter, r *http.Request) {
+	var request DaemonRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, daemonMaxRequestSize)).Decode(&request); err != nil {
+		writeDaemonResponse(w, http.StatusBadRequest, DaemonResponse{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	response, err := d.generate(r.Context(), request)
+	if errors.Is(err, ErrNothingToCommit) {
+		writeDaemonResponse(w, http.StatusUnprocessableEntity, DaemonResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		d.service.logger.ErrorContext(r.Context(), "Failed to generate commit messages", "error", err)
+		writeDaemonResponse(w, http.StatusInternalServerError, DaemonResponse{Error: err.Error()})
+		return
+	}
+	writeDaemonResponse(w, http.StatusOK, response)
+}
+
+// generate returns suggestions of requested diff, or of staged changes, must be called with mu held
+func (d *daemon) generate(ctx context.Context, request DaemonRequest) (DaemonResponse, error) {
+	s := d.service
+
+	diff, files := request.Diff, gitops.DiffFiles(request.Diff)
+	staged := diff == ""
+	if staged {
+		d.refresh(ctx)
+		if d.suggestions != nil && request.Branch == "" {
+			return DaemonResponse{Suggestions: d.suggestions, Cached: true}, nil
+		}
+		diff, files = d.diff, d.files
+	} else if len(diff) > s.settings.MaxDiffSizeBytes {
+		diff = gitops.PrioritizeDiff(diff, s.settings.MaxDiffSizeBytes)
+	}
+	if strings.TrimSpace(diff) == "" {
+		return DaemonResponse{}, ErrNothingToCommit
+	}
+
+	branch, promptBranch := request.Branch, request.Branch
+	if branch == "" {
+		var err error
+		if branch, promptBranch, err = s.currentBranch(ctx); err != nil {
+			return DaemonResponse{}, err
+		}
+	}
+
+	commitTemplate, err := s.gitOps.GetCommitTemplate()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load commit template", "error", err)
+	}
+
+	messages, err := s.aiService.GenerateCommitMessages(
+		ctx,
+		diff, promptBranch, files,
+		s.settings.Providers, s.settings.CustomPrompt, commitTemplate,
+		nil,
+		s.settings.First, s.settings.MultiLine,
+		s.promptTransformer(branch),
+	)
+	if err != nil {
+		return DaemonResponse{}, classify(ErrProvider, fmt.Errorf("failed to generate suggestions: %w", err))
+	}
+
+	suggestions := make(map[string]string, len(messages))
+	for provider, message := range messages {
+		processed, err := s.applyModules(ctx, branch, message)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Suggestion rejected by modules", "provider", provider, "error", err)
+			continue
+		}
+		suggestions[provider] = strings.TrimSpace(s.appendTrailers(ctx, branch, processed))
+	}
+	if len(suggestions) == 0 {
+		return DaemonResponse{}, fmt.Errorf("no valid suggestions available")
+	}
+
+	if staged && request.Branch == "" {
+		d.suggestions = suggestions
+	}
+	return DaemonResponse{Suggestions: suggestions}, nil
+}
+
+func writeDaemonResponse(w http.ResponseWriter, status int, response DaemonResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,126 @@
+package commit
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/commit/pkg/commit/mocks"
+)
+
+func TestDaemon_handleGenerate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stagedDiff := "diff --git a.go a.go\n@@ -1 +1 @@\n-a\n+b\n"
+	givenDiff := "diff --git b.go b.go\n@@ -1 +1 @@\n-c\n+d\n"
+
+	indexHash := "index-a"
+	git := mocks.NewMockgitOperationsAccessor(ctrl)
+	git.EXPECT().GetIndexHash().DoAndReturn(func() (string, error) { return indexHash, nil }).AnyTimes()
+	git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return(stagedDiff, nil).Times(2)
+	git.EXPECT().GetStagedFiles().Return([]string{"a.go"}, nil).Times(2)
+	git.EXPECT().GetCurrentBranch().Return("main", nil).AnyTimes()
+	git.EXPECT().GetCommitTemplate().Return("", nil).AnyTimes()
+
+	generated := 0
+	ai := mocks.NewMockaiServiceAccessor(ctrl)
+	ai.EXPECT().GenerateCommitMessages(
+		gomock.Any(), gomock.Any(), "main", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).DoAndReturn(func(
+		_ any, diff, _ string, files []string, _ []string, _, _ string, _ []string, _, _ bool, _ any,
+	) (map[string]string, error) {
+		generated++
+		return map[string]string{"openai": "feat: change " + files[0]}, nil
+	}).AnyTimes()
+
+	service := &Service{
+		logger:    slog.New(slog.DiscardHandler),
+		settings:  &Settings{MaxDiffSizeBytes: 1024, Trailers: []string{"Refs: 42"}},
+		gitOps:    git,
+		aiService: ai,
+	}
+	d := &daemon{service: service}
+
+	request := func(body string) (int, DaemonResponse) {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		d.handleGenerate(recorder, httptest.NewRequest(http.MethodPost, "/generate", strings.NewReader(body)))
+		var response DaemonResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return recorder.Code, response
+	}
+
+	code, response := request("{}")
+	if code != http.StatusOK || response.Suggestions["openai"] != "feat: change a.go\n\nRefs: 42" || response.Cached {
+		t.Errorf("staged request = %d %+v, want generated suggestion with trailers", code, response)
+	}
+
+	code, response = request("{}")
+	if code != http.StatusOK || !response.Cached || generated != 1 {
+		t.Errorf("repeated request = %d %+v after %d generations, want cached suggestion", code, response, generated)
+	}
+
+	body, _ := json.Marshal(DaemonRequest{Diff: givenDiff})
+	code, response = request(string(body))
+	if code != http.StatusOK || response.Suggestions["openai"] != "feat: change b.go\n\nRefs: 42" || generated != 2 {
+		t.Errorf("diff request = %d %+v, want suggestion of given diff", code, response)
+	}
+
+	// changed index drops cached suggestions
+	indexHash = "index-b"
+	if code, response = request("{}"); code != http.StatusOK || response.Cached || generated != 3 {
+		t.Errorf("request after staging = %d %+v, want new generation", code, response)
+	}
+
+	if code, response = request("not json"); code != http.StatusBadRequest || response.Error == "" {
+		t.Errorf("invalid request = %d %+v, want bad request", code, response)
+	}
+}
+
+func TestAuthorizeDaemon(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	bearer := func(value string) map[string]string { return map[string]string{"Authorization": value} }
+
+	tests := []struct {
+		name    string
+		token   string
+		headers map[string]string
+		want    int
+	}{
+		{name: "socket without token", want: http.StatusOK},
+		{name: "valid token", token: "secret", headers: bearer("Bearer secret"), want: http.StatusOK},
+		{name: "missing token", token: "secret", want: http.StatusUnauthorized},
+		{name: "wrong token", token: "secret", headers: bearer("Bearer other"), want: http.StatusUnauthorized},
+		{name: "token without scheme", token: "secret", headers: bearer("secret"), want: http.StatusUnauthorized},
+		{
+			name:    "browser request",
+			token:   "secret",
+			headers: map[string]string{"Authorization": "Bearer secret", "Origin": "http://example.com"},
+			want:    http.StatusForbidden,
+		},
+		{name: "browser request to socket", headers: map[string]string{"Origin": "null"}, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/health", nil)
+			for key, value := range tt.headers {
+				request.Header.Set(key, value)
+			}
+			recorder := httptest.NewRecorder()
+			authorizeDaemon(tt.token, ok).ServeHTTP(recorder, request)
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+}
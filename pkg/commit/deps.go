@@ -0,0 +1,200 @@
+package commit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hasansino/commit/pkg/commit/modules"
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+// dependencyBumpProvider names deterministic message of dependency-only changes among suggestions
+const dependencyBumpProvider = "dependencies"
+
+// packageJSONDependencyKeys are package.json sections listing dependencies
+var packageJSONDependencyKeys = map[string]bool{
+	"dependencies": true, "devDependencies": true, "peerDependencies": true, "optionalDependencies": true,
+}
+
+// dependencyChange is version delta of single dependency, empty version means added or removed
+type dependencyChange struct {
+	name     string
+	from, to string
+}
+
+func (c dependencyChange) String() string {
+	switch {
+	case c.from == "":
+		return "add " + c.name + " " + c.to
+	case c.to == "":
+		return "remove " + c.name
+	default:
+		return "bump " + c.name + " from " + c.from + " to " + c.to
+	}
+}
+
+// isDependencyFile reports whether file is dependency manifest or lockfile
+func isDependencyFile(file string) bool {
+	base := path.Base(file)
+	return base == "go.mod" || base == "package.json" || gitops.IsLockFile(base)
+}
+
+// dependencyBumpMessage returns message of dependency-only staged changes, generated from
+// version delta without providers, empty when other files or manifest fields changed
+func (s *Service) dependencyBumpMessage(ctx context.Context, stagedFiles []string) string {
+	if s.dependencyFiles == nil || len(stagedFiles) == 0 {
+		return ""
+	}
+	for _, file := range stagedFiles {
+		if !isDependencyFile(file) {
+			return ""
+		}
+	}
+
+	files, err := s.dependencyFiles()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to read staged dependency files", "error", err)
+		return ""
+	}
+
+	return formatDependencyBump(files)
+}
+
+// formatDependencyBump renders dependabot-like message of dependency changes
+func formatDependencyBump(files []modules.ChangedFile) string {
+	var changes []dependencyChange
+	for _, file := range files {
+		var (
+			fileChanges []dependencyChange
+			ok          bool
+		)
+		switch path.Base(file.Path) {
+		case "go.mod":
+			fileChanges, ok = diffDependencies(file, parseGoModRequires)
+		case "package.json":
+			fileChanges, ok = diffDependencies(file, parsePackageJSONDependencies)
+		default:
+			continue
+		}
+		if !ok {
+			return ""
+		}
+		changes = append(changes, fileChanges...)
+	}
+
+	switch len(changes) {
+	case 0:
+		return "chore(deps): lock file maintenance"
+	case 1:
+		return "chore(deps): " + changes[0].String()
+	}
+
+	lines := make([]string, 0, len(changes))
+	verb := "bump"
+	for _, change := range changes {
+		lines = append(lines, "- "+change.String())
+		if change.from == "" || change.to == "" {
+			verb = "update"
+		}
+	}
+	return fmt.Sprintf("chore(deps): %s %d dependencies\n\n%s", verb, len(changes), strings.Join(lines, "\n"))
+}
+
+// diffDependencies compares dependencies of manifest before and after change,
+// ok is false when anything but dependencies changed
+func diffDependencies(
+	file modules.ChangedFile,
+	parse func(content []byte) (map[string]string, string, bool),
+) ([]dependencyChange, bool) {
+	before, restBefore, okBefore := parse(file.Before)
+	after, restAfter, okAfter := parse(file.After)
+	if !okBefore || !okAfter || restBefore != restAfter {
+		return nil, false
+	}
+
+	names := make(map[string]bool, len(before)+len(after))
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+
+	var changes []dependencyChange
+	for name := range names {
+		if before[name] != after[name] {
+			changes = append(changes, dependencyChange{name: name, from: before[name], to: after[name]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].name < changes[j].name
+	})
+	return changes, true
+}
+
+// parseGoModRequires returns required module versions and the rest of go.mod
+func parseGoModRequires(content []byte) (map[string]string, string, bool) {
+	requires := make(map[string]string)
+	var (
+		rest    []string
+		inBlock bool
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && len(fields) >= 2:
+			requires[fields[0]] = fields[1]
+		case inBlock:
+		case line == "require (":
+			inBlock = true
+		case len(fields) >= 3 && fields[0] == "require":
+			requires[fields[1]] = fields[2]
+		case line != "":
+			rest = append(rest, line)
+		}
+	}
+	return requires, strings.Join(rest, "\n"), true
+}
+
+// parsePackageJSONDependencies returns dependency versions of all sections and the rest of package.json
+func parsePackageJSONDependencies(content []byte) (map[string]string, string, bool) {
+	if len(content) == 0 {
+		return map[string]string{}, "", true
+	}
+
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, "", false
+	}
+
+	dependencies := make(map[string]string)
+	for key := range packageJSONDependencyKeys {
+		var section map[string]string
+		if raw, ok := manifest[key]; ok {
+			if err := json.Unmarshal(raw, &section); err != nil {
+				return nil, "", false
+			}
+		}
+		for name, version := range section {
+			dependencies[name] = version
+		}
+		delete(manifest, key)
+	}
+
+	// map keys are marshaled sorted, so the rest compares regardless of formatting
+	rest, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, "", false
+	}
+	return dependencies, string(rest), true
+}
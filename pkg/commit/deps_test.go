@@ -0,0 +1,115 @@
+package commit
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hasansino/commit/pkg/commit/modules"
+)
+
+const goModBefore = `module example.com/app
+
+go 1.24
+
+require (
+	github.com/spf13/cobra v1.8.0
+	golang.org/x/sys v0.30.0 // indirect
+)
+
+require github.com/stretchr/testify v1.9.0
+`
+
+func TestFormatDependencyBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []modules.ChangedFile
+		expected string
+	}{
+		{
+			name: "single go module bump",
+			files: []modules.ChangedFile{
+				{
+					Path:   "go.mod",
+					Before: []byte(goModBefore),
+					After:  []byte(strings.Replace(goModBefore, "cobra v1.8.0", "cobra v1.9.1", 1)),
+				},
+				{Path: "go.sum", Before: []byte("a"), After: []byte("b")},
+			},
+			expected: "chore(deps): bump github.com/spf13/cobra from v1.8.0 to v1.9.1",
+		},
+		{
+			name: "several npm changes",
+			files: []modules.ChangedFile{
+				{
+					Path:   "web/package.json",
+					Before: []byte(`{"name":"web","dependencies":{"react":"^18.2.0","lodash":"^4.17.0"}}`),
+					After: []byte(`{
+  "name": "web",
+  "dependencies": {"react": "^18.3.1"},
+  "devDependencies": {"vitest": "^1.6.0"}
+}`),
+				},
+			},
+			expected: "chore(deps): update 3 dependencies\n\n" +
+				"- remove lodash\n- bump react from ^18.2.0 to ^18.3.1\n- add vitest ^1.6.0",
+		},
+		{
+			name:     "lockfiles only",
+			files:    []modules.ChangedFile{{Path: "go.sum", Before: []byte("a"), After: []byte("b")}},
+			expected: "chore(deps): lock file maintenance",
+		},
+		{
+			name: "go directive changed",
+			files: []modules.ChangedFile{
+				{
+					Path:   "go.mod",
+					Before: []byte(goModBefore),
+					After:  []byte(strings.Replace(goModBefore, "go 1.24", "go 1.25", 1)),
+				},
+			},
+			expected: "",
+		},
+		{
+			name: "scripts changed",
+			files: []modules.ChangedFile{
+				{
+					Path:   "package.json",
+					Before: []byte(`{"scripts":{"test":"jest"},"dependencies":{"react":"18.2.0"}}`),
+					After:  []byte(`{"scripts":{"test":"vitest"},"dependencies":{"react":"18.3.1"}}`),
+				},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDependencyBump(tt.files); got != tt.expected {
+				t.Errorf("formatDependencyBump() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestService_dependencyBumpMessage(t *testing.T) {
+	read := false
+	service := &Service{
+		logger: slog.New(slog.DiscardHandler),
+		dependencyFiles: func() ([]modules.ChangedFile, error) {
+			read = true
+			return []modules.ChangedFile{{Path: "yarn.lock", Before: []byte("a"), After: []byte("b")}}, nil
+		},
+	}
+
+	if got := service.dependencyBumpMessage(context.Background(), []string{"yarn.lock", "main.go"}); got != "" {
+		t.Errorf("dependencyBumpMessage() = %q, want empty for mixed changes", got)
+	}
+	if read {
+		t.Error("dependencyBumpMessage() read files of mixed changes")
+	}
+	if got := service.dependencyBumpMessage(context.Background(), []string{"yarn.lock"}); got == "" {
+		t.Error("dependencyBumpMessage() = empty, want message for lockfile change")
+	}
+}
@@ -0,0 +1,104 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+// diffFileStdin is --diff-file value reading diff from standard input
+const diffFileStdin = "-"
+
+// GenerateFromDiff generates message of diff supplied externally, from file or standard input,
+// and prints it or writes it to output file; nothing is staged or committed
+func (s *Service) GenerateFromDiff(ctx context.Context) error {
+	if s.aiService.NumProviders() == 0 {
+		s.logger.WarnContext(ctx, "No providers configured")
+		return fmt.Errorf("no api keys found in environment")
+	}
+
+	diff, err := readDiffFile(s.settings.DiffFile, os.Stdin, s.settings.MaxDiffSizeBytes)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to read diff", "path", s.settings.DiffFile, "error", err)
+		return err
+	}
+	if strings.TrimSpace(diff) == "" {
+		s.logger.WarnContext(ctx, "Provided diff is empty")
+		return nil
+	}
+
+	files := gitops.DiffFiles(diff)
+
+	var branch, promptBranch, commitTemplate string
+	if !s.repoless {
+		branch, promptBranch, err = s.currentBranch(ctx)
+		if err != nil {
+			return err
+		}
+
+		commitTemplate, err = s.gitOps.GetCommitTemplate()
+		if err != nil {
+			s.logger.WarnContext(ctx, "Failed to load commit template", "error", err)
+		}
+	}
+
+	s.logger.DebugContext(ctx, "Requesting commit messages...", "files", len(files))
+
+	messages, err := s.aiService.GenerateCommitMessages(
+		ctx,
+		diff, promptBranch, files,
+		s.settings.Providers, s.settings.CustomPrompt, commitTemplate,
+		nil,
+		s.settings.First, s.settings.MultiLine,
+		s.promptTransformer(branch),
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to generate commit messages", "error", err)
+		return fmt.Errorf("failed to generate suggestions: %w", err)
+	}
+
+	message := s.getRandomMessage(messages)
+	if message == "" {
+		return fmt.Errorf("no valid suggestions available")
+	}
+	message, err = s.applyModules(ctx, branch, message)
+	if err != nil {
+		return err
+	}
+	message = strings.TrimSpace(s.appendTrailers(ctx, branch, message))
+
+	if handled, err := s.outputMessage(ctx, message); handled {
+		return err
+	}
+
+	// printed as is, so it can be consumed by other tooling
+	fmt.Println(message)
+
+	return nil
+}
+
+// readDiffFile reads diff from path, or from stdin when path is "-", cut to maxSizeBytes
+func readDiffFile(path string, stdin io.Reader, maxSizeBytes int) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == diffFileStdin {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read diff: %w", err)
+	}
+
+	diff := string(data)
+	if maxSizeBytes > 0 && len(diff) > maxSizeBytes {
+		diff = gitops.PrioritizeDiff(diff, maxSizeBytes)
+	}
+	return diff, nil
+}
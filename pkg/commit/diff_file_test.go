@@ -0,0 +1,72 @@
+package commit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+func TestReadDiffFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.diff")
+	if err := os.WriteFile(path, []byte("diff --git a/a b/a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readDiffFile(path, nil, 1024)
+	if err != nil || got != "diff --git a/a b/a\n" {
+		t.Errorf("readDiffFile(file) = %q, %v", got, err)
+	}
+
+	got, err = readDiffFile("-", strings.NewReader("0123456789"), 4)
+	if err != nil || got != "0123" {
+		t.Errorf("readDiffFile(stdin) = %q, %v, want truncated diff", got, err)
+	}
+
+	// diff cut to the limit ends on whole line and is marked as truncated
+	long := "diff --git a/a b/a\n+first line\n+second line\n+third line\n"
+	got, err = readDiffFile("-", strings.NewReader(long), 50)
+	if err != nil || got != "diff --git a/a b/a\n+first line\n"+gitops.DiffTruncatedMarker {
+		t.Errorf("readDiffFile(long) = %q, %v, want diff cut on line boundary with marker", got, err)
+	}
+
+	if _, err := readDiffFile(filepath.Join(t.TempDir(), "missing"), nil, 1024); err == nil {
+		t.Error("readDiffFile(missing) error = nil, want error")
+	}
+}
+
+func TestService_GenerateFromDiff_OutsideRepository(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("GIT_DIR", "")
+	t.Setenv("GIT_WORK_TREE", "")
+
+	diffPath := filepath.Join(dir, "changes.diff")
+	if err := os.WriteFile(diffPath, []byte("diff --git a/a.go b/a.go\n+++ b/a.go\n+x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outputPath := filepath.Join(dir, "message.txt")
+
+	service, err := NewCommitService(&Settings{
+		Timeout:          30 * time.Second,
+		DiffFile:         diffPath,
+		OutputFile:       outputPath,
+		MaxDiffSizeBytes: 1024,
+	})
+	if err != nil {
+		t.Fatalf("NewCommitService() outside of repository error = %v", err)
+	}
+	service.aiService = &simpleTestAdapter{hasProviders: true, commitMsg: "feat: add a"}
+
+	if err := service.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	message, err := os.ReadFile(outputPath)
+	if err != nil || string(message) != "feat: add a\n" {
+		t.Errorf("written message = %q, %v, want %q", message, err, "feat: add a\n")
+	}
+}
@@ -0,0 +1,39 @@
+package commit
+
+import "errors"
+
+// Outcome errors let callers tell failures apart, e.g. to choose an exit code
+var (
+	ErrNothingToCommit = errors.New("nothing to commit")
+	ErrCanceled        = errors.New("canceled by user")
+	ErrProvider        = errors.New("provider failure")
+	ErrGit             = errors.New("git failure")
+	ErrPush            = errors.New("push failure")
+)
+
+// classifiedError keeps the message of err and matches its class with errors.Is
+type classifiedError struct {
+	class error
+	err   error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.err, e.class}
+}
+
+// classify marks err with outcome class, errors already classified keep their class
+func classify(class, err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, known := range []error{ErrNothingToCommit, ErrCanceled, ErrProvider, ErrGit, ErrPush} {
+		if errors.Is(err, known) {
+			return err
+		}
+	}
+	return &classifiedError{class: class, err: err}
+}
@@ -0,0 +1,60 @@
+package commit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name    string
+		class   error
+		err     error
+		want    error
+		notWant error
+	}{
+		{
+			name:  "plain error gets class",
+			class: ErrGit,
+			err:   errors.New("failed to stage files"),
+			want:  ErrGit,
+		},
+		{
+			name:    "classified error keeps class",
+			class:   ErrGit,
+			err:     fmt.Errorf("failed to push: %w", classify(ErrPush, errors.New("rejected"))),
+			want:    ErrPush,
+			notWant: ErrGit,
+		},
+		{
+			name:    "sentinel keeps itself",
+			class:   ErrProvider,
+			err:     ErrCanceled,
+			want:    ErrCanceled,
+			notWant: ErrProvider,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classify(tt.class, tt.err)
+			if got.Error() != tt.err.Error() {
+				t.Errorf("classify() message = %q, want %q", got.Error(), tt.err.Error())
+			}
+			if !errors.Is(got, tt.want) {
+				t.Errorf("classify() = %v, want errors.Is %v", got, tt.want)
+			}
+			if tt.notWant != nil && errors.Is(got, tt.notWant) {
+				t.Errorf("classify() = %v, want not errors.Is %v", got, tt.notWant)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("classify() = %v, want it to wrap %v", got, tt.err)
+			}
+		})
+	}
+
+	if classify(ErrGit, nil) != nil {
+		t.Errorf("classify() of nil error is not nil")
+	}
+}
@@ -0,0 +1,50 @@
+package commit
+
+import (
+	"context"
+	"os"
+
+	"github.com/hasansino/commit/pkg/commit/ui"
+)
+
+// confirmCost logs estimated token usage and cost of requests before they are sent, and in
+// interactive mode asks user to confirm when total cost exceeds threshold; false means declined.
+// Prompt is estimated before modules transform it, they usually change it only slightly.
+func (s *Service) confirmCost(
+	ctx context.Context,
+	diff, branch string, files []string,
+	commitTemplate string, recentCommits []string,
+) (bool, error) {
+	prompt := s.aiService.BuildPrompt(
+		diff, branch, files,
+		s.settings.CustomPrompt, commitTemplate,
+		recentCommits,
+		s.settings.MultiLine,
+	)
+	estimates := s.aiService.EstimateRequests(s.settings.Providers, prompt)
+
+	var total float64
+	for _, estimate := range estimates {
+		attrs := []any{
+			"provider", estimate.Provider,
+			"model", estimate.Model,
+			"input_tokens", estimate.InputTokens,
+			"output_tokens", estimate.OutputTokens,
+		}
+		if estimate.Cost >= 0 {
+			attrs = append(attrs, "cost_usd", estimate.Cost)
+			total += estimate.Cost
+		}
+		s.logger.InfoContext(ctx, "Estimated request", attrs...)
+	}
+
+	if s.settings.CostThreshold <= 0 || total <= s.settings.CostThreshold {
+		return true, nil
+	}
+	if s.settings.Auto || s.settings.Yes {
+		s.logger.WarnContext(ctx, "Estimated cost exceeds threshold",
+			"cost_usd", total, "threshold_usd", s.settings.CostThreshold)
+		return true, nil
+	}
+	return ui.ConfirmCost(os.Stdin, os.Stdout, estimates)
+}
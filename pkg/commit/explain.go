@@ -0,0 +1,96 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	_ "embed"
+)
+
+//go:embed prompt-explain.md
+var explainPrompt string
+
+// Explain asks providers to explain an existing commit from its message and diff,
+// explanations are printed, one per provider
+func (s *Service) Explain(ctx context.Context, ref string) error {
+	if err := s.checkRepository(ctx); err != nil {
+		return err
+	}
+
+	sha, err := s.gitOps.ResolveCommit(ref)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to resolve commit", "ref", ref, "error", err)
+		return fmt.Errorf("failed to resolve commit: %w", err)
+	}
+
+	message, err := s.gitOps.GetCommitMessage(sha)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get commit message", "error", err)
+		return err
+	}
+
+	diff, files, err := s.gitOps.GetCommitDiff(sha, s.settings.MaxDiffSizeBytes)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get commit diff", "error", err)
+		return fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	if strings.TrimSpace(diff) == "" {
+		s.logger.WarnContext(ctx, "Commit has no changes to explain", "commit", sha)
+		return nil
+	}
+
+	s.logger.DebugContext(ctx, "Requesting explanation...", "commit", sha, "files", len(files))
+
+	responses, err := s.aiService.Ask(
+		ctx, s.settings.Providers,
+		buildExplainPrompt(sha, message, diff, files),
+		s.settings.First,
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to request explanation", "error", err)
+		return fmt.Errorf("failed to request explanation: %w", err)
+	}
+
+	fmt.Print(formatExplanations(responses))
+
+	return nil
+}
+
+func buildExplainPrompt(sha, message, diff string, files []string) string {
+	if strings.TrimSpace(message) == "" {
+		message = "(empty)"
+	}
+	result := explainPrompt
+	result = strings.ReplaceAll(result, "{commit}", sha)
+	result = strings.ReplaceAll(result, "{message}", message)
+	result = strings.ReplaceAll(result, "{files}", strings.Join(files, ", "))
+	result = strings.ReplaceAll(result, "{diff}", diff)
+	return result
+}
+
+// formatExplanations renders responses sorted by provider, provider headings are added
+// only when there is more than one response
+func formatExplanations(responses map[string]string) string {
+	providers := make([]string, 0, len(responses))
+	for provider, response := range responses {
+		if strings.TrimSpace(response) != "" {
+			providers = append(providers, provider)
+		}
+	}
+	sort.Strings(providers)
+
+	var b strings.Builder
+	for i, provider := range providers {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if len(providers) > 1 {
+			b.WriteString(provider + ":\n\n")
+		}
+		b.WriteString(strings.TrimSpace(responses[provider]) + "\n")
+	}
+	return b.String()
+}
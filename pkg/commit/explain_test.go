@@ -0,0 +1,32 @@
+package commit
+
+import (
+	"testing"
+)
+
+func TestFormatExplanations(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]string
+		want      string
+	}{
+		{
+			name:      "single provider",
+			responses: map[string]string{"claude": "  Adds retries.\n"},
+			want:      "Adds retries.\n",
+		},
+		{
+			name:      "several providers sorted",
+			responses: map[string]string{"openai": "Second.", "claude": "First.", "gemini": " "},
+			want:      "claude:\n\nFirst.\n\nopenai:\n\nSecond.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatExplanations(tt.responses); got != tt.want {
+				t.Errorf("formatExplanations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
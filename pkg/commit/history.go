@@ -0,0 +1,225 @@
+package commit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// historyLimit is number of generations kept per repository
+const historyLimit = 50
+
+// HistoryEntry is single generation of suggestions, stored per repository
+type HistoryEntry struct {
+	Time        time.Time         `json:"time"`
+	Branch      string            `json:"branch"`
+	DiffHash    string            `json:"diff_hash"`
+	CacheKey    string            `json:"cache_key,omitempty"`
+	Suggestions map[string]string `json:"suggestions"`
+}
+
+// historyStore keeps generations of one repository in JSON lines file, oldest first
+type historyStore struct {
+	path  string
+	limit int
+}
+
+// newHistoryStore returns store of repository in dir, file is named after hash of repository root
+func newHistoryStore(dir, repoRoot string) *historyStore {
+	sum := sha256.Sum256([]byte(repoRoot))
+	return &historyStore{
+		path:  filepath.Join(dir, hex.EncodeToString(sum[:8])+".jsonl"),
+		limit: historyLimit,
+	}
+}
+
+// List returns stored entries, oldest first, missing file means empty history
+func (h *historyStore) List() ([]HistoryEntry, error) {
+	data, err := os.ReadFile(h.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue // corrupted line does not invalidate others
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return entries, nil
+}
+
+// Add appends entry and drops the oldest ones over limit
+func (h *historyStore) Add(entry HistoryEntry) error {
+	entries, err := h.List()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	if len(entries) > h.limit {
+		entries = entries[len(entries)-h.limit:]
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode history: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	// written aside and renamed, concurrent runs never see partial file
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if err := os.Rename(tmp, h.path); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// Clear removes all entries
+func (h *historyStore) Clear() error {
+	if err := os.Remove(h.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to clear history: %w", err)
+	}
+	return nil
+}
+
+// hashDiff identifies staged changes in history
+func hashDiff(diff string) string {
+	sum := sha256.Sum256([]byte(diff))
+	return hex.EncodeToString(sum[:])
+}
+
+// recordHistory stores generated suggestions, history is convenience and never fails the commit
+func (s *Service) recordHistory(ctx context.Context, branch, diff string, suggestions map[string]string) {
+	if s.history == nil || len(suggestions) == 0 {
+		return
+	}
+	err := s.history.Add(HistoryEntry{
+		Time:        time.Now(),
+		Branch:      branch,
+		DiffHash:    hashDiff(diff),
+		CacheKey:    s.suggestionCacheKey(ctx, branch),
+		Suggestions: suggestions,
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to record suggestion history", "error", err)
+	}
+}
+
+// previousSuggestions returns suggestions of earlier runs for the same diff, newest win
+func (s *Service) previousSuggestions(ctx context.Context, diff string) map[string]string {
+	if s.history == nil {
+		return nil
+	}
+	entries, err := s.history.List()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to read suggestion history", "error", err)
+		return nil
+	}
+
+	diffHash := hashDiff(diff)
+	previous := make(map[string]string)
+	for _, entry := range entries {
+		if entry.DiffHash != diffHash {
+			continue
+		}
+		for provider, message := range entry.Suggestions {
+			previous[provider] = message
+		}
+	}
+	return previous
+}
+
+// suggestionCacheKey identifies staged content together with options shaping suggestions,
+// empty when suggestion cache is disabled or index cannot be read
+func (s *Service) suggestionCacheKey(ctx context.Context, branch string) string {
+	if s.settings == nil || !s.settings.SuggestionCache {
+		return ""
+	}
+	indexHash, err := s.gitOps.GetIndexHash()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to hash staged changes", "error", err)
+		return ""
+	}
+	options, err := json.Marshal([]any{
+		indexHash, branch,
+		s.settings.Providers, s.settings.Models, s.settings.CustomPrompt,
+		s.settings.First, s.settings.MultiLine, s.settings.RecentCommits,
+	})
+	if err != nil {
+		return ""
+	}
+	return hashDiff(string(options))
+}
+
+// cachedSuggestions returns suggestions of the latest run with unchanged staged content and options,
+// they are restored without asking providers again
+func (s *Service) cachedSuggestions(ctx context.Context, branch string) map[string]string {
+	if s.history == nil {
+		return nil
+	}
+	key := s.suggestionCacheKey(ctx, branch)
+	if key == "" {
+		return nil
+	}
+	entries, err := s.history.List()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to read suggestion history", "error", err)
+		return nil
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].CacheKey == key && len(entries[i].Suggestions) > 0 {
+			return entries[i].Suggestions
+		}
+	}
+	return nil
+}
+
+// History returns suggestions generated in current repository, newest first
+func (s *Service) History(_ context.Context) ([]HistoryEntry, error) {
+	if s.history == nil {
+		return nil, fmt.Errorf("history is disabled")
+	}
+	entries, err := s.history.List()
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// ClearHistory removes suggestions generated in current repository
+func (s *Service) ClearHistory(_ context.Context) error {
+	if s.history == nil {
+		return fmt.Errorf("history is disabled")
+	}
+	return s.history.Clear()
+}
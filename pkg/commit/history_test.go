@@ -0,0 +1,116 @@
+package commit
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/commit/pkg/commit/mocks"
+)
+
+func TestHistoryStore(t *testing.T) {
+	store := newHistoryStore(t.TempDir(), "/repo")
+	store.limit = 2
+
+	entries, err := store.List()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("List() of missing history = %v, %v, want empty", entries, err)
+	}
+
+	for _, branch := range []string{"first", "second", "third"} {
+		entry := HistoryEntry{Branch: branch, Suggestions: map[string]string{"claude": branch}}
+		if err := store.Add(entry); err != nil {
+			t.Fatalf("Add() unexpected error = %v", err)
+		}
+	}
+
+	entries, err = store.List()
+	if err != nil {
+		t.Fatalf("List() unexpected error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Branch != "second" || entries[1].Branch != "third" {
+		t.Errorf("List() = %v, want second and third entries", entries)
+	}
+
+	if err := store.Clear(); err != nil {
+		t.Fatalf("Clear() unexpected error = %v", err)
+	}
+	if entries, _ := store.List(); len(entries) != 0 {
+		t.Errorf("List() after Clear() = %v, want empty", entries)
+	}
+}
+
+func TestService_previousSuggestions(t *testing.T) {
+	dir := t.TempDir()
+	service := &Service{
+		logger:  slog.New(slog.DiscardHandler),
+		history: newHistoryStore(dir, "/repo"),
+	}
+	ctx := context.Background()
+
+	service.recordHistory(ctx, "main", "diff a", map[string]string{"claude": "feat: old", "openai": "feat: other"})
+	service.recordHistory(ctx, "main", "diff b", map[string]string{"claude": "fix: unrelated"})
+	service.recordHistory(ctx, "main", "diff a", map[string]string{"claude": "feat: newer"})
+
+	previous := service.previousSuggestions(ctx, "diff a")
+	if len(previous) != 2 || previous["claude"] != "feat: newer" || previous["openai"] != "feat: other" {
+		t.Errorf("previousSuggestions() = %v, want newest suggestion of every provider for the diff", previous)
+	}
+
+	if previous := service.previousSuggestions(ctx, "diff c"); len(previous) != 0 {
+		t.Errorf("previousSuggestions() of unknown diff = %v, want empty", previous)
+	}
+
+	// other repositories do not share history
+	other := &Service{logger: service.logger, history: newHistoryStore(dir, "/other")}
+	if previous := other.previousSuggestions(ctx, "diff a"); len(previous) != 0 {
+		t.Errorf("previousSuggestions() of other repository = %v, want empty", previous)
+	}
+}
+
+func TestService_cachedSuggestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	indexHash := "index-a"
+	git := mocks.NewMockgitOperationsAccessor(ctrl)
+	git.EXPECT().GetIndexHash().DoAndReturn(func() (string, error) { return indexHash, nil }).AnyTimes()
+
+	settings := &Settings{SuggestionCache: true, Providers: []string{"claude"}}
+	service := &Service{
+		logger:   slog.New(slog.DiscardHandler),
+		settings: settings,
+		gitOps:   git,
+		history:  newHistoryStore(t.TempDir(), "/repo"),
+	}
+	ctx := context.Background()
+
+	service.recordHistory(ctx, "main", "diff a", map[string]string{"claude": "feat: old"})
+	service.recordHistory(ctx, "main", "diff a", map[string]string{"claude": "feat: newer"})
+
+	if cached := service.cachedSuggestions(ctx, "main"); cached["claude"] != "feat: newer" {
+		t.Errorf("cachedSuggestions() = %v, want latest suggestions of unchanged index", cached)
+	}
+	if cached := service.cachedSuggestions(ctx, "feature"); len(cached) != 0 {
+		t.Errorf("cachedSuggestions() of other branch = %v, want empty", cached)
+	}
+
+	settings.MultiLine = true
+	if cached := service.cachedSuggestions(ctx, "main"); len(cached) != 0 {
+		t.Errorf("cachedSuggestions() with changed options = %v, want empty", cached)
+	}
+	settings.MultiLine = false
+
+	indexHash = "index-b"
+	if cached := service.cachedSuggestions(ctx, "main"); len(cached) != 0 {
+		t.Errorf("cachedSuggestions() of changed index = %v, want empty", cached)
+	}
+
+	indexHash = "index-a"
+	settings.SuggestionCache = false
+	if cached := service.cachedSuggestions(ctx, "main"); len(cached) != 0 {
+		t.Errorf("cachedSuggestions() with disabled cache = %v, want empty", cached)
+	}
+}
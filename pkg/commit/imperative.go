@@ -0,0 +1,36 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	_ "embed"
+)
+
+//go:embed prompt-imperative.md
+var imperativePrompt string
+
+// rewriteImperative asks providers to put subject description into imperative mood,
+// used by normalizer module as a check of its heuristic result
+func (s *Service) rewriteImperative(ctx context.Context, description string) (string, error) {
+	s.logger.DebugContext(ctx, "Requesting imperative mood check...", "subject", description)
+
+	responses, err := s.aiService.Ask(
+		ctx, s.settings.Providers,
+		strings.ReplaceAll(imperativePrompt, "{subject}", description),
+		true,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	response := strings.TrimSpace(s.getRandomMessage(responses))
+	if response == "" {
+		return "", fmt.Errorf("no imperative mood check received from providers")
+	}
+
+	// providers tend to quote single line answers
+	subject, _, _ := strings.Cut(response, "\n")
+	return strings.Trim(strings.TrimSpace(subject), "\"'`"), nil
+}
@@ -0,0 +1,39 @@
+package commit
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/commit/pkg/commit/mocks"
+)
+
+func TestService_rewriteImperative(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ai := mocks.NewMockaiServiceAccessor(ctrl)
+	ai.EXPECT().
+		Ask(gomock.Any(), []string{"claude"}, gomock.Any(), true).
+		DoAndReturn(func(_ context.Context, _ []string, prompt string, _ bool) (map[string]string, error) {
+			if !strings.Contains(prompt, "Added retries") {
+				t.Errorf("prompt does not contain subject: %q", prompt)
+			}
+			return map[string]string{"claude": "\"Add retries\"\nExplanation that should be dropped"}, nil
+		})
+
+	service := &Service{
+		logger:    slog.New(slog.DiscardHandler),
+		settings:  &Settings{Providers: []string{"claude"}},
+		aiService: ai,
+	}
+
+	got, err := service.rewriteImperative(context.Background(), "Added retries")
+	if err != nil {
+		t.Fatalf("rewriteImperative() error = %v", err)
+	}
+	if got != "Add retries" {
+		t.Errorf("rewriteImperative() = %q, want %q", got, "Add retries")
+	}
+}
@@ -0,0 +1,109 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	_ "embed"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+//go:embed prompt-chunk.md
+var chunkPrompt string
+
+// chunkSummaryConcurrency limits requests summarizing chunks in flight
+const chunkSummaryConcurrency = 4
+
+// reduceDiff replaces diff which was truncated with summaries of chunks of the full diff
+func (s *Service) reduceDiff(ctx context.Context, diff string) (string, error) {
+	full, err := s.gitOps.GetStagedDiff(math.MaxInt, s.settings.NewFileHeadLines)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get full staged diff", "error", err)
+		return "", classify(ErrGit, fmt.Errorf("failed to get diff: %w", err))
+	}
+
+	chunks := gitops.SplitDiff(full, s.settings.MaxDiffSizeBytes)
+
+	s.logger.InfoContext(ctx, "Diff exceeds size limit, summarizing its chunks",
+		"size", len(full), "chunks", len(chunks))
+
+	summaries, err := s.summarizeChunks(ctx, chunks)
+	if err != nil {
+		return "", classify(ErrProvider, fmt.Errorf("failed to summarize diff chunks: %w", err))
+	}
+
+	return formatChunkSummaries(chunks, summaries), nil
+}
+
+// summarizeChunks asks providers for summary of every chunk, a few chunks at a time
+func (s *Service) summarizeChunks(ctx context.Context, chunks []string) ([]string, error) {
+	summarizer := s.summarizer
+	if summarizer == nil {
+		summarizer = s.aiService
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		firstErr  error
+		summaries = make([]string, len(chunks))
+		slots     = make(chan struct{}, chunkSummaryConcurrency)
+	)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			prompt := strings.ReplaceAll(chunkPrompt, "{part}", strconv.Itoa(i+1))
+			prompt = strings.ReplaceAll(prompt, "{parts}", strconv.Itoa(len(chunks)))
+			prompt = strings.ReplaceAll(prompt, "{diff}", chunk)
+
+			responses, err := summarizer.Ask(ctx, s.settings.Providers, prompt, true)
+			summary := strings.TrimSpace(s.getRandomMessage(responses))
+			if err == nil && summary == "" {
+				err = fmt.Errorf("no summary received for chunk %d", i+1)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			summaries[i] = summary
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return summaries, nil
+}
+
+// formatChunkSummaries renders summaries in place of diff in prompts, with files of every chunk
+func formatChunkSummaries(chunks, summaries []string) string {
+	var b strings.Builder
+	b.WriteString("(diff is too large to include, summaries of its parts follow)\n")
+	for i, summary := range summaries {
+		b.WriteString(fmt.Sprintf("\n### Part %d of %d", i+1, len(summaries)))
+		if files := gitops.DiffFiles(chunks[i]); len(files) > 0 {
+			b.WriteString(" (" + strings.Join(files, ", ") + ")")
+		}
+		b.WriteString("\n\n" + summary + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package commit
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/commit/pkg/commit/mocks"
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+func TestService_reduceDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fileA := "diff --git a.go a.go\n@@ -1 +1 @@\n-a\n+b\n"
+	fileB := "diff --git b.go b.go\n@@ -1 +1 @@\n-c\n+d\n"
+
+	git := mocks.NewMockgitOperationsAccessor(ctrl)
+	git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return(fileA+fileB, nil)
+
+	summarizer := mocks.NewMockaiServiceAccessor(ctrl)
+	summarizer.EXPECT().Ask(gomock.Any(), gomock.Any(), gomock.Any(), true).
+		DoAndReturn(func(_ context.Context, _ []string, prompt string, _ bool) (map[string]string, error) {
+			if strings.Contains(prompt, "a.go") {
+				return map[string]string{"openai": "- changed a"}, nil
+			}
+			return map[string]string{"openai": "- changed b"}, nil
+		}).Times(2)
+
+	svc := &Service{
+		logger:     slog.New(slog.DiscardHandler),
+		settings:   &Settings{MaxDiffSizeBytes: len(fileA) + 1},
+		gitOps:     git,
+		summarizer: summarizer,
+	}
+
+	got, err := svc.reduceDiff(context.Background(), fileA+gitops.DiffTruncatedMarker)
+	if err != nil {
+		t.Fatalf("reduceDiff() error = %v", err)
+	}
+	for _, want := range []string{"Part 1 of 2 (a.go)", "- changed a", "Part 2 of 2 (b.go)", "- changed b"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("reduceDiff() = %q, want it to contain %q", got, want)
+		}
+	}
+}
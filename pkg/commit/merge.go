@@ -0,0 +1,38 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	_ "embed"
+)
+
+//go:embed prompt-merge.md
+var mergePrompt string
+
+// mergeMessages asks providers to combine suggestions selected in interactive mode into one message
+func (s *Service) mergeMessages(ctx context.Context, messages []string) (string, error) {
+	if len(messages) < 2 {
+		return "", fmt.Errorf("at least two messages are required to merge")
+	}
+
+	s.logger.DebugContext(ctx, "Requesting merge of suggestions...", "count", len(messages))
+
+	candidates := make([]string, 0, len(messages))
+	for i, message := range messages {
+		candidates = append(candidates, fmt.Sprintf("## Candidate %d\n\n%s", i+1, strings.TrimSpace(message)))
+	}
+	prompt := strings.ReplaceAll(mergePrompt, "{messages}", strings.Join(candidates, "\n\n"))
+
+	responses, err := s.aiService.Ask(ctx, s.settings.Providers, prompt, true)
+	if err != nil {
+		return "", err
+	}
+
+	response := strings.TrimSpace(s.getRandomMessage(responses))
+	if response == "" {
+		return "", fmt.Errorf("no merged message received from providers")
+	}
+	return response, nil
+}
@@ -0,0 +1,46 @@
+package commit
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/commit/pkg/commit/mocks"
+)
+
+func TestService_mergeMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ai := mocks.NewMockaiServiceAccessor(ctrl)
+	ai.EXPECT().
+		Ask(gomock.Any(), []string{"claude"}, gomock.Any(), true).
+		DoAndReturn(func(_ context.Context, _ []string, prompt string, _ bool) (map[string]string, error) {
+			if !strings.Contains(prompt, "## Candidate 1\n\nfeat: add login") ||
+				!strings.Contains(prompt, "## Candidate 2\n\nfeat(auth): support login\n\nAdds form.") {
+				t.Errorf("prompt does not contain numbered candidates: %q", prompt)
+			}
+			return map[string]string{"claude": "feat(auth): add login\n\nAdds form."}, nil
+		})
+
+	service := &Service{
+		logger:    slog.New(slog.DiscardHandler),
+		settings:  &Settings{Providers: []string{"claude"}},
+		aiService: ai,
+	}
+
+	got, err := service.mergeMessages(
+		context.Background(), []string{"feat: add login", "feat(auth): support login\n\nAdds form.\n"},
+	)
+	if err != nil {
+		t.Fatalf("mergeMessages() error = %v", err)
+	}
+	if got != "feat(auth): add login\n\nAdds form." {
+		t.Errorf("mergeMessages() = %q, want merged message", got)
+	}
+
+	if _, err := service.mergeMessages(context.Background(), []string{"feat: add login"}); err == nil {
+		t.Errorf("mergeMessages() of single message should fail")
+	}
+}
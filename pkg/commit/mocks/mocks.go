@@ -14,6 +14,8 @@ import (
 	reflect "reflect"
 	time "time"
 
+	modules "github.com/hasansino/commit/pkg/commit/modules"
+	ui "github.com/hasansino/commit/pkg/commit/ui"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -70,6 +72,20 @@ func (mr *MockproviderAccessorMockRecorder) IsAvailable() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAvailable", reflect.TypeOf((*MockproviderAccessor)(nil).IsAvailable))
 }
 
+// Model mocks base method.
+func (m *MockproviderAccessor) Model() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Model")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Model indicates an expected call of Model.
+func (mr *MockproviderAccessorMockRecorder) Model() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Model", reflect.TypeOf((*MockproviderAccessor)(nil).Model))
+}
+
 // Name mocks base method.
 func (m *MockproviderAccessor) Name() string {
 	m.ctrl.T.Helper()
@@ -96,6 +112,21 @@ func (mr *MockproviderAccessorMockRecorder) SetTimeout(timeout any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeout", reflect.TypeOf((*MockproviderAccessor)(nil).SetTimeout), timeout)
 }
 
+// Usage mocks base method.
+func (m *MockproviderAccessor) Usage() (int64, int64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockproviderAccessorMockRecorder) Usage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockproviderAccessor)(nil).Usage))
+}
+
 // MockmoduleAccessor is a mock of moduleAccessor interface.
 type MockmoduleAccessor struct {
 	ctrl     *gomock.Controller
@@ -151,9 +182,9 @@ func (mr *MockmoduleAccessorMockRecorder) TransformCommitMessage(ctx, branch, me
 }
 
 // TransformPrompt mocks base method.
-func (m *MockmoduleAccessor) TransformPrompt(ctx context.Context, prompt string) (string, bool, error) {
+func (m *MockmoduleAccessor) TransformPrompt(ctx context.Context, branch, prompt string) (string, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TransformPrompt", ctx, prompt)
+	ret := m.ctrl.Call(m, "TransformPrompt", ctx, branch, prompt)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -161,9 +192,177 @@ func (m *MockmoduleAccessor) TransformPrompt(ctx context.Context, prompt string)
 }
 
 // TransformPrompt indicates an expected call of TransformPrompt.
-func (mr *MockmoduleAccessorMockRecorder) TransformPrompt(ctx, prompt any) *gomock.Call {
+func (mr *MockmoduleAccessorMockRecorder) TransformPrompt(ctx, branch, prompt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformPrompt", reflect.TypeOf((*MockmoduleAccessor)(nil).TransformPrompt), ctx, prompt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformPrompt", reflect.TypeOf((*MockmoduleAccessor)(nil).TransformPrompt), ctx, branch, prompt)
+}
+
+// MockpromptContributorAccessor is a mock of promptContributorAccessor interface.
+type MockpromptContributorAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockpromptContributorAccessorMockRecorder
+	isgomock struct{}
+}
+
+// MockpromptContributorAccessorMockRecorder is the mock recorder for MockpromptContributorAccessor.
+type MockpromptContributorAccessorMockRecorder struct {
+	mock *MockpromptContributorAccessor
+}
+
+// NewMockpromptContributorAccessor creates a new mock instance.
+func NewMockpromptContributorAccessor(ctrl *gomock.Controller) *MockpromptContributorAccessor {
+	mock := &MockpromptContributorAccessor{ctrl: ctrl}
+	mock.recorder = &MockpromptContributorAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpromptContributorAccessor) EXPECT() *MockpromptContributorAccessorMockRecorder {
+	return m.recorder
+}
+
+// PromptContext mocks base method.
+func (m *MockpromptContributorAccessor) PromptContext(ctx context.Context, branch string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromptContext", ctx, branch)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PromptContext indicates an expected call of PromptContext.
+func (mr *MockpromptContributorAccessorMockRecorder) PromptContext(ctx, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromptContext", reflect.TypeOf((*MockpromptContributorAccessor)(nil).PromptContext), ctx, branch)
+}
+
+// MocktaskValidatorAccessor is a mock of taskValidatorAccessor interface.
+type MocktaskValidatorAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktaskValidatorAccessorMockRecorder
+	isgomock struct{}
+}
+
+// MocktaskValidatorAccessorMockRecorder is the mock recorder for MocktaskValidatorAccessor.
+type MocktaskValidatorAccessorMockRecorder struct {
+	mock *MocktaskValidatorAccessor
+}
+
+// NewMocktaskValidatorAccessor creates a new mock instance.
+func NewMocktaskValidatorAccessor(ctrl *gomock.Controller) *MocktaskValidatorAccessor {
+	mock := &MocktaskValidatorAccessor{ctrl: ctrl}
+	mock.recorder = &MocktaskValidatorAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktaskValidatorAccessor) EXPECT() *MocktaskValidatorAccessorMockRecorder {
+	return m.recorder
+}
+
+// ValidateTask mocks base method.
+func (m *MocktaskValidatorAccessor) ValidateTask(ctx context.Context, branch string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateTask", ctx, branch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateTask indicates an expected call of ValidateTask.
+func (mr *MocktaskValidatorAccessorMockRecorder) ValidateTask(ctx, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateTask", reflect.TypeOf((*MocktaskValidatorAccessor)(nil).ValidateTask), ctx, branch)
+}
+
+// MockcommitNotifierAccessor is a mock of commitNotifierAccessor interface.
+type MockcommitNotifierAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockcommitNotifierAccessorMockRecorder
+	isgomock struct{}
+}
+
+// MockcommitNotifierAccessorMockRecorder is the mock recorder for MockcommitNotifierAccessor.
+type MockcommitNotifierAccessorMockRecorder struct {
+	mock *MockcommitNotifierAccessor
+}
+
+// NewMockcommitNotifierAccessor creates a new mock instance.
+func NewMockcommitNotifierAccessor(ctrl *gomock.Controller) *MockcommitNotifierAccessor {
+	mock := &MockcommitNotifierAccessor{ctrl: ctrl}
+	mock.recorder = &MockcommitNotifierAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcommitNotifierAccessor) EXPECT() *MockcommitNotifierAccessorMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockcommitNotifierAccessor) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockcommitNotifierAccessorMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockcommitNotifierAccessor)(nil).Name))
+}
+
+// NotifyCommit mocks base method.
+func (m *MockcommitNotifierAccessor) NotifyCommit(ctx context.Context, event modules.CommitEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyCommit", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyCommit indicates an expected call of NotifyCommit.
+func (mr *MockcommitNotifierAccessorMockRecorder) NotifyCommit(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyCommit", reflect.TypeOf((*MockcommitNotifierAccessor)(nil).NotifyCommit), ctx, event)
+}
+
+// MocksuggestionsNotifierAccessor is a mock of suggestionsNotifierAccessor interface.
+type MocksuggestionsNotifierAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MocksuggestionsNotifierAccessorMockRecorder
+	isgomock struct{}
+}
+
+// MocksuggestionsNotifierAccessorMockRecorder is the mock recorder for MocksuggestionsNotifierAccessor.
+type MocksuggestionsNotifierAccessorMockRecorder struct {
+	mock *MocksuggestionsNotifierAccessor
+}
+
+// NewMocksuggestionsNotifierAccessor creates a new mock instance.
+func NewMocksuggestionsNotifierAccessor(ctrl *gomock.Controller) *MocksuggestionsNotifierAccessor {
+	mock := &MocksuggestionsNotifierAccessor{ctrl: ctrl}
+	mock.recorder = &MocksuggestionsNotifierAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksuggestionsNotifierAccessor) EXPECT() *MocksuggestionsNotifierAccessorMockRecorder {
+	return m.recorder
+}
+
+// NotifySuggestions mocks base method.
+func (m *MocksuggestionsNotifierAccessor) NotifySuggestions(ctx context.Context, event modules.SuggestionsEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifySuggestions", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifySuggestions indicates an expected call of NotifySuggestions.
+func (mr *MocksuggestionsNotifierAccessorMockRecorder) NotifySuggestions(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifySuggestions", reflect.TypeOf((*MocksuggestionsNotifierAccessor)(nil).NotifySuggestions), ctx, event)
 }
 
 // MockgitOperationsAccessor is a mock of gitOperationsAccessor interface.
@@ -190,18 +389,32 @@ func (m *MockgitOperationsAccessor) EXPECT() *MockgitOperationsAccessorMockRecor
 	return m.recorder
 }
 
+// AddNote mocks base method.
+func (m *MockgitOperationsAccessor) AddNote(notesRef, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNote", notesRef, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNote indicates an expected call of AddNote.
+func (mr *MockgitOperationsAccessorMockRecorder) AddNote(notesRef, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNote", reflect.TypeOf((*MockgitOperationsAccessor)(nil).AddNote), notesRef, message)
+}
+
 // CreateCommit mocks base method.
-func (m *MockgitOperationsAccessor) CreateCommit(message string) error {
+func (m *MockgitOperationsAccessor) CreateCommit(message string, noVerify, allowEmpty bool, author, date string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCommit", message)
+	ret := m.ctrl.Call(m, "CreateCommit", message, noVerify, allowEmpty, author, date)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateCommit indicates an expected call of CreateCommit.
-func (mr *MockgitOperationsAccessorMockRecorder) CreateCommit(message any) *gomock.Call {
+func (mr *MockgitOperationsAccessorMockRecorder) CreateCommit(message, noVerify, allowEmpty, author, date any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommit", reflect.TypeOf((*MockgitOperationsAccessor)(nil).CreateCommit), message)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommit", reflect.TypeOf((*MockgitOperationsAccessor)(nil).CreateCommit), message, noVerify, allowEmpty, author, date)
 }
 
 // CreateTag mocks base method.
@@ -218,6 +431,126 @@ func (mr *MockgitOperationsAccessorMockRecorder) CreateTag(tag, message any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockgitOperationsAccessor)(nil).CreateTag), tag, message)
 }
 
+// Deepen mocks base method.
+func (m *MockgitOperationsAccessor) Deepen(remote string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deepen", remote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deepen indicates an expected call of Deepen.
+func (mr *MockgitOperationsAccessorMockRecorder) Deepen(remote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deepen", reflect.TypeOf((*MockgitOperationsAccessor)(nil).Deepen), remote)
+}
+
+// DeleteTag mocks base method.
+func (m *MockgitOperationsAccessor) DeleteTag(tag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockgitOperationsAccessorMockRecorder) DeleteTag(tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockgitOperationsAccessor)(nil).DeleteTag), tag)
+}
+
+// GetBranchDiff mocks base method.
+func (m *MockgitOperationsAccessor) GetBranchDiff(base, remote string, maxSizeBytes int) (string, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchDiff", base, remote, maxSizeBytes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBranchDiff indicates an expected call of GetBranchDiff.
+func (mr *MockgitOperationsAccessorMockRecorder) GetBranchDiff(base, remote, maxSizeBytes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchDiff", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetBranchDiff), base, remote, maxSizeBytes)
+}
+
+// GetCommitDiff mocks base method.
+func (m *MockgitOperationsAccessor) GetCommitDiff(sha string, maxSizeBytes int) (string, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitDiff", sha, maxSizeBytes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCommitDiff indicates an expected call of GetCommitDiff.
+func (mr *MockgitOperationsAccessorMockRecorder) GetCommitDiff(sha, maxSizeBytes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitDiff", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetCommitDiff), sha, maxSizeBytes)
+}
+
+// GetCommitMessage mocks base method.
+func (m *MockgitOperationsAccessor) GetCommitMessage(sha string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitMessage", sha)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitMessage indicates an expected call of GetCommitMessage.
+func (mr *MockgitOperationsAccessorMockRecorder) GetCommitMessage(sha any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitMessage", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetCommitMessage), sha)
+}
+
+// GetCommitTemplate mocks base method.
+func (m *MockgitOperationsAccessor) GetCommitTemplate() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitTemplate")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitTemplate indicates an expected call of GetCommitTemplate.
+func (mr *MockgitOperationsAccessorMockRecorder) GetCommitTemplate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitTemplate", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetCommitTemplate))
+}
+
+// GetCommitsSince mocks base method.
+func (m *MockgitOperationsAccessor) GetCommitsSince(tag string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitsSince", tag)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitsSince indicates an expected call of GetCommitsSince.
+func (mr *MockgitOperationsAccessorMockRecorder) GetCommitsSince(tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitsSince", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetCommitsSince), tag)
+}
+
+// GetConflictMarkers mocks base method.
+func (m *MockgitOperationsAccessor) GetConflictMarkers() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConflictMarkers")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConflictMarkers indicates an expected call of GetConflictMarkers.
+func (mr *MockgitOperationsAccessorMockRecorder) GetConflictMarkers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConflictMarkers", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetConflictMarkers))
+}
+
 // GetConflictedFiles mocks base method.
 func (m *MockgitOperationsAccessor) GetConflictedFiles() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -248,19 +581,138 @@ func (mr *MockgitOperationsAccessorMockRecorder) GetCurrentBranch() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentBranch", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetCurrentBranch))
 }
 
+// GetDefaultBranch mocks base method.
+func (m *MockgitOperationsAccessor) GetDefaultBranch(remote string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultBranch", remote)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDefaultBranch indicates an expected call of GetDefaultBranch.
+func (mr *MockgitOperationsAccessorMockRecorder) GetDefaultBranch(remote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultBranch", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetDefaultBranch), remote)
+}
+
+// GetGitDir mocks base method.
+func (m *MockgitOperationsAccessor) GetGitDir() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitDir")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGitDir indicates an expected call of GetGitDir.
+func (mr *MockgitOperationsAccessorMockRecorder) GetGitDir() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitDir", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetGitDir))
+}
+
+// GetIndexHash mocks base method.
+func (m *MockgitOperationsAccessor) GetIndexHash() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexHash")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndexHash indicates an expected call of GetIndexHash.
+func (mr *MockgitOperationsAccessorMockRecorder) GetIndexHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexHash", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetIndexHash))
+}
+
+// GetLargeBinaries mocks base method.
+func (m *MockgitOperationsAccessor) GetLargeBinaries(thresholdBytes int64) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLargeBinaries", thresholdBytes)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLargeBinaries indicates an expected call of GetLargeBinaries.
+func (mr *MockgitOperationsAccessorMockRecorder) GetLargeBinaries(thresholdBytes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLargeBinaries", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetLargeBinaries), thresholdBytes)
+}
+
 // GetLatestTag mocks base method.
-func (m *MockgitOperationsAccessor) GetLatestTag() (string, error) {
+func (m *MockgitOperationsAccessor) GetLatestTag(prefix, pattern string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLatestTag")
+	ret := m.ctrl.Call(m, "GetLatestTag", prefix, pattern)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLatestTag indicates an expected call of GetLatestTag.
-func (mr *MockgitOperationsAccessorMockRecorder) GetLatestTag() *gomock.Call {
+func (mr *MockgitOperationsAccessorMockRecorder) GetLatestTag(prefix, pattern any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestTag", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetLatestTag))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestTag", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetLatestTag), prefix, pattern)
+}
+
+// GetNote mocks base method.
+func (m *MockgitOperationsAccessor) GetNote(notesRef, sha string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNote", notesRef, sha)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetNote indicates an expected call of GetNote.
+func (mr *MockgitOperationsAccessorMockRecorder) GetNote(notesRef, sha any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNote", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetNote), notesRef, sha)
+}
+
+// GetRecentCommits mocks base method.
+func (m *MockgitOperationsAccessor) GetRecentCommits(n int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentCommits", n)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentCommits indicates an expected call of GetRecentCommits.
+func (mr *MockgitOperationsAccessorMockRecorder) GetRecentCommits(n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentCommits", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetRecentCommits), n)
+}
+
+// GetRemoteDivergence mocks base method.
+func (m *MockgitOperationsAccessor) GetRemoteDivergence(remote string) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemoteDivergence", remote)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRemoteDivergence indicates an expected call of GetRemoteDivergence.
+func (mr *MockgitOperationsAccessorMockRecorder) GetRemoteDivergence(remote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteDivergence", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetRemoteDivergence), remote)
+}
+
+// GetRemoteURL mocks base method.
+func (m *MockgitOperationsAccessor) GetRemoteURL(remoteName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemoteURL", remoteName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemoteURL indicates an expected call of GetRemoteURL.
+func (mr *MockgitOperationsAccessorMockRecorder) GetRemoteURL(remoteName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteURL", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetRemoteURL), remoteName)
 }
 
 // GetRepoState mocks base method.
@@ -279,18 +731,48 @@ func (mr *MockgitOperationsAccessorMockRecorder) GetRepoState() *gomock.Call {
 }
 
 // GetStagedDiff mocks base method.
-func (m *MockgitOperationsAccessor) GetStagedDiff(maxSizeBytes int) (string, error) {
+func (m *MockgitOperationsAccessor) GetStagedDiff(maxSizeBytes, newFileHeadLines int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStagedDiff", maxSizeBytes)
+	ret := m.ctrl.Call(m, "GetStagedDiff", maxSizeBytes, newFileHeadLines)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStagedDiff indicates an expected call of GetStagedDiff.
-func (mr *MockgitOperationsAccessorMockRecorder) GetStagedDiff(maxSizeBytes any) *gomock.Call {
+func (mr *MockgitOperationsAccessorMockRecorder) GetStagedDiff(maxSizeBytes, newFileHeadLines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStagedDiff", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetStagedDiff), maxSizeBytes, newFileHeadLines)
+}
+
+// GetStagedFiles mocks base method.
+func (m *MockgitOperationsAccessor) GetStagedFiles() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStagedFiles")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStagedFiles indicates an expected call of GetStagedFiles.
+func (mr *MockgitOperationsAccessorMockRecorder) GetStagedFiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStagedFiles", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetStagedFiles))
+}
+
+// GetSubmoduleSummary mocks base method.
+func (m *MockgitOperationsAccessor) GetSubmoduleSummary(includeLog bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmoduleSummary", includeLog)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmoduleSummary indicates an expected call of GetSubmoduleSummary.
+func (mr *MockgitOperationsAccessorMockRecorder) GetSubmoduleSummary(includeLog any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStagedDiff", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetStagedDiff), maxSizeBytes)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmoduleSummary", reflect.TypeOf((*MockgitOperationsAccessor)(nil).GetSubmoduleSummary), includeLog)
 }
 
 // HasConflicts mocks base method.
@@ -309,19 +791,63 @@ func (mr *MockgitOperationsAccessorMockRecorder) HasConflicts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasConflicts", reflect.TypeOf((*MockgitOperationsAccessor)(nil).HasConflicts))
 }
 
+// HasRemoteBranch mocks base method.
+func (m *MockgitOperationsAccessor) HasRemoteBranch(remote, branch string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRemoteBranch", remote, branch)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasRemoteBranch indicates an expected call of HasRemoteBranch.
+func (mr *MockgitOperationsAccessorMockRecorder) HasRemoteBranch(remote, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRemoteBranch", reflect.TypeOf((*MockgitOperationsAccessor)(nil).HasRemoteBranch), remote, branch)
+}
+
 // IncrementVersion mocks base method.
-func (m *MockgitOperationsAccessor) IncrementVersion(currentTag, incrementType string) (string, error) {
+func (m *MockgitOperationsAccessor) IncrementVersion(currentTag, incrementType, prefix string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IncrementVersion", currentTag, incrementType)
+	ret := m.ctrl.Call(m, "IncrementVersion", currentTag, incrementType, prefix)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IncrementVersion indicates an expected call of IncrementVersion.
-func (mr *MockgitOperationsAccessorMockRecorder) IncrementVersion(currentTag, incrementType any) *gomock.Call {
+func (mr *MockgitOperationsAccessorMockRecorder) IncrementVersion(currentTag, incrementType, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementVersion", reflect.TypeOf((*MockgitOperationsAccessor)(nil).IncrementVersion), currentTag, incrementType, prefix)
+}
+
+// InstallHook mocks base method.
+func (m *MockgitOperationsAccessor) InstallHook(name, script string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallHook", name, script)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstallHook indicates an expected call of InstallHook.
+func (mr *MockgitOperationsAccessorMockRecorder) InstallHook(name, script any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallHook", reflect.TypeOf((*MockgitOperationsAccessor)(nil).InstallHook), name, script)
+}
+
+// IsCommitPushed mocks base method.
+func (m *MockgitOperationsAccessor) IsCommitPushed(sha string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCommitPushed", sha)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCommitPushed indicates an expected call of IsCommitPushed.
+func (mr *MockgitOperationsAccessorMockRecorder) IsCommitPushed(sha any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementVersion", reflect.TypeOf((*MockgitOperationsAccessor)(nil).IncrementVersion), currentTag, incrementType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCommitPushed", reflect.TypeOf((*MockgitOperationsAccessor)(nil).IsCommitPushed), sha)
 }
 
 // IsGitRepository mocks base method.
@@ -338,48 +864,192 @@ func (mr *MockgitOperationsAccessorMockRecorder) IsGitRepository() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsGitRepository", reflect.TypeOf((*MockgitOperationsAccessor)(nil).IsGitRepository))
 }
 
+// IsShallowRepository mocks base method.
+func (m *MockgitOperationsAccessor) IsShallowRepository() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsShallowRepository")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsShallowRepository indicates an expected call of IsShallowRepository.
+func (mr *MockgitOperationsAccessorMockRecorder) IsShallowRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsShallowRepository", reflect.TypeOf((*MockgitOperationsAccessor)(nil).IsShallowRepository))
+}
+
+// PullRebase mocks base method.
+func (m *MockgitOperationsAccessor) PullRebase(remote string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullRebase", remote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PullRebase indicates an expected call of PullRebase.
+func (mr *MockgitOperationsAccessorMockRecorder) PullRebase(remote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullRebase", reflect.TypeOf((*MockgitOperationsAccessor)(nil).PullRebase), remote)
+}
+
 // Push mocks base method.
-func (m *MockgitOperationsAccessor) Push() (string, error) {
+func (m *MockgitOperationsAccessor) Push(remote string, forceWithLease, setUpstream bool) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Push")
+	ret := m.ctrl.Call(m, "Push", remote, forceWithLease, setUpstream)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Push indicates an expected call of Push.
-func (mr *MockgitOperationsAccessorMockRecorder) Push() *gomock.Call {
+func (mr *MockgitOperationsAccessorMockRecorder) Push(remote, forceWithLease, setUpstream any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockgitOperationsAccessor)(nil).Push), remote, forceWithLease, setUpstream)
+}
+
+// PushNotes mocks base method.
+func (m *MockgitOperationsAccessor) PushNotes(notesRef, remote string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushNotes", notesRef, remote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushNotes indicates an expected call of PushNotes.
+func (mr *MockgitOperationsAccessorMockRecorder) PushNotes(notesRef, remote any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockgitOperationsAccessor)(nil).Push))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushNotes", reflect.TypeOf((*MockgitOperationsAccessor)(nil).PushNotes), notesRef, remote)
 }
 
 // PushTag mocks base method.
-func (m *MockgitOperationsAccessor) PushTag(tag string) error {
+func (m *MockgitOperationsAccessor) PushTag(tag, remote string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PushTag", tag)
+	ret := m.ctrl.Call(m, "PushTag", tag, remote)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PushTag indicates an expected call of PushTag.
-func (mr *MockgitOperationsAccessorMockRecorder) PushTag(tag any) *gomock.Call {
+func (mr *MockgitOperationsAccessorMockRecorder) PushTag(tag, remote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushTag", reflect.TypeOf((*MockgitOperationsAccessor)(nil).PushTag), tag, remote)
+}
+
+// ResolveCommit mocks base method.
+func (m *MockgitOperationsAccessor) ResolveCommit(ref string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCommit", ref)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCommit indicates an expected call of ResolveCommit.
+func (mr *MockgitOperationsAccessorMockRecorder) ResolveCommit(ref any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushTag", reflect.TypeOf((*MockgitOperationsAccessor)(nil).PushTag), tag)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCommit", reflect.TypeOf((*MockgitOperationsAccessor)(nil).ResolveCommit), ref)
+}
+
+// ResolveRewordTarget mocks base method.
+func (m *MockgitOperationsAccessor) ResolveRewordTarget(ref string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRewordTarget", ref)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveRewordTarget indicates an expected call of ResolveRewordTarget.
+func (mr *MockgitOperationsAccessorMockRecorder) ResolveRewordTarget(ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRewordTarget", reflect.TypeOf((*MockgitOperationsAccessor)(nil).ResolveRewordTarget), ref)
+}
+
+// RestoreStash mocks base method.
+func (m *MockgitOperationsAccessor) RestoreStash() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreStash")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreStash indicates an expected call of RestoreStash.
+func (mr *MockgitOperationsAccessorMockRecorder) RestoreStash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreStash", reflect.TypeOf((*MockgitOperationsAccessor)(nil).RestoreStash))
+}
+
+// RewordCommit mocks base method.
+func (m *MockgitOperationsAccessor) RewordCommit(sha, message string, noVerify bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RewordCommit", sha, message, noVerify)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RewordCommit indicates an expected call of RewordCommit.
+func (mr *MockgitOperationsAccessorMockRecorder) RewordCommit(sha, message, noVerify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewordCommit", reflect.TypeOf((*MockgitOperationsAccessor)(nil).RewordCommit), sha, message, noVerify)
 }
 
 // StageFiles mocks base method.
-func (m *MockgitOperationsAccessor) StageFiles(excludePatterns, includePatterns []string, useGlobalGitignore bool) ([]string, error) {
+func (m *MockgitOperationsAccessor) StageFiles(excludePatterns, includePatterns []string, useGlobalGitignore bool, scopeDir string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StageFiles", excludePatterns, includePatterns, useGlobalGitignore)
+	ret := m.ctrl.Call(m, "StageFiles", excludePatterns, includePatterns, useGlobalGitignore, scopeDir)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // StageFiles indicates an expected call of StageFiles.
-func (mr *MockgitOperationsAccessorMockRecorder) StageFiles(excludePatterns, includePatterns, useGlobalGitignore any) *gomock.Call {
+func (mr *MockgitOperationsAccessorMockRecorder) StageFiles(excludePatterns, includePatterns, useGlobalGitignore, scopeDir any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StageFiles", reflect.TypeOf((*MockgitOperationsAccessor)(nil).StageFiles), excludePatterns, includePatterns, useGlobalGitignore, scopeDir)
+}
+
+// StagePaths mocks base method.
+func (m *MockgitOperationsAccessor) StagePaths(paths []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StagePaths", paths)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StagePaths indicates an expected call of StagePaths.
+func (mr *MockgitOperationsAccessorMockRecorder) StagePaths(paths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StagePaths", reflect.TypeOf((*MockgitOperationsAccessor)(nil).StagePaths), paths)
+}
+
+// StashUnstaged mocks base method.
+func (m *MockgitOperationsAccessor) StashUnstaged() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StashUnstaged")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StashUnstaged indicates an expected call of StashUnstaged.
+func (mr *MockgitOperationsAccessorMockRecorder) StashUnstaged() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StageFiles", reflect.TypeOf((*MockgitOperationsAccessor)(nil).StageFiles), excludePatterns, includePatterns, useGlobalGitignore)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StashUnstaged", reflect.TypeOf((*MockgitOperationsAccessor)(nil).StashUnstaged))
+}
+
+// UndoLastCommit mocks base method.
+func (m *MockgitOperationsAccessor) UndoLastCommit() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoLastCommit")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UndoLastCommit indicates an expected call of UndoLastCommit.
+func (mr *MockgitOperationsAccessorMockRecorder) UndoLastCommit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoLastCommit", reflect.TypeOf((*MockgitOperationsAccessor)(nil).UndoLastCommit))
 }
 
 // UnstageAll mocks base method.
@@ -420,19 +1090,103 @@ func (m *MockaiServiceAccessor) EXPECT() *MockaiServiceAccessorMockRecorder {
 	return m.recorder
 }
 
+// Ask mocks base method.
+func (m *MockaiServiceAccessor) Ask(ctx context.Context, providers []string, prompt string, first bool) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ask", ctx, providers, prompt, first)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ask indicates an expected call of Ask.
+func (mr *MockaiServiceAccessorMockRecorder) Ask(ctx, providers, prompt, first any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ask", reflect.TypeOf((*MockaiServiceAccessor)(nil).Ask), ctx, providers, prompt, first)
+}
+
+// BuildPrompt mocks base method.
+func (m *MockaiServiceAccessor) BuildPrompt(diff, branch string, files []string, customPrompt, commitTemplate string, recentCommits []string, multiLine bool) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildPrompt", diff, branch, files, customPrompt, commitTemplate, recentCommits, multiLine)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BuildPrompt indicates an expected call of BuildPrompt.
+func (mr *MockaiServiceAccessorMockRecorder) BuildPrompt(diff, branch, files, customPrompt, commitTemplate, recentCommits, multiLine any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildPrompt", reflect.TypeOf((*MockaiServiceAccessor)(nil).BuildPrompt), diff, branch, files, customPrompt, commitTemplate, recentCommits, multiLine)
+}
+
+// CancelProvider mocks base method.
+func (m *MockaiServiceAccessor) CancelProvider(provider string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CancelProvider", provider)
+}
+
+// CancelProvider indicates an expected call of CancelProvider.
+func (mr *MockaiServiceAccessorMockRecorder) CancelProvider(provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelProvider", reflect.TypeOf((*MockaiServiceAccessor)(nil).CancelProvider), provider)
+}
+
+// EstimateRequests mocks base method.
+func (m *MockaiServiceAccessor) EstimateRequests(providers []string, prompt string) []ui.CostEstimate {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateRequests", providers, prompt)
+	ret0, _ := ret[0].([]ui.CostEstimate)
+	return ret0
+}
+
+// EstimateRequests indicates an expected call of EstimateRequests.
+func (mr *MockaiServiceAccessorMockRecorder) EstimateRequests(providers, prompt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateRequests", reflect.TypeOf((*MockaiServiceAccessor)(nil).EstimateRequests), providers, prompt)
+}
+
 // GenerateCommitMessages mocks base method.
-func (m *MockaiServiceAccessor) GenerateCommitMessages(ctx context.Context, diff, branch string, files, providers []string, customPrompt string, first, multiLine bool) (map[string]string, error) {
+func (m *MockaiServiceAccessor) GenerateCommitMessages(ctx context.Context, diff, branch string, files, providers []string, customPrompt, commitTemplate string, recentCommits []string, first, multiLine bool, transformPrompt func(context.Context, string) string) (map[string]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateCommitMessages", ctx, diff, branch, files, providers, customPrompt, first, multiLine)
+	ret := m.ctrl.Call(m, "GenerateCommitMessages", ctx, diff, branch, files, providers, customPrompt, commitTemplate, recentCommits, first, multiLine, transformPrompt)
 	ret0, _ := ret[0].(map[string]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateCommitMessages indicates an expected call of GenerateCommitMessages.
-func (mr *MockaiServiceAccessorMockRecorder) GenerateCommitMessages(ctx, diff, branch, files, providers, customPrompt, first, multiLine any) *gomock.Call {
+func (mr *MockaiServiceAccessorMockRecorder) GenerateCommitMessages(ctx, diff, branch, files, providers, customPrompt, commitTemplate, recentCommits, first, multiLine, transformPrompt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCommitMessages", reflect.TypeOf((*MockaiServiceAccessor)(nil).GenerateCommitMessages), ctx, diff, branch, files, providers, customPrompt, commitTemplate, recentCommits, first, multiLine, transformPrompt)
+}
+
+// GenerationMetadata mocks base method.
+func (m *MockaiServiceAccessor) GenerationMetadata(provider string) map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerationMetadata", provider)
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GenerationMetadata indicates an expected call of GenerationMetadata.
+func (mr *MockaiServiceAccessorMockRecorder) GenerationMetadata(provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerationMetadata", reflect.TypeOf((*MockaiServiceAccessor)(nil).GenerationMetadata), provider)
+}
+
+// GenerationStats mocks base method.
+func (m *MockaiServiceAccessor) GenerationStats(provider string) (ui.ProviderStats, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerationStats", provider)
+	ret0, _ := ret[0].(ui.ProviderStats)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GenerationStats indicates an expected call of GenerationStats.
+func (mr *MockaiServiceAccessorMockRecorder) GenerationStats(provider any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCommitMessages", reflect.TypeOf((*MockaiServiceAccessor)(nil).GenerateCommitMessages), ctx, diff, branch, files, providers, customPrompt, first, multiLine)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerationStats", reflect.TypeOf((*MockaiServiceAccessor)(nil).GenerationStats), provider)
 }
 
 // NumProviders mocks base method.
@@ -448,3 +1202,29 @@ func (mr *MockaiServiceAccessorMockRecorder) NumProviders() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumProviders", reflect.TypeOf((*MockaiServiceAccessor)(nil).NumProviders))
 }
+
+// ProviderNames mocks base method.
+func (m *MockaiServiceAccessor) ProviderNames(requested []string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderNames", requested)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// ProviderNames indicates an expected call of ProviderNames.
+func (mr *MockaiServiceAccessorMockRecorder) ProviderNames(requested any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderNames", reflect.TypeOf((*MockaiServiceAccessor)(nil).ProviderNames), requested)
+}
+
+// SetProgress mocks base method.
+func (m *MockaiServiceAccessor) SetProgress(progress ui.ProgressFunc) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetProgress", progress)
+}
+
+// SetProgress indicates an expected call of SetProgress.
+func (mr *MockaiServiceAccessorMockRecorder) SetProgress(progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProgress", reflect.TypeOf((*MockaiServiceAccessor)(nil).SetProgress), progress)
+}
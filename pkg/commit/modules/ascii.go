@@ -0,0 +1,93 @@
+package modules
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const ASCIIModuleName = "ascii_only"
+
+// asciiReplacements transliterates characters which do not decompose into ASCII letter and marks
+var asciiReplacements = map[rune]string{
+	'‘': "'", '’': "'", '‚': "'", '′': "'",
+	'“': `"`, '”': `"`, '„': `"`, '″': `"`, '«': `"`, '»': `"`,
+	'–': "-", '—': "-", '‐': "-", '‑': "-", '−': "-",
+	'…': "...", '•': "*", '·': "*", '→': "->", '←': "<-", '⇒': "=>", '×': "x",
+	' ': " ", ' ': " ", ' ': " ",
+	'ß': "ss", 'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe", 'Ø': "O", 'ø': "o",
+	'Ł': "L", 'ł': "l", 'Đ': "D", 'đ': "d", 'Þ': "Th", 'þ': "th", 'ı': "i",
+}
+
+// doubleSpacePattern matches spaces left by removed characters inside lines
+var doubleSpacePattern = regexp.MustCompile(`([^ \n]) {2,}`)
+
+// ASCIIFilter makes commit messages ASCII-only for tooling which breaks on anything else:
+// accented letters and typographic punctuation are transliterated, emoji and the rest are removed
+type ASCIIFilter struct{}
+
+func NewASCIIFilter() *ASCIIFilter {
+	return &ASCIIFilter{}
+}
+
+func (a *ASCIIFilter) Name() string {
+	return ASCIIModuleName
+}
+
+func (a *ASCIIFilter) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (a *ASCIIFilter) TransformCommitMessage(_ context.Context, _, message string) (string, bool, error) {
+	ascii := ToASCII(message)
+	if ascii == message {
+		return message, false, nil
+	}
+	return ascii, true, nil
+}
+
+// ToASCII transliterates or removes non-ASCII characters of text
+func ToASCII(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = lineToASCII(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineToASCII converts single line, spaces left by removed characters are collapsed
+func lineToASCII(line string) string {
+	isASCII := true
+	for i := 0; i < len(line); i++ {
+		if line[i] > unicode.MaxASCII {
+			isASCII = false
+			break
+		}
+	}
+	if isASCII {
+		return line
+	}
+
+	var builder strings.Builder
+	for _, r := range norm.NFD.String(line) {
+		switch {
+		case r <= unicode.MaxASCII:
+			builder.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+			// combining marks of decomposed accented letters
+		default:
+			builder.WriteString(asciiReplacements[r])
+		}
+	}
+
+	converted := doubleSpacePattern.ReplaceAllString(builder.String(), "$1 ")
+	converted = strings.TrimRight(converted, " ")
+	// indentation is kept, but not space left by removed leading emoji
+	if !strings.HasPrefix(line, " ") {
+		converted = strings.TrimLeft(converted, " ")
+	}
+	return converted
+}
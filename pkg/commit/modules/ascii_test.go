@@ -0,0 +1,55 @@
+package modules
+
+import (
+	"context"
+	"testing"
+)
+
+func TestASCIIFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:     "ascii message",
+			message:  "feat: add login\n\n  indented  code",
+			expected: "feat: add login\n\n  indented  code",
+		},
+		{
+			name:         "emoji",
+			message:      "✨ feat: add login 🚀\n\n- support SSO 🔐 providers",
+			expected:     "feat: add login\n\n- support SSO providers",
+			shouldChange: true,
+		},
+		{
+			name:         "accents and punctuation",
+			message:      "fix: handle “café” naïve résumé — Straße…\n\nZürich’s łódź",
+			expected:     "fix: handle \"cafe\" naive resume - Strasse...\n\nZurich's lodz",
+			shouldChange: true,
+		},
+		{
+			name:         "non-latin script",
+			message:      "docs: update README 文档",
+			expected:     "docs: update README",
+			shouldChange: true,
+		},
+	}
+
+	filter := NewASCIIFilter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, changed, err := filter.TransformCommitMessage(context.Background(), "main", tt.message)
+			if err != nil {
+				t.Fatalf("TransformCommitMessage() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+}
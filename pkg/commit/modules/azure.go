@@ -0,0 +1,77 @@
+package modules
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+type AzureWorkItemPlacement string
+
+const AzureModuleName = "azure_work_item_linker"
+
+const (
+	AzureWorkItemPlacementNone    AzureWorkItemPlacement = "none"
+	AzureWorkItemPlacementSubject AzureWorkItemPlacement = "subject" // feat: add endpoint AB#1234
+	AzureWorkItemPlacementFooter  AzureWorkItemPlacement = "footer"  // last line of message body
+)
+
+// azureWorkItemPattern matches Azure Boards work item mentions, e.g. feature/AB#1234-login
+var azureWorkItemPattern = regexp.MustCompile(`(?i)\bAB#(\d+)\b`)
+
+// AzureWorkItemLinker adds AB#<id> mentions found in branch name to commit message,
+// Azure Boards links commits mentioning work items this way
+type AzureWorkItemLinker struct {
+	placement AzureWorkItemPlacement
+}
+
+func NewAzureWorkItemLinker(placement AzureWorkItemPlacement) *AzureWorkItemLinker {
+	return &AzureWorkItemLinker{placement: placement}
+}
+
+func (a *AzureWorkItemLinker) Name() string {
+	return AzureModuleName
+}
+
+func (a *AzureWorkItemLinker) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (a *AzureWorkItemLinker) TransformCommitMessage(_ context.Context, branch, message string) (string, bool, error) {
+	if a.placement != AzureWorkItemPlacementSubject && a.placement != AzureWorkItemPlacementFooter {
+		return message, false, nil
+	}
+
+	var mentions []string
+	for _, id := range detectAzureWorkItems(branch) {
+		mention := "AB#" + id
+		if !strings.Contains(strings.ToUpper(message), mention) {
+			mentions = append(mentions, mention)
+		}
+	}
+	if len(mentions) == 0 {
+		return message, false, nil
+	}
+
+	if a.placement == AzureWorkItemPlacementFooter {
+		return strings.TrimRight(message, "\n") + "\n\n" + strings.Join(mentions, " "), true, nil
+	}
+
+	lines := strings.SplitN(message, "\n", 2)
+	lines[0] = lines[0] + " " + strings.Join(mentions, " ")
+
+	return strings.Join(lines, "\n"), true, nil
+}
+
+// detectAzureWorkItems returns unique work item ids mentioned in branch name, in order
+func detectAzureWorkItems(branch string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, matches := range azureWorkItemPattern.FindAllStringSubmatch(branch, -1) {
+		if !seen[matches[1]] {
+			seen[matches[1]] = true
+			ids = append(ids, matches[1])
+		}
+	}
+	return ids
+}
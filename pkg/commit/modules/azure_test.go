@@ -0,0 +1,82 @@
+package modules
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAzureWorkItemLinker(t *testing.T) {
+	tests := []struct {
+		name         string
+		placement    AzureWorkItemPlacement
+		branch       string
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:         "subject",
+			placement:    AzureWorkItemPlacementSubject,
+			branch:       "feature/AB#1234-login",
+			message:      "feat: add login\n\nUses OAuth.",
+			expected:     "feat: add login AB#1234\n\nUses OAuth.",
+			shouldChange: true,
+		},
+		{
+			name:         "footer",
+			placement:    AzureWorkItemPlacementFooter,
+			branch:       "feature/AB#1234-login",
+			message:      "feat: add login\n\nUses OAuth.\n",
+			expected:     "feat: add login\n\nUses OAuth.\n\nAB#1234",
+			shouldChange: true,
+		},
+		{
+			name:         "multiple work items and lowercase prefix",
+			placement:    AzureWorkItemPlacementFooter,
+			branch:       "bugfix/ab#12-ab#34-ab#12",
+			message:      "fix: handle timeout",
+			expected:     "fix: handle timeout\n\nAB#12 AB#34",
+			shouldChange: true,
+		},
+		{
+			name:         "already mentioned",
+			placement:    AzureWorkItemPlacementSubject,
+			branch:       "feature/AB#1234-login",
+			message:      "feat: add login (AB#1234)",
+			expected:     "feat: add login (AB#1234)",
+			shouldChange: false,
+		},
+		{
+			name:         "jira style key is not a work item",
+			placement:    AzureWorkItemPlacementSubject,
+			branch:       "feature/AB-1234-login",
+			message:      "feat: add login",
+			expected:     "feat: add login",
+			shouldChange: false,
+		},
+		{
+			name:         "disabled",
+			placement:    AzureWorkItemPlacementNone,
+			branch:       "feature/AB#1234-login",
+			message:      "feat: add login",
+			expected:     "feat: add login",
+			shouldChange: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linker := NewAzureWorkItemLinker(tt.placement)
+			result, changed, err := linker.TransformCommitMessage(context.Background(), tt.branch, tt.message)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("changed = %v, want %v", changed, tt.shouldChange)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
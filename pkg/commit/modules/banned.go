@@ -0,0 +1,90 @@
+package modules
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+type BannedWordAction string
+
+const BannedWordsModuleName = "banned_words"
+
+const (
+	BannedWordActionOff    BannedWordAction = "off"
+	BannedWordActionMask   BannedWordAction = "mask"   // shit -> s***
+	BannedWordActionReject BannedWordAction = "reject" // commit is refused
+)
+
+// ErrMessageRejected is returned by modules refusing message, commit must not be created
+var ErrMessageRejected = errors.New("commit message rejected")
+
+//go:embed banned-words.txt
+var defaultBannedWords string
+
+// BannedWordFilter masks or rejects prohibited words of commit messages,
+// default profanity list is extended with configured words
+type BannedWordFilter struct {
+	action  BannedWordAction
+	pattern *regexp.Regexp
+}
+
+// NewBannedWordFilter creates filter, words match case-insensitively as whole words and may be phrases
+func NewBannedWordFilter(action BannedWordAction, words []string) *BannedWordFilter {
+	all := append(strings.Fields(defaultBannedWords), words...)
+
+	var alternatives []string
+	seen := make(map[string]bool)
+	for _, word := range all {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" || seen[word] {
+			continue
+		}
+		seen[word] = true
+		alternatives = append(alternatives, regexp.QuoteMeta(word))
+	}
+	// longest first, so phrases win over words they contain
+	sort.Slice(alternatives, func(i, j int) bool {
+		return len(alternatives[i]) > len(alternatives[j])
+	})
+
+	return &BannedWordFilter{
+		action:  action,
+		pattern: regexp.MustCompile(`(?i)\b(?:` + strings.Join(alternatives, "|") + `)\b`),
+	}
+}
+
+func (b *BannedWordFilter) Name() string {
+	return BannedWordsModuleName
+}
+
+func (b *BannedWordFilter) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (b *BannedWordFilter) TransformCommitMessage(_ context.Context, _, message string) (string, bool, error) {
+	found := b.pattern.FindAllString(message, -1)
+	if len(found) == 0 {
+		return message, false, nil
+	}
+
+	switch b.action {
+	case BannedWordActionMask:
+		return b.pattern.ReplaceAllStringFunc(message, maskWord), true, nil
+	case BannedWordActionReject:
+		return message, false, fmt.Errorf("%w: contains banned words %s", ErrMessageRejected, strings.Join(found, ", "))
+	}
+
+	return message, false, nil
+}
+
+// maskWord keeps first letter and replaces the rest with asterisks
+func maskWord(word string) string {
+	_, size := utf8.DecodeRuneInString(word)
+	return word[:size] + strings.Repeat("*", utf8.RuneCountInString(word)-1)
+}
@@ -0,0 +1,68 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBannedWordFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		action       BannedWordAction
+		words        []string
+		message      string
+		expected     string
+		shouldChange bool
+		wantErr      bool
+	}{
+		{
+			name:         "masks default profanity",
+			action:       BannedWordActionMask,
+			message:      "fix: remove Shitty workaround\n\nWTF was that.",
+			expected:     "fix: remove S***** workaround\n\nW** was that.",
+			shouldChange: true,
+		},
+		{
+			name:         "masks custom phrase",
+			action:       BannedWordActionMask,
+			words:        []string{"quick hack", "Foo"},
+			message:      "feat: add quick hack for foo",
+			expected:     "feat: add q********* for f**",
+			shouldChange: true,
+		},
+		{
+			name:     "matches whole words only",
+			action:   BannedWordActionMask,
+			message:  "docs: describe scrap and passport handling",
+			expected: "docs: describe scrap and passport handling",
+		},
+		{
+			name:     "rejects",
+			action:   BannedWordActionReject,
+			words:    []string{"internal-codename"},
+			message:  "feat: ship internal-codename",
+			expected: "feat: ship internal-codename",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewBannedWordFilter(tt.action, tt.words)
+			result, changed, err := filter.TransformCommitMessage(context.Background(), "main", tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransformCommitMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrMessageRejected) {
+				t.Errorf("TransformCommitMessage() error = %v, want ErrMessageRejected", err)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+}
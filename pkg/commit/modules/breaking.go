@@ -0,0 +1,286 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const BreakingChangeModuleName = "breaking_change_detector"
+
+// maxBreakingChangeReasons limits reasons listed in BREAKING CHANGE footer
+const maxBreakingChangeReasons = 5
+
+// breakingChangeFooterPattern matches footer of conventional commits introducing breaking change
+var breakingChangeFooterPattern = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+
+// ChangedFile is staged file content before and after change, empty Before means file is added
+// and empty After means file is deleted
+type ChangedFile struct {
+	Path          string
+	Before, After []byte
+}
+
+// ChangedFilesSource returns staged files changed against HEAD
+type ChangedFilesSource func() ([]ChangedFile, error)
+
+// BreakingChangeDetector marks conventional commits breaking public Go API with ! and BREAKING CHANGE footer:
+// removed or renamed exported identifiers, changed signatures, removed struct fields, new interface methods
+type BreakingChangeDetector struct {
+	changedFiles ChangedFilesSource
+}
+
+func NewBreakingChangeDetector(changedFiles ChangedFilesSource) *BreakingChangeDetector {
+	return &BreakingChangeDetector{changedFiles: changedFiles}
+}
+
+func (b *BreakingChangeDetector) Name() string {
+	return BreakingChangeModuleName
+}
+
+func (b *BreakingChangeDetector) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (b *BreakingChangeDetector) TransformCommitMessage(_ context.Context, _, message string) (string, bool, error) {
+	files, err := b.changedFiles()
+	if err != nil {
+		return message, false, fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	reasons := DetectBreakingChanges(files)
+	if len(reasons) == 0 {
+		return message, false, nil
+	}
+
+	lines := strings.SplitN(message, "\n", 2)
+	workDone := false
+
+	if matches := conventionalHeaderPattern.FindStringSubmatch(lines[0]); matches != nil && matches[3] == "" {
+		lines[0] = matches[1] + matches[2] + "!: " + matches[4]
+		workDone = true
+	}
+	message = strings.Join(lines, "\n")
+
+	if !breakingChangeFooterPattern.MatchString(message) {
+		if len(reasons) > maxBreakingChangeReasons {
+			more := fmt.Sprintf("and %d more", len(reasons)-maxBreakingChangeReasons)
+			reasons = append(reasons[:maxBreakingChangeReasons], more)
+		}
+		message = strings.TrimRight(message, "\n") + "\n\nBREAKING CHANGE: " + strings.Join(reasons, ", ")
+		workDone = true
+	}
+
+	return message, workDone, nil
+}
+
+// apiKey identifies exported declaration, members are struct fields, methods and interface methods
+type apiKey struct {
+	pkg   string
+	name  string // T, T.Field, T.Method
+	iface bool   // interface method, adding one is breaking too
+}
+
+func (k apiKey) String() string {
+	if k.pkg == "." {
+		return k.name
+	}
+	return k.pkg + "." + k.name
+}
+
+// owner returns key of type declaring member, isMember is false for top level declarations
+func (k apiKey) owner() (apiKey, bool) {
+	typeName, _, isMember := strings.Cut(k.name, ".")
+	return apiKey{pkg: k.pkg, name: typeName}, isMember
+}
+
+// DetectBreakingChanges compares exported API of changed Go packages before and after change,
+// tests, internal packages and main packages are not public API
+func DetectBreakingChanges(files []ChangedFile) []string {
+	before := make(map[apiKey]string)
+	after := make(map[apiKey]string)
+	for _, file := range files {
+		if !isPublicGoFile(file.Path) {
+			continue
+		}
+		pkg := path.Dir(file.Path)
+		collectExportedAPI(before, pkg, file.Before)
+		collectExportedAPI(after, pkg, file.After)
+	}
+
+	var reasons []string
+	for key, signature := range before {
+		newSignature, exists := after[key]
+		switch {
+		case !exists:
+			// members of removed type are not worth listing separately, owner declared
+			// in unchanged file is seen neither before nor after and is not removed
+			if owner, isMember := key.owner(); isMember {
+				_, ownerExisted := before[owner]
+				if _, ownerExists := after[owner]; ownerExisted && !ownerExists {
+					continue
+				}
+			}
+			reasons = append(reasons, "removed "+key.String())
+		case newSignature != signature:
+			reasons = append(reasons, "changed "+key.String())
+		}
+	}
+	for key := range after {
+		if !key.iface {
+			continue
+		}
+		// methods added to existing interfaces break their implementations
+		owner, _ := key.owner()
+		if _, existed := before[key]; !existed && before[owner] == "interface" {
+			reasons = append(reasons, "added "+key.String())
+		}
+	}
+
+	sort.Strings(reasons)
+	return reasons
+}
+
+// isPublicGoFile reports whether file can declare API importable by other modules
+func isPublicGoFile(file string) bool {
+	if !strings.HasSuffix(file, ".go") || strings.HasSuffix(file, "_test.go") {
+		return false
+	}
+	for _, segment := range strings.Split(path.Dir(file), "/") {
+		if segment == "internal" || segment == "testdata" || segment == "vendor" {
+			return false
+		}
+	}
+	return true
+}
+
+// collectExportedAPI adds exported declarations of Go source to api with their signatures
+func collectExportedAPI(api map[apiKey]string, pkg string, src []byte) {
+	if len(src) == 0 {
+		return
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil || file.Name.Name == "main" {
+		return
+	}
+
+	qualify := func(name string) apiKey {
+		return apiKey{pkg: pkg, name: name}
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if !decl.Name.IsExported() {
+				continue
+			}
+			name := decl.Name.Name
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				recv := receiverName(decl.Recv.List[0].Type)
+				if !ast.IsExported(recv) {
+					continue
+				}
+				name = recv + "." + name
+			}
+			api[qualify(name)] = funcSignature(fset, decl.Type)
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if spec.Name.IsExported() {
+						collectTypeAPI(api, fset, qualify(spec.Name.Name), spec)
+					}
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						if name.IsExported() {
+							api[qualify(name.Name)] = decl.Tok.String() + " " + nodeString(fset, spec.Type)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// collectTypeAPI adds type with its exported struct fields or interface methods
+func collectTypeAPI(api map[apiKey]string, fset *token.FileSet, key apiKey, spec *ast.TypeSpec) {
+	member := func(name string, iface bool) apiKey {
+		return apiKey{pkg: key.pkg, name: key.name + "." + name, iface: iface}
+	}
+
+	switch typ := spec.Type.(type) {
+	case *ast.StructType:
+		api[key] = "struct"
+		for _, field := range typ.Fields.List {
+			for _, fieldName := range field.Names {
+				if fieldName.IsExported() {
+					api[member(fieldName.Name, false)] = nodeString(fset, field.Type)
+				}
+			}
+		}
+	case *ast.InterfaceType:
+		api[key] = "interface"
+		for _, method := range typ.Methods.List {
+			for _, methodName := range method.Names {
+				if funcType, ok := method.Type.(*ast.FuncType); ok {
+					api[member(methodName.Name, true)] = funcSignature(fset, funcType)
+				}
+			}
+		}
+	default:
+		api[key] = nodeString(fset, spec.Type)
+	}
+}
+
+// funcSignature renders parameter and result types, names are not part of API
+func funcSignature(fset *token.FileSet, funcType *ast.FuncType) string {
+	fieldTypes := func(fields *ast.FieldList) string {
+		if fields == nil {
+			return ""
+		}
+		var types []string
+		for _, field := range fields.List {
+			count := max(len(field.Names), 1)
+			for range count {
+				types = append(types, nodeString(fset, field.Type))
+			}
+		}
+		return strings.Join(types, ", ")
+	}
+	return "func(" + fieldTypes(funcType.Params) + ") (" + fieldTypes(funcType.Results) + ")"
+}
+
+// receiverName returns type name of method receiver, e.g. T for *T[K]
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+func nodeString(fset *token.FileSet, node ast.Node) string {
+	if node == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		return ""
+	}
+	return buf.String()
+}
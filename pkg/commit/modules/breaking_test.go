@@ -0,0 +1,208 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const breakingTestBefore = `package api
+
+type Client struct {
+	Timeout int
+	Retries int
+	secret  string
+}
+
+type Store interface {
+	Get(key string) (string, error)
+}
+
+const Version = "1"
+
+func New(url string, timeout int) *Client { return nil }
+
+func (c *Client) Close() error { return nil }
+
+func Helper() {}
+
+func unexported(a int) {}
+`
+
+func TestDetectBreakingChanges(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []ChangedFile
+		want  []string
+	}{
+		{
+			name: "compatible changes",
+			files: []ChangedFile{{
+				Path:   "pkg/api/api.go",
+				Before: []byte(breakingTestBefore),
+				After: []byte(`package api
+
+type Client struct {
+	Timeout int
+	Retries int
+	Debug   bool
+}
+
+type Store interface {
+	Get(name string) (string, error)
+}
+
+const Version = "2"
+
+func New(address string, timeout int) *Client { return nil }
+
+func (c *Client) Close() error { return nil }
+
+func Helper() {}
+
+func NewDefault() *Client { return nil }
+
+func unexported(a, b int) {}
+`),
+			}},
+		},
+		{
+			name: "breaking changes",
+			files: []ChangedFile{
+				{
+					Path:   "pkg/api/api.go",
+					Before: []byte(breakingTestBefore),
+					After: []byte(`package api
+
+type Client struct {
+	Timeout int64
+}
+
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+}
+
+const Version = "1"
+
+func New(url string, timeout int, debug bool) *Client { return nil }
+
+func (c *Client) Shutdown() error { return nil }
+`),
+				},
+			},
+			want: []string{
+				"added pkg/api.Store.Set",
+				"changed pkg/api.Client.Timeout",
+				"changed pkg/api.New",
+				"removed pkg/api.Client.Close",
+				"removed pkg/api.Client.Retries",
+				"removed pkg/api.Helper",
+			},
+		},
+		{
+			name: "declaration moved between files",
+			files: []ChangedFile{
+				{Path: "api.go", Before: []byte("package api\n\nfunc Helper() {}\n"), After: []byte("package api\n")},
+				{Path: "util.go", After: []byte("package api\n\nfunc Helper() {}\n")},
+			},
+		},
+		{
+			name: "method removed from type declared in unchanged file",
+			files: []ChangedFile{{
+				Path:   "client_methods.go",
+				Before: []byte("package api\n\nfunc (c *Client) Close() error { return nil }\n"),
+				After:  []byte("package api\n"),
+			}},
+			want: []string{"removed Client.Close"},
+		},
+		{
+			name:  "deleted type reported once",
+			files: []ChangedFile{{Path: "api.go", Before: []byte(breakingTestBefore)}},
+			want: []string{
+				"removed Client", "removed Helper", "removed New",
+				"removed Store", "removed Version",
+			},
+		},
+		{
+			name: "internal, main and test files ignored",
+			files: []ChangedFile{
+				{Path: "internal/api/api.go", Before: []byte(breakingTestBefore)},
+				{Path: "cmd/tool/main.go", Before: []byte("package main\n\nfunc Run() {}\n")},
+				{Path: "api_test.go", Before: []byte("package api\n\nfunc TestHelper() {}\n")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectBreakingChanges(tt.files)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectBreakingChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakingChangeDetector(t *testing.T) {
+	breaking := []ChangedFile{{Path: "api.go", Before: []byte("package api\n\nfunc Helper() {}\n")}}
+
+	tests := []struct {
+		name         string
+		files        []ChangedFile
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:         "conventional commit marked",
+			files:        breaking,
+			message:      "refactor(api): drop helper\n\nNobody used it.",
+			expected:     "refactor(api)!: drop helper\n\nNobody used it.\n\nBREAKING CHANGE: removed Helper",
+			shouldChange: true,
+		},
+		{
+			name:         "already marked",
+			files:        breaking,
+			message:      "refactor!: drop helper\n\nBREAKING CHANGE: Helper is gone",
+			expected:     "refactor!: drop helper\n\nBREAKING CHANGE: Helper is gone",
+			shouldChange: false,
+		},
+		{
+			name:         "plain message gets footer only",
+			files:        breaking,
+			message:      "Drop helper",
+			expected:     "Drop helper\n\nBREAKING CHANGE: removed Helper",
+			shouldChange: true,
+		},
+		{
+			name:         "no breaking changes",
+			files:        nil,
+			message:      "feat: add helper",
+			expected:     "feat: add helper",
+			shouldChange: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewBreakingChangeDetector(func() ([]ChangedFile, error) { return tt.files, nil })
+			result, changed, err := detector.TransformCommitMessage(context.Background(), "main", tt.message)
+			if err != nil {
+				t.Fatalf("TransformCommitMessage() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+
+	failing := NewBreakingChangeDetector(func() ([]ChangedFile, error) { return nil, errors.New("no index") })
+	if _, _, err := failing.TransformCommitMessage(context.Background(), "main", "feat: x"); err == nil {
+		t.Error("TransformCommitMessage() expected error when files are unavailable")
+	}
+}
@@ -0,0 +1,178 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const ChatNotifierName = "chat_notifier"
+
+const defaultChatTimeout = 10 * time.Second
+
+// Events chat notifier is sent on
+const (
+	ChatNotifyOnCommit = "commit" // every created commit
+	ChatNotifyOnPush   = "push"   // pushed commits
+	ChatNotifyOnTag    = "tag"    // commits released with a tag
+)
+
+// Chat webhook formats
+const (
+	ChatFormatSlack = "slack" // also accepted by Mattermost and Rocket.Chat
+	ChatFormatTeams = "teams"
+)
+
+// ChatNotifier posts created commits to Slack or Microsoft Teams incoming webhooks,
+// format of each webhook is detected from its host
+type ChatNotifier struct {
+	webhooks []string
+	on       string
+	client   *http.Client
+}
+
+func NewChatNotifier(webhooks []string, on string) *ChatNotifier {
+	return &ChatNotifier{
+		webhooks: webhooks,
+		on:       on,
+		client:   &http.Client{Timeout: defaultChatTimeout},
+	}
+}
+
+func (c *ChatNotifier) Name() string {
+	return ChatNotifierName
+}
+
+func (c *ChatNotifier) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		c.client.Timeout = timeout
+	}
+}
+
+// NotifyCommit posts commit to every webhook when it matches event notifier is configured for,
+// all webhooks are tried even when some fail
+func (c *ChatNotifier) NotifyCommit(ctx context.Context, event CommitEvent) error {
+	switch {
+	case c.on == ChatNotifyOnPush && !event.Pushed:
+		return nil
+	case c.on == ChatNotifyOnTag && event.Tag == "":
+		return nil
+	}
+
+	var failed []string
+	for _, webhook := range c.webhooks {
+		if err := c.post(ctx, webhook, chatPayload(ChatWebhookFormat(webhook), event)); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to notify chat: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func (c *ChatNotifier) post(ctx context.Context, webhook string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode chat message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// url of webhook is its secret, it is not part of the error
+		return fmt.Errorf("request to %s failed", webhookHost(webhook))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s: %s", webhookHost(webhook), resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// ChatWebhookFormat returns format of webhook, Teams webhooks are served by office.com
+// connectors or by Power Automate workflows, everything else gets Slack compatible format
+func ChatWebhookFormat(webhook string) string {
+	host := strings.ToLower(webhookHost(webhook))
+	for _, suffix := range []string{"office.com", "office365.com", "logic.azure.com", "powerplatform.com"} {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return ChatFormatTeams
+		}
+	}
+	return ChatFormatSlack
+}
+
+func webhookHost(webhook string) string {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return "webhook"
+	}
+	return u.Hostname()
+}
+
+// chatPayload renders commit as message of webhook format: repository and branch,
+// subject linked to commit, then tag and merge request when there are any
+func chatPayload(format string, event CommitEvent) any {
+	title := fmt.Sprintf("%s: new commit on %s", event.Repo, event.Branch)
+	if event.Tag != "" {
+		title = fmt.Sprintf("%s: %s released from %s", event.Repo, event.Tag, event.Branch)
+	}
+
+	if format == ChatFormatTeams {
+		body := []map[string]any{
+			{"type": "TextBlock", "text": title, "weight": "Bolder", "wrap": true},
+			{"type": "TextBlock", "text": markdownLink(event.Subject(), event.URL), "wrap": true},
+		}
+		if event.MergeRequestURL != "" {
+			body = append(body, map[string]any{
+				"type": "TextBlock", "text": markdownLink("Merge request", event.MergeRequestURL), "wrap": true,
+			})
+		}
+		return map[string]any{
+			"type": "message",
+			"attachments": []map[string]any{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]any{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			}},
+		}
+	}
+
+	lines := []string{"*" + title + "*", slackLink(event.Subject(), event.URL)}
+	if event.MergeRequestURL != "" {
+		lines = append(lines, slackLink("Merge request", event.MergeRequestURL))
+	}
+	return map[string]string{"text": strings.Join(lines, "\n")}
+}
+
+func markdownLink(text, link string) string {
+	if link == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, link)
+}
+
+// slackLink links text in Slack mrkdwn, which reserves &, < and > in text
+func slackLink(text, link string) string {
+	text = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+	if link == "" {
+		return text
+	}
+	return fmt.Sprintf("<%s|%s>", link, text)
+}
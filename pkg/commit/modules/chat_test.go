@@ -0,0 +1,95 @@
+package modules
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChatWebhookFormat(t *testing.T) {
+	tests := []struct {
+		webhook string
+		want    string
+	}{
+		{"https://hooks.slack.com/services/T000/B000/XXX", ChatFormatSlack},
+		{"https://contoso.webhook.office.com/webhookb2/abc", ChatFormatTeams},
+		{"https://prod-01.westus.logic.azure.com:443/workflows/abc/triggers/manual/paths/invoke", ChatFormatTeams},
+		{"https://mattermost.example.com/hooks/abc", ChatFormatSlack},
+		{"https://notoffice.com/hook", ChatFormatSlack},
+	}
+
+	for _, tt := range tests {
+		if got := ChatWebhookFormat(tt.webhook); got != tt.want {
+			t.Errorf("ChatWebhookFormat(%s) = %s, want %s", tt.webhook, got, tt.want)
+		}
+	}
+}
+
+func TestChatNotifier_NotifyCommit(t *testing.T) {
+	var messages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		messages = append(messages, payload.Text)
+	}))
+	defer server.Close()
+
+	event := CommitEvent{
+		Repo:            "owner/repo",
+		Branch:          "main",
+		Hash:            "0123abc",
+		Message:         "feat: add <retry> & backoff\n\nbody",
+		URL:             "https://github.com/owner/repo/commit/0123abc",
+		Pushed:          true,
+		MergeRequestURL: "https://github.com/owner/repo/pull/1",
+	}
+
+	ctx := context.Background()
+	if err := NewChatNotifier([]string{server.URL}, ChatNotifyOnPush).NotifyCommit(ctx, event); err != nil {
+		t.Fatalf("NotifyCommit() error = %v", err)
+	}
+	want := "*owner/repo: new commit on main*\n" +
+		"<https://github.com/owner/repo/commit/0123abc|feat: add &lt;retry&gt; &amp; backoff>\n" +
+		"<https://github.com/owner/repo/pull/1|Merge request>"
+	if len(messages) != 1 || messages[0] != want {
+		t.Errorf("messages = %q, want %q", messages, want)
+	}
+
+	// untagged commit is not released
+	if err := NewChatNotifier([]string{server.URL}, ChatNotifyOnTag).NotifyCommit(ctx, event); err != nil {
+		t.Fatalf("NotifyCommit() error = %v", err)
+	}
+	event.Tag = "v1.2.0"
+	if err := NewChatNotifier([]string{server.URL}, ChatNotifyOnTag).NotifyCommit(ctx, event); err != nil {
+		t.Fatalf("NotifyCommit() error = %v", err)
+	}
+	if len(messages) != 2 || !strings.HasPrefix(messages[1], "*owner/repo: v1.2.0 released from main*") {
+		t.Errorf("messages = %q, want one release message after the first", messages)
+	}
+}
+
+func TestChatPayload_Teams(t *testing.T) {
+	event := CommitEvent{Repo: "repo", Branch: "main", Message: "fix: crash", URL: "https://example.com/c/1"}
+
+	data, err := json.Marshal(chatPayload(ChatFormatTeams, event))
+	if err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	payload := string(data)
+	for _, want := range []string{
+		`"contentType":"application/vnd.microsoft.card.adaptive"`,
+		`"text":"repo: new commit on main"`,
+		`"text":"[fix: crash](https://example.com/c/1)"`,
+	} {
+		if !strings.Contains(payload, want) {
+			t.Errorf("payload = %s, want %s", payload, want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const CoAuthorsModuleName = "co_authors"
+
+// coAuthorPattern matches co-author given as Name <email>
+var coAuthorPattern = regexp.MustCompile(`^\s*([^<>]+?)\s*<([^<>\s]+@[^<>\s]+)>\s*$`)
+
+// ConfigSource returns git configuration value by key, empty when not set
+type ConfigSource func(key string) string
+
+// CoAuthorDetector appends Co-authored-by trailers for people pairing on the commit:
+// explicit list (names with emails or .pairs initials) and active git-duet or git-together pair
+type CoAuthorDetector struct {
+	coAuthors  []string
+	pairing    bool
+	config     ConfigSource
+	pairsFiles []string
+}
+
+// NewCoAuthorDetector creates detector, pairsFiles are git-pair .pairs files resolving initials,
+// first existing one is used
+func NewCoAuthorDetector(coAuthors []string, pairing bool, config ConfigSource, pairsFiles []string) *CoAuthorDetector {
+	return &CoAuthorDetector{
+		coAuthors:  coAuthors,
+		pairing:    pairing,
+		config:     config,
+		pairsFiles: pairsFiles,
+	}
+}
+
+func (c *CoAuthorDetector) Name() string {
+	return CoAuthorsModuleName
+}
+
+func (c *CoAuthorDetector) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (c *CoAuthorDetector) TransformCommitMessage(_ context.Context, _, message string) (string, bool, error) {
+	coAuthors, err := c.detect()
+	if err != nil {
+		return message, false, err
+	}
+
+	self := strings.ToLower(c.config("user.email"))
+	lowerMessage := strings.ToLower(message)
+
+	var trailers []string
+	seen := make(map[string]bool)
+	for _, coAuthor := range coAuthors {
+		// entries of pairing tools are not validated, malformed ones are skipped
+		matches := coAuthorPattern.FindStringSubmatch(coAuthor)
+		if len(matches) != 3 {
+			continue
+		}
+		email := strings.ToLower(matches[2])
+		if email == self || seen[email] || strings.Contains(lowerMessage, "<"+email+">") {
+			continue
+		}
+		seen[email] = true
+		trailers = append(trailers, "Co-authored-by: "+matches[1]+" <"+matches[2]+">")
+	}
+	if len(trailers) == 0 {
+		return message, false, nil
+	}
+
+	return appendTrailers(message, trailers), true, nil
+}
+
+// detect collects co-authors in Name <email> form
+func (c *CoAuthorDetector) detect() ([]string, error) {
+	var (
+		coAuthors []string
+		initials  []string
+	)
+	for _, coAuthor := range c.coAuthors {
+		coAuthor = strings.TrimSpace(coAuthor)
+		switch {
+		case coAuthor == "":
+		case coAuthorPattern.MatchString(coAuthor):
+			coAuthors = append(coAuthors, coAuthor)
+		default:
+			initials = append(initials, coAuthor)
+		}
+	}
+
+	if len(initials) > 0 {
+		pairs, err := c.loadPairs()
+		if err != nil {
+			return nil, err
+		}
+		for _, initial := range initials {
+			coAuthor, ok := pairs[strings.ToLower(initial)]
+			if !ok {
+				return nil, fmt.Errorf("unknown co-author %q, use Name <email> or initials from .pairs", initial)
+			}
+			if !coAuthorPattern.MatchString(coAuthor) {
+				return nil, fmt.Errorf("invalid .pairs entry of %q: %s (must be \"Name; username\" or \"Name; email\")",
+					initial, coAuthor)
+			}
+			coAuthors = append(coAuthors, coAuthor)
+		}
+	}
+
+	if c.pairing {
+		coAuthors = append(coAuthors, c.gitDuetCoAuthors()...)
+		coAuthors = append(coAuthors, c.gitTogetherCoAuthors()...)
+	}
+
+	return coAuthors, nil
+}
+
+// gitDuetCoAuthors returns committer of active git-duet pair, git-duet keeps it in git config
+func (c *CoAuthorDetector) gitDuetCoAuthors() []string {
+	name := c.config("duet.env.git-committer-name")
+	email := c.config("duet.env.git-committer-email")
+	if name == "" || email == "" || email == c.config("duet.env.git-author-email") {
+		return nil
+	}
+	return []string{name + " <" + email + ">"}
+}
+
+// gitTogetherCoAuthors returns active git-together authors except the first one, who is the author,
+// authors are configured as "Name; username" with shared domain, or "Name; email"
+func (c *CoAuthorDetector) gitTogetherCoAuthors() []string {
+	active := strings.Split(c.config("git-together.active"), "+")
+	if len(active) < 2 {
+		return nil
+	}
+
+	domain := c.config("git-together.domain")
+	var coAuthors []string
+	for _, initial := range active[1:] {
+		name, username, ok := strings.Cut(c.config("git-together.authors."+initial), ";")
+		if !ok {
+			continue
+		}
+		if coAuthor := formatCoAuthor(name, username, domain); coAuthor != "" {
+			coAuthors = append(coAuthors, coAuthor)
+		}
+	}
+	return coAuthors
+}
+
+// loadPairs reads initials of first existing git-pair .pairs file:
+// pairs map initials to "Name; username", email is username at email domain
+func (c *CoAuthorDetector) loadPairs() (map[string]string, error) {
+	for _, file := range c.pairsFiles {
+		content, err := os.ReadFile(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		var config struct {
+			Pairs map[string]string `yaml:"pairs"`
+			Email struct {
+				Domain string `yaml:"domain"`
+			} `yaml:"email"`
+		}
+		if err := yaml.Unmarshal(content, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		pairs := make(map[string]string, len(config.Pairs))
+		for initials, person := range config.Pairs {
+			name, username, _ := strings.Cut(person, ";")
+			if username == "" {
+				username, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(name)), " ")
+			}
+			if coAuthor := formatCoAuthor(name, username, config.Email.Domain); coAuthor != "" {
+				pairs[strings.ToLower(initials)] = coAuthor
+			}
+		}
+		return pairs, nil
+	}
+
+	return nil, fmt.Errorf("no .pairs file found to resolve co-author initials")
+}
+
+// formatCoAuthor returns Name <email>, username without @ is completed with domain
+func formatCoAuthor(name, username, domain string) string {
+	name, email := strings.TrimSpace(name), strings.TrimSpace(username)
+	if !strings.Contains(email, "@") {
+		if domain == "" {
+			return ""
+		}
+		email += "@" + domain
+	}
+	if name == "" || email == "" {
+		return ""
+	}
+	return name + " <" + email + ">"
+}
+
+// appendTrailers adds trailers to trailer block ending the message, creating it when missing
+func appendTrailers(message string, trailers []string) string {
+	message = strings.TrimRight(message, "\n")
+
+	paragraphs := strings.Split(message, "\n\n")
+	if len(paragraphs) > 1 && isTrailerBlock(paragraphs[len(paragraphs)-1]) {
+		return message + "\n" + strings.Join(trailers, "\n")
+	}
+	return message + "\n\n" + strings.Join(trailers, "\n")
+}
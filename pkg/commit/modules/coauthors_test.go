@@ -0,0 +1,113 @@
+package modules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCoAuthorDetector(t *testing.T) {
+	dir := t.TempDir()
+	pairsFile := filepath.Join(dir, ".pairs")
+	pairs := "pairs:\n  jd: Jane Doe; jane\n  js: John Smith\nemail:\n  domain: example.com\n"
+	if err := os.WriteFile(pairsFile, []byte(pairs), 0644); err != nil {
+		t.Fatalf("Failed to write .pairs: %v", err)
+	}
+
+	config := map[string]string{
+		"user.email":                   "me@example.com",
+		"duet.env.git-author-email":    "me@example.com",
+		"duet.env.git-committer-name":  "Duet Partner",
+		"duet.env.git-committer-email": "partner@example.com",
+		"git-together.active":          "me+nn+ab",
+		"git-together.domain":          "rocinante.com",
+		"git-together.authors.nn":      "Naomi Nagata; nnagata",
+		"git-together.authors.ab":      "Amos Burton; amos@baltimore.org",
+	}
+	source := func(key string) string { return config[key] }
+
+	tests := []struct {
+		name      string
+		coAuthors []string
+		pairing   bool
+		message   string
+		expected  string
+		wantErr   bool
+	}{
+		{
+			name:      "explicit and initials",
+			coAuthors: []string{"Ann Lee <ann@example.org>", "jd", "JS", "Self <me@example.com>"},
+			message:   "feat: add login",
+			expected: "feat: add login\n\n" +
+				"Co-authored-by: Ann Lee <ann@example.org>\n" +
+				"Co-authored-by: Jane Doe <jane@example.com>\n" +
+				"Co-authored-by: John Smith <john@example.com>",
+		},
+		{
+			name:    "pairing tools appended to existing trailers",
+			pairing: true,
+			message: "fix: handle timeout\n\nBody.\n\nRefs: TASK-1\n",
+			expected: "fix: handle timeout\n\nBody.\n\nRefs: TASK-1\n" +
+				"Co-authored-by: Duet Partner <partner@example.com>\n" +
+				"Co-authored-by: Naomi Nagata <nnagata@rocinante.com>\n" +
+				"Co-authored-by: Amos Burton <amos@baltimore.org>",
+		},
+		{
+			name:      "already mentioned",
+			coAuthors: []string{"jd"},
+			message:   "feat: x\n\nCo-authored-by: Jane Doe <Jane@example.com>",
+			expected:  "feat: x\n\nCo-authored-by: Jane Doe <Jane@example.com>",
+		},
+		{
+			name:      "unknown initials",
+			coAuthors: []string{"zz"},
+			message:   "feat: x",
+			expected:  "feat: x",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairsFiles := []string{filepath.Join(dir, "missing"), pairsFile}
+			detector := NewCoAuthorDetector(tt.coAuthors, tt.pairing, source, pairsFiles)
+			result, _, err := detector.TransformCommitMessage(context.Background(), "main", tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransformCommitMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCoAuthorDetector_MalformedEntries(t *testing.T) {
+	pairsFile := filepath.Join(t.TempDir(), ".pairs")
+	pairs := "pairs:\n  jd: Jane Doe; jane doe\n  js: John Smith\nemail:\n  domain: example.com\n"
+	if err := os.WriteFile(pairsFile, []byte(pairs), 0644); err != nil {
+		t.Fatalf("Failed to write .pairs: %v", err)
+	}
+	config := map[string]string{
+		"git-together.active":     "me+bd+js",
+		"git-together.domain":     "example.com",
+		"git-together.authors.bd": "Bad Entry; bad entry",
+		"git-together.authors.js": "John Smith; john",
+	}
+	source := func(key string) string { return config[key] }
+
+	detector := NewCoAuthorDetector([]string{"jd"}, false, source, []string{pairsFile})
+	if _, _, err := detector.TransformCommitMessage(context.Background(), "main", "feat: x"); err == nil {
+		t.Error("TransformCommitMessage() with malformed .pairs entry error = nil, want error")
+	}
+
+	detector = NewCoAuthorDetector([]string{"js"}, true, source, []string{pairsFile})
+	result, _, err := detector.TransformCommitMessage(context.Background(), "main", "feat: x")
+	if err != nil {
+		t.Fatalf("TransformCommitMessage() error = %v", err)
+	}
+	if want := "feat: x\n\nCo-authored-by: John Smith <john@example.com>"; result != want {
+		t.Errorf("TransformCommitMessage() = %q, want %q", result, want)
+	}
+}
@@ -0,0 +1,29 @@
+package modules
+
+import "strings"
+
+// CommitEvent describes created commit to notifiers
+type CommitEvent struct {
+	Repo            string // owner/repo of push remote, or name of worktree directory
+	Branch          string
+	Hash            string
+	Message         string
+	URL             string // web page of commit, empty unless it was pushed to a known platform
+	Tag             string // tag created with commit, if any
+	Pushed          bool   // commit and tag were pushed
+	MergeRequestURL string // created pull request or page creating one, empty unless pushed
+}
+
+// Subject returns first line of commit message
+func (e CommitEvent) Subject() string {
+	subject, _, _ := strings.Cut(e.Message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// ShortHash returns abbreviated commit hash
+func (e CommitEvent) ShortHash() string {
+	if len(e.Hash) > 12 {
+		return e.Hash[:12]
+	}
+	return e.Hash
+}
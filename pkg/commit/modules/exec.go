@@ -0,0 +1,72 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	ExternalHookPrompt        = "prompt"         // module receives prompt sent to providers
+	ExternalHookCommitMessage = "commit_message" // module receives generated commit message
+)
+
+// ExternalInput is JSON written to stdin of external module
+type ExternalInput struct {
+	Hook    string `json:"hook"`
+	Branch  string `json:"branch"`
+	Message string `json:"message"`
+}
+
+// ExternalModule runs executable as module: input is passed as JSON on stdin and
+// transformed text is read from stdout, empty output leaves text unchanged
+type ExternalModule struct {
+	path string
+}
+
+func NewExternalModule(path string) *ExternalModule {
+	return &ExternalModule{path: path}
+}
+
+func (e *ExternalModule) Name() string {
+	return "exec:" + filepath.Base(e.path)
+}
+
+func (e *ExternalModule) TransformPrompt(ctx context.Context, branch, prompt string) (string, bool, error) {
+	return e.run(ctx, ExternalHookPrompt, branch, prompt)
+}
+
+func (e *ExternalModule) TransformCommitMessage(ctx context.Context, branch, message string) (string, bool, error) {
+	return e.run(ctx, ExternalHookCommitMessage, branch, message)
+}
+
+func (e *ExternalModule) run(ctx context.Context, hook, branch, text string) (string, bool, error) {
+	input, err := json.Marshal(ExternalInput{Hook: hook, Branch: branch, Message: text})
+	if err != nil {
+		return text, false, fmt.Errorf("failed to encode module input: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return text, false, fmt.Errorf("module %s failed: %w: %s", e.path, err, msg)
+		}
+		return text, false, fmt.Errorf("module %s failed: %w", e.path, err)
+	}
+
+	output := strings.TrimRight(stdout.String(), "\n")
+	if strings.TrimSpace(output) == "" || output == text {
+		return text, false, nil
+	}
+
+	return output, true, nil
+}
@@ -0,0 +1,85 @@
+package modules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeExternalModule(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "module.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExternalModule(t *testing.T) {
+	tests := []struct {
+		name         string
+		script       string
+		expected     string
+		shouldChange bool
+		wantErr      bool
+	}{
+		{
+			name:         "transforms message",
+			script:       "cat > /dev/null\necho 'feat: add login'\necho\necho 'Reviewed-by: Bot'\n",
+			expected:     "feat: add login\n\nReviewed-by: Bot",
+			shouldChange: true,
+		},
+		{
+			name:         "receives json input",
+			script:       "cat\n",
+			expected:     `{"hook":"commit_message","branch":"feature/login","message":"feat: add login"}`,
+			shouldChange: true,
+		},
+		{
+			name:     "empty output leaves message unchanged",
+			script:   "cat > /dev/null\n",
+			expected: "feat: add login",
+		},
+		{
+			name:     "failure",
+			script:   "echo 'no network' >&2\nexit 3\n",
+			expected: "feat: add login",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			module := NewExternalModule(writeExternalModule(t, tt.script))
+			result, changed, err := module.TransformCommitMessage(
+				context.Background(), "feature/login", "feat: add login",
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransformCommitMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "no network") {
+				t.Errorf("TransformCommitMessage() error = %v, want stderr included", err)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+}
+
+func TestExternalModule_TransformPrompt(t *testing.T) {
+	module := NewExternalModule(writeExternalModule(t, `grep -q '"hook":"prompt"' && echo 'rewritten prompt'`+"\n"))
+
+	result, changed, err := module.TransformPrompt(context.Background(), "main", "prompt")
+	if err != nil {
+		t.Fatalf("TransformPrompt() error = %v", err)
+	}
+	if result != "rewritten prompt" || !changed {
+		t.Errorf("TransformPrompt() = %q, %v, want rewritten prompt", result, changed)
+	}
+}
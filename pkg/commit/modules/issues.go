@@ -0,0 +1,160 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const IssueCloserModuleName = "issue_closer"
+
+const (
+	IssuePlatformGitHub = "github"
+	IssuePlatformGitLab = "gitlab"
+)
+
+// closingKeywords are keywords closing issues per platform, GitLab understands more forms
+var closingKeywords = map[string]map[string]bool{
+	IssuePlatformGitHub: {
+		"close": true, "closes": true, "closed": true,
+		"fix": true, "fixes": true, "fixed": true,
+		"resolve": true, "resolves": true, "resolved": true,
+	},
+	IssuePlatformGitLab: {
+		"close": true, "closes": true, "closed": true, "closing": true,
+		"fix": true, "fixes": true, "fixed": true, "fixing": true,
+		"resolve": true, "resolves": true, "resolved": true, "resolving": true,
+		"implement": true, "implements": true, "implemented": true, "implementing": true,
+	},
+}
+
+var (
+	// branchIssuePatterns match issue numbers in branch names, e.g. 123-login, fix/issue-123, gh-123,
+	// bare numbers are not issues as they are just as likely dates or versions, e.g. hotfix/2024-10-16
+	branchIssuePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)(?:^|/)(?:issues?|gh|gl|bug)[-_]?(\d+)(?:[-_/]|$)`),
+		regexp.MustCompile(`(?i)(?:^|/)(\d+)[-_][a-z]`),
+	}
+	// subjectIssuePattern matches issue mentioned in subject, e.g. fix login (#123)
+	subjectIssuePattern = regexp.MustCompile(`(?:^|[\s(])#(\d+)\b`)
+	// subjectFixPattern matches subjects of fixes, issues they mention are the fixed ones,
+	// e.g. fix(auth): login timeout (#123) or Resolve crash on start (#45)
+	subjectFixPattern = regexp.MustCompile(
+		`(?i)^(?:fix(?:\([^)]*\))?!?:|(?:fix|fixes|fixed|resolve|resolves|resolved)\b)`,
+	)
+	// closedIssuePattern matches issues already closed by message, e.g. Fixes #123
+	closedIssuePattern = regexp.MustCompile(`(?i)\b([a-z]+):?\s+#(\d+)\b`)
+)
+
+// IssueCloser adds closing keywords for issues referenced by branch name or subject,
+// so the platform closes them when commit reaches default branch
+type IssueCloser struct {
+	platform string
+	keyword  string
+}
+
+// NewIssueCloser creates closer for GitHub or GitLab, keyword must close issues on the platform
+func NewIssueCloser(platform, keyword string) (*IssueCloser, error) {
+	keywords, ok := closingKeywords[platform]
+	if !ok {
+		return nil, fmt.Errorf("issue closing keywords are not supported on %s", platform)
+	}
+	if !keywords[strings.ToLower(keyword)] {
+		return nil, fmt.Errorf("%q does not close issues on %s", keyword, platform)
+	}
+
+	return &IssueCloser{
+		platform: platform,
+		keyword:  strings.ToUpper(keyword[:1]) + strings.ToLower(keyword[1:]),
+	}, nil
+}
+
+// IsClosingKeyword reports whether keyword closes issues on any supported platform
+func IsClosingKeyword(keyword string) bool {
+	for _, keywords := range closingKeywords {
+		if keywords[strings.ToLower(keyword)] {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *IssueCloser) Name() string {
+	return IssueCloserModuleName
+}
+
+func (c *IssueCloser) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (c *IssueCloser) TransformCommitMessage(_ context.Context, branch, message string) (string, bool, error) {
+	closed := make(map[string]bool)
+	for _, matches := range closedIssuePattern.FindAllStringSubmatch(message, -1) {
+		if closingKeywords[c.platform][strings.ToLower(matches[1])] {
+			closed[matches[2]] = true
+		}
+	}
+
+	subject, _, _ := strings.Cut(message, "\n")
+
+	var footer []string
+	for _, issue := range append(detectBranchIssues(branch), detectSubjectIssues(subject)...) {
+		if !closed[issue] {
+			closed[issue] = true
+			footer = append(footer, c.keyword+" #"+issue)
+		}
+	}
+	if len(footer) == 0 {
+		return message, false, nil
+	}
+
+	return insertFooter(message, strings.Join(footer, "\n")), true, nil
+}
+
+// detectBranchIssues returns issue number of branch name, if any
+func detectBranchIssues(branch string) []string {
+	for _, pattern := range branchIssuePatterns {
+		if matches := pattern.FindStringSubmatch(branch); matches != nil {
+			return []string{matches[1]}
+		}
+	}
+	return nil
+}
+
+// detectSubjectIssues returns issue numbers mentioned in subject of a fix, other subjects
+// may only refer to issues, e.g. feat: add export (#9) of an epic
+func detectSubjectIssues(subject string) []string {
+	if !subjectFixPattern.MatchString(subject) {
+		return nil
+	}
+	var issues []string
+	for _, matches := range subjectIssuePattern.FindAllStringSubmatch(subject, -1) {
+		issues = append(issues, matches[1])
+	}
+	return issues
+}
+
+// insertFooter adds paragraph to the end of message, but before trailer block,
+// which git only recognizes as the last paragraph
+func insertFooter(message, footer string) string {
+	message = strings.TrimRight(message, "\n")
+
+	paragraphs := strings.Split(message, "\n\n")
+	if len(paragraphs) > 1 && isTrailerBlock(paragraphs[len(paragraphs)-1]) {
+		last := len(paragraphs) - 1
+		return strings.Join(paragraphs[:last], "\n\n") + "\n\n" + footer + "\n\n" + paragraphs[last]
+	}
+
+	return message + "\n\n" + footer
+}
+
+// isTrailerBlock reports whether all lines of paragraph are git trailers
+func isTrailerBlock(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		if !trailerPattern.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
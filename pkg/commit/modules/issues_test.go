@@ -0,0 +1,116 @@
+package modules
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIssueCloser(t *testing.T) {
+	tests := []struct {
+		name         string
+		platform     string
+		keyword      string
+		branch       string
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:         "issue number prefix of branch",
+			platform:     IssuePlatformGitHub,
+			keyword:      "closes",
+			branch:       "fix/123-login-timeout",
+			message:      "fix: handle login timeout\n\nRetries once.",
+			expected:     "fix: handle login timeout\n\nRetries once.\n\nCloses #123",
+			shouldChange: true,
+		},
+		{
+			name:     "issue prefix and subject mention before trailers",
+			platform: IssuePlatformGitLab,
+			keyword:  "implements",
+			branch:   "feature/issue-7",
+			message:  "fix(export): empty rows (#9)\n\nSigned-off-by: Dev <dev@example.com>",
+			expected: "fix(export): empty rows (#9)\n\nImplements #7\nImplements #9\n\n" +
+				"Signed-off-by: Dev <dev@example.com>",
+			shouldChange: true,
+		},
+		{
+			name:     "already closed by message",
+			platform: IssuePlatformGitHub,
+			keyword:  "fixes",
+			branch:   "gh-42",
+			message:  "fix: crash\n\nResolves: #42",
+			expected: "fix: crash\n\nResolves: #42",
+		},
+		{
+			name:     "date in branch is not an issue",
+			platform: IssuePlatformGitHub,
+			keyword:  "closes",
+			branch:   "hotfix/2024-10-16",
+			message:  "fix: crash on start",
+			expected: "fix: crash on start",
+		},
+		{
+			name:     "issue referenced by subject of feature is not closed",
+			platform: IssuePlatformGitHub,
+			keyword:  "closes",
+			branch:   "feature/export",
+			message:  "feat: add csv export (#9)",
+			expected: "feat: add csv export (#9)",
+		},
+		{
+			name:         "issue fixed by plain subject",
+			platform:     IssuePlatformGitHub,
+			keyword:      "fixes",
+			branch:       "main",
+			message:      "Resolve crash on start (#45)",
+			expected:     "Resolve crash on start (#45)\n\nFixes #45",
+			shouldChange: true,
+		},
+		{
+			name:     "jira branch is not an issue",
+			platform: IssuePlatformGitHub,
+			keyword:  "closes",
+			branch:   "feature/TASK-123-login",
+			message:  "feat: login",
+			expected: "feat: login",
+		},
+		{
+			name:         "github does not understand gitlab keyword as closing",
+			platform:     IssuePlatformGitHub,
+			keyword:      "closes",
+			branch:       "12-docs",
+			message:      "docs: usage\n\nImplements #12",
+			expected:     "docs: usage\n\nImplements #12\n\nCloses #12",
+			shouldChange: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			closer, err := NewIssueCloser(tt.platform, tt.keyword)
+			if err != nil {
+				t.Fatalf("NewIssueCloser() error = %v", err)
+			}
+			result, changed, err := closer.TransformCommitMessage(context.Background(), tt.branch, tt.message)
+			if err != nil {
+				t.Fatalf("TransformCommitMessage() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+}
+
+func TestNewIssueCloser(t *testing.T) {
+	if _, err := NewIssueCloser(IssuePlatformGitHub, "implements"); err == nil {
+		t.Error("NewIssueCloser() expected error for keyword GitHub does not support")
+	}
+	if _, err := NewIssueCloser("unknown", "closes"); err == nil {
+		t.Error("NewIssueCloser() expected error for unknown platform")
+	}
+}
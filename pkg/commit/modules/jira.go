@@ -2,6 +2,7 @@ package modules
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -53,9 +54,17 @@ var conventionalCommitTypes = map[string]bool{
 	"revert":   true,
 }
 
+// maxJiraDescriptionLength limits issue description injected into prompt
+const maxJiraDescriptionLength = 2000
+
 type JIRATaskDetector struct {
 	position JiraTaskPosition
 	style    JiraTaskStyle
+	client   *JiraClient // optional, used for prompt enrichment and task validation
+	enrich   bool        // add issue summary and description to prompts
+
+	comment    bool   // comment issue with created commit
+	transition string // status issue is moved to after commit, empty keeps it
 }
 
 func NewJIRATaskDetector(position JiraTaskPosition, style JiraTaskStyle) *JIRATaskDetector {
@@ -69,8 +78,136 @@ func (j *JIRATaskDetector) Name() string {
 	return JiraModuleName
 }
 
-func (j *JIRATaskDetector) TransformPrompt(_ context.Context, prompt string) (string, bool, error) {
-	return prompt, false, nil
+// WithClient enables fetching detected issue from Jira API,
+// with enrich set issue is also given to providers as task context
+func (j *JIRATaskDetector) WithClient(client *JiraClient, enrich bool) *JIRATaskDetector {
+	j.client = client
+	j.enrich = enrich
+	return j
+}
+
+// WithCommitActions enables commenting issue detected in branch with created commit
+// and moving it to transition status, e.g. "In Review", client is required
+func (j *JIRATaskDetector) WithCommitActions(comment bool, transition string) *JIRATaskDetector {
+	j.comment = comment
+	j.transition = transition
+	return j
+}
+
+// NotifyCommit comments and transitions issue detected in branch of created commit,
+// issues already in transition status are not transitioned again
+func (j *JIRATaskDetector) NotifyCommit(ctx context.Context, event CommitEvent) error {
+	if j.client == nil {
+		return fmt.Errorf("jira client is not configured")
+	}
+
+	jiraID := j.detectJiraID(event.Branch)
+	if jiraID == "" {
+		return nil
+	}
+
+	if j.comment {
+		if err := j.client.AddComment(ctx, jiraID, formatJiraCommitComment(event)); err != nil {
+			return err
+		}
+	}
+
+	if j.transition != "" {
+		issue, err := j.client.GetIssue(ctx, jiraID)
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(issue.Status, j.transition) {
+			return nil
+		}
+		return j.client.TransitionIssue(ctx, jiraID, j.transition)
+	}
+
+	return nil
+}
+
+// formatJiraCommitComment renders commit as wiki markup, message is kept verbatim
+func formatJiraCommitComment(event CommitEvent) string {
+	commit := event.ShortHash()
+	if event.URL != "" {
+		commit = fmt.Sprintf("[%s|%s]", commit, event.URL)
+	}
+	return fmt.Sprintf("Commit %s on branch {{%s}}:\n{noformat}\n%s\n{noformat}", commit, event.Branch, event.Message)
+}
+
+// ValidateTask checks that issue detected in branch exists and is in progress,
+// branches without issue key are not validated
+func (j *JIRATaskDetector) ValidateTask(ctx context.Context, branch string) error {
+	if j.client == nil {
+		return fmt.Errorf("jira client is not configured")
+	}
+
+	jiraID := j.detectJiraID(branch)
+	if jiraID == "" {
+		return nil
+	}
+
+	issue, err := j.client.GetIssue(ctx, jiraID)
+	if err != nil {
+		return err
+	}
+	if !issue.InProgress() {
+		return fmt.Errorf("%w: %s is %q", ErrJiraIssueNotInProgress, jiraID, issue.Status)
+	}
+
+	return nil
+}
+
+func (j *JIRATaskDetector) TransformPrompt(ctx context.Context, branch, prompt string) (string, bool, error) {
+	issueContext, workDone, err := j.PromptContext(ctx, branch)
+	if err != nil || !workDone {
+		return prompt, false, err
+	}
+	return prompt + issueContext, true, nil
+}
+
+// PromptContext returns issue detected in branch as task context appended to prompt,
+// it does not depend on other modules, so it is fetched concurrently with other contributors
+func (j *JIRATaskDetector) PromptContext(ctx context.Context, branch string) (string, bool, error) {
+	if j.client == nil || !j.enrich {
+		return "", false, nil
+	}
+
+	jiraID := j.detectJiraID(branch)
+	if jiraID == "" {
+		return "", false, nil
+	}
+
+	issue, err := j.client.GetIssue(ctx, jiraID)
+	if err != nil {
+		return "", false, err
+	}
+
+	return formatJiraIssueContext(issue), true, nil
+}
+
+// formatJiraIssueContext describes task the changes belong to, for providers to reflect its intent
+func formatJiraIssueContext(issue *JiraIssue) string {
+	var sb strings.Builder
+	sb.WriteString("\n\nThe changes belong to Jira issue ")
+	sb.WriteString(issue.Key)
+	sb.WriteString(": ")
+	sb.WriteString(issue.Summary)
+	sb.WriteString("\n")
+
+	description := strings.TrimSpace(issue.Description)
+	if len(description) > maxJiraDescriptionLength {
+		description = strings.ToValidUTF8(description[:maxJiraDescriptionLength], "") + "..."
+	}
+	if description != "" {
+		sb.WriteString("Issue description:\n")
+		sb.WriteString(description)
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("Use the issue to understand intent of the changes, but describe the changes themselves ")
+	sb.WriteString("and do not mention the issue key.\n")
+	return sb.String()
 }
 func (j *JIRATaskDetector) TransformCommitMessage(_ context.Context, branch, message string) (string, bool, error) {
 	if j.position == JiraTaskPositionNone {
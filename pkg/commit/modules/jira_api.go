@@ -0,0 +1,211 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultJiraTimeout = 10 * time.Second
+
+// jiraStatusCategoryInProgress is key of status category grouping all "in progress" statuses
+const jiraStatusCategoryInProgress = "indeterminate"
+
+var (
+	ErrJiraIssueNotFound      = errors.New("jira issue not found")
+	ErrJiraIssueNotInProgress = errors.New("jira issue is not in progress")
+)
+
+// JiraIssue is a subset of Jira issue fields relevant for commit messages
+type JiraIssue struct {
+	Key            string
+	Summary        string
+	Description    string
+	Status         string
+	StatusCategory string // new, indeterminate (in progress) or done
+}
+
+// JiraClient reads issues from Jira REST API,
+// Jira Cloud uses email with API token, Server/Data Center uses personal access token alone
+type JiraClient struct {
+	baseURL string
+	email   string
+	token   string
+	client  *http.Client
+}
+
+func NewJiraClient() *JiraClient {
+	return &JiraClient{
+		baseURL: strings.TrimRight(os.Getenv("JIRA_URL"), "/"),
+		email:   os.Getenv("JIRA_EMAIL"),
+		token:   os.Getenv("JIRA_API_TOKEN"),
+		client:  &http.Client{Timeout: defaultJiraTimeout},
+	}
+}
+
+func (c *JiraClient) IsAvailable() bool {
+	return c.baseURL != "" && c.token != ""
+}
+
+func (c *JiraClient) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		c.client.Timeout = timeout
+	}
+}
+
+// GetIssue fetches issue by key, description is returned as plain text (wiki markup of API v2)
+func (c *JiraClient) GetIssue(ctx context.Context, key string) (*JiraIssue, error) {
+	endpoint := fmt.Sprintf(
+		"%s/rest/api/2/issue/%s?fields=summary,description,status",
+		c.baseURL, url.PathEscape(key),
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jira request: %w", err)
+	}
+	c.authorize(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request jira issue %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrJiraIssueNotFound, key)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf(
+			"failed to get jira issue %s: %s: %s",
+			key, resp.Status, strings.TrimSpace(string(body)),
+		)
+	}
+
+	var payload struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary     string `json:"summary"`
+			Description string `json:"description"`
+			Status      struct {
+				Name           string `json:"name"`
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode jira issue %s: %w", key, err)
+	}
+
+	return &JiraIssue{
+		Key:            payload.Key,
+		Summary:        payload.Fields.Summary,
+		Description:    payload.Fields.Description,
+		Status:         payload.Fields.Status.Name,
+		StatusCategory: payload.Fields.Status.StatusCategory.Key,
+	}, nil
+}
+
+// AddComment posts comment to issue, body is wiki markup of API v2
+func (c *JiraClient) AddComment(ctx context.Context, key, body string) error {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s/comment", c.baseURL, url.PathEscape(key))
+	if err := c.send(ctx, http.MethodPost, endpoint, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("failed to comment jira issue %s: %w", key, err)
+	}
+	return nil
+}
+
+// TransitionIssue moves issue to status, transition is matched by its name or name of status it leads to,
+// as workflows name them differently, e.g. "Start review" leading to "In Review"
+func (c *JiraClient) TransitionIssue(ctx context.Context, key, status string) error {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s/transitions", c.baseURL, url.PathEscape(key))
+
+	var payload struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := c.send(ctx, http.MethodGet, endpoint, nil, &payload); err != nil {
+		return fmt.Errorf("failed to get transitions of jira issue %s: %w", key, err)
+	}
+
+	for _, transition := range payload.Transitions {
+		if !strings.EqualFold(transition.Name, status) && !strings.EqualFold(transition.To.Name, status) {
+			continue
+		}
+		request := map[string]map[string]string{"transition": {"id": transition.ID}}
+		if err := c.send(ctx, http.MethodPost, endpoint, request, nil); err != nil {
+			return fmt.Errorf("failed to transition jira issue %s to %q: %w", key, status, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("jira issue %s has no transition to %q", key, status)
+}
+
+// send makes API request with optional json payload, response is decoded into result unless it is nil
+func (c *JiraClient) send(ctx context.Context, method, endpoint string, payload, result any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode jira request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create jira request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.authorize(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode jira response: %w", err)
+	}
+	return nil
+}
+
+func (c *JiraClient) authorize(req *http.Request) {
+	req.Header.Set("Accept", "application/json")
+	if c.email != "" {
+		req.SetBasicAuth(c.email, c.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
+// InProgress reports whether issue status belongs to "in progress" category,
+// custom workflow statuses are mapped to one of the categories by Jira itself
+func (i *JiraIssue) InProgress() bool {
+	return i.StatusCategory == jiraStatusCategoryInProgress
+}
@@ -0,0 +1,212 @@
+package modules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestJiraServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "dev@example.com" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/rest/api/2/issue/TASK-123":
+			_, _ = w.Write([]byte(`{"key":"TASK-123","fields":{` +
+				`"summary":"Retry failed uploads",` +
+				`"description":"Uploads fail on flaky networks, retry them with backoff.",` +
+				`"status":{"name":"In Progress","statusCategory":{"key":"indeterminate"}}}}`))
+		case "/rest/api/2/issue/TASK-7":
+			_, _ = w.Write([]byte(`{"key":"TASK-7","fields":{"summary":"Old task",` +
+				`"status":{"name":"Closed","statusCategory":{"key":"done"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMessages":["Issue does not exist"]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestJiraClient_GetIssue(t *testing.T) {
+	server := newTestJiraServer(t)
+	t.Setenv("JIRA_URL", server.URL+"/")
+	t.Setenv("JIRA_EMAIL", "dev@example.com")
+	t.Setenv("JIRA_API_TOKEN", "secret")
+
+	client := NewJiraClient()
+	if !client.IsAvailable() {
+		t.Fatal("IsAvailable() = false, want true")
+	}
+
+	issue, err := client.GetIssue(context.Background(), "TASK-123")
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	want := JiraIssue{
+		Key:            "TASK-123",
+		Summary:        "Retry failed uploads",
+		Description:    "Uploads fail on flaky networks, retry them with backoff.",
+		Status:         "In Progress",
+		StatusCategory: "indeterminate",
+	}
+	if *issue != want {
+		t.Errorf("GetIssue() = %+v, want %+v", *issue, want)
+	}
+
+	if _, err := client.GetIssue(context.Background(), "TASK-404"); err == nil {
+		t.Error("GetIssue() expected error for unknown issue")
+	}
+}
+
+func TestJiraTaskDetector_TransformPrompt(t *testing.T) {
+	server := newTestJiraServer(t)
+	t.Setenv("JIRA_URL", server.URL)
+	t.Setenv("JIRA_EMAIL", "dev@example.com")
+	t.Setenv("JIRA_API_TOKEN", "secret")
+
+	tests := []struct {
+		name     string
+		client   *JiraClient
+		branch   string
+		wantDone bool
+		wantErr  bool
+	}{
+		{name: "enriched", client: NewJiraClient(), branch: "feature/TASK-123-retry", wantDone: true},
+		{name: "no client", client: nil, branch: "feature/TASK-123-retry"},
+		{name: "no issue in branch", client: NewJiraClient(), branch: "main"},
+		{name: "unknown issue", client: NewJiraClient(), branch: "feature/TASK-404", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewJIRATaskDetector(JiraTaskPositionNone, JiraTaskStylePlain).WithClient(tt.client, true)
+
+			got, done, err := detector.TransformPrompt(context.Background(), tt.branch, "PROMPT")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransformPrompt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if done != tt.wantDone {
+				t.Errorf("TransformPrompt() done = %v, want %v", done, tt.wantDone)
+			}
+			if !done {
+				if got != "PROMPT" {
+					t.Errorf("TransformPrompt() changed prompt without work done: %q", got)
+				}
+				return
+			}
+			for _, want := range []string{"PROMPT", "TASK-123: Retry failed uploads", "retry them with backoff"} {
+				if !strings.Contains(got, want) {
+					t.Errorf("TransformPrompt() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestJiraTaskDetector_ValidateTask(t *testing.T) {
+	server := newTestJiraServer(t)
+	t.Setenv("JIRA_URL", server.URL)
+	t.Setenv("JIRA_EMAIL", "dev@example.com")
+	t.Setenv("JIRA_API_TOKEN", "secret")
+
+	tests := []struct {
+		name    string
+		branch  string
+		wantErr error
+	}{
+		{name: "in progress", branch: "feature/TASK-123-retry"},
+		{name: "no issue in branch", branch: "main"},
+		{name: "closed", branch: "bugfix/TASK-7", wantErr: ErrJiraIssueNotInProgress},
+		{name: "unknown", branch: "TASK-404-typo", wantErr: ErrJiraIssueNotFound},
+	}
+
+	detector := NewJIRATaskDetector(JiraTaskPositionNone, JiraTaskStylePlain).WithClient(NewJiraClient(), false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := detector.ValidateTask(context.Background(), tt.branch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateTask() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// validation alone does not enrich prompts
+	if _, done, _ := detector.TransformPrompt(context.Background(), "feature/TASK-123", "PROMPT"); done {
+		t.Error("TransformPrompt() enriched prompt with enrichment disabled")
+	}
+}
+
+func TestJIRATaskDetector_NotifyCommit(t *testing.T) {
+	var comments, transitions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Body       string            `json:"body"`
+			Transition map[string]string `json:"transition"`
+		}
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+		}
+		switch {
+		case r.URL.Path == "/rest/api/2/issue/TASK-123/comment" && r.Method == http.MethodPost:
+			comments = append(comments, payload.Body)
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/rest/api/2/issue/TASK-123/transitions" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"transitions":[` +
+				`{"id":"11","name":"Done","to":{"name":"Done"}},` +
+				`{"id":"21","name":"Start review","to":{"name":"In Review"}}]}`))
+		case r.URL.Path == "/rest/api/2/issue/TASK-123/transitions" && r.Method == http.MethodPost:
+			transitions = append(transitions, payload.Transition["id"])
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/2/issue/TASK-123":
+			_, _ = w.Write([]byte(`{"key":"TASK-123","fields":{"summary":"Retry",` +
+				`"status":{"name":"In Progress","statusCategory":{"key":"indeterminate"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("JIRA_URL", server.URL)
+	t.Setenv("JIRA_EMAIL", "")
+	t.Setenv("JIRA_API_TOKEN", "secret")
+
+	detector := NewJIRATaskDetector(JiraTaskPositionNone, JiraTaskStylePlain).
+		WithClient(NewJiraClient(), false).
+		WithCommitActions(true, "in review")
+
+	event := CommitEvent{
+		Branch:  "feature/TASK-123-retry",
+		Hash:    "0123456789abcdef0123",
+		Message: "feat: retry uploads",
+		URL:     "https://github.com/o/r/commit/0123456789abcdef0123",
+	}
+	if err := detector.NotifyCommit(context.Background(), event); err != nil {
+		t.Fatalf("NotifyCommit() error = %v", err)
+	}
+	if len(comments) != 1 || !strings.Contains(comments[0], "[0123456789ab|https://github.com/o/r/commit/") ||
+		!strings.Contains(comments[0], "feat: retry uploads") {
+		t.Errorf("comments = %q, want commit link and message", comments)
+	}
+	if len(transitions) != 1 || transitions[0] != "21" {
+		t.Errorf("transitions = %v, want [21] matched by target status", transitions)
+	}
+
+	// branches without issue are ignored
+	event.Branch = "main"
+	if err := detector.NotifyCommit(context.Background(), event); err != nil || len(comments) != 1 {
+		t.Errorf("NotifyCommit() on branch without issue = %v, %d comments, want no requests", err, len(comments))
+	}
+
+	detector.WithCommitActions(false, "Closed")
+	event.Branch = "TASK-123"
+	if err := detector.NotifyCommit(context.Background(), event); err == nil {
+		t.Error("NotifyCommit() with unavailable transition, want error")
+	}
+}
@@ -0,0 +1,190 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type SubjectCase string
+type ImperativeMode string
+
+const NormalizeModuleName = "message_normalizer"
+
+const (
+	SubjectCaseKeep     SubjectCase = "keep"
+	SubjectCaseLower    SubjectCase = "lower"    // feat: add endpoint
+	SubjectCaseSentence SubjectCase = "sentence" // feat: Add endpoint
+)
+
+const (
+	ImperativeModeOff       ImperativeMode = "off"
+	ImperativeModeHeuristic ImperativeMode = "heuristic" // "Added" and "Adds" of known verbs become "Add"
+	ImperativeModeAI        ImperativeMode = "ai"        // heuristic, then providers rewrite what is left
+)
+
+// ImperativeRewriter rewrites subject description into imperative mood, e.g. with AI providers
+type ImperativeRewriter func(ctx context.Context, description string) (string, error)
+
+// imperativeVerbs are verbs commit subjects usually start with
+var imperativeVerbs = []string{
+	"add", "adjust", "allow", "apply", "avoid", "build", "bump", "change", "check", "clean",
+	"commit", "convert", "copy", "correct", "create", "decrease", "delete", "deprecate",
+	"disable", "document", "downgrade", "drop", "enable", "ensure", "expose", "extend",
+	"extract", "fix", "format", "handle", "implement", "improve", "include", "increase",
+	"initialize", "inline", "introduce", "limit", "log", "make", "map", "merge", "migrate",
+	"modify", "move", "optimize", "pin", "prevent", "refactor", "reduce", "release", "remove",
+	"rename", "reorder", "replace", "restore", "return", "revert", "rewrite", "run", "set",
+	"simplify", "skip", "sort", "split", "stop", "strip", "support", "switch", "tag", "test",
+	"tweak", "update", "upgrade", "use", "validate", "verify", "wrap", "write",
+}
+
+// doubledFinalVerbs double final consonant in past tense and gerund, e.g. dropped
+var doubledFinalVerbs = map[string]bool{
+	"commit": true, "drop": true, "format": true, "log": true, "map": true, "pin": true, "run": true,
+	"set": true, "skip": true, "split": true, "stop": true, "strip": true, "tag": true, "wrap": true,
+}
+
+// irregularVerbForms maps irregular past forms to base verbs
+var irregularVerbForms = map[string]string{
+	"built": "build", "made": "make", "ran": "run", "rewrote": "rewrite", "rewritten": "rewrite",
+	"wrote": "write", "written": "write",
+}
+
+// imperativeForms maps inflected forms of imperativeVerbs to base verbs
+var imperativeForms = buildImperativeForms()
+
+func buildImperativeForms() map[string]string {
+	forms := make(map[string]string)
+	for form, verb := range irregularVerbForms {
+		forms[form] = verb
+	}
+	for _, verb := range imperativeVerbs {
+		stem := verb
+		last := verb[len(verb)-1]
+		switch {
+		case doubledFinalVerbs[verb]:
+			stem = verb + string(last)
+		case last == 'e':
+			stem = verb[:len(verb)-1]
+		}
+
+		switch {
+		case last == 'y' && !strings.ContainsRune("aeiou", rune(verb[len(verb)-2])):
+			base := verb[:len(verb)-1]
+			forms[base+"ies"] = verb
+			forms[base+"ied"] = verb
+		case strings.HasSuffix(verb, "sh"), strings.HasSuffix(verb, "ch"), last == 'x', last == 's':
+			forms[verb+"es"] = verb
+			forms[stem+"ed"] = verb
+		default:
+			forms[verb+"s"] = verb
+			forms[stem+"ed"] = verb
+		}
+		forms[stem+"ing"] = verb
+	}
+	return forms
+}
+
+// MessageNormalizer makes subjects consistent regardless of provider quirks:
+// case of first letter, trailing period and imperative mood
+type MessageNormalizer struct {
+	subjectCase SubjectCase
+	stripPeriod bool
+	imperative  ImperativeMode
+	rewriteByAI ImperativeRewriter
+}
+
+// NewMessageNormalizer creates normalizer, rewriter is required in ai imperative mode only
+func NewMessageNormalizer(
+	subjectCase SubjectCase, stripPeriod bool,
+	imperative ImperativeMode, rewriter ImperativeRewriter,
+) *MessageNormalizer {
+	return &MessageNormalizer{
+		subjectCase: subjectCase,
+		stripPeriod: stripPeriod,
+		imperative:  imperative,
+		rewriteByAI: rewriter,
+	}
+}
+
+func (n *MessageNormalizer) Name() string {
+	return NormalizeModuleName
+}
+
+func (n *MessageNormalizer) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (n *MessageNormalizer) TransformCommitMessage(ctx context.Context, _, message string) (string, bool, error) {
+	lines := strings.SplitN(message, "\n", 2)
+
+	// conventional prefix is left alone, rules apply to description
+	prefix, description := "", strings.TrimSpace(lines[0])
+	if matches := conventionalHeaderPattern.FindStringSubmatch(description); matches != nil {
+		prefix = matches[1] + matches[2] + matches[3] + ": "
+		description = matches[4]
+	}
+
+	if n.stripPeriod && strings.HasSuffix(description, ".") && !strings.HasSuffix(description, "..") {
+		description = strings.TrimRight(strings.TrimSuffix(description, "."), " ")
+	}
+
+	if n.imperative == ImperativeModeHeuristic || n.imperative == ImperativeModeAI {
+		description = toImperative(description)
+	}
+	if n.imperative == ImperativeModeAI && n.rewriteByAI != nil {
+		rewritten, err := n.rewriteByAI(ctx, description)
+		if err != nil {
+			return message, false, fmt.Errorf("failed to check imperative mood: %w", err)
+		}
+		if rewritten = strings.TrimSpace(rewritten); rewritten != "" {
+			description = rewritten
+		}
+	}
+
+	switch n.subjectCase {
+	case SubjectCaseLower:
+		description = setFirstLetterCase(description, unicode.ToLower)
+	case SubjectCaseSentence:
+		description = setFirstLetterCase(description, unicode.ToUpper)
+	}
+
+	subject := prefix + description
+	if subject == lines[0] {
+		return message, false, nil
+	}
+	lines[0] = subject
+
+	return strings.Join(lines, "\n"), true, nil
+}
+
+// toImperative replaces inflected first word of known verbs with its base form, keeping its case
+func toImperative(description string) string {
+	word, rest, _ := strings.Cut(description, " ")
+	verb, ok := imperativeForms[strings.ToLower(word)]
+	if !ok {
+		return description
+	}
+
+	if first, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(first) {
+		verb = strings.ToUpper(verb[:1]) + verb[1:]
+	}
+	if rest == "" {
+		return verb
+	}
+	return verb + " " + rest
+}
+
+// setFirstLetterCase changes case of the first letter, words in upper case (acronyms) are kept
+func setFirstLetterCase(description string, to func(rune) rune) string {
+	word, _, _ := strings.Cut(description, " ")
+	if word == "" || utf8.RuneCountInString(word) > 1 && strings.ToUpper(word) == word {
+		return description
+	}
+
+	first, size := utf8.DecodeRuneInString(description)
+	return string(to(first)) + description[size:]
+}
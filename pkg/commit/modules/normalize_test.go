@@ -0,0 +1,129 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMessageNormalizer(t *testing.T) {
+	tests := []struct {
+		name         string
+		subjectCase  SubjectCase
+		stripPeriod  bool
+		imperative   ImperativeMode
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:         "lower case after conventional prefix",
+			subjectCase:  SubjectCaseLower,
+			message:      "feat(api): Add endpoint\n\nBody Text.",
+			expected:     "feat(api): add endpoint\n\nBody Text.",
+			shouldChange: true,
+		},
+		{
+			name:         "acronym kept",
+			subjectCase:  SubjectCaseLower,
+			message:      "fix: API returns 500",
+			expected:     "fix: API returns 500",
+			shouldChange: false,
+		},
+		{
+			name:         "sentence case without prefix",
+			subjectCase:  SubjectCaseSentence,
+			message:      "update readme",
+			expected:     "Update readme",
+			shouldChange: true,
+		},
+		{
+			name:         "trailing period stripped",
+			stripPeriod:  true,
+			message:      "fix: handle timeout.\n\nRetries once.",
+			expected:     "fix: handle timeout\n\nRetries once.",
+			shouldChange: true,
+		},
+		{
+			name:         "ellipsis kept",
+			stripPeriod:  true,
+			message:      "chore: wip...",
+			expected:     "chore: wip...",
+			shouldChange: false,
+		},
+		{
+			name:         "past tense made imperative",
+			imperative:   ImperativeModeHeuristic,
+			message:      "feat!: Added retries to uploads",
+			expected:     "feat!: Add retries to uploads",
+			shouldChange: true,
+		},
+		{
+			name:         "third person and irregular forms",
+			imperative:   ImperativeModeHeuristic,
+			subjectCase:  SubjectCaseLower,
+			message:      "Simplifies parser",
+			expected:     "simplify parser",
+			shouldChange: true,
+		},
+		{
+			name:         "doubled consonant",
+			imperative:   ImperativeModeHeuristic,
+			message:      "chore: dropped support for go 1.22",
+			expected:     "chore: drop support for go 1.22",
+			shouldChange: true,
+		},
+		{
+			name:         "unknown word untouched",
+			imperative:   ImperativeModeHeuristic,
+			message:      "docs: readme typo",
+			expected:     "docs: readme typo",
+			shouldChange: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalizer := NewMessageNormalizer(tt.subjectCase, tt.stripPeriod, tt.imperative, nil)
+			result, changed, err := normalizer.TransformCommitMessage(context.Background(), "main", tt.message)
+			if err != nil {
+				t.Fatalf("TransformCommitMessage() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+}
+
+func TestMessageNormalizer_AI(t *testing.T) {
+	var asked string
+	rewriter := func(_ context.Context, description string) (string, error) {
+		asked = description
+		return "  make uploads resumable\n", nil
+	}
+
+	normalizer := NewMessageNormalizer(SubjectCaseKeep, false, ImperativeModeAI, rewriter)
+	result, changed, err := normalizer.TransformCommitMessage(
+		context.Background(), "main", "feat: Wrote uploads resumable support",
+	)
+	if err != nil {
+		t.Fatalf("TransformCommitMessage() error = %v", err)
+	}
+	if asked != "Write uploads resumable support" {
+		t.Errorf("rewriter got %q, want heuristic result first", asked)
+	}
+	if result != "feat: make uploads resumable" || !changed {
+		t.Errorf("TransformCommitMessage() = %q, %v", result, changed)
+	}
+
+	failing := NewMessageNormalizer(SubjectCaseLower, false, ImperativeModeAI,
+		func(context.Context, string) (string, error) { return "", errors.New("timeout") })
+	result, changed, err = failing.TransformCommitMessage(context.Background(), "main", "Fix bug")
+	if err == nil || changed || result != "Fix bug" {
+		t.Errorf("TransformCommitMessage() = %q, %v, %v, want unchanged message and error", result, changed, err)
+	}
+}
@@ -0,0 +1,51 @@
+package modules
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+const ScopeModuleName = "scope_injector"
+
+// conventionalHeaderPattern splits conventional commit header into type, scope, breaking mark and description
+var conventionalHeaderPattern = regexp.MustCompile(`^([a-z]+)(\([^)]*\))?(!?): (.+)$`)
+
+// ScopeInjector sets conventional commit scope to a fixed value, used for monorepo subdirectories
+type ScopeInjector struct {
+	scope string
+}
+
+func NewScopeInjector(scope string) *ScopeInjector {
+	return &ScopeInjector{scope: scope}
+}
+
+func (s *ScopeInjector) Name() string {
+	return ScopeModuleName
+}
+
+func (s *ScopeInjector) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (s *ScopeInjector) TransformCommitMessage(_ context.Context, _, message string) (string, bool, error) {
+	if s.scope == "" {
+		return message, false, nil
+	}
+
+	lines := strings.SplitN(message, "\n", 2)
+
+	matches := conventionalHeaderPattern.FindStringSubmatch(lines[0])
+	if matches == nil || !conventionalCommitTypes[matches[1]] {
+		return message, false, nil
+	}
+
+	scope := "(" + s.scope + ")"
+	if matches[2] == scope {
+		return message, false, nil
+	}
+
+	lines[0] = matches[1] + scope + matches[3] + ": " + matches[4]
+
+	return strings.Join(lines, "\n"), true, nil
+}
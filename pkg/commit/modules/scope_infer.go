@@ -0,0 +1,273 @@
+package modules
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type ScopeInference string
+
+const ScopeInferenceModuleName = "scope_inferrer"
+
+const (
+	ScopeInferenceOff      ScopeInference = "off"
+	ScopeInferenceMissing  ScopeInference = "missing"  // add scope when provider did not give one
+	ScopeInferenceOverride ScopeInference = "override" // replace scope given by provider
+)
+
+var scopeInvalidChars = regexp.MustCompile(`[^a-z0-9\-_]+`)
+
+// genericScopeDirs are layout directories too broad to be a scope on their own
+var genericScopeDirs = map[string]bool{
+	"apps": true, "cmd": true, "internal": true, "lib": true, "libs": true,
+	"packages": true, "pkg": true, "services": true, "src": true,
+}
+
+// StagedPathsSource returns staged file paths relative to repository root
+type StagedPathsSource func() ([]string, error)
+
+// ScopeInferrer derives conventional commit scope from staged paths: monorepo workspace
+// (go.work, package.json workspaces), Go package name or common directory of changes
+type ScopeInferrer struct {
+	root  string
+	mode  ScopeInference
+	files StagedPathsSource
+}
+
+// NewScopeInferrer creates inferrer, manifests are read from root of repository worktree
+func NewScopeInferrer(root string, mode ScopeInference, files StagedPathsSource) *ScopeInferrer {
+	return &ScopeInferrer{root: root, mode: mode, files: files}
+}
+
+func (s *ScopeInferrer) Name() string {
+	return ScopeInferenceModuleName
+}
+
+func (s *ScopeInferrer) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (s *ScopeInferrer) TransformCommitMessage(_ context.Context, _, message string) (string, bool, error) {
+	if s.mode != ScopeInferenceMissing && s.mode != ScopeInferenceOverride {
+		return message, false, nil
+	}
+
+	lines := strings.SplitN(message, "\n", 2)
+
+	matches := conventionalHeaderPattern.FindStringSubmatch(lines[0])
+	if matches == nil || !conventionalCommitTypes[matches[1]] {
+		return message, false, nil
+	}
+	if s.mode == ScopeInferenceMissing && matches[2] != "" {
+		return message, false, nil
+	}
+
+	files, err := s.files()
+	if err != nil {
+		return message, false, fmt.Errorf("failed to get staged files: %w", err)
+	}
+
+	scope := InferScope(s.root, files)
+	if scope == "" || matches[2] == "("+scope+")" {
+		return message, false, nil
+	}
+
+	lines[0] = matches[1] + "(" + scope + ")" + matches[3] + ": " + matches[4]
+
+	return strings.Join(lines, "\n"), true, nil
+}
+
+// InferScope returns scope for changes of files, empty when changes are spread too wide
+func InferScope(root string, files []string) string {
+	if len(files) == 0 {
+		return ""
+	}
+
+	// deepest workspace first, workspaces can be nested
+	workspaces := workspaceDirs(root)
+	sort.Slice(workspaces, func(i, j int) bool {
+		return len(workspaces[i]) > len(workspaces[j])
+	})
+	for _, workspace := range workspaces {
+		if allWithin(files, workspace) {
+			return DirectoryScope(filepath.Join(root, workspace))
+		}
+	}
+
+	dir := commonDir(files)
+	if dir == "" || genericScopeDirs[path.Base(dir)] {
+		return ""
+	}
+
+	if name := goPackageName(root, dir, files); name != "" {
+		return sanitizeScope(name)
+	}
+
+	return DirectoryScope(filepath.Join(root, dir))
+}
+
+// DirectoryScope returns conventional commit scope for a directory,
+// package.json name is preferred, otherwise directory name is used
+func DirectoryScope(absDir string) string {
+	name := filepath.Base(absDir)
+
+	if content, err := os.ReadFile(filepath.Join(absDir, "package.json")); err == nil {
+		var manifest struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(content, &manifest) == nil && manifest.Name != "" {
+			name = manifest.Name
+		}
+	}
+
+	return sanitizeScope(name)
+}
+
+// sanitizeScope makes name usable as scope, @org/package -> package
+func sanitizeScope(name string) string {
+	if idx := strings.LastIndex(name, "/"); idx != -1 {
+		name = name[idx+1:]
+	}
+
+	name = scopeInvalidChars.ReplaceAllString(strings.ToLower(name), "-")
+	return strings.Trim(name, "-")
+}
+
+// workspaceDirs lists slash-separated workspace directories of go.work and package.json
+func workspaceDirs(root string) []string {
+	var dirs []string
+
+	if content, err := os.ReadFile(filepath.Join(root, "go.work")); err == nil {
+		dirs = append(dirs, goWorkUses(content)...)
+	}
+
+	if content, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
+		for _, pattern := range packageJSONWorkspaces(content) {
+			matches, _ := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+			for _, match := range matches {
+				if info, err := os.Stat(match); err != nil || !info.IsDir() {
+					continue
+				}
+				if rel, err := filepath.Rel(root, match); err == nil {
+					dirs = append(dirs, filepath.ToSlash(rel))
+				}
+			}
+		}
+	}
+
+	var workspaces []string
+	for _, dir := range dirs {
+		if dir = path.Clean(strings.TrimPrefix(dir, "./")); dir != "." && !strings.HasPrefix(dir, "..") {
+			workspaces = append(workspaces, dir)
+		}
+	}
+	return workspaces
+}
+
+// goWorkUses parses use directives of go.work, both single and block form
+func goWorkUses(content []byte) []string {
+	var (
+		uses    []string
+		inBlock bool
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case inBlock && fields[0] == ")":
+			inBlock = false
+		case inBlock:
+			uses = append(uses, strings.Trim(fields[0], `"`))
+		case fields[0] == "use" && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+		case fields[0] == "use" && len(fields) > 1:
+			uses = append(uses, strings.Trim(fields[1], `"`))
+		}
+	}
+	return uses
+}
+
+// packageJSONWorkspaces returns workspace patterns, both array and yarn object form
+func packageJSONWorkspaces(content []byte) []string {
+	var manifest struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if json.Unmarshal(content, &manifest) != nil || len(manifest.Workspaces) == 0 {
+		return nil
+	}
+
+	var patterns []string
+	if json.Unmarshal(manifest.Workspaces, &patterns) == nil {
+		return patterns
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if json.Unmarshal(manifest.Workspaces, &object) == nil {
+		return object.Packages
+	}
+	return nil
+}
+
+// allWithin reports whether all files are inside dir
+func allWithin(files []string, dir string) bool {
+	for _, file := range files {
+		if !strings.HasPrefix(file, dir+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+// commonDir returns deepest directory containing all files, empty for repository root
+func commonDir(files []string) string {
+	dir := path.Dir(files[0])
+	for _, file := range files[1:] {
+		for dir != "." && !strings.HasPrefix(file, dir+"/") {
+			dir = path.Dir(dir)
+		}
+	}
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// goPackageName returns name of Go package when all files are Go sources of dir,
+// main packages are named after their directory instead
+func goPackageName(root, dir string, files []string) string {
+	for _, file := range files {
+		if path.Dir(file) != dir || !strings.HasSuffix(file, ".go") {
+			return ""
+		}
+	}
+
+	for _, file := range files {
+		parsed, err := parser.ParseFile(
+			token.NewFileSet(), filepath.Join(root, filepath.FromSlash(file)), nil, parser.PackageClauseOnly,
+		)
+		if err != nil {
+			// deleted files
+			continue
+		}
+		name := strings.TrimSuffix(parsed.Name.Name, "_test")
+		if name == "main" {
+			return ""
+		}
+		return name
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package modules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeScopeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestInferScope(t *testing.T) {
+	root := t.TempDir()
+	writeScopeTestFiles(t, root, map[string]string{
+		"go.work":                      "go 1.24\n\nuse (\n\t./services/billing // api\n\t./tools\n)\nuse ./\n",
+		"package.json":                 `{"workspaces": {"packages": ["web/*"]}}`,
+		"web/ui-kit/package.json":      `{"name": "@acme/ui-kit"}`,
+		"web/ui-kit/button.tsx":        "",
+		"services/billing/invoice.go":  "package billing\n",
+		"pkg/commit/modules/jira.go":   "package modules\n",
+		"pkg/commit/modules/ticket.go": "package modules\n",
+		"cmd/tool/main.go":             "package main\n",
+		"docs/guide/intro.md":          "",
+	})
+
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{
+			name:  "go.work module",
+			files: []string{"services/billing/invoice.go", "services/billing/pdf/pdf.go"},
+			want:  "billing",
+		},
+		{name: "package.json workspace", files: []string{"web/ui-kit/button.tsx"}, want: "ui-kit"},
+		{
+			name:  "go package",
+			files: []string{"pkg/commit/modules/jira.go", "pkg/commit/modules/ticket.go"},
+			want:  "modules",
+		},
+		{name: "main package named after directory", files: []string{"cmd/tool/main.go"}, want: "tool"},
+		{name: "common directory", files: []string{"docs/guide/intro.md", "docs/guide/setup.md"}, want: "guide"},
+		{name: "generic directory", files: []string{"pkg/a/a.go", "pkg/b/b.go"}, want: ""},
+		{name: "repository root", files: []string{"README.md", "pkg/commit/modules/jira.go"}, want: ""},
+		{name: "no files", files: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InferScope(root, tt.files); got != tt.want {
+				t.Errorf("InferScope() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeInferrer(t *testing.T) {
+	root := t.TempDir()
+	writeScopeTestFiles(t, root, map[string]string{"api/handler.go": "package api\n"})
+	files := func() ([]string, error) { return []string{"api/handler.go"}, nil }
+
+	tests := []struct {
+		name         string
+		mode         ScopeInference
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:         "missing scope added",
+			mode:         ScopeInferenceMissing,
+			message:      "fix!: handle nil request\n\nBody",
+			expected:     "fix(api)!: handle nil request\n\nBody",
+			shouldChange: true,
+		},
+		{
+			name:     "provider scope kept",
+			mode:     ScopeInferenceMissing,
+			message:  "fix(http): handle nil request",
+			expected: "fix(http): handle nil request",
+		},
+		{
+			name:         "provider scope corrected",
+			mode:         ScopeInferenceOverride,
+			message:      "fix(http): handle nil request",
+			expected:     "fix(api): handle nil request",
+			shouldChange: true,
+		},
+		{
+			name:     "non conventional message",
+			mode:     ScopeInferenceOverride,
+			message:  "Handle nil request",
+			expected: "Handle nil request",
+		},
+		{
+			name:     "disabled",
+			mode:     ScopeInferenceOff,
+			message:  "fix: handle nil request",
+			expected: "fix: handle nil request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inferrer := NewScopeInferrer(root, tt.mode, files)
+			result, changed, err := inferrer.TransformCommitMessage(context.Background(), "main", tt.message)
+			if err != nil {
+				t.Fatalf("TransformCommitMessage() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+}
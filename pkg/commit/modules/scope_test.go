@@ -0,0 +1,82 @@
+package modules
+
+import (
+	"context"
+	"testing"
+)
+
+func TestScopeInjector(t *testing.T) {
+	tests := []struct {
+		name         string
+		scope        string
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:         "adds scope",
+			scope:        "api",
+			message:      "feat: add endpoint",
+			expected:     "feat(api): add endpoint",
+			shouldChange: true,
+		},
+		{
+			name:         "replaces scope",
+			scope:        "api",
+			message:      "fix(handler): handle nil body",
+			expected:     "fix(api): handle nil body",
+			shouldChange: true,
+		},
+		{
+			name:         "keeps breaking mark and body",
+			scope:        "api",
+			message:      "feat!: drop v1\n\nBREAKING CHANGE: v1 removed",
+			expected:     "feat(api)!: drop v1\n\nBREAKING CHANGE: v1 removed",
+			shouldChange: true,
+		},
+		{
+			name:         "same scope",
+			scope:        "api",
+			message:      "feat(api): add endpoint",
+			expected:     "feat(api): add endpoint",
+			shouldChange: false,
+		},
+		{
+			name:         "not conventional",
+			scope:        "api",
+			message:      "Add endpoint",
+			expected:     "Add endpoint",
+			shouldChange: false,
+		},
+		{
+			name:         "unknown type",
+			scope:        "api",
+			message:      "note: add endpoint",
+			expected:     "note: add endpoint",
+			shouldChange: false,
+		},
+		{
+			name:         "empty scope",
+			scope:        "",
+			message:      "feat: add endpoint",
+			expected:     "feat: add endpoint",
+			shouldChange: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			injector := NewScopeInjector(tt.scope)
+			result, changed, err := injector.TransformCommitMessage(context.Background(), "main", tt.message)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("changed = %v, want %v", changed, tt.shouldChange)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+const TemplateModuleName = "template_rewriter"
+
+// blankLinesPattern matches runs of blank lines left by empty template sections
+var blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+
+// TemplateFields are parts of commit message available to message templates
+type TemplateFields struct {
+	Type     string // conventional commit type, empty for other messages
+	Scope    string
+	Breaking bool
+	Subject  string // description without type, scope and ticket
+	Body     string
+	Trailers string // trailer block ending the message, e.g. Co-authored-by lines
+	Ticket   string // Jira issue, Azure Boards work item or issue number of branch
+	Branch   string
+}
+
+// TemplateRewriter renders commit message with repository template, so teams with rigid
+// formats get conforming messages regardless of what providers and modules produced
+type TemplateRewriter struct {
+	template *template.Template
+}
+
+// NewTemplateRewriter parses Go text/template rendered with TemplateFields
+func NewTemplateRewriter(text string) (*TemplateRewriter, error) {
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message template: %w", err)
+	}
+	return &TemplateRewriter{template: tmpl}, nil
+}
+
+func (t *TemplateRewriter) Name() string {
+	return TemplateModuleName
+}
+
+func (t *TemplateRewriter) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (t *TemplateRewriter) TransformCommitMessage(_ context.Context, branch, message string) (string, bool, error) {
+	var rendered strings.Builder
+	if err := t.template.Execute(&rendered, ParseTemplateFields(branch, message)); err != nil {
+		return message, false, fmt.Errorf("failed to render message template: %w", err)
+	}
+
+	result := blankLinesPattern.ReplaceAllString(strings.TrimSpace(rendered.String()), "\n\n")
+	if result == "" || result == message {
+		return message, false, nil
+	}
+
+	return result, true, nil
+}
+
+// ParseTemplateFields splits commit message into template fields
+func ParseTemplateFields(branch, message string) TemplateFields {
+	fields := TemplateFields{Branch: branch, Ticket: detectBranchTicket(branch)}
+
+	subject, rest, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	fields.Subject = strings.TrimSpace(subject)
+
+	// ticket placed by other modules is re-placed by template
+	if fields.Ticket != "" {
+		ticketPattern := regexp.MustCompile(`\s*[\[(]?` + regexp.QuoteMeta(fields.Ticket) + `\b[\])]?:?\s*`)
+		fields.Subject = strings.TrimSpace(ticketPattern.ReplaceAllString(fields.Subject, " "))
+	}
+
+	if matches := conventionalHeaderPattern.FindStringSubmatch(fields.Subject); matches != nil {
+		fields.Type = matches[1]
+		fields.Scope = strings.Trim(matches[2], "()")
+		fields.Breaking = matches[3] == "!"
+		fields.Subject = matches[4]
+	}
+
+	paragraphs := strings.Split(strings.TrimSpace(rest), "\n\n")
+	if last := len(paragraphs) - 1; paragraphs[last] != "" && isTrailerBlock(paragraphs[last]) {
+		fields.Trailers = paragraphs[last]
+		paragraphs = paragraphs[:last]
+	}
+	fields.Body = strings.TrimSpace(strings.Join(paragraphs, "\n\n"))
+	fields.Breaking = fields.Breaking || breakingChangeFooterPattern.MatchString(rest)
+
+	return fields
+}
+
+// detectBranchTicket returns Jira issue, Azure Boards work item or issue number found in branch name
+func detectBranchTicket(branch string) string {
+	if jiraID := (&JIRATaskDetector{}).detectJiraID(branch); jiraID != "" {
+		return jiraID
+	}
+	if workItems := detectAzureWorkItems(branch); len(workItems) > 0 {
+		return "AB#" + workItems[0]
+	}
+	if issues := detectBranchIssues(branch); len(issues) > 0 {
+		return "#" + issues[0]
+	}
+	return ""
+}
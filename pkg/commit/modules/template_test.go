@@ -0,0 +1,80 @@
+package modules
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTemplateRewriter(t *testing.T) {
+	const rigid = "{{.Ticket}} {{.Type}}{{if .Scope}}[{{.Scope}}]{{end}}: {{.Subject}}\n\n{{.Body}}\n\n{{.Trailers}}"
+
+	tests := []struct {
+		name         string
+		template     string
+		branch       string
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:         "re-places ticket and scope",
+			template:     rigid,
+			branch:       "feature/TASK-12-login",
+			message:      "[TASK-12] feat(auth): add login\n\nAdds form.\n\nCo-authored-by: Dev <dev@example.com>",
+			expected:     "TASK-12 feat[auth]: add login\n\nAdds form.\n\nCo-authored-by: Dev <dev@example.com>",
+			shouldChange: true,
+		},
+		{
+			name:         "empty sections collapse",
+			template:     rigid,
+			branch:       "AB#34-cleanup",
+			message:      "chore: remove dead code",
+			expected:     "AB#34 chore: remove dead code",
+			shouldChange: true,
+		},
+		{
+			name:         "breaking and issue number",
+			template:     "{{.Subject}}{{if .Breaking}} [BREAKING]{{end}} ({{.Ticket}})",
+			branch:       "fix/123-api",
+			message:      "fix(api)!: rename endpoint (#123)",
+			expected:     "rename endpoint [BREAKING] (#123)",
+			shouldChange: true,
+		},
+		{
+			name:     "already conforming",
+			template: "{{.Type}}: {{.Subject}}",
+			branch:   "main",
+			message:  "docs: update readme",
+			expected: "docs: update readme",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rewriter, err := NewTemplateRewriter(tt.template)
+			if err != nil {
+				t.Fatalf("NewTemplateRewriter() error = %v", err)
+			}
+			result, changed, err := rewriter.TransformCommitMessage(context.Background(), tt.branch, tt.message)
+			if err != nil {
+				t.Fatalf("TransformCommitMessage() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+}
+
+func TestNewTemplateRewriter_UnknownField(t *testing.T) {
+	rewriter, err := NewTemplateRewriter("{{.Summary}}")
+	if err != nil {
+		t.Fatalf("NewTemplateRewriter() error = %v", err)
+	}
+	if _, _, err := rewriter.TransformCommitMessage(context.Background(), "main", "feat: x"); err == nil {
+		t.Error("TransformCommitMessage() expected error for unknown field")
+	}
+}
@@ -0,0 +1,116 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+type TicketPosition string
+
+const TicketModuleName = "ticket_detector"
+
+const (
+	TicketPositionPrefix TicketPosition = "prefix" // YT-12 feat: add endpoint
+	TicketPositionSuffix TicketPosition = "suffix" // feat: add endpoint YT-12
+	TicketPositionFooter TicketPosition = "footer" // last line of message body, e.g. Refs: YT-12
+)
+
+// DefaultTicketFormat renders ticket as git trailer
+const DefaultTicketFormat = "Refs: {{.Ticket}}"
+
+// ticketData is available to ticket format templates
+type ticketData struct {
+	Ticket string
+	Branch string
+}
+
+// TicketDetector finds ticket in branch name with user supplied regex and adds it
+// to commit message rendered with user supplied template, for trackers without native support
+type TicketDetector struct {
+	pattern  *regexp.Regexp
+	format   *template.Template
+	position TicketPosition
+}
+
+// NewTicketDetector compiles branch pattern and message format, ticket is the capture group
+// named ticket, the first capture group or the whole match, in this order
+func NewTicketDetector(pattern, format string, position TicketPosition) (*TicketDetector, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket pattern: %w", err)
+	}
+
+	if format == "" {
+		format = DefaultTicketFormat
+	}
+	tmpl, err := template.New("ticket").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket format: %w", err)
+	}
+
+	switch position {
+	case TicketPositionPrefix, TicketPositionSuffix, TicketPositionFooter:
+	case "":
+		position = TicketPositionFooter
+	default:
+		return nil, fmt.Errorf("invalid ticket position: %s (must be prefix, suffix or footer)", position)
+	}
+
+	return &TicketDetector{pattern: re, format: tmpl, position: position}, nil
+}
+
+func (d *TicketDetector) Name() string {
+	return TicketModuleName
+}
+
+func (d *TicketDetector) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (d *TicketDetector) TransformCommitMessage(_ context.Context, branch, message string) (string, bool, error) {
+	ticket := d.detectTicket(branch)
+	if ticket == "" || strings.Contains(message, ticket) {
+		return message, false, nil
+	}
+
+	var b strings.Builder
+	if err := d.format.Execute(&b, ticketData{Ticket: ticket, Branch: branch}); err != nil {
+		return message, false, fmt.Errorf("failed to render ticket format: %w", err)
+	}
+	rendered := strings.TrimSpace(b.String())
+	if rendered == "" {
+		return message, false, nil
+	}
+
+	if d.position == TicketPositionFooter {
+		return strings.TrimRight(message, "\n") + "\n\n" + rendered, true, nil
+	}
+
+	lines := strings.SplitN(message, "\n", 2)
+	if d.position == TicketPositionPrefix {
+		lines[0] = rendered + " " + lines[0]
+	} else {
+		lines[0] = lines[0] + " " + rendered
+	}
+
+	return strings.Join(lines, "\n"), true, nil
+}
+
+func (d *TicketDetector) detectTicket(branch string) string {
+	matches := d.pattern.FindStringSubmatch(branch)
+	if matches == nil {
+		return ""
+	}
+
+	if i := d.pattern.SubexpIndex("ticket"); i > 0 {
+		return matches[i]
+	}
+	if len(matches) > 1 {
+		return matches[1]
+	}
+
+	return matches[0]
+}
@@ -0,0 +1,104 @@
+package modules
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTicketDetector(t *testing.T) {
+	tests := []struct {
+		name         string
+		pattern      string
+		format       string
+		position     TicketPosition
+		branch       string
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:         "default format in footer",
+			pattern:      `([A-Z]+-\d+)`,
+			branch:       "feature/YT-12-search",
+			message:      "feat: add search",
+			expected:     "feat: add search\n\nRefs: YT-12",
+			shouldChange: true,
+		},
+		{
+			name:         "named group and prefix",
+			pattern:      `^(?:\w+/)?(?P<ticket>\d+)-`,
+			format:       "#{{.Ticket}}",
+			position:     TicketPositionPrefix,
+			branch:       "bugfix/4821-crash",
+			message:      "fix: crash on start\n\nBody.",
+			expected:     "#4821 fix: crash on start\n\nBody.",
+			shouldChange: true,
+		},
+		{
+			name:         "whole match and suffix",
+			pattern:      `RM\d+`,
+			format:       "({{.Ticket}})",
+			position:     TicketPositionSuffix,
+			branch:       "RM77-cleanup",
+			message:      "chore: cleanup",
+			expected:     "chore: cleanup (RM77)",
+			shouldChange: true,
+		},
+		{
+			name:         "already mentioned",
+			pattern:      `([A-Z]+-\d+)`,
+			branch:       "feature/YT-12-search",
+			message:      "feat: add search\n\nRefs: YT-12",
+			expected:     "feat: add search\n\nRefs: YT-12",
+			shouldChange: false,
+		},
+		{
+			name:         "no match",
+			pattern:      `([A-Z]+-\d+)`,
+			branch:       "main",
+			message:      "feat: add search",
+			expected:     "feat: add search",
+			shouldChange: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector, err := NewTicketDetector(tt.pattern, tt.format, tt.position)
+			if err != nil {
+				t.Fatalf("NewTicketDetector() error = %v", err)
+			}
+			result, changed, err := detector.TransformCommitMessage(context.Background(), tt.branch, tt.message)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("changed = %v, want %v", changed, tt.shouldChange)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewTicketDetector_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		format   string
+		position TicketPosition
+	}{
+		{name: "invalid pattern", pattern: `([A-Z]+`},
+		{name: "invalid format", pattern: `\d+`, format: "{{.Ticket"},
+		{name: "invalid position", pattern: `\d+`, position: "infix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTicketDetector(tt.pattern, tt.format, tt.position); err == nil {
+				t.Errorf("NewTicketDetector() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package modules
+
+import (
+	"context"
+	"strings"
+)
+
+const TrailersModuleName = "trailers"
+
+// TrailerAppender appends fixed trailers, e.g. Signed-off-by or Reviewed-by, missing in the message
+type TrailerAppender struct {
+	trailers []string
+}
+
+func NewTrailerAppender(trailers []string) *TrailerAppender {
+	return &TrailerAppender{trailers: trailers}
+}
+
+// IsTrailer reports whether line is git trailer in "Key: value" form
+func IsTrailer(line string) bool {
+	return trailerPattern.MatchString(line)
+}
+
+func (t *TrailerAppender) Name() string {
+	return TrailersModuleName
+}
+
+func (t *TrailerAppender) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (t *TrailerAppender) TransformCommitMessage(_ context.Context, _, message string) (string, bool, error) {
+	present := make(map[string]bool)
+	for _, line := range strings.Split(message, "\n") {
+		present[strings.ToLower(strings.TrimSpace(line))] = true
+	}
+
+	var trailers []string
+	for _, trailer := range t.trailers {
+		trailer = strings.TrimSpace(trailer)
+		if !IsTrailer(trailer) || present[strings.ToLower(trailer)] {
+			continue
+		}
+		present[strings.ToLower(trailer)] = true
+		trailers = append(trailers, trailer)
+	}
+	if len(trailers) == 0 {
+		return message, false, nil
+	}
+
+	return appendTrailers(message, trailers), true, nil
+}
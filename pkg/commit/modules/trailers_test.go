@@ -0,0 +1,59 @@
+package modules
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTrailerAppender_TransformCommitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		trailers []string
+		message  string
+		want     string
+		wantDone bool
+	}{
+		{
+			name:     "adds trailer block",
+			trailers: []string{"Signed-off-by: Jane Doe <jane@example.com>"},
+			message:  "feat: add export",
+			want:     "feat: add export\n\nSigned-off-by: Jane Doe <jane@example.com>",
+			wantDone: true,
+		},
+		{
+			name:     "extends existing trailer block",
+			trailers: []string{"Signed-off-by: Jane Doe <jane@example.com>"},
+			message:  "feat: add export\n\nBody text.\n\nCo-authored-by: John <john@example.com>",
+			want: "feat: add export\n\nBody text.\n\nCo-authored-by: John <john@example.com>\n" +
+				"Signed-off-by: Jane Doe <jane@example.com>",
+			wantDone: true,
+		},
+		{
+			name:     "skips trailer already present",
+			trailers: []string{"Reviewed-by: Team", "Signed-off-by: Jane Doe <jane@example.com>"},
+			message:  "fix: typo\n\nsigned-off-by: Jane Doe <jane@example.com>",
+			want:     "fix: typo\n\nsigned-off-by: Jane Doe <jane@example.com>\nReviewed-by: Team",
+			wantDone: true,
+		},
+		{
+			name:     "ignores invalid trailers",
+			trailers: []string{"not a trailer", ""},
+			message:  "fix: typo",
+			want:     "fix: typo",
+			wantDone: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appender := NewTrailerAppender(tt.trailers)
+			got, done, err := appender.TransformCommitMessage(context.Background(), "", tt.message)
+			if err != nil {
+				t.Fatalf("TransformCommitMessage() unexpected error = %v", err)
+			}
+			if got != tt.want || done != tt.wantDone {
+				t.Errorf("TransformCommitMessage() = %q, %v, want %q, %v", got, done, tt.want, tt.wantDone)
+			}
+		})
+	}
+}
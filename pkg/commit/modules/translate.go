@@ -0,0 +1,84 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type TranslationMode string
+
+const TranslateModuleName = "message_translator"
+
+const (
+	TranslationModeReplace   TranslationMode = "replace"   // message is replaced by translation
+	TranslationModeBilingual TranslationMode = "bilingual" // translation follows original message
+)
+
+// Translator translates commit message text into language, e.g. with AI providers
+type Translator func(ctx context.Context, text, language string) (string, error)
+
+// MessageTranslator translates commit messages, conventional prefix and trailers
+// are machine readable and stay as they are
+type MessageTranslator struct {
+	language  string
+	mode      TranslationMode
+	translate Translator
+}
+
+func NewMessageTranslator(language string, mode TranslationMode, translator Translator) *MessageTranslator {
+	return &MessageTranslator{language: language, mode: mode, translate: translator}
+}
+
+func (m *MessageTranslator) Name() string {
+	return TranslateModuleName
+}
+
+func (m *MessageTranslator) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (m *MessageTranslator) TransformCommitMessage(ctx context.Context, _, message string) (string, bool, error) {
+	message = strings.TrimSpace(message)
+
+	subject, body, _ := strings.Cut(message, "\n")
+	prefix, description := "", subject
+	if matches := conventionalHeaderPattern.FindStringSubmatch(subject); matches != nil {
+		prefix = matches[1] + matches[2] + matches[3] + ": "
+		description = matches[4]
+	}
+
+	var trailers string
+	paragraphs := strings.Split(strings.TrimSpace(body), "\n\n")
+	if last := len(paragraphs) - 1; paragraphs[last] != "" && isTrailerBlock(paragraphs[last]) {
+		trailers = paragraphs[last]
+		paragraphs = paragraphs[:last]
+	}
+	body = strings.TrimSpace(strings.Join(paragraphs, "\n\n"))
+
+	text := description
+	if body != "" {
+		text += "\n\n" + body
+	}
+	translated, err := m.translate(ctx, text, m.language)
+	if err != nil {
+		return message, false, fmt.Errorf("failed to translate commit message: %w", err)
+	}
+	translated = strings.TrimSpace(translated)
+	if translated == "" || translated == text {
+		return message, false, nil
+	}
+
+	var result string
+	switch m.mode {
+	case TranslationModeBilingual:
+		result = prefix + text + "\n\n" + translated
+	default:
+		result = prefix + translated
+	}
+	if trailers != "" {
+		result += "\n\n" + trailers
+	}
+
+	return result, true, nil
+}
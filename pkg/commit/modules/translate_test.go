@@ -0,0 +1,71 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMessageTranslator(t *testing.T) {
+	translations := map[string]string{
+		"add login":                     "Anmeldung hinzufügen",
+		"add login\n\nUses OAuth flow.": "Anmeldung hinzufügen\n\nVerwendet OAuth-Ablauf.",
+	}
+	translator := func(_ context.Context, text, language string) (string, error) {
+		if language != "German" {
+			return "", errors.New("unexpected language")
+		}
+		if translated, ok := translations[text]; ok {
+			return translated, nil
+		}
+		return "", errors.New("provider unavailable")
+	}
+
+	tests := []struct {
+		name         string
+		mode         TranslationMode
+		message      string
+		expected     string
+		shouldChange bool
+		wantErr      bool
+	}{
+		{
+			name:    "replace keeps prefix and trailers",
+			mode:    TranslationModeReplace,
+			message: "feat(auth): add login\n\nUses OAuth flow.\n\nCo-authored-by: Dev <dev@example.com>",
+			expected: "feat(auth): Anmeldung hinzufügen\n\nVerwendet OAuth-Ablauf.\n\n" +
+				"Co-authored-by: Dev <dev@example.com>",
+			shouldChange: true,
+		},
+		{
+			name:         "bilingual",
+			mode:         TranslationModeBilingual,
+			message:      "feat: add login",
+			expected:     "feat: add login\n\nAnmeldung hinzufügen",
+			shouldChange: true,
+		},
+		{
+			name:     "translation failure",
+			mode:     TranslationModeReplace,
+			message:  "fix: crash",
+			expected: "fix: crash",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			module := NewMessageTranslator("German", tt.mode, translator)
+			result, changed, err := module.TransformCommitMessage(context.Background(), "main", tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransformCommitMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+}
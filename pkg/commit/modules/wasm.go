@@ -0,0 +1,199 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+const (
+	// defaultWASMMemoryLimitPages caps plugin memory at 128 MiB, enough for Go runtime of wasip1 builds
+	defaultWASMMemoryLimitPages = 2048
+	// defaultWASMCallTimeout stops plugins stuck in a loop, they only transform short texts
+	defaultWASMCallTimeout = 10 * time.Second
+)
+
+// WASMModule runs WebAssembly plugin as module. Plugins are WASI commands speaking the protocol
+// of external modules: JSON input on stdin and transformed text on stdout. They are sandboxed,
+// without access to files, network, environment or clock beyond what WASI gives by default.
+type WASMModule struct {
+	name     string
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	timeout  time.Duration
+}
+
+// WASMPlugins are plugins compiled by one runtime, which has to be closed once they are not used
+type WASMPlugins struct {
+	Modules []*WASMModule
+	runtime wazero.Runtime
+}
+
+// Close releases runtime and compiled code of plugins
+func (p *WASMPlugins) Close(ctx context.Context) error {
+	if p == nil || p.runtime == nil {
+		return nil
+	}
+	return p.runtime.Close(ctx)
+}
+
+type wasmConfig struct {
+	cacheDir         string
+	memoryLimitPages uint32
+	callTimeout      time.Duration
+}
+
+// WASMOption configures plugins loaded by LoadWASMModules
+type WASMOption func(c *wasmConfig)
+
+// WithCompilationCacheDir caches compiled code of plugins in dir
+func WithCompilationCacheDir(dir string) WASMOption {
+	return func(c *wasmConfig) {
+		c.cacheDir = dir
+	}
+}
+
+// WithMemoryLimitPages caps memory of every plugin instance, page is 64 KiB
+func WithMemoryLimitPages(pages uint32) WASMOption {
+	return func(c *wasmConfig) {
+		c.memoryLimitPages = pages
+	}
+}
+
+// WithCallTimeout limits time of every plugin call, zero disables the limit
+func WithCallTimeout(timeout time.Duration) WASMOption {
+	return func(c *wasmConfig) {
+		c.callTimeout = timeout
+	}
+}
+
+// LoadWASMModules compiles all .wasm plugins of dir in name order, missing dir means no plugins
+func LoadWASMModules(ctx context.Context, dir string, options ...WASMOption) (*WASMPlugins, error) {
+	wasm := wasmConfig{memoryLimitPages: defaultWASMMemoryLimitPages, callTimeout: defaultWASMCallTimeout}
+	for _, option := range options {
+		option(&wasm)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &WASMPlugins{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".wasm") {
+			files = append(files, entry.Name())
+		}
+	}
+	if len(files) == 0 {
+		return &WASMPlugins{}, nil
+	}
+	sort.Strings(files)
+
+	config := wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(wasm.memoryLimitPages)
+	if wasm.cacheDir != "" {
+		if cache, err := wazero.NewCompilationCacheWithDir(wasm.cacheDir); err == nil {
+			config = config.WithCompilationCache(cache)
+		}
+	}
+	runtime := wazero.NewRuntimeWithConfig(ctx, config)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to initialize WASI: %w", err)
+	}
+
+	plugins := make([]*WASMModule, 0, len(files))
+	for _, file := range files {
+		code, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			_ = runtime.Close(ctx)
+			return nil, fmt.Errorf("failed to read plugin %s: %w", file, err)
+		}
+		compiled, err := runtime.CompileModule(ctx, code)
+		if err != nil {
+			_ = runtime.Close(ctx)
+			return nil, fmt.Errorf("failed to compile plugin %s: %w", file, err)
+		}
+		plugins = append(plugins, &WASMModule{
+			name:     strings.TrimSuffix(file, ".wasm"),
+			runtime:  runtime,
+			compiled: compiled,
+			timeout:  wasm.callTimeout,
+		})
+	}
+
+	return &WASMPlugins{Modules: plugins, runtime: runtime}, nil
+}
+
+func (w *WASMModule) Name() string {
+	return "wasm:" + w.name
+}
+
+func (w *WASMModule) TransformPrompt(ctx context.Context, branch, prompt string) (string, bool, error) {
+	return w.run(ctx, ExternalHookPrompt, branch, prompt)
+}
+
+func (w *WASMModule) TransformCommitMessage(ctx context.Context, branch, message string) (string, bool, error) {
+	return w.run(ctx, ExternalHookCommitMessage, branch, message)
+}
+
+// run instantiates plugin for every call, WASI commands exit after their _start
+func (w *WASMModule) run(ctx context.Context, hook, branch, text string) (string, bool, error) {
+	input, err := json.Marshal(ExternalInput{Hook: hook, Branch: branch, Message: text})
+	if err != nil {
+		return text, false, fmt.Errorf("failed to encode module input: %w", err)
+	}
+
+	// runtime closes instances once their context is done, so plugin stuck in a loop is stopped
+	if w.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	config := wazero.NewModuleConfig().
+		WithName("").
+		WithArgs(w.name).
+		WithStdin(bytes.NewReader(input)).
+		WithStdout(&stdout).
+		WithStderr(&stderr)
+
+	instance, err := w.runtime.InstantiateModule(ctx, w.compiled, config)
+	if instance != nil {
+		_ = instance.Close(ctx)
+	}
+	if exitErr := (*sys.ExitError)(nil); errors.As(err, &exitErr) && exitErr.ExitCode() == 0 {
+		err = nil
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return text, false, fmt.Errorf("plugin %s failed: %w: %s", w.name, err, msg)
+		}
+		return text, false, fmt.Errorf("plugin %s failed: %w", w.name, err)
+	}
+
+	output := strings.TrimRight(stdout.String(), "\n")
+	if strings.TrimSpace(output) == "" || output == text {
+		return text, false, nil
+	}
+
+	return output, true, nil
+}
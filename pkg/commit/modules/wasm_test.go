@@ -0,0 +1,110 @@
+package modules
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// hand-assembled WASI commands, writing "feat: add login from plugin\n" to stdout and trapping
+const (
+	wasmWritingPlugin = "0061736d01000000010c0260047f7f7f7f017f60000002230116776173695f736e617073686f745f70726576" +
+		"696577310866645f77726974650000030201010503010001071302066d656d6f72790200065f737461727400010a0f010d00" +
+		"410141004101410810001a0b0b32010041000b2c100000001c0000000000000000000000666561743a20616464206c6f6769" +
+		"6e2066726f6d20706c7567696e0a"
+	wasmTrappingPlugin = "0061736d01000000010c0260047f7f7f7f017f60000002230116776173695f736e617073686f745f7072657669" +
+		"6577310866645f77726974650000030201010503010001071302066d656d6f72790200065f737461727400010a05010300000b" +
+		"0b16010041000b1010000000000000000000000000000000"
+	// wasmLoopingPlugin never returns from its _start
+	wasmLoopingPlugin = "0061736d0100000001040160000003020100070a01065f737461727400000a0901070003400c000b0b"
+)
+
+// writeTestPlugins writes plugins given as hex code by file name into new directory
+func writeTestPlugins(t *testing.T, plugins map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for file, code := range plugins {
+		content, err := hex.DecodeString(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadWASMModules(t *testing.T) {
+	ctx := context.Background()
+
+	empty, err := LoadWASMModules(ctx, filepath.Join(t.TempDir(), "missing"))
+	if err != nil || len(empty.Modules) != 0 {
+		t.Fatalf("LoadWASMModules() = %v, %v, want no plugins for missing directory", empty, err)
+	}
+
+	dir := writeTestPlugins(t, map[string]string{
+		"a-writer.wasm": wasmWritingPlugin,
+		"b-trap.wasm":   wasmTrappingPlugin,
+		"README.md":     "",
+	})
+
+	loaded, err := LoadWASMModules(ctx, dir, WithCompilationCacheDir(t.TempDir()))
+	if err != nil {
+		t.Fatalf("LoadWASMModules() error = %v", err)
+	}
+	defer func() {
+		if err := loaded.Close(ctx); err != nil {
+			t.Errorf("Close() error = %v", err)
+		}
+	}()
+	plugins := loaded.Modules
+	if len(plugins) != 2 || plugins[0].Name() != "wasm:a-writer" || plugins[1].Name() != "wasm:b-trap" {
+		t.Fatalf("LoadWASMModules() loaded %d plugins, want a-writer and b-trap", len(plugins))
+	}
+
+	result, changed, err := plugins[0].TransformCommitMessage(ctx, "main", "feat: add login")
+	if err != nil {
+		t.Fatalf("TransformCommitMessage() error = %v", err)
+	}
+	if result != "feat: add login from plugin" || !changed {
+		t.Errorf("TransformCommitMessage() = %q, %v, want plugin output", result, changed)
+	}
+
+	result, changed, err = plugins[1].TransformPrompt(ctx, "main", "prompt")
+	if err == nil {
+		t.Error("TransformPrompt() expected error of trapping plugin")
+	}
+	if result != "prompt" || changed {
+		t.Errorf("TransformPrompt() = %q, %v, want prompt unchanged", result, changed)
+	}
+}
+
+func TestLoadWASMModules_Limits(t *testing.T) {
+	ctx := context.Background()
+
+	// writing plugin declares one page of memory
+	dir := writeTestPlugins(t, map[string]string{"writer.wasm": wasmWritingPlugin})
+	if _, err := LoadWASMModules(ctx, dir, WithMemoryLimitPages(0)); err == nil {
+		t.Error("LoadWASMModules() expected error of plugin exceeding memory limit")
+	}
+
+	dir = writeTestPlugins(t, map[string]string{"loop.wasm": wasmLoopingPlugin})
+	loaded, err := LoadWASMModules(ctx, dir, WithCallTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("LoadWASMModules() error = %v", err)
+	}
+	defer func() { _ = loaded.Close(ctx) }()
+
+	result, changed, err := loaded.Modules[0].TransformCommitMessage(ctx, "main", "feat: add login")
+	if err == nil {
+		t.Error("TransformCommitMessage() expected error of plugin exceeding call timeout")
+	}
+	if result != "feat: add login" || changed {
+		t.Errorf("TransformCommitMessage() = %q, %v, want message unchanged", result, changed)
+	}
+}
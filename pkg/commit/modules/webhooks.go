@@ -0,0 +1,191 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const LifecycleWebhooksName = "lifecycle_webhooks"
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// Lifecycle events webhooks are fired on
+const (
+	EventSuggestionsGenerated = "suggestions-generated"
+	EventCommitCreated        = "commit-created"
+	EventTagCreated           = "tag-created"
+	EventPushed               = "pushed"
+)
+
+// LifecycleEvents lists all events in order they are fired
+var LifecycleEvents = []string{EventSuggestionsGenerated, EventCommitCreated, EventTagCreated, EventPushed}
+
+// SuggestionsEvent describes generated suggestions to notifiers
+type SuggestionsEvent struct {
+	Repo        string
+	Branch      string
+	Suggestions map[string]string // message by provider
+}
+
+// webhookPayload is body of every lifecycle webhook request, fields unrelated to event are omitted
+type webhookPayload struct {
+	Event           string            `json:"event"`
+	Timestamp       string            `json:"timestamp"`
+	Repo            string            `json:"repo,omitempty"`
+	Branch          string            `json:"branch,omitempty"`
+	Commit          *webhookCommit    `json:"commit,omitempty"`
+	Tag             string            `json:"tag,omitempty"`
+	MergeRequestURL string            `json:"merge_request_url,omitempty"`
+	Suggestions     map[string]string `json:"suggestions,omitempty"`
+}
+
+type webhookCommit struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+}
+
+// LifecycleWebhooks posts JSON payload of lifecycle events to webhooks, requests are signed
+// with HMAC-SHA256 of body in X-Commit-Signature-256 header when secret is set
+type LifecycleWebhooks struct {
+	urls   []string
+	events map[string]bool
+	secret string
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewLifecycleWebhooks creates webhooks fired on events, all events when none are given
+func NewLifecycleWebhooks(urls, events []string, secret string) *LifecycleWebhooks {
+	if len(events) == 0 {
+		events = LifecycleEvents
+	}
+	enabled := make(map[string]bool, len(events))
+	for _, event := range events {
+		enabled[strings.ToLower(strings.TrimSpace(event))] = true
+	}
+	return &LifecycleWebhooks{
+		urls:   urls,
+		events: enabled,
+		secret: secret,
+		client: &http.Client{Timeout: defaultWebhookTimeout},
+		now:    time.Now,
+	}
+}
+
+func (w *LifecycleWebhooks) Name() string {
+	return LifecycleWebhooksName
+}
+
+func (w *LifecycleWebhooks) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		w.client.Timeout = timeout
+	}
+}
+
+// NotifySuggestions fires suggestions-generated event
+func (w *LifecycleWebhooks) NotifySuggestions(ctx context.Context, event SuggestionsEvent) error {
+	return w.fire(ctx, webhookPayload{
+		Event:       EventSuggestionsGenerated,
+		Repo:        event.Repo,
+		Branch:      event.Branch,
+		Suggestions: event.Suggestions,
+	})
+}
+
+// NotifyCommit fires commit-created event, then tag-created and pushed when commit was tagged and pushed
+func (w *LifecycleWebhooks) NotifyCommit(ctx context.Context, event CommitEvent) error {
+	payload := webhookPayload{
+		Repo:   event.Repo,
+		Branch: event.Branch,
+		Commit: &webhookCommit{
+			Hash:    event.Hash,
+			Subject: event.Subject(),
+			Message: event.Message,
+			URL:     event.URL,
+		},
+		Tag:             event.Tag,
+		MergeRequestURL: event.MergeRequestURL,
+	}
+
+	events := []string{EventCommitCreated}
+	if event.Tag != "" {
+		events = append(events, EventTagCreated)
+	}
+	if event.Pushed {
+		events = append(events, EventPushed)
+	}
+
+	var failed []string
+	for _, name := range events {
+		payload.Event = name
+		if err := w.fire(ctx, payload); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// fire posts payload to every webhook when its event is enabled, all webhooks are tried even when some fail
+func (w *LifecycleWebhooks) fire(ctx context.Context, payload webhookPayload) error {
+	if !w.events[payload.Event] {
+		return nil
+	}
+	payload.Timestamp = w.now().UTC().Format(time.RFC3339)
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s webhook payload: %w", payload.Event, err)
+	}
+
+	var failed []string
+	for _, url := range w.urls {
+		if err := w.post(ctx, url, payload.Event, body); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to fire %s webhooks: %s", payload.Event, strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func (w *LifecycleWebhooks) post(ctx context.Context, url, event string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Commit-Event", event)
+	if w.secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.secret))
+		mac.Write(body)
+		req.Header.Set("X-Commit-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		// url may carry a token, it is not part of the error
+		return fmt.Errorf("request to %s failed", webhookHost(url))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s: %s", webhookHost(url), resp.Status, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
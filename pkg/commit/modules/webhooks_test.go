@@ -0,0 +1,112 @@
+package modules
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLifecycleWebhooks(t *testing.T) {
+	type request struct {
+		event     string
+		signature string
+		payload   webhookPayload
+		body      []byte
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload webhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		requests = append(requests, request{
+			event: r.Header.Get("X-Commit-Event"), signature: r.Header.Get("X-Commit-Signature-256"),
+			payload: payload, body: body,
+		})
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		events     []string
+		event      CommitEvent
+		wantEvents []string
+	}{
+		{
+			name: "all events of pushed release",
+			event: CommitEvent{
+				Repo: "o/r", Branch: "main", Hash: "abc", Message: "feat: x", Tag: "v1.0.0", Pushed: true,
+			},
+			wantEvents: []string{EventCommitCreated, EventTagCreated, EventPushed},
+		},
+		{
+			name:       "local commit",
+			event:      CommitEvent{Repo: "o/r", Branch: "main", Hash: "abc", Message: "feat: x"},
+			wantEvents: []string{EventCommitCreated},
+		},
+		{
+			name:       "selected events",
+			events:     []string{"pushed"},
+			event:      CommitEvent{Repo: "o/r", Branch: "main", Hash: "abc", Message: "feat: x", Pushed: true},
+			wantEvents: []string{EventPushed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			webhooks := NewLifecycleWebhooks([]string{server.URL}, tt.events, "")
+			if err := webhooks.NotifyCommit(context.Background(), tt.event); err != nil {
+				t.Fatalf("NotifyCommit() error = %v", err)
+			}
+			if len(requests) != len(tt.wantEvents) {
+				t.Fatalf("fired %d webhooks, want %v", len(requests), tt.wantEvents)
+			}
+			for i, want := range tt.wantEvents {
+				got := requests[i]
+				if got.event != want || got.payload.Event != want {
+					t.Errorf("webhook %d event = %s (header %s), want %s", i, got.payload.Event, got.event, want)
+				}
+				commit := got.payload.Commit
+				if commit == nil || commit.Hash != "abc" || commit.Subject != "feat: x" {
+					t.Errorf("webhook %d commit = %+v, want commit abc", i, got.payload.Commit)
+				}
+				if got.signature != "" {
+					t.Errorf("webhook %d signed without secret", i)
+				}
+			}
+		})
+	}
+
+	t.Run("signed suggestions", func(t *testing.T) {
+		requests = nil
+		webhooks := NewLifecycleWebhooks([]string{server.URL}, nil, "secret")
+		webhooks.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+		event := SuggestionsEvent{Repo: "o/r", Branch: "main", Suggestions: map[string]string{"openai": "feat: x"}}
+		if err := webhooks.NotifySuggestions(context.Background(), event); err != nil {
+			t.Fatalf("NotifySuggestions() error = %v", err)
+		}
+		if len(requests) != 1 {
+			t.Fatalf("fired %d webhooks, want 1", len(requests))
+		}
+		got := requests[0]
+		if got.payload.Event != EventSuggestionsGenerated || got.payload.Suggestions["openai"] != "feat: x" ||
+			got.payload.Timestamp != "2026-01-02T03:04:05Z" || got.payload.Commit != nil {
+			t.Errorf("payload = %+v, want suggestions without commit", got.payload)
+		}
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(got.body)
+		if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); got.signature != want {
+			t.Errorf("signature = %s, want %s", got.signature, want)
+		}
+	})
+}
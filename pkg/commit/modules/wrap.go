@@ -0,0 +1,160 @@
+package modules
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+type SubjectOverflow string
+
+const WrapModuleName = "message_wrapper"
+
+const (
+	SubjectOverflowTruncate SubjectOverflow = "truncate" // words past the limit are dropped
+	SubjectOverflowWrap     SubjectOverflow = "wrap"     // words past the limit open the body
+)
+
+var (
+	// listItemPattern matches indentation and marker of list items, continuation lines are aligned to it
+	listItemPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+`)
+	// trailerPattern matches git trailers, e.g. Signed-off-by: Name <email>, they must stay on one line
+	trailerPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*: \S`)
+)
+
+// MessageWrapper enforces the 50/72 rule: limits subject length, hard-wraps body lines
+// and separates body from subject with a blank line
+type MessageWrapper struct {
+	subjectLimit int
+	bodyWidth    int
+	overflow     SubjectOverflow
+}
+
+// NewMessageWrapper creates wrapper, zero subjectLimit or bodyWidth disables the respective rule
+func NewMessageWrapper(subjectLimit, bodyWidth int, overflow SubjectOverflow) *MessageWrapper {
+	if overflow == "" {
+		overflow = SubjectOverflowTruncate
+	}
+	return &MessageWrapper{
+		subjectLimit: subjectLimit,
+		bodyWidth:    bodyWidth,
+		overflow:     overflow,
+	}
+}
+
+func (w *MessageWrapper) Name() string {
+	return WrapModuleName
+}
+
+func (w *MessageWrapper) TransformPrompt(_ context.Context, _, prompt string) (string, bool, error) {
+	return prompt, false, nil
+}
+
+func (w *MessageWrapper) TransformCommitMessage(_ context.Context, _, message string) (string, bool, error) {
+	original := strings.TrimRight(message, "\n")
+	lines := strings.Split(original, "\n")
+
+	subject := strings.TrimSpace(lines[0])
+	body := lines[1:]
+	for len(body) > 0 && strings.TrimSpace(body[0]) == "" {
+		body = body[1:]
+	}
+
+	if w.subjectLimit > 0 && utf8.RuneCountInString(subject) > w.subjectLimit {
+		var rest string
+		subject, rest = splitSubject(subject, w.subjectLimit)
+		if w.overflow == SubjectOverflowWrap && rest != "" {
+			if len(body) > 0 {
+				body = append([]string{rest, ""}, body...)
+			} else {
+				body = []string{rest}
+			}
+		}
+	}
+
+	if w.bodyWidth > 0 {
+		body = wrapBody(body, w.bodyWidth)
+	}
+
+	result := subject
+	if len(body) > 0 {
+		result += "\n\n" + strings.Join(body, "\n")
+	}
+	if result == original {
+		return message, false, nil
+	}
+
+	return result, true, nil
+}
+
+// splitSubject cuts subject at last word boundary within limit,
+// single word longer than limit is cut as is
+func splitSubject(subject string, limit int) (string, string) {
+	runes := []rune(subject)
+	cut := limit
+	for i := limit; i > 0; i-- {
+		if runes[i] == ' ' {
+			cut = i
+			break
+		}
+	}
+
+	head := strings.TrimRight(string(runes[:cut]), " ,;:-")
+	rest := strings.TrimSpace(string(runes[cut:]))
+	return head, rest
+}
+
+// wrapBody hard-wraps long prose lines at width, keeping list indentation;
+// code, trailers and lines without spaces (e.g. URLs) are left intact
+func wrapBody(lines []string, width int) []string {
+	var (
+		wrapped []string
+		inFence bool
+	)
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			wrapped = append(wrapped, line)
+			continue
+		}
+		if inFence ||
+			utf8.RuneCountInString(line) <= width ||
+			strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") ||
+			trailerPattern.MatchString(line) {
+			wrapped = append(wrapped, line)
+			continue
+		}
+		wrapped = append(wrapped, wrapLine(line, width)...)
+	}
+	return wrapped
+}
+
+// wrapLine greedily fills lines of width, continuation lines are indented like list item text
+func wrapLine(line string, width int) []string {
+	prefix := listItemPattern.FindString(line)
+	if prefix == "" {
+		prefix = line[:len(line)-len(strings.TrimLeft(line, " "))]
+	}
+	words := strings.Fields(line[len(prefix):])
+	if len(words) < 2 {
+		return []string{line}
+	}
+
+	indent := strings.Repeat(" ", utf8.RuneCountInString(prefix))
+
+	var (
+		result  []string
+		current = prefix + words[0]
+	)
+	for _, word := range words[1:] {
+		if utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > width {
+			result = append(result, current)
+			current = indent + word
+			continue
+		}
+		current += " " + word
+	}
+
+	return append(result, current)
+}
@@ -0,0 +1,99 @@
+package modules
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMessageWrapper(t *testing.T) {
+	tests := []struct {
+		name         string
+		subjectLimit int
+		bodyWidth    int
+		overflow     SubjectOverflow
+		message      string
+		expected     string
+		shouldChange bool
+	}{
+		{
+			name:         "short message untouched",
+			subjectLimit: 50,
+			bodyWidth:    72,
+			message:      "fix: handle timeout\n\nRetries once.\n",
+			expected:     "fix: handle timeout\n\nRetries once.\n",
+		},
+		{
+			name:         "subject truncated at word boundary",
+			subjectLimit: 50,
+			message:      "feat: add retry with exponential backoff to uploads, downloads and sync",
+			expected:     "feat: add retry with exponential backoff to",
+			shouldChange: true,
+		},
+		{
+			name:         "subject overflow moved to body",
+			subjectLimit: 28,
+			overflow:     SubjectOverflowWrap,
+			message:      "feat: add retry to uploads and downloads\n\nUses backoff.",
+			expected:     "feat: add retry to uploads\n\nand downloads\n\nUses backoff.",
+			shouldChange: true,
+		},
+		{
+			name:         "single long word cut",
+			subjectLimit: 10,
+			message:      "refactor:simplify",
+			expected:     "refactor:s",
+			shouldChange: true,
+		},
+		{
+			name:         "missing blank line inserted",
+			subjectLimit: 50,
+			message:      "fix: handle timeout\nRetries once.",
+			expected:     "fix: handle timeout\n\nRetries once.",
+			shouldChange: true,
+		},
+		{
+			name:      "body wrapped",
+			bodyWidth: 20,
+			message:   "fix: x\n\nThis line is much longer than twenty columns.",
+			expected: "fix: x\n\nThis line is much\n" +
+				"longer than twenty\ncolumns.",
+			shouldChange: true,
+		},
+		{
+			name:      "list items keep indentation",
+			bodyWidth: 20,
+			message:   "fix: x\n\n- first item that needs wrapping\n  1. nested numbered item here",
+			expected: "fix: x\n\n- first item that\n  needs wrapping\n" +
+				"  1. nested numbered\n     item here",
+			shouldChange: true,
+		},
+		{
+			name:      "code, urls and trailers preserved",
+			bodyWidth: 20,
+			message: "fix: x\n\n    indented code line longer than width\n" +
+				"```\nfenced code line longer than width\n```\n" +
+				"https://example.com/a/very/long/link/without/spaces\n" +
+				"Signed-off-by: Somebody Long Named <somebody@example.com>",
+			expected: "fix: x\n\n    indented code line longer than width\n" +
+				"```\nfenced code line longer than width\n```\n" +
+				"https://example.com/a/very/long/link/without/spaces\n" +
+				"Signed-off-by: Somebody Long Named <somebody@example.com>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapper := NewMessageWrapper(tt.subjectLimit, tt.bodyWidth, tt.overflow)
+			result, changed, err := wrapper.TransformCommitMessage(context.Background(), "main", tt.message)
+			if err != nil {
+				t.Fatalf("TransformCommitMessage() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("TransformCommitMessage() = %q, want %q", result, tt.expected)
+			}
+			if changed != tt.shouldChange {
+				t.Errorf("TransformCommitMessage() changed = %v, want %v", changed, tt.shouldChange)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package commit
+
+import (
+	"context"
+	"strings"
+)
+
+// notesRef keeps audit notes of generated commits apart from regular notes
+const notesRef = "refs/notes/commit-ai"
+
+// generatedNoteMarker starts generation notes, it marks commits created by the tool
+const generatedNoteMarker = "Generated-By: commit"
+
+// noteFields maps generation metadata keys to note lines, in order
+var noteFields = []struct {
+	key   string
+	label string
+}{
+	{key: "provider", label: "Provider"},
+	{key: "model", label: "Model"},
+	{key: "prompt_sha256", label: "Prompt-SHA256"},
+	{key: "input_tokens", label: "Input-Tokens"},
+	{key: "output_tokens", label: "Output-Tokens"},
+}
+
+// formatGenerationNote renders generation metadata as trailer-like lines
+func formatGenerationNote(metadata map[string]string) string {
+	lines := []string{generatedNoteMarker}
+	for _, field := range noteFields {
+		if value := metadata[field.key]; value != "" {
+			lines = append(lines, field.label+": "+value)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// providerOf returns provider which suggested message, empty when message was not suggested as is
+func providerOf(messages map[string]string, message string) string {
+	for provider, suggestion := range messages {
+		if suggestion == message {
+			return provider
+		}
+	}
+	return ""
+}
+
+// writeNote records how HEAD commit message was generated, returns whether note was written,
+// notes are auditing aid only and never fail the commit
+func (s *Service) writeNote(ctx context.Context, provider string) bool {
+	metadata := s.aiService.GenerationMetadata(provider)
+	if metadata == nil {
+		s.logger.DebugContext(ctx, "No generation metadata for commit message, skipping note", "provider", provider)
+		return false
+	}
+
+	if err := s.gitOps.AddNote(notesRef, formatGenerationNote(metadata)); err != nil {
+		s.logger.WarnContext(ctx, "Failed to write generation note", "error", err)
+		return false
+	}
+
+	s.logger.DebugContext(ctx, "Generation note written", "ref", notesRef, "provider", provider)
+
+	return true
+}
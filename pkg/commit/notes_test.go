@@ -0,0 +1,24 @@
+package commit
+
+import "testing"
+
+func TestFormatGenerationNote(t *testing.T) {
+	note := formatGenerationNote(map[string]string{
+		"provider":      "claude",
+		"model":         "claude-haiku-4-5",
+		"prompt_sha256": "abc",
+		"input_tokens":  "120",
+		"output_tokens": "30",
+		"unknown":       "ignored",
+	})
+
+	want := "Generated-By: commit\n" +
+		"Provider: claude\n" +
+		"Model: claude-haiku-4-5\n" +
+		"Prompt-SHA256: abc\n" +
+		"Input-Tokens: 120\n" +
+		"Output-Tokens: 30"
+	if note != want {
+		t.Errorf("formatGenerationNote() = %q, want %q", note, want)
+	}
+}
@@ -0,0 +1,35 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// outputMessage writes final message to output file or prints it when configured so,
+// reports whether message was handled and must not be committed
+func (s *Service) outputMessage(ctx context.Context, message string) (bool, error) {
+	switch {
+	case s.settings.OutputFile != "":
+		if err := writeMessageFile(s.settings.OutputFile, message); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to write message file", "path", s.settings.OutputFile, "error", err)
+			return true, err
+		}
+		s.logger.InfoContext(ctx, "Commit message written, nothing committed", "path", s.settings.OutputFile)
+		return true, nil
+	case s.settings.PrintOnly:
+		// printed as is, so it can be consumed by other tooling
+		fmt.Println(message)
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// writeMessageFile replaces content of path with message ending with newline, as git expects
+func writeMessageFile(path, message string) error {
+	if err := os.WriteFile(path, []byte(message+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write message file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package commit
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestService_outputMessage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "message.txt")
+	if err := os.WriteFile(path, []byte("old content\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		settings    *Settings
+		wantHandled bool
+		wantFile    string
+	}{
+		{
+			name:        "commit",
+			settings:    &Settings{},
+			wantHandled: false,
+			wantFile:    "old content\n",
+		},
+		{
+			name:        "print only",
+			settings:    &Settings{PrintOnly: true},
+			wantHandled: true,
+			wantFile:    "old content\n",
+		},
+		{
+			name:        "output file",
+			settings:    &Settings{OutputFile: path, PrintOnly: true},
+			wantHandled: true,
+			wantFile:    "feat: add output\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &Service{logger: slog.New(slog.DiscardHandler), settings: tt.settings}
+
+			handled, err := service.outputMessage(context.Background(), "feat: add output")
+			if err != nil {
+				t.Fatalf("outputMessage() error = %v", err)
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("outputMessage() handled = %v, want %v", handled, tt.wantHandled)
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.wantFile {
+				t.Errorf("file content = %q, want %q", content, tt.wantFile)
+			}
+		})
+	}
+}
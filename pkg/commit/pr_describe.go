@@ -0,0 +1,278 @@
+package commit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+
+	"github.com/hasansino/commit/pkg/commit/ui"
+	"github.com/hasansino/commit/pkg/gitops"
+
+	_ "embed"
+)
+
+//go:embed prompt-pr.md
+var pullRequestPrompt string
+
+// pullRequestDescription is a generated title and markdown body of pull or merge request
+type pullRequestDescription struct {
+	Title string
+	Body  string
+}
+
+// pullRequestOptions tune created pull or merge request
+type pullRequestOptions struct {
+	Draft              bool
+	Labels             []string
+	Squash             bool // gitlab only, squash commits on merge
+	RemoveSourceBranch bool // gitlab only, delete source branch on merge
+}
+
+// DescribePullRequest generates pull request title and description from branch diff against its base,
+// result is printed, copied to clipboard or posted to GitHub or GitLab when post is set
+func (s *Service) DescribePullRequest(ctx context.Context, post bool) error {
+	if err := s.checkRepository(ctx); err != nil {
+		return err
+	}
+
+	if err := s.ensureFullHistory(ctx, "branch diff"); err != nil {
+		return err
+	}
+
+	branch, _, err := s.currentBranch(ctx)
+	if err != nil {
+		return err
+	}
+	if branch == "" {
+		return fmt.Errorf("cannot describe pull request for detached HEAD")
+	}
+
+	remote := s.settings.PushRemote
+	if remote == "" {
+		remote = gitops.DefaultRemote
+	}
+	base, target := s.pullRequestTarget(remote)
+
+	responses, err := s.describeBranch(ctx, branch, remote, base)
+	if err != nil || len(responses) == 0 {
+		return err
+	}
+
+	var text string
+
+	if s.settings.Auto {
+		text = s.getRandomMessage(responses)
+	} else if s.settings.Yes {
+		text = s.topRankedMessage(ctx, responses)
+	} else {
+		uiModel, err := ui.RenderInteractiveUI(ctx, responses, nil, s.accessibleOptions()...)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				s.logger.WarnContext(ctx, "Interactive mode canceled by user")
+				return nil
+			}
+			s.logger.ErrorContext(ctx, "Failed to enter interactive mode", "error", err)
+			return fmt.Errorf("failed to run interactive ui: %w", err)
+		}
+		text = uiModel.GetFinalChoice()
+	}
+
+	description := parsePullRequestDescription(text)
+	if description.Title == "" {
+		s.logger.WarnContext(ctx, "No pull request description provided")
+		return fmt.Errorf("no pull request description provided")
+	}
+
+	switch {
+	case post:
+		_, err := s.postPullRequest(ctx, remote, branch, target, description)
+		return err
+	case s.settings.Copy:
+		if err := clipboard.WriteAll(description.String()); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to copy pull request description to clipboard", "error", err)
+			return fmt.Errorf("failed to copy to clipboard: %w", err)
+		}
+		s.logger.InfoContext(ctx, "Pull request description copied to clipboard", "title", description.Title)
+	default:
+		// printed as is, so it can be piped into pull request tooling
+		fmt.Println(description.String())
+	}
+
+	return nil
+}
+
+// pullRequestTarget returns ref branch is diffed against and branch pull request targets,
+// --pr-base takes precedence over --branch-base, both default to default branch of remote
+func (s *Service) pullRequestTarget(remote string) (string, string) {
+	switch {
+	case s.settings.PRBase != "":
+		return remote + "/" + s.settings.PRBase, s.settings.PRBase
+	case s.settings.BranchBase != "":
+		return s.settings.BranchBase, strings.TrimPrefix(s.settings.BranchBase, remote+"/")
+	}
+	target := s.gitOps.GetDefaultBranch(remote)
+	return remote + "/" + target, target
+}
+
+// describeBranch asks providers for pull request descriptions of branch changes against base,
+// no responses and no error mean branch has no changes
+func (s *Service) describeBranch(ctx context.Context, branch, remote, base string) (map[string]string, error) {
+	diff, files, err := s.gitOps.GetBranchDiff(base, remote, s.settings.MaxDiffSizeBytes)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get branch diff", "error", err)
+		return nil, fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	if strings.TrimSpace(diff) == "" {
+		s.logger.WarnContext(ctx, "Branch has no changes against its base", "base", base)
+		return nil, nil
+	}
+
+	commits, err := s.gitOps.GetCommitsSince(base)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to get branch commits", "error", err)
+	}
+
+	s.logger.DebugContext(ctx, "Requesting pull request descriptions...", "files", len(files), "commits", len(commits))
+
+	prompt := s.applyPromptModules(ctx, branch, buildPullRequestPrompt(branch, base, files, commits, diff))
+
+	responses, err := s.aiService.Ask(ctx, s.settings.Providers, prompt, s.settings.First)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to generate pull request descriptions", "error", err)
+		return nil, fmt.Errorf("failed to generate suggestions: %w", err)
+	}
+	return responses, nil
+}
+
+// createPullRequest opens pull request of pushed branch with top-ranked generated description and
+// returns its url, failures are only logged as commit is already pushed, empty url tells compare url
+// is still needed
+func (s *Service) createPullRequest(ctx context.Context) string {
+	branch, _, err := s.currentBranch(ctx)
+	if err != nil || branch == "" {
+		return ""
+	}
+
+	remote := s.settings.PushRemote
+	if remote == "" {
+		remote = gitops.DefaultRemote
+	}
+	base, target := s.pullRequestTarget(remote)
+
+	responses, err := s.describeBranch(ctx, branch, remote, base)
+	if err != nil || len(responses) == 0 {
+		return ""
+	}
+
+	description := parsePullRequestDescription(s.topRankedMessage(ctx, responses))
+	if description.Title == "" {
+		s.logger.WarnContext(ctx, "No pull request description generated")
+		return ""
+	}
+
+	url, err := s.postPullRequest(ctx, remote, branch, target, description)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Pull request not created", "error", err)
+		return ""
+	}
+	return url
+}
+
+// postPullRequest opens pull or merge request on platform of remote and returns its url,
+// branch must be pushed beforehand, url is empty in dry run
+func (s *Service) postPullRequest(
+	ctx context.Context,
+	remote, branch, target string,
+	description pullRequestDescription,
+) (string, error) {
+	if !s.gitOps.HasRemoteBranch(remote, branch) {
+		return "", fmt.Errorf("branch %s is not pushed to %s, push it first", branch, remote)
+	}
+
+	remoteURL, err := s.gitOps.GetRemoteURL(remote)
+	if err != nil {
+		return "", err
+	}
+	platformHosts, _ := parsePlatformHosts(s.settings.PlatformHosts) // validated with settings
+	info, err := gitops.ParseRemoteURL(remoteURL, platformHosts)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse remote url: %w", err)
+	}
+
+	client, err := newPullRequestClient(info)
+	if err != nil {
+		return "", err
+	}
+
+	if s.settings.DryRun {
+		s.logger.InfoContext(ctx, "Dry run: pull request not created",
+			"platform", info.Platform, "branch", branch, "target", target, "title", description.Title)
+		return "", nil
+	}
+
+	options := pullRequestOptions{
+		Draft:              s.settings.PRDraft,
+		Labels:             s.settings.PRLabels,
+		Squash:             s.settings.MRSquash,
+		RemoveSourceBranch: s.settings.MRRemoveSource,
+	}
+	url, err := client.CreatePullRequest(ctx, branch, target, description, options)
+	if err != nil && url != "" {
+		// pull request exists, only its labels are missing
+		s.logger.WarnContext(ctx, "Failed to label pull request", "error", err)
+	} else if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create pull request", "error", err)
+		return "", err
+	}
+
+	s.logger.InfoContext(ctx, "Pull request created", "url", url)
+
+	return url, nil
+}
+
+func buildPullRequestPrompt(branch, base string, files, commits []string, diff string) string {
+	var b strings.Builder
+	for _, commit := range commits {
+		b.WriteString("- " + strings.ReplaceAll(commit, "\n", "\n  ") + "\n")
+	}
+	commitList := strings.TrimSuffix(b.String(), "\n")
+	if commitList == "" {
+		commitList = "none"
+	}
+
+	result := pullRequestPrompt
+	result = strings.ReplaceAll(result, "{branch}", branch)
+	result = strings.ReplaceAll(result, "{base}", base)
+	result = strings.ReplaceAll(result, "{commits}", commitList)
+	result = strings.ReplaceAll(result, "{files}", strings.Join(files, "\n"))
+	result = strings.ReplaceAll(result, "{diff}", diff)
+	return result
+}
+
+// parsePullRequestDescription splits response into title (first non-empty line) and body,
+// markdown heading or "Title:" prefix of the title is dropped
+func parsePullRequestDescription(text string) pullRequestDescription {
+	text = strings.TrimSpace(text)
+	title, body, _ := strings.Cut(text, "\n")
+
+	title = strings.TrimSpace(strings.TrimLeft(title, "# "))
+	if len(title) > len("title:") && strings.EqualFold(title[:len("title:")], "title:") {
+		title = strings.TrimSpace(title[len("title:"):])
+	}
+
+	return pullRequestDescription{
+		Title: title,
+		Body:  strings.TrimSpace(body),
+	}
+}
+
+func (d pullRequestDescription) String() string {
+	if d.Body == "" {
+		return d.Title
+	}
+	return d.Title + "\n\n" + d.Body
+}
@@ -0,0 +1,298 @@
+package commit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+func TestParsePullRequestDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want pullRequestDescription
+	}{
+		{
+			name: "title and body",
+			text: "Add retry to uploads\n\nUploads are retried on network errors.\n\n- add backoff",
+			want: pullRequestDescription{
+				Title: "Add retry to uploads",
+				Body:  "Uploads are retried on network errors.\n\n- add backoff",
+			},
+		},
+		{
+			name: "title only",
+			text: "  Fix typo  \n",
+			want: pullRequestDescription{Title: "Fix typo"},
+		},
+		{
+			name: "markdown heading",
+			text: "# Add retry to uploads\nBody",
+			want: pullRequestDescription{Title: "Add retry to uploads", Body: "Body"},
+		},
+		{
+			name: "title prefix",
+			text: "Title: Add retry to uploads\n\nBody",
+			want: pullRequestDescription{Title: "Add retry to uploads", Body: "Body"},
+		},
+		{
+			name: "empty",
+			text: "",
+			want: pullRequestDescription{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parsePullRequestDescription(tt.text)
+			if got != tt.want {
+				t.Errorf("parsePullRequestDescription() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequestClients(t *testing.T) {
+	description := pullRequestDescription{Title: "Add feature", Body: "Details"}
+
+	tests := []struct {
+		name       string
+		info       *gitops.RemoteInfo
+		tokenEnv   string
+		apiURLEnv  string
+		wantPath   string
+		wantHeader string
+		wantFields map[string]string
+		response   string
+		wantURL    string
+	}{
+		{
+			name: "github",
+			info: &gitops.RemoteInfo{
+				Platform: gitops.PlatformGitHub, Host: "github.com", Owner: "owner", Repo: "repo",
+			},
+			tokenEnv:   "GITHUB_TOKEN",
+			apiURLEnv:  "GITHUB_API_URL",
+			wantPath:   "/repos/owner/repo/pulls",
+			wantHeader: "Authorization",
+			wantFields: map[string]string{"title": "Add feature", "body": "Details", "head": "feature", "base": "main"},
+			response:   `{"html_url":"https://github.com/owner/repo/pull/1"}`,
+			wantURL:    "https://github.com/owner/repo/pull/1",
+		},
+		{
+			name: "gitlab nested group",
+			info: &gitops.RemoteInfo{
+				Platform: gitops.PlatformGitLab, Host: "gitlab.com", Owner: "group/sub", Repo: "repo",
+			},
+			tokenEnv:   "GITLAB_TOKEN",
+			apiURLEnv:  "CI_API_V4_URL",
+			wantPath:   "/projects/group%2Fsub%2Frepo/merge_requests",
+			wantHeader: "PRIVATE-TOKEN",
+			wantFields: map[string]string{
+				"title": "Add feature", "description": "Details",
+				"source_branch": "feature", "target_branch": "main",
+			},
+			response: `{"web_url":"https://gitlab.com/group/sub/repo/-/merge_requests/1"}`,
+			wantURL:  "https://gitlab.com/group/sub/repo/-/merge_requests/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("method = %s, want POST", r.Method)
+				}
+				if r.URL.EscapedPath() != tt.wantPath {
+					t.Errorf("path = %s, want %s", r.URL.EscapedPath(), tt.wantPath)
+				}
+				if r.Header.Get(tt.wantHeader) == "" {
+					t.Errorf("header %s is missing", tt.wantHeader)
+				}
+				var fields map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+					t.Errorf("failed to decode payload: %v", err)
+				}
+				for key, want := range tt.wantFields {
+					if fields[key] != want {
+						t.Errorf("payload %s = %v, want %q", key, fields[key], want)
+					}
+				}
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			t.Setenv(tt.tokenEnv, "secret")
+			t.Setenv(tt.apiURLEnv, server.URL)
+
+			client, err := newPullRequestClient(tt.info)
+			if err != nil {
+				t.Fatalf("newPullRequestClient() error = %v", err)
+			}
+			got, err := client.CreatePullRequest(
+				context.Background(), "feature", "main", description, pullRequestOptions{},
+			)
+			if err != nil {
+				t.Fatalf("CreatePullRequest() error = %v", err)
+			}
+			if got != tt.wantURL {
+				t.Errorf("CreatePullRequest() = %s, want %s", got, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestPullRequestClient_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"A pull request already exists"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	credentialToken = func(string) string { return "" }
+	t.Cleanup(func() { credentialToken = defaultCredentialToken })
+	info := &gitops.RemoteInfo{Platform: gitops.PlatformGitHub, Host: "github.com", Owner: "owner", Repo: "repo"}
+	if _, err := newPullRequestClient(info); err == nil {
+		t.Error("newPullRequestClient() without token, want error")
+	}
+
+	unknown := &gitops.RemoteInfo{Platform: gitops.PlatformUnknown, Host: "example.com"}
+	if _, err := newPullRequestClient(unknown); err == nil {
+		t.Error("newPullRequestClient() for unknown platform, want error")
+	}
+
+	// Hosts merely named like github must not receive tokens
+	t.Setenv("GITHUB_TOKEN", "secret")
+	lookalike := &gitops.RemoteInfo{Platform: gitops.PlatformGitHub, Host: "github.example.com", Owner: "o", Repo: "r"}
+	if _, err := newPullRequestClient(lookalike); err == nil {
+		t.Error("newPullRequestClient() for unmapped github-like host, want error")
+	}
+	lookalike.Mapped = true
+	if _, err := newPullRequestClient(lookalike); err != nil {
+		t.Errorf("newPullRequestClient() for mapped enterprise host error = %v", err)
+	}
+	t.Setenv("GITHUB_TOKEN", "")
+
+	t.Setenv("GH_TOKEN", "secret")
+	t.Setenv("GITHUB_API_URL", server.URL)
+	client, err := newPullRequestClient(info)
+	if err != nil {
+		t.Fatalf("newPullRequestClient() error = %v", err)
+	}
+	_, err = client.CreatePullRequest(
+		context.Background(), "feature", "main", pullRequestDescription{Title: "x"}, pullRequestOptions{},
+	)
+	if err == nil {
+		t.Fatal("CreatePullRequest() error = nil, want error")
+	}
+}
+
+func TestPullRequestClient_Options(t *testing.T) {
+	description := pullRequestDescription{Title: "Add feature", Body: "Details"}
+	options := pullRequestOptions{Draft: true, Labels: []string{"bug", "ai"}, Squash: true, RemoveSourceBranch: true}
+
+	requests := make(map[string]map[string]any)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fields map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		requests[r.URL.EscapedPath()] = fields
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":7,"html_url":"https://github.com/o/r/pull/7"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "secret")
+	t.Setenv("GITHUB_API_URL", server.URL)
+	t.Setenv("GITLAB_TOKEN", "secret")
+	t.Setenv("CI_API_V4_URL", server.URL)
+
+	github, err := newPullRequestClient(
+		&gitops.RemoteInfo{Platform: gitops.PlatformGitHub, Host: "github.com", Owner: "o", Repo: "r"},
+	)
+	if err != nil {
+		t.Fatalf("newPullRequestClient() error = %v", err)
+	}
+	if _, err := github.CreatePullRequest(context.Background(), "feature", "main", description, options); err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+	if draft := requests["/repos/o/r/pulls"]["draft"]; draft != true {
+		t.Errorf("github draft = %v, want true", draft)
+	}
+	if labels := fmt.Sprint(requests["/repos/o/r/issues/7/labels"]["labels"]); labels != "[bug ai]" {
+		t.Errorf("github labels = %s, want [bug ai]", labels)
+	}
+
+	gitlab, err := newPullRequestClient(
+		&gitops.RemoteInfo{Platform: gitops.PlatformGitLab, Host: "gitlab.com", Owner: "o", Repo: "r"},
+	)
+	if err != nil {
+		t.Fatalf("newPullRequestClient() error = %v", err)
+	}
+	if _, err := gitlab.CreatePullRequest(context.Background(), "feature", "main", description, options); err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+	fields := requests["/projects/o%2Fr/merge_requests"]
+	if fields["title"] != "Draft: Add feature" || fields["labels"] != "bug,ai" ||
+		fields["squash"] != true || fields["remove_source_branch"] != true {
+		t.Errorf("gitlab payload = %v, want draft title, labels, squash and source branch removal", fields)
+	}
+	if _, ok := requests["/repos/o/r/pulls"]["squash"]; ok {
+		t.Error("github payload has gitlab only squash option")
+	}
+}
+
+func TestCodeCommitClient_CreatePullRequest(t *testing.T) {
+	var gotArgs []string
+	awsCLI = func(_ context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`{"pullRequest":{"pullRequestId":"42"}}`), nil
+	}
+	t.Cleanup(func() { awsCLI = defaultAWSCLI })
+
+	client := &codeCommitClient{repo: "MyRepo", region: "eu-west-1", profile: "dev"}
+	got, err := client.CreatePullRequest(
+		context.Background(), "feature", "main", pullRequestDescription{Title: "Add feature", Body: "Details"},
+		pullRequestOptions{},
+	)
+	if err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+
+	want := "https://eu-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/MyRepo/pull-requests/42/" +
+		"details?region=eu-west-1"
+	if got != want {
+		t.Errorf("CreatePullRequest() = %s, want %s", got, want)
+	}
+	args := strings.Join(gotArgs, " ")
+	for _, want := range []string{
+		"--targets repositoryName=MyRepo,sourceReference=feature,destinationReference=main",
+		"--region eu-west-1", "--profile dev", "--title Add feature",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("aws args = %s, want %s", args, want)
+		}
+	}
+}
+
+func TestGitLabAPIURL(t *testing.T) {
+	t.Setenv("CI_API_V4_URL", "")
+	t.Setenv("GITLAB_URL", "http://code.internal:8080/")
+
+	if got := gitLabAPIURL("code.internal"); got != "http://code.internal:8080/api/v4" {
+		t.Errorf("gitLabAPIURL() of GITLAB_URL host = %s, want instance url", got)
+	}
+	if got := gitLabAPIURL("gitlab.com"); got != "https://gitlab.com/api/v4" {
+		t.Errorf("gitLabAPIURL() of other host = %s, want https://gitlab.com/api/v4", got)
+	}
+}
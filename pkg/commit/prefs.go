@@ -0,0 +1,106 @@
+package commit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hasansino/commit/pkg/commit/ui"
+)
+
+// uiPreferences are interactive mode choices remembered per repository
+type uiPreferences struct {
+	Push        bool   `json:"push,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	SignOff     bool   `json:"sign_off,omitempty"`
+	ShowDiff    *bool  `json:"show_diff,omitempty"`
+	ShowHistory bool   `json:"show_history,omitempty"`
+}
+
+// prefsStore keeps preferences of one repository in JSON file
+type prefsStore struct {
+	path string
+}
+
+// newPrefsStore returns store of repository in dir, file is named after hash of repository root
+func newPrefsStore(dir, repoRoot string) *prefsStore {
+	sum := sha256.Sum256([]byte(repoRoot))
+	return &prefsStore{path: filepath.Join(dir, hex.EncodeToString(sum[:8])+".json")}
+}
+
+// Load returns stored preferences, missing file means defaults
+func (p *prefsStore) Load() (uiPreferences, error) {
+	var prefs uiPreferences
+	data, err := os.ReadFile(p.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return prefs, nil
+	}
+	if err != nil {
+		return prefs, fmt.Errorf("failed to read preferences: %w", err)
+	}
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return uiPreferences{}, fmt.Errorf("failed to decode preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// Save replaces stored preferences
+func (p *prefsStore) Save(prefs uiPreferences) error {
+	data, err := json.Marshal(prefs)
+	if err != nil {
+		return fmt.Errorf("failed to encode preferences: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create preferences directory: %w", err)
+	}
+	// written aside and renamed, concurrent runs never see partial file
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write preferences: %w", err)
+	}
+	if err := os.Rename(tmp, p.path); err != nil {
+		return fmt.Errorf("failed to write preferences: %w", err)
+	}
+	return nil
+}
+
+// loadPreferences returns remembered choices, preferences are convenience and never fail the commit
+func (s *Service) loadPreferences(ctx context.Context) uiPreferences {
+	if s.prefs == nil {
+		return uiPreferences{}
+	}
+	prefs, err := s.prefs.Load()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load interactive mode preferences", "error", err)
+	}
+	return prefs
+}
+
+// savePreferences remembers options and layout chosen in interactive mode, options chosen
+// during dry run are not remembered as dry run turns all of them off, neither is push when
+// it could not be chosen
+func (s *Service) savePreferences(ctx context.Context, prefs uiPreferences, model *ui.Model, canPush bool) {
+	if s.prefs == nil {
+		return
+	}
+
+	layout := model.GetLayout()
+	prefs.ShowDiff, prefs.ShowHistory = layout.ShowDiff, layout.ShowHistory
+	if !model.GetCheckboxValue(ui.CheckboxIDDryRun) {
+		prefs.Tag, prefs.SignOff = s.settings.Tag, s.settings.SignOff
+		if canPush {
+			prefs.Push = s.settings.Push
+		}
+	}
+
+	if err := s.prefs.Save(prefs); err != nil {
+		s.logger.WarnContext(ctx, "Failed to save interactive mode preferences", "error", err)
+	}
+}
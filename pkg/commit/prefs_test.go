@@ -0,0 +1,43 @@
+package commit
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPrefsStore(t *testing.T) {
+	store := newPrefsStore(t.TempDir(), "/repo")
+
+	prefs, err := store.Load()
+	if err != nil || prefs != (uiPreferences{}) {
+		t.Fatalf("Load() of missing preferences = %+v, %v, want defaults", prefs, err)
+	}
+
+	showDiff := false
+	saved := uiPreferences{Push: true, Tag: "minor", SignOff: true, ShowDiff: &showDiff, ShowHistory: true}
+	if err := store.Save(saved); err != nil {
+		t.Fatalf("Save() unexpected error = %v", err)
+	}
+
+	prefs, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	if !prefs.Push || prefs.Tag != "minor" || !prefs.SignOff || !prefs.ShowHistory {
+		t.Errorf("Load() = %+v, want %+v", prefs, saved)
+	}
+	if prefs.ShowDiff == nil || *prefs.ShowDiff {
+		t.Errorf("Load() ShowDiff = %v, want explicitly hidden", prefs.ShowDiff)
+	}
+
+	if other, _ := newPrefsStore(t.TempDir(), "/other").Load(); other != (uiPreferences{}) {
+		t.Errorf("Load() of other repository = %+v, want defaults", other)
+	}
+
+	if err := os.WriteFile(store.path, []byte("{broken"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(); err == nil {
+		t.Error("Load() of corrupted preferences expected error")
+	}
+}
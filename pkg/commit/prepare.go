@@ -0,0 +1,94 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+// prepareCommitMsgHook fills message of plain git commit with generated suggestion, messages given
+// by -m, -F, templates, merges and amends are left alone; failures never block the commit
+const prepareCommitMsgHook = `#!/bin/sh
+# installed by commit init: fills message of plain git commit with generated suggestion
+[ -z "$2" ] || exit 0
+command -v commit >/dev/null 2>&1 || exit 0
+commit prepare-commit-msg "$1" || true
+`
+
+// InstallPrepareCommitMsgHook installs hook generating messages for plain git commit, returns its path
+func (s *Service) InstallPrepareCommitMsgHook(ctx context.Context) (string, error) {
+	path, err := s.gitOps.InstallHook(gitops.HookPrepareCommitMsg, prepareCommitMsgHook)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to install hook", "hook", gitops.HookPrepareCommitMsg, "error", err)
+		return "", fmt.Errorf("failed to install hook: %w", err)
+	}
+	return path, nil
+}
+
+// PrepareCommitMessage generates message of changes already staged and writes it above content of
+// message file, as prepare-commit-msg hook does; nothing is staged or committed
+func (s *Service) PrepareCommitMessage(ctx context.Context, messageFile string) error {
+	if s.aiService.NumProviders() == 0 {
+		s.logger.WarnContext(ctx, "No providers configured")
+		return fmt.Errorf("no api keys found in environment")
+	}
+
+	diff, err := s.gitOps.GetStagedDiff(s.settings.MaxDiffSizeBytes, s.settings.NewFileHeadLines)
+	if err != nil {
+		return fmt.Errorf("failed to get diff: %w", err)
+	}
+	if strings.TrimSpace(diff) == "" {
+		s.logger.DebugContext(ctx, "No changes staged, message left empty")
+		return nil
+	}
+
+	files, err := s.gitOps.GetStagedFiles()
+	if err != nil {
+		return fmt.Errorf("failed to get staged files: %w", err)
+	}
+
+	branch, promptBranch, err := s.currentBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	commitTemplate, err := s.gitOps.GetCommitTemplate()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load commit template", "error", err)
+	}
+
+	messages, err := s.aiService.GenerateCommitMessages(
+		ctx,
+		diff, promptBranch, files,
+		s.settings.Providers, s.settings.CustomPrompt, commitTemplate,
+		nil,
+		true, s.settings.MultiLine,
+		s.promptTransformer(branch),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate suggestions: %w", err)
+	}
+
+	message := s.getRandomMessage(messages)
+	if message == "" {
+		return fmt.Errorf("no valid suggestions available")
+	}
+	message, err = s.applyModules(ctx, branch, message)
+	if err != nil {
+		return err
+	}
+	message = strings.TrimSpace(s.appendTrailers(ctx, branch, message))
+
+	// git comments below the message show status, they are kept for the editor
+	existing, err := os.ReadFile(messageFile)
+	if err != nil {
+		return fmt.Errorf("failed to read message file: %w", err)
+	}
+	if err := os.WriteFile(messageFile, []byte(message+"\n"+string(existing)), 0o644); err != nil {
+		return fmt.Errorf("failed to write message file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package commit
+
+import (
+	"sort"
+	"strings"
+)
+
+// estimatedOutputTokens is typical size of response with few suggestions, used before request is sent
+const estimatedOutputTokens = 300
+
+// modelPrice is list price of model in USD per million tokens
+type modelPrice struct {
+	input, output float64
+}
+
+// modelPrices are list prices by model name prefix, longest matching prefix wins
+var modelPrices = map[string]modelPrice{
+	"gpt-4o-mini":           {input: 0.15, output: 0.60},
+	"gpt-4o":                {input: 2.50, output: 10},
+	"gpt-4.1-nano":          {input: 0.10, output: 0.40},
+	"gpt-4.1-mini":          {input: 0.40, output: 1.60},
+	"gpt-4.1":               {input: 2, output: 8},
+	"claude-haiku-4-5":      {input: 1, output: 5},
+	"claude-3-5-haiku":      {input: 0.80, output: 4},
+	"claude-sonnet-4":       {input: 3, output: 15},
+	"claude-opus-4":         {input: 15, output: 75},
+	"gemini-2.5-flash-lite": {input: 0.10, output: 0.40},
+	"gemini-2.5-flash":      {input: 0.30, output: 2.50},
+	"gemini-2.5-pro":        {input: 1.25, output: 10},
+}
+
+// estimateCost returns approximate cost of request in USD, ok is false when model price is unknown
+func estimateCost(model string, inputTokens, outputTokens int64) (float64, bool) {
+	prefixes := make([]string, 0, len(modelPrices))
+	for prefix := range modelPrices {
+		if strings.HasPrefix(model, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if len(prefixes) == 0 {
+		return 0, false
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	price := modelPrices[prefixes[0]]
+	return (float64(inputTokens)*price.input + float64(outputTokens)*price.output) / 1e6, true
+}
+
+// estimateTokens approximates token count of text, tokenizers average about four characters per token
+// on English text and code
+func estimateTokens(text string) int64 {
+	return int64((len(text) + 3) / 4)
+}
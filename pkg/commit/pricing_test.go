@@ -0,0 +1,59 @@
+package commit
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateCost(t *testing.T) {
+	tests := []struct {
+		name         string
+		model        string
+		inputTokens  int64
+		outputTokens int64
+		want         float64
+		wantOK       bool
+	}{
+		{
+			name:         "exact model",
+			model:        "gpt-4o-mini",
+			inputTokens:  1_000_000,
+			outputTokens: 1_000_000,
+			want:         0.75,
+			wantOK:       true,
+		},
+		{
+			name:         "longest prefix wins",
+			model:        "gemini-2.5-flash-lite-preview",
+			inputTokens:  2_000_000,
+			outputTokens: 0,
+			want:         0.20,
+			wantOK:       true,
+		},
+		{
+			name:         "dated model version",
+			model:        "claude-haiku-4-5-20251001",
+			inputTokens:  1000,
+			outputTokens: 100,
+			want:         0.0015,
+			wantOK:       true,
+		},
+		{
+			name:   "unknown model",
+			model:  "llama-3",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := estimateCost(tt.model, tt.inputTokens, tt.outputTokens)
+			if ok != tt.wantOK {
+				t.Fatalf("estimateCost() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("estimateCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
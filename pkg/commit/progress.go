@@ -0,0 +1,31 @@
+package commit
+
+import (
+	"context"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/hasansino/commit/pkg/commit/ui"
+)
+
+// generateWithProgress runs generation showing live status of providers in interactive mode,
+// user can cancel slow providers and continue with suggestions which already arrived
+func (s *Service) generateWithProgress(
+	ctx context.Context,
+	generate func(ctx context.Context, instruction string) (map[string]string, error),
+) (map[string]string, error) {
+	providers := s.aiService.ProviderNames(s.settings.Providers)
+	if s.settings.Auto || s.settings.Yes || s.settings.Quiet || s.settings.Accessible ||
+		len(providers) == 0 || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return generate(ctx, "")
+	}
+
+	return ui.RenderProgress(ctx, providers, s.aiService.CancelProvider,
+		func(progress ui.ProgressFunc) (map[string]string, error) {
+			s.aiService.SetProgress(progress)
+			defer s.aiService.SetProgress(nil)
+			return generate(ctx, "")
+		},
+	)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
@@ -20,8 +21,14 @@ const (
 type Claude struct {
 	apiKey  string
 	model   string
-	client  *anthropic.Client
 	timeout time.Duration
+
+	clientMu sync.Mutex
+	client   *anthropic.Client // created on first request
+
+	mu           sync.Mutex
+	inputTokens  int64
+	outputTokens int64
 }
 
 func NewClaude() *Claude {
@@ -46,29 +53,44 @@ func (p *Claude) SetTimeout(timeout time.Duration) {
 	}
 }
 
-func (p *Claude) Ask(ctx context.Context, prompt string) ([]string, error) {
-	if !p.IsAvailable() {
-		return nil, fmt.Errorf("api key not found")
+// SetModel overrides model configured by environment, empty keeps it
+func (p *Claude) SetModel(model string) {
+	if model != "" {
+		p.model = model
 	}
+}
 
-	if p.client == nil {
-		httpClient := &http.Client{
-			Timeout: p.timeout,
-		}
-		client := anthropic.NewClient(
-			option.WithAPIKey(p.apiKey),
-			option.WithHTTPClient(httpClient),
-		)
-		p.client = &client
+// Model returns model used for requests, configured or default one
+func (p *Claude) Model() string {
+	if len(p.model) > 0 {
+		return p.model
 	}
+	return defaultModel
+}
 
-	model := defaultModel
-	if len(p.model) > 0 {
-		model = p.model
+// Usage returns input and output tokens consumed by all requests so far
+func (p *Claude) Usage() (int64, int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inputTokens, p.outputTokens
+}
+
+func (p *Claude) addUsage(inputTokens, outputTokens int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inputTokens += inputTokens
+	p.outputTokens += outputTokens
+}
+
+func (p *Claude) Ask(ctx context.Context, prompt string) ([]string, error) {
+	if !p.IsAvailable() {
+		return nil, fmt.Errorf("api key not found")
 	}
 
-	message, err := p.client.Messages.New(ctx, anthropic.MessageNewParams{
-		Model:     anthropic.Model(model),
+	client := p.getClient()
+
+	message, err := client.Messages.New(ctx, anthropic.MessageNewParams{
+		Model:     anthropic.Model(p.Model()),
 		MaxTokens: int64(defaultMaxTokens),
 		Messages: []anthropic.MessageParam{
 			anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
@@ -78,6 +100,8 @@ func (p *Claude) Ask(ctx context.Context, prompt string) ([]string, error) {
 		return nil, fmt.Errorf("failed to create message: %w", err)
 	}
 
+	p.addUsage(message.Usage.InputTokens, message.Usage.OutputTokens)
+
 	// "end_turn", "max_tokens", "stop_sequence", "tool_use", "pause_turn", "refusal"
 	if len(message.StopReason) != 0 && !validStopReason(message.StopReason) {
 		return nil, fmt.Errorf("stopped with reason: %s", message.StopReason)
@@ -106,3 +130,21 @@ func validStopReason(reason anthropic.StopReason) bool {
 		return false
 	}
 }
+
+// getClient creates sdk client on first use, safe for concurrent requests
+func (p *Claude) getClient() *anthropic.Client {
+	p.clientMu.Lock()
+	defer p.clientMu.Unlock()
+	if p.client != nil {
+		return p.client
+	}
+	httpClient := &http.Client{
+		Timeout: p.timeout,
+	}
+	client := anthropic.NewClient(
+		option.WithAPIKey(p.apiKey),
+		option.WithHTTPClient(httpClient),
+	)
+	p.client = &client
+	return p.client
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"google.golang.org/genai"
@@ -19,8 +20,14 @@ const (
 type Gemini struct {
 	apiKey  string
 	model   string
-	client  *genai.Client
 	timeout time.Duration
+
+	clientMu sync.Mutex
+	client   *genai.Client // created on first request
+
+	mu           sync.Mutex
+	inputTokens  int64
+	outputTokens int64
 }
 
 func NewGemini() *Gemini {
@@ -45,36 +52,51 @@ func (p *Gemini) SetTimeout(timeout time.Duration) {
 	}
 }
 
+// SetModel overrides model configured by environment, empty keeps it
+func (p *Gemini) SetModel(model string) {
+	if model != "" {
+		p.model = model
+	}
+}
+
+// Model returns model used for requests, configured or default one
+func (p *Gemini) Model() string {
+	if len(p.model) > 0 {
+		return p.model
+	}
+	return defaultModel
+}
+
+// Usage returns input and output tokens consumed by all requests so far
+func (p *Gemini) Usage() (int64, int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inputTokens, p.outputTokens
+}
+
+func (p *Gemini) addUsage(inputTokens, outputTokens int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inputTokens += inputTokens
+	p.outputTokens += outputTokens
+}
+
 func (p *Gemini) Ask(ctx context.Context, prompt string) ([]string, error) {
 	if !p.IsAvailable() {
 		return nil, fmt.Errorf("api key not found")
 	}
 
-	if p.client == nil {
-		httpClient := &http.Client{
-			Timeout: p.timeout,
-		}
-		client, err := genai.NewClient(ctx, &genai.ClientConfig{
-			APIKey:     p.apiKey,
-			HTTPClient: httpClient,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to create genai client: %w", err)
-		}
-		p.client = client
+	client, err := p.getClient(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	contents := []*genai.Content{
 		genai.NewContentFromText(prompt, "user"),
 	}
 
-	model := defaultModel
-	if len(p.model) > 0 {
-		model = p.model
-	}
-
-	resp, err := p.client.Models.GenerateContent(
-		ctx, model, contents,
+	resp, err := client.Models.GenerateContent(
+		ctx, p.Model(), contents,
 		&genai.GenerateContentConfig{
 			MaxOutputTokens: defaultMaxTokens,
 			CandidateCount:  1,
@@ -83,6 +105,10 @@ func (p *Gemini) Ask(ctx context.Context, prompt string) ([]string, error) {
 		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
 
+	if resp.UsageMetadata != nil {
+		p.addUsage(int64(resp.UsageMetadata.PromptTokenCount), int64(resp.UsageMetadata.CandidatesTokenCount))
+	}
+
 	if len(resp.Candidates) == 0 {
 		return nil, fmt.Errorf("no candidates received")
 	}
@@ -115,3 +141,24 @@ func validFinishReason(reason genai.FinishReason) bool {
 		return false
 	}
 }
+
+// getClient creates sdk client on first use, safe for concurrent requests
+func (p *Gemini) getClient(ctx context.Context) (*genai.Client, error) {
+	p.clientMu.Lock()
+	defer p.clientMu.Unlock()
+	if p.client != nil {
+		return p.client, nil
+	}
+	httpClient := &http.Client{
+		Timeout: p.timeout,
+	}
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey:     p.apiKey,
+		HTTPClient: httpClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create genai client: %w", err)
+	}
+	p.client = client
+	return p.client, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/openai/openai-go/v3"
@@ -21,8 +22,14 @@ const (
 type OpenAI struct {
 	apiKey  string
 	model   string
-	client  *openai.Client
 	timeout time.Duration
+
+	clientMu sync.Mutex
+	client   *openai.Client // created on first request
+
+	mu           sync.Mutex
+	inputTokens  int64
+	outputTokens int64
 }
 
 func NewOpenAI() *OpenAI {
@@ -47,33 +54,48 @@ func (p *OpenAI) SetTimeout(timeout time.Duration) {
 	}
 }
 
-func (p *OpenAI) Ask(ctx context.Context, prompt string) ([]string, error) {
-	if !p.IsAvailable() {
-		return nil, fmt.Errorf("openai api key not found")
+// SetModel overrides model configured by environment, empty keeps it
+func (p *OpenAI) SetModel(model string) {
+	if model != "" {
+		p.model = model
 	}
+}
 
-	if p.client == nil {
-		httpClient := &http.Client{
-			Timeout: p.timeout,
-		}
-		client := openai.NewClient(
-			option.WithAPIKey(p.apiKey),
-			option.WithHTTPClient(httpClient),
-		)
-		p.client = &client
+// Model returns model used for requests, configured or default one
+func (p *OpenAI) Model() string {
+	if len(p.model) > 0 {
+		return p.model
 	}
+	return defaultModel
+}
 
-	model := defaultModel
-	if len(p.model) > 0 {
-		model = p.model
+// Usage returns input and output tokens consumed by all requests so far
+func (p *OpenAI) Usage() (int64, int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inputTokens, p.outputTokens
+}
+
+func (p *OpenAI) addUsage(inputTokens, outputTokens int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inputTokens += inputTokens
+	p.outputTokens += outputTokens
+}
+
+func (p *OpenAI) Ask(ctx context.Context, prompt string) ([]string, error) {
+	if !p.IsAvailable() {
+		return nil, fmt.Errorf("openai api key not found")
 	}
 
-	chatCompletion, err := p.client.Chat.Completions.New(
+	client := p.getClient()
+
+	chatCompletion, err := client.Chat.Completions.New(
 		ctx, openai.ChatCompletionNewParams{
 			Messages: []openai.ChatCompletionMessageParamUnion{
 				openai.UserMessage(prompt),
 			},
-			Model:               model,
+			Model:               p.Model(),
 			MaxCompletionTokens: openai.Int(defaultMaxTokens),
 			N:                   openai.Int(1), // number of candidates
 		})
@@ -81,6 +103,8 @@ func (p *OpenAI) Ask(ctx context.Context, prompt string) ([]string, error) {
 		return nil, fmt.Errorf("failed to create completion: %w", err)
 	}
 
+	p.addUsage(chatCompletion.Usage.PromptTokens, chatCompletion.Usage.CompletionTokens)
+
 	if len(chatCompletion.Choices) == 0 {
 		return nil, fmt.Errorf("no candidates received")
 	}
@@ -107,3 +131,21 @@ func validFinishReason(reason string) bool {
 		return false
 	}
 }
+
+// getClient creates sdk client on first use, safe for concurrent requests
+func (p *OpenAI) getClient() *openai.Client {
+	p.clientMu.Lock()
+	defer p.clientMu.Unlock()
+	if p.client != nil {
+		return p.client
+	}
+	httpClient := &http.Client{
+		Timeout: p.timeout,
+	}
+	client := openai.NewClient(
+		option.WithAPIKey(p.apiKey),
+		option.WithHTTPClient(httpClient),
+	)
+	p.client = &client
+	return p.client
+}
@@ -0,0 +1,354 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hasansino/commit/pkg/commit/modules"
+	"github.com/hasansino/commit/pkg/commit/ui"
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+// publication records what publishCommit created, for notifiers
+type publication struct {
+	tag             string // created tag, pushed with commit when pushed is set
+	pushed          bool
+	mergeRequestURL string // created pull request, or page creating one
+}
+
+// publishCommit creates commit, tag and pushes them in an order which keeps repository consistent:
+// nothing leaves the machine until everything is created locally, and local tag
+// (optionally commit) is rolled back when a later step fails
+func (s *Service) publishCommit(ctx context.Context, commitMessage, provider string) (publication, error) {
+	// Version bump from truncated history would be wrong, check before anything is created
+	if s.settings.Tag != "" {
+		if err := s.ensureFullHistory(ctx, "tagging"); err != nil {
+			return publication{}, err
+		}
+	}
+
+	err := s.gitOps.CreateCommit(
+		commitMessage, s.settings.NoVerify, s.settings.AllowEmpty,
+		s.settings.Author, s.settings.Date,
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create commit", "error", err)
+		return publication{}, classify(ErrGit, fmt.Errorf("failed to create commit: %w", err))
+	}
+	s.logger.InfoContext(
+		ctx, "Commit created",
+		"commit_message", commitMessage,
+	)
+
+	// Rebase changes commit hash, so it has to happen before tagging
+	if s.settings.Push {
+		if err := s.ensureRemoteFresh(ctx); err != nil {
+			s.rollback(ctx, "", true)
+			return publication{}, classify(ErrPush, err)
+		}
+	}
+
+	var newTag string
+	if s.settings.Tag != "" {
+		tag, err := s.createTag(ctx, commitMessage)
+		if err != nil {
+			s.rollback(ctx, "", true)
+			return publication{}, classify(ErrGit, err)
+		}
+		newTag = tag
+	}
+
+	// Notes are written only once commit can no longer be rebased or rolled back,
+	// otherwise they would be left attached to a commit that is gone
+	if !s.settings.Push {
+		if s.settings.Notes {
+			s.writeNote(ctx, provider)
+		}
+		return publication{tag: newTag}, nil
+	}
+
+	mrURL, err := s.gitOps.Push(s.settings.PushRemote, s.settings.ForceWithLease, s.settings.SetUpstream)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to push to remote", "error", err)
+		s.rollback(ctx, newTag, true)
+		return publication{}, classify(ErrPush, fmt.Errorf("failed to push: %w", err))
+	}
+	s.logger.InfoContext(ctx, "Successfully pushed to remote")
+
+	if s.settings.Notes && s.writeNote(ctx, provider) {
+		if err := s.gitOps.PushNotes(notesRef, s.settings.PushRemote); err != nil {
+			s.logger.WarnContext(ctx, "Failed to push generation notes", "error", err)
+		}
+	}
+
+	if newTag != "" {
+		if err := s.gitOps.PushTag(newTag, s.settings.PushRemote); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to push tag", "tag", newTag, "error", err)
+			// Commit is already on remote, only the tag can be safely removed
+			s.rollback(ctx, newTag, false)
+			return publication{}, classify(ErrPush, fmt.Errorf("failed to push tag %s: %w", newTag, err))
+		}
+		s.logger.InfoContext(ctx, "Tag pushed to remote", "tag", newTag)
+	}
+
+	// platforms without compare page, e.g. CodeCommit, still get pull requests through their api
+	if s.settings.CreatePR {
+		if url := s.createPullRequest(ctx); url != "" {
+			mrURL = url
+		} else if mrURL != "" {
+			s.logger.InfoContext(ctx, "Create merge/pull request", "url", mrURL)
+		}
+	} else if mrURL != "" {
+		s.logger.InfoContext(ctx, "Create merge/pull request", "url", mrURL)
+	}
+
+	return publication{tag: newTag, pushed: true, mergeRequestURL: mrURL}, nil
+}
+
+// notifyCommit tells notifiers about created commit, their failures are only logged
+// as commit is already created
+func (s *Service) notifyCommit(ctx context.Context, commitMessage string, published publication) {
+	if len(s.notifiers) == 0 {
+		return
+	}
+
+	hash, err := s.gitOps.ResolveCommit("HEAD")
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to resolve created commit for notifications", "error", err)
+		return
+	}
+	branch, _ := s.gitOps.GetCurrentBranch()
+	event := modules.CommitEvent{
+		Repo:            s.repoName(),
+		Branch:          branch,
+		Hash:            hash,
+		Message:         commitMessage,
+		URL:             s.commitURL(hash),
+		Tag:             published.tag,
+		Pushed:          published.pushed,
+		MergeRequestURL: published.mergeRequestURL,
+	}
+
+	for _, notifier := range s.notifiers {
+		if err := notifier.NotifyCommit(ctx, event); err != nil {
+			s.logger.WarnContext(ctx, "Failed to notify about commit", "notifier", notifier.Name(), "error", err)
+		}
+	}
+}
+
+// notifySuggestions tells notifiers interested in suggestions about generated ones,
+// their failures are only logged
+func (s *Service) notifySuggestions(ctx context.Context, branch string, suggestions map[string]string) {
+	var event *modules.SuggestionsEvent
+	for _, notifier := range s.notifiers {
+		suggestionsNotifier, ok := notifier.(suggestionsNotifierAccessor)
+		if !ok {
+			continue
+		}
+		if event == nil {
+			event = &modules.SuggestionsEvent{Repo: s.repoName(), Branch: branch, Suggestions: suggestions}
+		}
+		if err := suggestionsNotifier.NotifySuggestions(ctx, *event); err != nil {
+			s.logger.WarnContext(ctx, "Failed to notify about suggestions", "notifier", notifier.Name(), "error", err)
+		}
+	}
+}
+
+// commitURL returns web page of commit pushed to push remote, empty when it was not pushed
+// or platform of remote is unknown
+func (s *Service) commitURL(hash string) string {
+	if !s.settings.Push {
+		return ""
+	}
+	return gitops.CommitURL(s.pushRemoteInfo(), hash)
+}
+
+// repoName returns owner/repo of push remote, or name of worktree directory without remote
+func (s *Service) repoName() string {
+	if info := s.pushRemoteInfo(); info != nil && info.Repo != "" {
+		return strings.TrimPrefix(info.Owner+"/"+info.Repo, "/")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(wd)
+}
+
+// pushRemoteInfo returns parsed url of push remote, nil when it is missing or unparsable
+func (s *Service) pushRemoteInfo() *gitops.RemoteInfo {
+	remote := s.settings.PushRemote
+	if remote == "" {
+		remote = gitops.DefaultRemote
+	}
+	remoteURL, err := s.gitOps.GetRemoteURL(remote)
+	if err != nil {
+		return nil
+	}
+	platformHosts, _ := parsePlatformHosts(s.settings.PlatformHosts) // validated with settings
+	info, err := gitops.ParseRemoteURL(remoteURL, platformHosts)
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// tagPrefix returns prefix of semver tags, defaulting to v
+func (s *Service) tagPrefix() string {
+	if s.settings.TagPrefix == "" {
+		return defaultTagPrefix
+	}
+	return s.settings.TagPrefix
+}
+
+// tagPreviewer returns preview of tags created by increments, latest tag and commits since it are read once,
+// nil when they cannot be read
+func (s *Service) tagPreviewer(ctx context.Context) ui.TagPreviewFunc {
+	latestTag, err := s.gitOps.GetLatestTag(s.tagPrefix(), s.settings.TagPattern)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to get latest tag for preview", "error", err)
+		return nil
+	}
+	commits, err := s.gitOps.GetCommitsSince(latestTag)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to get commits since latest tag for preview", "error", err)
+		return nil
+	}
+
+	return func(increment, message string) string {
+		if increment == "auto" {
+			// IncrementVersion would not see the commit which is not created yet
+			increment = gitops.DetectIncrementType(append(commits[:len(commits):len(commits)], message))
+		}
+		tag, err := s.gitOps.IncrementVersion(latestTag, increment, s.tagPrefix())
+		if err != nil {
+			return ""
+		}
+		return tag
+	}
+}
+
+// createTag computes next version and creates annotated tag locally
+func (s *Service) createTag(ctx context.Context, commitMessage string) (string, error) {
+	latestTag, err := s.gitOps.GetLatestTag(s.tagPrefix(), s.settings.TagPattern)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get latest tag", "error", err)
+		return "", fmt.Errorf("failed to get latest tag: %w", err)
+	}
+
+	if latestTag == "" {
+		s.logger.WarnContext(ctx, "No existing tags found, will create first tag")
+	} else {
+		s.logger.InfoContext(ctx, "Latest tag found", "tag", latestTag)
+	}
+
+	newTag, err := s.gitOps.IncrementVersion(latestTag, s.settings.Tag, s.tagPrefix())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to increment version", "error", err)
+		return "", fmt.Errorf("failed to increment version: %w", err)
+	}
+
+	tagMessage := commitMessage
+	if s.settings.ReleaseNotes {
+		releaseNotes, err := s.generateReleaseNotes(ctx, latestTag, newTag)
+		if err != nil {
+			// release notes are optional, fall back to commit message annotation
+			s.logger.WarnContext(ctx, "Failed to generate release notes", "error", err)
+		} else {
+			tagMessage = newTag + "\n\n" + releaseNotes
+		}
+	}
+
+	if err := s.gitOps.CreateTag(newTag, tagMessage); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create tag", "tag", newTag, "error", err)
+		return "", fmt.Errorf("failed to create tag %s: %w", newTag, err)
+	}
+
+	s.logger.InfoContext(ctx, "Tag created", "tag", newTag)
+
+	return newTag, nil
+}
+
+// rollback removes locally created tag and, when enabled and allowed, the commit itself,
+// rollback failures are only logged so the original error is preserved
+func (s *Service) rollback(ctx context.Context, tag string, commitAllowed bool) {
+	if tag != "" {
+		if err := s.gitOps.DeleteTag(tag); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to roll back tag", "tag", tag, "error", err)
+		} else {
+			s.logger.WarnContext(ctx, "Rolled back local tag", "tag", tag)
+		}
+	}
+
+	if !commitAllowed || !s.settings.RollbackCommit {
+		return
+	}
+
+	if err := s.gitOps.UndoLastCommit(); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to roll back commit", "error", err)
+		return
+	}
+	s.logger.WarnContext(ctx, "Rolled back commit, changes are left staged")
+}
+
+// ensureRemoteFresh detects whether remote branch received new commits before pushing,
+// local commits are rebased on top of them when enabled, otherwise push is aborted
+func (s *Service) ensureRemoteFresh(ctx context.Context) error {
+	// Lease protects against overwriting unseen remote work on its own
+	if s.settings.ForceWithLease {
+		return nil
+	}
+
+	ahead, behind, err := s.gitOps.GetRemoteDivergence(s.settings.PushRemote)
+	if err != nil {
+		// Push itself will surface real connectivity problems
+		s.logger.WarnContext(ctx, "Failed to check remote branch state", "error", err)
+		return nil
+	}
+
+	if behind == 0 {
+		return nil
+	}
+
+	if !s.settings.PullRebase {
+		s.logger.ErrorContext(
+			ctx, "Remote branch has diverged",
+			"ahead", ahead,
+			"behind", behind,
+		)
+		return fmt.Errorf(
+			"remote branch has %d new commit(s), commit was not pushed: "+
+				"pull and push manually or use --pull-rebase-before-push", behind,
+		)
+	}
+
+	s.logger.InfoContext(ctx, "Rebasing onto remote branch", "behind", behind)
+
+	if err := s.gitOps.PullRebase(s.settings.PushRemote); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to rebase onto remote branch", "error", err)
+		return fmt.Errorf("failed to rebase onto remote branch, commit was not pushed: %w", err)
+	}
+
+	return nil
+}
+
+// newIssueCloser creates issue closing module for platform of remote,
+// nil when remote is missing or hosted elsewhere than GitHub or GitLab
+func newIssueCloser(git *gitops.Operations, remote, keyword string) (*modules.IssueCloser, error) {
+	if remote == "" {
+		remote = gitops.DefaultRemote
+	}
+	info, err := git.GetRemoteInfo(remote)
+	if err != nil || (info.Platform != gitops.PlatformGitHub && info.Platform != gitops.PlatformGitLab) {
+		return nil, nil
+	}
+
+	issueCloser, err := modules.NewIssueCloser(string(info.Platform), keyword)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue closing keyword: %w", err)
+	}
+	return issueCloser, nil
+}
@@ -0,0 +1,274 @@
+package commit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+const defaultPullRequestTimeout = 15 * time.Second
+
+// pullRequestClient opens pull or merge requests on hosting platform
+type pullRequestClient interface {
+	CreatePullRequest(
+		ctx context.Context, branch, target string, description pullRequestDescription, options pullRequestOptions,
+	) (string, error)
+}
+
+// newPullRequestClient creates API client for platform of remote, token is read from environment,
+// or from git credential helper (e.g. system keychain) when environment has none
+func newPullRequestClient(info *gitops.RemoteInfo) (pullRequestClient, error) {
+	httpClient := &http.Client{Timeout: defaultPullRequestTimeout}
+
+	switch info.Platform {
+	case gitops.PlatformGitHub:
+		baseURL, ok := gitHubAPIURL(info)
+		if !ok {
+			// Host merely named like github could be anyone's, it must not receive tokens
+			return nil, fmt.Errorf(
+				"host %s is not known to serve github api, map it with platform-hosts or set GITHUB_API_URL",
+				info.Host,
+			)
+		}
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			token = os.Getenv("GH_TOKEN")
+		}
+		if token == "" {
+			token = credentialToken(info.Host)
+		}
+		if token == "" {
+			return nil, fmt.Errorf(
+				"GITHUB_TOKEN, GH_TOKEN or token of git credential helper is required to create pull requests",
+			)
+		}
+		return &gitHubClient{
+			baseURL: baseURL,
+			owner:   info.Owner,
+			repo:    info.Repo,
+			token:   token,
+			client:  httpClient,
+		}, nil
+	case gitops.PlatformGitLab:
+		token := os.Getenv("GITLAB_TOKEN")
+		if token == "" {
+			token = credentialToken(info.Host)
+		}
+		if token == "" {
+			return nil, fmt.Errorf(
+				"GITLAB_TOKEN or token of git credential helper is required to create merge requests",
+			)
+		}
+		return &gitLabClient{
+			baseURL: gitLabAPIURL(info.Host),
+			project: info.Owner + "/" + info.Repo,
+			token:   token,
+			client:  httpClient,
+		}, nil
+	case gitops.PlatformCodeCommit:
+		if _, err := exec.LookPath("aws"); err != nil {
+			return nil, fmt.Errorf("aws cli is required to create codecommit pull requests: %w", err)
+		}
+		if info.Region == "" {
+			return nil, fmt.Errorf("region of codecommit repository %s is unknown, set AWS_REGION", info.Repo)
+		}
+		return &codeCommitClient{repo: info.Repo, region: info.Region, profile: info.Profile}, nil
+	default:
+		return nil, fmt.Errorf("unsupported platform of remote host %s", info.Host)
+	}
+}
+
+// credentialToken returns password of host stored by git credential helper, e.g. in system keychain,
+// helpers are never allowed to prompt
+func defaultCredentialToken(host string) string {
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=" + host + "\n\n")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=", "GCM_INTERACTIVE=never")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if password, ok := strings.CutPrefix(line, "password="); ok {
+			return password
+		}
+	}
+	return ""
+}
+
+// credentialToken is replaced in tests
+var credentialToken = defaultCredentialToken
+
+// gitHubAPIURL returns REST API root, GITHUB_API_URL (set by GitHub Actions) takes precedence,
+// enterprise servers serve API under /api/v3 and are trusted only when mapped in platform hosts
+func gitHubAPIURL(info *gitops.RemoteInfo) (string, bool) {
+	if apiURL := os.Getenv("GITHUB_API_URL"); apiURL != "" {
+		return strings.TrimRight(apiURL, "/"), true
+	}
+	if strings.EqualFold(info.Host, "github.com") {
+		return "https://api.github.com", true
+	}
+	if !info.Mapped {
+		return "", false
+	}
+	return "https://" + info.Host + "/api/v3", true
+}
+
+// gitLabAPIURL returns REST API root, CI_API_V4_URL (set by GitLab CI) takes precedence,
+// then GITLAB_URL of self-hosted instance serving host, e.g. over plain http
+func gitLabAPIURL(host string) string {
+	if apiURL := os.Getenv("CI_API_V4_URL"); apiURL != "" {
+		return strings.TrimRight(apiURL, "/")
+	}
+	if instanceURL, ok := gitops.GitLabInstanceURL(host); ok {
+		return instanceURL + "/api/v4"
+	}
+	return "https://" + host + "/api/v4"
+}
+
+type gitHubClient struct {
+	baseURL string
+	owner   string
+	repo    string
+	token   string
+	client  *http.Client
+}
+
+func (c *gitHubClient) CreatePullRequest(
+	ctx context.Context,
+	branch, target string,
+	description pullRequestDescription,
+	options pullRequestOptions,
+) (string, error) {
+	repoURL := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, url.PathEscape(c.owner), url.PathEscape(c.repo))
+	payload := map[string]any{
+		"title": description.Title,
+		"body":  description.Body,
+		"head":  branch,
+		"base":  target,
+	}
+	if options.Draft {
+		payload["draft"] = true
+	}
+
+	var result struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	err := postJSON(ctx, c.client, repoURL+"/pulls", payload, &result, c.authorize)
+	if err != nil {
+		return "", fmt.Errorf("failed to create github pull request: %w", err)
+	}
+
+	// pull requests are labeled as issues, labels cannot be given on creation
+	if len(options.Labels) > 0 {
+		endpoint := fmt.Sprintf("%s/issues/%d/labels", repoURL, result.Number)
+		err := postJSON(ctx, c.client, endpoint, map[string][]string{"labels": options.Labels}, nil, c.authorize)
+		if err != nil {
+			return result.HTMLURL, fmt.Errorf("failed to label github pull request %s: %w", result.HTMLURL, err)
+		}
+	}
+	return result.HTMLURL, nil
+}
+
+func (c *gitHubClient) authorize(req *http.Request) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+}
+
+type gitLabClient struct {
+	baseURL string
+	project string
+	token   string
+	client  *http.Client
+}
+
+func (c *gitLabClient) CreatePullRequest(
+	ctx context.Context,
+	branch, target string,
+	description pullRequestDescription,
+	options pullRequestOptions,
+) (string, error) {
+	endpoint := fmt.Sprintf("%s/projects/%s/merge_requests", c.baseURL, url.PathEscape(c.project))
+	title := description.Title
+	if options.Draft {
+		title = "Draft: " + title
+	}
+	payload := map[string]any{
+		"title":         title,
+		"description":   description.Body,
+		"source_branch": branch,
+		"target_branch": target,
+	}
+	if len(options.Labels) > 0 {
+		payload["labels"] = strings.Join(options.Labels, ",")
+	}
+	if options.Squash {
+		payload["squash"] = true
+	}
+	if options.RemoveSourceBranch {
+		payload["remove_source_branch"] = true
+	}
+
+	var result struct {
+		WebURL string `json:"web_url"`
+	}
+	err := postJSON(ctx, c.client, endpoint, payload, &result, func(req *http.Request) {
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create gitlab merge request: %w", err)
+	}
+	return result.WebURL, nil
+}
+
+// postJSON sends payload and decodes response into result, response body is part of error
+// for non-2xx statuses since platforms explain validation failures there
+func postJSON(
+	ctx context.Context,
+	client *http.Client,
+	endpoint string,
+	payload, result any,
+	authorize func(req *http.Request),
+) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	authorize(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package commit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// awsCLI is replaced in tests
+var awsCLI = defaultAWSCLI
+
+// defaultAWSCLI runs aws cli and returns its output, stderr of failed command is part of error
+func defaultAWSCLI(ctx context.Context, args ...string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, "aws", args...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return output, err
+}
+
+// codeCommitClient creates pull requests through aws cli, which resolves credentials
+// the same way git-remote-codecommit and credential helper of aws cli do
+type codeCommitClient struct {
+	repo    string
+	region  string
+	profile string
+}
+
+// CreatePullRequest opens CodeCommit pull request, draft and labels are not supported by CodeCommit
+func (c *codeCommitClient) CreatePullRequest(
+	ctx context.Context,
+	branch, target string,
+	description pullRequestDescription,
+	_ pullRequestOptions,
+) (string, error) {
+	args := []string{
+		"codecommit", "create-pull-request",
+		"--title", description.Title,
+		"--description", description.Body,
+		"--targets", fmt.Sprintf(
+			"repositoryName=%s,sourceReference=%s,destinationReference=%s", c.repo, branch, target,
+		),
+		"--region", c.region,
+		"--output", "json",
+	}
+	if c.profile != "" {
+		args = append(args, "--profile", c.profile)
+	}
+
+	output, err := awsCLI(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create codecommit pull request: %w", err)
+	}
+
+	var result struct {
+		PullRequest struct {
+			PullRequestID string `json:"pullRequestId"`
+		} `json:"pullRequest"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return "", fmt.Errorf("failed to decode codecommit response: %w", err)
+	}
+
+	return fmt.Sprintf(
+		"https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/pull-requests/%s/details?region=%s",
+		c.region, url.PathEscape(c.repo), url.PathEscape(result.PullRequest.PullRequestID), c.region,
+	), nil
+}
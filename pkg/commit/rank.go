@@ -0,0 +1,56 @@
+package commit
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+)
+
+// rankProviders orders providers of suggestions: in order of configured providers first,
+// remaining ones by latency of their response, fastest first, and by name
+func (s *Service) rankProviders(messages map[string]string) []string {
+	providers := make([]string, 0, len(messages))
+	for provider, message := range messages {
+		if message != "" {
+			providers = append(providers, provider)
+		}
+	}
+
+	latency := func(provider string) time.Duration {
+		if stats, ok := s.aiService.GenerationStats(provider); ok {
+			return stats.Latency
+		}
+		return time.Duration(1<<63 - 1)
+	}
+	preference := func(provider string) int {
+		if i := slices.Index(s.settings.Providers, provider); i >= 0 {
+			return i
+		}
+		return len(s.settings.Providers)
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		a, b := providers[i], providers[j]
+		if preference(a) != preference(b) {
+			return preference(a) < preference(b)
+		}
+		if latency(a) != latency(b) {
+			return latency(a) < latency(b)
+		}
+		return a < b
+	})
+
+	return providers
+}
+
+// topRankedMessage returns suggestion of the best ranked provider, used instead of
+// interactive selection when --yes is set
+func (s *Service) topRankedMessage(ctx context.Context, messages map[string]string) string {
+	ranked := s.rankProviders(messages)
+	if len(ranked) == 0 {
+		return ""
+	}
+	s.logger.DebugContext(ctx, "Selected top-ranked suggestion", "provider", ranked[0])
+	return messages[ranked[0]]
+}
@@ -0,0 +1,65 @@
+package commit
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/commit/pkg/commit/mocks"
+	"github.com/hasansino/commit/pkg/commit/ui"
+)
+
+func TestService_rankProviders(t *testing.T) {
+	latencies := map[string]time.Duration{
+		"claude": 3 * time.Second,
+		"openai": time.Second,
+		"gemini": 2 * time.Second,
+	}
+	messages := map[string]string{"claude": "a", "openai": "b", "gemini": "c", "failed": ""}
+
+	tests := []struct {
+		name      string
+		providers []string
+		want      []string
+	}{
+		{
+			name: "by latency",
+			want: []string{"openai", "gemini", "claude"},
+		},
+		{
+			name:      "configured order first",
+			providers: []string{"claude"},
+			want:      []string{"claude", "openai", "gemini"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ai := mocks.NewMockaiServiceAccessor(ctrl)
+			ai.EXPECT().GenerationStats(gomock.Any()).DoAndReturn(func(provider string) (ui.ProviderStats, bool) {
+				latency, ok := latencies[provider]
+				return ui.ProviderStats{Latency: latency}, ok
+			}).AnyTimes()
+
+			service := &Service{
+				logger:    slog.New(slog.DiscardHandler),
+				settings:  &Settings{Providers: tt.providers},
+				aiService: ai,
+			}
+
+			if got := service.rankProviders(messages); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankProviders() = %v, want %v", got, tt.want)
+			}
+			if got := service.topRankedMessage(context.Background(), messages); got != messages[tt.want[0]] {
+				t.Errorf("topRankedMessage() = %q, want %q", got, messages[tt.want[0]])
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	_ "embed"
+)
+
+//go:embed prompt-release-notes.md
+var releaseNotesPrompt string
+
+// generateReleaseNotes asks providers to summarize commits since previous tag into tag annotation
+func (s *Service) generateReleaseNotes(ctx context.Context, previousTag, newTag string) (string, error) {
+	commits, err := s.gitOps.GetCommitsSince(previousTag)
+	if err != nil {
+		return "", err
+	}
+
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no commits since %s", previousTag)
+	}
+
+	s.logger.DebugContext(ctx, "Requesting release notes...", "commits", len(commits))
+
+	responses, err := s.aiService.Ask(
+		ctx, s.settings.Providers,
+		buildReleaseNotesPrompt(newTag, previousTag, commits),
+		true,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	notes := strings.TrimSpace(s.getRandomMessage(responses))
+	if notes == "" {
+		return "", fmt.Errorf("no release notes received from providers")
+	}
+
+	return notes, nil
+}
+
+func buildReleaseNotesPrompt(version, previous string, commits []string) string {
+	if previous == "" {
+		previous = "none, this is the first release"
+	}
+
+	var b strings.Builder
+	for _, commit := range commits {
+		b.WriteString("- " + strings.ReplaceAll(commit, "\n", "\n  ") + "\n")
+	}
+
+	result := releaseNotesPrompt
+	result = strings.ReplaceAll(result, "{version}", version)
+	result = strings.ReplaceAll(result, "{previous}", previous)
+	result = strings.ReplaceAll(result, "{commits}", strings.TrimSuffix(b.String(), "\n"))
+	return result
+}
@@ -0,0 +1,39 @@
+package commit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildReleaseNotesPrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+		commits  []string
+		contains []string
+	}{
+		{
+			name:     "with previous tag",
+			previous: "v1.0.0",
+			commits:  []string{"feat: add export", "fix: handle empty input\n\nCloses #12"},
+			contains: []string{"v1.1.0", "v1.0.0", "- feat: add export", "- fix: handle empty input\n  \n  Closes #12"},
+		},
+		{
+			name:     "first release",
+			previous: "",
+			commits:  []string{"feat: initial"},
+			contains: []string{"none, this is the first release", "- feat: initial"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildReleaseNotesPrompt("v1.1.0", tt.previous, tt.commits)
+			for _, expected := range tt.contains {
+				if !strings.Contains(result, expected) {
+					t.Errorf("buildReleaseNotesPrompt() does not contain %q", expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package commit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	_ "embed"
+)
+
+//go:embed prompt-review.md
+var reviewPrompt string
+
+// reviewCategories lists finding categories in output order with their headings
+var reviewCategories = []struct {
+	name    string
+	heading string
+}{
+	{"bug", "Potential bugs"},
+	{"tests", "Missing tests"},
+	{"style", "Style issues"},
+}
+
+var reviewSeverityOrder = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+// reviewFinding is a single problem reported by provider
+type reviewFinding struct {
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Message  string `json:"message"`
+	Provider string `json:"-"`
+}
+
+// Review asks providers to review staged changes, or whole branch with branch diff enabled,
+// and prints their findings grouped by category without touching the repository
+func (s *Service) Review(ctx context.Context) error {
+	if err := s.checkRepository(ctx); err != nil {
+		return err
+	}
+
+	var (
+		diff  string
+		files []string
+		err   error
+	)
+
+	if s.settings.BranchDiff {
+		if err := s.ensureFullHistory(ctx, "branch diff"); err != nil {
+			return err
+		}
+		diff, files, err = s.gitOps.GetBranchDiff(
+			s.settings.BranchBase, s.settings.PushRemote,
+			s.settings.MaxDiffSizeBytes,
+		)
+	} else {
+		files, err = s.gitOps.GetStagedFiles()
+		if err == nil && len(files) > 0 {
+			diff, err = s.gitOps.GetStagedDiff(s.settings.MaxDiffSizeBytes, s.settings.NewFileHeadLines)
+		}
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get diff", "error", err)
+		return fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	if strings.TrimSpace(diff) == "" {
+		s.logger.WarnContext(ctx, "Nothing to review, stage changes or use --branch-diff")
+		return nil
+	}
+
+	_, promptBranch, err := s.currentBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.logger.DebugContext(ctx, "Requesting review...", "files", len(files))
+
+	responses, err := s.aiService.Ask(
+		ctx, s.settings.Providers,
+		buildReviewPrompt(diff, promptBranch, files),
+		s.settings.First,
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to request review", "error", err)
+		return fmt.Errorf("failed to request review: %w", err)
+	}
+
+	var (
+		findings []reviewFinding
+		parsed   int
+	)
+	for provider, response := range responses {
+		providerFindings, err := parseReviewFindings(response)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Failed to parse review", "provider", provider, "error", err)
+			continue
+		}
+		for i := range providerFindings {
+			providerFindings[i].Provider = provider
+		}
+		findings = append(findings, providerFindings...)
+		parsed++
+	}
+
+	if parsed == 0 {
+		return fmt.Errorf("no valid review received from providers")
+	}
+
+	fmt.Print(formatReview(findings))
+
+	return nil
+}
+
+func buildReviewPrompt(diff, branch string, files []string) string {
+	result := reviewPrompt
+	result = strings.ReplaceAll(result, "{branch}", branch)
+	result = strings.ReplaceAll(result, "{files}", strings.Join(files, ", "))
+	result = strings.ReplaceAll(result, "{diff}", diff)
+	return result
+}
+
+// parseReviewFindings decodes provider response, findings without message are dropped,
+// unknown categories are reported as style issues and unknown severities as low
+func parseReviewFindings(response string) ([]reviewFinding, error) {
+	start := strings.Index(response, "[")
+	end := strings.LastIndex(response, "]")
+	if start == -1 || end == -1 || end < start {
+		return nil, fmt.Errorf("no json array found in response")
+	}
+
+	var raw []reviewFinding
+	if err := json.Unmarshal([]byte(response[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode review: %w", err)
+	}
+
+	findings := make([]reviewFinding, 0, len(raw))
+	for _, finding := range raw {
+		finding.Message = strings.TrimSpace(finding.Message)
+		if finding.Message == "" {
+			continue
+		}
+		finding.Category = strings.ToLower(strings.TrimSpace(finding.Category))
+		if !isReviewCategory(finding.Category) {
+			finding.Category = "style"
+		}
+		finding.Severity = strings.ToLower(strings.TrimSpace(finding.Severity))
+		if _, ok := reviewSeverityOrder[finding.Severity]; !ok {
+			finding.Severity = "low"
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings, nil
+}
+
+func isReviewCategory(category string) bool {
+	for _, c := range reviewCategories {
+		if c.name == category {
+			return true
+		}
+	}
+	return false
+}
+
+// formatReview renders findings grouped by category, most severe first
+func formatReview(findings []reviewFinding) string {
+	if len(findings) == 0 {
+		return "No issues found.\n"
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return reviewSeverityOrder[findings[i].Severity] < reviewSeverityOrder[findings[j].Severity]
+		}
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+
+	var b strings.Builder
+	for _, category := range reviewCategories {
+		var section []reviewFinding
+		for _, finding := range findings {
+			if finding.Category == category.name {
+				section = append(section, finding)
+			}
+		}
+		if len(section) == 0 {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s (%d):\n", category.heading, len(section))
+		for _, finding := range section {
+			location := finding.File
+			if location != "" && finding.Line > 0 {
+				location = fmt.Sprintf("%s:%d", location, finding.Line)
+			}
+			if location != "" {
+				location += " "
+			}
+			fmt.Fprintf(&b, "  [%s] %s%s", finding.Severity, location, finding.Message)
+			if finding.Provider != "" {
+				fmt.Fprintf(&b, " (%s)", finding.Provider)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package commit
+
+import (
+	"testing"
+)
+
+func TestParseReviewFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     []reviewFinding
+		wantErr  bool
+	}{
+		{
+			name: "wrapped in code block",
+			response: "```json\n" +
+				`[{"category":"bug","severity":"high","file":"a.go","line":3,"message":"nil map"}]` +
+				"\n```",
+			want: []reviewFinding{{Category: "bug", Severity: "high", File: "a.go", Line: 3, Message: "nil map"}},
+		},
+		{
+			name:     "normalizes category and severity",
+			response: `[{"category":"Naming","severity":"critical","message":" rename x "}]`,
+			want:     []reviewFinding{{Category: "style", Severity: "low", Message: "rename x"}},
+		},
+		{
+			name:     "drops empty messages",
+			response: `[{"category":"tests","severity":"medium","message":""}]`,
+			want:     []reviewFinding{},
+		},
+		{
+			name:     "empty review",
+			response: `[]`,
+			want:     []reviewFinding{},
+		},
+		{
+			name:     "no array",
+			response: "looks good to me",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseReviewFindings(tt.response)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseReviewFindings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseReviewFindings() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("finding %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFormatReview(t *testing.T) {
+	findings := []reviewFinding{
+		{Category: "style", Severity: "low", File: "b.go", Message: "unclear name", Provider: "openai"},
+		{Category: "bug", Severity: "low", File: "a.go", Line: 7, Message: "ignored error", Provider: "claude"},
+		{Category: "bug", Severity: "high", File: "a.go", Line: 12, Message: "nil dereference", Provider: "claude"},
+		{Category: "tests", Severity: "medium", Message: "parser is not covered"},
+	}
+
+	want := `Potential bugs (2):
+  [high] a.go:12 nil dereference (claude)
+  [low] a.go:7 ignored error (claude)
+
+Missing tests (1):
+  [medium] parser is not covered
+
+Style issues (1):
+  [low] b.go unclear name (openai)
+`
+
+	if got := formatReview(findings); got != want {
+		t.Errorf("formatReview() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := formatReview(nil); got != "No issues found.\n" {
+		t.Errorf("formatReview(nil) = %q", got)
+	}
+}
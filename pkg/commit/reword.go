@@ -0,0 +1,130 @@
+package commit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hasansino/commit/pkg/commit/ui"
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+// Reword generates a new message for an existing commit from its diff and rewrites it,
+// commits already present on a remote are rejected unless force is set
+func (s *Service) Reword(ctx context.Context, ref string, force bool) error {
+	if err := s.checkRepository(ctx); err != nil {
+		return err
+	}
+
+	sha, err := s.gitOps.ResolveRewordTarget(ref)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Commit cannot be reworded", "ref", ref, "error", err)
+		return fmt.Errorf("failed to resolve commit: %w", err)
+	}
+
+	pushed, err := s.gitOps.IsCommitPushed(sha)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to check if commit is pushed", "error", err)
+		return fmt.Errorf("failed to check if commit is pushed: %w", err)
+	}
+	if pushed {
+		if !force {
+			s.logger.ErrorContext(ctx, "Commit is already pushed, use --force to rewrite it anyway", "commit", sha)
+			return fmt.Errorf("commit %s is already pushed", gitops.ShortSHA(sha))
+		}
+		s.logger.WarnContext(ctx, "Rewording pushed commit, force push will be required", "commit", sha)
+	}
+
+	diff, files, err := s.gitOps.GetCommitDiff(sha, s.settings.MaxDiffSizeBytes)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get commit diff", "error", err)
+		return fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	if strings.TrimSpace(diff) == "" {
+		s.logger.WarnContext(ctx, "Commit has no changes to describe", "commit", sha)
+		return nil
+	}
+
+	branch, promptBranch, err := s.currentBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	commitTemplate, err := s.gitOps.GetCommitTemplate()
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load commit template", "error", err)
+	}
+
+	s.logger.DebugContext(ctx, "Requesting commit messages...")
+
+	messages, err := s.aiService.GenerateCommitMessages(
+		ctx,
+		diff, promptBranch, files,
+		s.settings.Providers, s.settings.CustomPrompt, commitTemplate,
+		nil,
+		s.settings.First, s.settings.MultiLine,
+		s.promptTransformer(branch),
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to generate commit messages", "error", err)
+		return fmt.Errorf("failed to generate suggestions: %w", err)
+	}
+
+	var commitMessage string
+
+	if s.settings.Auto {
+		commitMessage = s.getRandomMessage(messages)
+	} else if s.settings.Yes {
+		commitMessage = s.topRankedMessage(ctx, messages)
+	} else {
+		uiModel, err := ui.RenderInteractiveUI(
+			ctx,
+			messages,
+			map[string]bool{ui.CheckboxIDDryRun: s.settings.DryRun},
+			s.accessibleOptions()...,
+		)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				s.logger.WarnContext(ctx, "Interactive mode canceled by user")
+				return nil
+			}
+			s.logger.ErrorContext(ctx, "Failed to enter interactive mode", "error", err)
+			return fmt.Errorf("failed to run interactive ui: %w", err)
+		}
+		commitMessage = uiModel.GetFinalChoice()
+		s.settings.DryRun = uiModel.GetCheckboxValue(ui.CheckboxIDDryRun)
+	}
+
+	if len(commitMessage) == 0 {
+		s.logger.WarnContext(ctx, "No commit message provided")
+		return fmt.Errorf("no commit message provided")
+	}
+
+	commitMessage, err = s.applyModules(ctx, branch, commitMessage)
+	if err != nil {
+		return err
+	}
+	commitMessage = s.appendTrailers(ctx, branch, commitMessage)
+	commitMessage = strings.TrimSpace(commitMessage)
+
+	if s.settings.DryRun {
+		s.logger.WarnContext(ctx, "Dry run enabled, no side effects created")
+		s.logger.InfoContext(ctx, "Final commit message", "commit", sha, "message", commitMessage)
+		return nil
+	}
+
+	if err := s.gitOps.RewordCommit(sha, commitMessage, s.settings.NoVerify); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to reword commit", "error", err)
+		return fmt.Errorf("failed to reword commit: %w", err)
+	}
+
+	s.logger.InfoContext(
+		ctx, "Commit reworded",
+		"commit", sha,
+		"commit_message", commitMessage,
+	)
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package commit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JSON-RPC 2.0 error codes, codes above -32000 are defined by the tool
+const (
+	rpcParseError      = -32700
+	rpcInvalidRequest  = -32600
+	rpcMethodNotFound  = -32601
+	rpcInvalidParams   = -32602
+	rpcInternalError   = -32603
+	rpcNothingToCommit = -32001
+)
+
+const rpcMaxMessageSize = 16 << 20
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcCommitParams commit message, usually one of suggestions, which are final already,
+// push and tag override settings of the session
+type rpcCommitParams struct {
+	Message  string  `json:"message"`
+	Provider string  `json:"provider,omitempty"`
+	Push     *bool   `json:"push,omitempty"`
+	Tag      *string `json:"tag,omitempty"`
+}
+
+type rpcCommitResult struct {
+	Hash            string `json:"hash"`
+	Tag             string `json:"tag,omitempty"`
+	Pushed          bool   `json:"pushed"`
+	MergeRequestURL string `json:"merge_request_url,omitempty"`
+}
+
+// ServeRPC answers JSON-RPC 2.0 requests of editor plugins, one message per line, until
+// input ends, shutdown is requested or ctx is done. Methods are generate, listSuggestions,
+// commit and shutdown, suggestions are cached like in daemon
+func (s *Service) ServeRPC(ctx context.Context, r io.Reader, w io.Writer) error {
+	if s.aiService.NumProviders() == 0 {
+		s.logger.WarnContext(ctx, "No providers configured")
+		return fmt.Errorf("no api keys found in environment")
+	}
+
+	d := &daemon{service: s}
+	encoder := json.NewEncoder(w)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), rpcMaxMessageSize)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		response, shutdown := d.handleRPC(ctx, []byte(line))
+		if response != nil {
+			if err := encoder.Encode(response); err != nil {
+				return fmt.Errorf("failed to write rpc response: %w", err)
+			}
+		}
+		if shutdown {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read rpc request: %w", err)
+	}
+	return nil
+}
+
+// handleRPC answers one message, response is nil for notifications, which have no id
+func (d *daemon) handleRPC(ctx context.Context, message []byte) (*rpcResponse, bool) {
+	var request rpcRequest
+	if err := json.Unmarshal(message, &request); err != nil {
+		return rpcFailure(nil, rpcParseError, fmt.Sprintf("invalid json: %v", err)), false
+	}
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		return rpcFailure(request.ID, rpcInvalidRequest, "invalid request: jsonrpc 2.0 with method expected"), false
+	}
+
+	result, rpcErr := d.callRPC(ctx, request.Method, request.Params)
+	if len(request.ID) == 0 {
+		if rpcErr != nil {
+			d.service.logger.WarnContext(ctx, "RPC notification failed",
+				"method", request.Method, "error", rpcErr.Message)
+		}
+		return nil, request.Method == "shutdown"
+	}
+
+	response := &rpcResponse{JSONRPC: "2.0", ID: request.ID, Result: result, Error: rpcErr}
+	if rpcErr == nil && result == nil {
+		// result is required in successful responses
+		response.Result = struct{}{}
+	}
+	return response, request.Method == "shutdown" && rpcErr == nil
+}
+
+func (d *daemon) callRPC(ctx context.Context, method string, params json.RawMessage) (any, *rpcError) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch method {
+	case "generate":
+		var request DaemonRequest
+		if err := decodeRPCParams(params, &request); err != nil {
+			return nil, err
+		}
+		response, err := d.generate(ctx, request)
+		if err != nil {
+			return nil, rpcErrorOf(err)
+		}
+		return response, nil
+
+	case "listSuggestions":
+		d.refresh(ctx)
+		return DaemonResponse{Suggestions: d.suggestions, Cached: d.suggestions != nil}, nil
+
+	case "commit":
+		var request rpcCommitParams
+		if err := decodeRPCParams(params, &request); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(request.Message) == "" {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "invalid params: message is required"}
+		}
+		result, err := d.service.commitMessage(ctx, request)
+		if err != nil {
+			return nil, rpcErrorOf(err)
+		}
+		return result, nil
+
+	case "shutdown":
+		return nil, nil
+
+	default:
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method not found: %s", method)}
+	}
+}
+
+// commitMessage creates commit of staged changes with given message, then tags, pushes
+// and notifies like interactive commits do, push and tag of request override settings
+func (s *Service) commitMessage(ctx context.Context, request rpcCommitParams) (rpcCommitResult, error) {
+	settings := *s.settings
+	if request.Push != nil {
+		settings.Push = *request.Push
+	}
+	if request.Tag != nil {
+		settings.Tag = *request.Tag
+	}
+	err := checkChoice("tag increment type", settings.Tag, "major", "minor", "patch", "prerelease", "auto")
+	if err != nil {
+		return rpcCommitResult{}, err
+	}
+	session := *s
+	session.settings = &settings
+
+	message := strings.TrimSpace(request.Message)
+	published, err := session.publishCommit(ctx, message, request.Provider)
+	if err != nil {
+		return rpcCommitResult{}, err
+	}
+	session.notifyCommit(ctx, message, published)
+
+	hash, err := s.gitOps.ResolveCommit("HEAD")
+	if err != nil {
+		return rpcCommitResult{}, fmt.Errorf("failed to resolve created commit: %w", err)
+	}
+	return rpcCommitResult{
+		Hash:            hash,
+		Tag:             published.tag,
+		Pushed:          published.pushed,
+		MergeRequestURL: published.mergeRequestURL,
+	}, nil
+}
+
+func decodeRPCParams(params json.RawMessage, target any) *rpcError {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, target); err != nil {
+		return &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
+
+func rpcErrorOf(err error) *rpcError {
+	if errors.Is(err, ErrNothingToCommit) {
+		return &rpcError{Code: rpcNothingToCommit, Message: err.Error()}
+	}
+	return &rpcError{Code: rpcInternalError, Message: err.Error()}
+}
+
+func rpcFailure(id json.RawMessage, code int, message string) *rpcResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
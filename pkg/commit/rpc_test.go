@@ -0,0 +1,120 @@
+package commit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/commit/pkg/commit/mocks"
+)
+
+func TestService_ServeRPC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stagedDiff := "diff --git a.go a.go\n@@ -1 +1 @@\n-a\n+b\n"
+
+	git := mocks.NewMockgitOperationsAccessor(ctrl)
+	git.EXPECT().GetIndexHash().Return("index-a", nil).AnyTimes()
+	git.EXPECT().GetStagedDiff(gomock.Any(), gomock.Any()).Return(stagedDiff, nil).Times(1)
+	git.EXPECT().GetStagedFiles().Return([]string{"a.go"}, nil).Times(1)
+	git.EXPECT().GetCurrentBranch().Return("main", nil).AnyTimes()
+	git.EXPECT().GetCommitTemplate().Return("", nil).AnyTimes()
+
+	ai := mocks.NewMockaiServiceAccessor(ctrl)
+	ai.EXPECT().NumProviders().Return(1).AnyTimes()
+	ai.EXPECT().GenerateCommitMessages(
+		gomock.Any(), gomock.Any(), "main", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).Return(map[string]string{"openai": "feat: change a.go"}, nil).Times(1)
+
+	service := &Service{
+		logger:    slog.New(slog.DiscardHandler),
+		settings:  &Settings{MaxDiffSizeBytes: 1024},
+		gitOps:    git,
+		aiService: ai,
+	}
+
+	input := strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "listSuggestions"}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "generate", "params": {}}`,
+		`{"jsonrpc": "2.0", "id": "3", "method": "listSuggestions"}`,
+		`{"jsonrpc": "2.0", "method": "listSuggestions"}`,
+		`not json`,
+		`{"jsonrpc": "2.0", "id": 4, "method": "amend"}`,
+		`{"jsonrpc": "2.0", "id": 5, "method": "commit", "params": {"message": " "}}`,
+		`{"jsonrpc": "2.0", "id": 6, "method": "commit", "params": ["feat: change"]}`,
+		`{"id": 7, "method": "generate"}`,
+		`{"jsonrpc": "2.0", "id": 8, "method": "shutdown"}`,
+		`{"jsonrpc": "2.0", "id": 9, "method": "generate"}`,
+	}, "\n")
+
+	var output strings.Builder
+	if err := service.ServeRPC(context.Background(), strings.NewReader(input), &output); err != nil {
+		t.Fatalf("ServeRPC() unexpected error = %v", err)
+	}
+
+	type response struct {
+		ID     json.RawMessage `json:"id"`
+		Result *struct {
+			Suggestions map[string]string `json:"suggestions"`
+			Cached      bool              `json:"cached"`
+		} `json:"result"`
+		Error *rpcError `json:"error"`
+	}
+	var responses []response
+	scanner := bufio.NewScanner(strings.NewReader(output.String()))
+	for scanner.Scan() {
+		var r response
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("failed to decode response %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, r)
+	}
+
+	tests := []struct {
+		id        string
+		wantCode  int
+		wantFirst string
+		wantCache bool
+	}{
+		{id: "1"},
+		{id: "2", wantFirst: "feat: change a.go"},
+		{id: `"3"`, wantFirst: "feat: change a.go", wantCache: true},
+		{id: "null", wantCode: rpcParseError},
+		{id: "4", wantCode: rpcMethodNotFound},
+		{id: "5", wantCode: rpcInvalidParams},
+		{id: "6", wantCode: rpcInvalidParams},
+		{id: "7", wantCode: rpcInvalidRequest},
+		{id: "8"},
+	}
+	if len(responses) != len(tests) {
+		t.Fatalf("ServeRPC() wrote %d responses, want %d:\n%s", len(responses), len(tests), output.String())
+	}
+	for i, tt := range tests {
+		got := responses[i]
+		if string(got.ID) != tt.id {
+			t.Errorf("response %d id = %s, want %s", i, got.ID, tt.id)
+			continue
+		}
+		if tt.wantCode != 0 {
+			if got.Error == nil || got.Error.Code != tt.wantCode {
+				t.Errorf("response %s error = %+v, want code %d", tt.id, got.Error, tt.wantCode)
+			}
+			continue
+		}
+		if got.Error != nil || got.Result == nil {
+			t.Errorf("response %s = error %+v, want result", tt.id, got.Error)
+			continue
+		}
+		if got.Result.Suggestions["openai"] != tt.wantFirst || got.Result.Cached != tt.wantCache {
+			t.Errorf("response %s result = %+v, want suggestion %q cached %v",
+				tt.id, got.Result, tt.wantFirst, tt.wantCache)
+		}
+	}
+}
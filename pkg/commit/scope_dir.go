@@ -0,0 +1,9 @@
+package commit
+
+import "github.com/hasansino/commit/pkg/commit/modules"
+
+// deriveScopeName returns conventional commit scope for a directory,
+// package.json name is preferred, otherwise directory name is used
+func deriveScopeName(absDir string) string {
+	return modules.DirectoryScope(absDir)
+}
@@ -0,0 +1,54 @@
+package commit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDeriveScopeName(t *testing.T) {
+	root := t.TempDir()
+
+	plainDir := filepath.Join(root, "Billing_Service")
+	npmDir := filepath.Join(root, "web")
+
+	for _, dir := range []string{plainDir, npmDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	err := os.WriteFile(filepath.Join(npmDir, "package.json"), []byte(`{"name": "@acme/ui-kit"}`), 0o644)
+	if err != nil {
+		t.Fatalf("failed to write package.json: %v", err)
+	}
+
+	if result := deriveScopeName(plainDir); result != "billing_service" {
+		t.Errorf("deriveScopeName() = %q, want %q", result, "billing_service")
+	}
+	if result := deriveScopeName(npmDir); result != "ui-kit" {
+		t.Errorf("deriveScopeName() = %q, want %q", result, "ui-kit")
+	}
+}
+
+func TestNewCommitService_ScopeDir(t *testing.T) {
+	dir := newTestRepository(t)
+	if err := os.MkdirAll(filepath.Join(dir, "services", "billing"), 0o755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	t.Chdir(filepath.Join(dir, "services"))
+
+	settings := &Settings{Timeout: 30 * time.Second, ScopeDir: "billing"}
+	service, err := NewCommitService(settings)
+	if err != nil {
+		t.Fatalf("NewCommitService() error = %v", err)
+	}
+
+	if service.scopeDir != "services/billing" {
+		t.Errorf("scopeDir = %q, want %q", service.scopeDir, "services/billing")
+	}
+	if settings.ScopeDir != "billing" {
+		t.Errorf("settings.ScopeDir = %q, caller settings must not be modified", settings.ScopeDir)
+	}
+}
@@ -2,25 +2,120 @@ package commit
 
 import (
 	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/hasansino/commit/pkg/commit/modules"
+	"github.com/hasansino/commit/pkg/gitops"
 )
 
 type Settings struct {
 	Providers          []string      // AI providers to use for commit message generation
 	Timeout            time.Duration // Timeout for API requests
+	MaxConcurrent      int           // Provider requests in flight at once, 0 is unlimited
+	Models             []string      // Models as provider=model, override *_MODEL environment variables
 	CustomPrompt       string        // Custom prompt template for commit messages
 	First              bool          // Use the first received message and discard others
 	Auto               bool          // Auto-commit with the first suggestion, no interactive mode
 	DryRun             bool          // Show what would be committed without actually committing
+	Copy               bool          // Copy final message to clipboard instead of committing
+	History            bool          // Store suggestions per repository to recall them in later runs
+	SuggestionCache    bool          // Reuse suggestions of previous run when staged changes and options are unchanged
+	Accessible         bool          // Linear, screen reader friendly prompts instead of full screen UI
+	RememberUI         bool          // Remember interactive mode options and layout per repository
+	CostThreshold      float64       // Confirm requests estimated to cost more than this many USD, 0 disables
 	ExcludePatterns    []string      // File patterns to exclude from the commit
 	IncludePatterns    []string      // File patterns to include in the commit
 	MultiLine          bool          // Use multi-line commit messages
 	Push               bool          // Push after commit
-	Tag                string        // Tag increment type: major, minor, or patch
+	PushRemote         string        // Remote to push to, defaults to origin
+	ForceWithLease     bool          // Push with --force-with-lease
+	SetUpstream        bool          // Set upstream when pushing branch without one
+	PullRebase         bool          // Rebase onto remote branch before push when it has new commits
+	RollbackCommit     bool          // Undo local commit when tagging or pushing it fails
+	CreatePR           bool          // Create pull or merge request with generated description after push
+	PRBase             string        // Branch created pull request targets, defaults to default branch of push remote
+	PRDraft            bool          // Create pull request as draft
+	PRLabels           []string      // Labels of created pull request
+	MRSquash           bool          // Squash commits of created gitlab merge request when it is merged
+	MRRemoveSource     bool          // Delete source branch of created gitlab merge request when it is merged
+	PlatformHosts      []string      // Platforms of hosts as host=platform, for instances not named after platform
+	Tag                string        // Tag increment type: major, minor, patch, prerelease or auto
 	UseGlobalGitignore bool          // Use global gitignore from git config core.excludesFile
 	MaxDiffSizeBytes   int           // Maximum diff size in bytes to consider for commit message generation
+	NewFileHeadLines   int           // New files longer than this are summarized in prompts, 0 disables
+	MapReduce          bool          // Diffs over MaxDiffSizeBytes are summarized by chunks instead of being cut
+	SummaryModels      []string      // Models as provider=model for chunk summaries, e.g. cheaper ones
 	JiraTaskPosition   string        // Jira task position: prefix/infix/suffix/none
-	JiraTaskStyle      string        // Jira task style: brackets/parens/none
+	JiraTaskStyle      string        // Jira task style: brackets/parens/plain-colon/plain/none
+	JiraEnrich         bool          // Fetch detected Jira issue from API and add it to prompt
+	JiraValidate       string        // Handling of Jira issues not in progress or unknown: refuse, warn or off
+	JiraComment        bool          // Comment detected Jira issue with created commit
+	JiraTransition     string        // Move detected Jira issue to this status after commit, e.g. In Review
+	ChatWebhooks       []string      // Slack or Teams incoming webhooks notified about commits
+	ChatNotifyOn       string        // Commits chat is notified about: commit, push or tag
+	Webhooks           []string      // URLs receiving JSON payload of lifecycle events
+	WebhookEvents      []string      // Lifecycle events webhooks are fired on, empty fires all
+	WebhookSecret      string        // Key of HMAC-SHA256 signature of webhook payloads, empty leaves them unsigned
+	AzureWorkItem      string        // Azure Boards work item placement: subject/footer/none
+	TicketPattern      string        // Regex detecting ticket in branch name for trackers without native module
+	TicketFormat       string        // Template rendering detected ticket, e.g. "Refs: {{.Ticket}}"
+	TicketPosition     string        // Ticket position in commit message: prefix/suffix/footer
+	InferScope         string        // Conventional commit scope inferred from staged paths: missing/override/off
+	DepsMessage        bool          // Describe dependency-only changes by version delta, without providers
+	DetectBreaking     bool          // Mark commits breaking exported Go API with ! and BREAKING CHANGE footer
+	CoAuthors          []string      // Co-authors as Name <email> or initials from .pairs file
+	Pairing            bool          // Add active git-duet/git-together pair as co-authors
+	SignOff            bool          // Add Signed-off-by trailer of committer identity
+	Trailers           []string      // Trailers in "Key: value" form added to every commit message
+	MessageTemplate    string        // Path to Go text/template every commit message is rendered with
+	TranslateTo        string        // Language commit messages are translated into, empty disables
+	TranslateMode      string        // Translation placement: replace or bilingual
+	ASCIIOnly          bool          // Transliterate or strip emoji and non-ASCII characters of commit messages
+	BannedWords        []string      // Words prohibited in commit messages in addition to default profanity list
+	BannedWordsAction  string        // Handling of banned words in commit messages: mask/reject/off
+	PluginDir          string        // Directory of WASM plugin modules, empty loads none
+	ExecModules        []string      // Executables transforming prompts and messages, JSON on stdin, text on stdout
+	CloseIssues        string        // Keyword closing issues referenced by branch or subject, e.g. closes, or off
+	SubjectCase        string        // Case of subject first letter: lower/sentence/keep
+	StripPeriod        bool          // Remove trailing period from subject
+	Imperative         string        // Imperative mood enforcement of subject: heuristic/ai/off
+	SubjectLimit       int           // Maximum subject length, 0 disables
+	SubjectOverflow    string        // Handling of subject words past the limit: truncate/wrap
+	BodyWidth          int           // Column to hard-wrap body lines at, 0 disables
+	NoVerify           bool          // Skip pre-commit and commit-msg hooks
+	SubmoduleLog       bool          // Include commit log of updated submodules in prompts
+	ScopeDir           string        // Restrict staging to a subdirectory and derive commit scope from it
+	ReleaseNotes       bool          // Generate tag annotation from commits since previous tag
+	TagPrefix          string        // Prefix of semver tags, e.g. v, release- or app/v, defaults to v
+	TagPattern         string        // Glob used to list existing tags, defaults to prefix followed by *
+	Author             string        // Override commit author, in "Name <email>" form
+	Date               string        // Override commit author date
+	AllowEmpty         bool          // Create commit even when there are no changes
+	RecentCommits      int           // Number of recent commit subjects included in prompts as style reference
+	BranchDiff         bool          // Summarize whole branch against its base instead of committing staged changes
+	BranchBase         string        // Base ref for branch diff, defaults to default branch of push remote
+	StashUnrelated     bool          // Stash unstaged and untracked changes during commit and restore them afterwards
+	Deepen             bool          // Fetch full history of shallow clone when tagging or diffing branch needs it
+	LargeBinaryBytes   int64         // Warn about staged non-LFS binaries larger than this, 0 disables
+	AbortOnLargeBinary bool          // Abort instead of warning about large non-LFS binaries
+	RepoPath           string        // Path inside repository worktree, defaults to GIT_WORK_TREE or current directory
+	DiffAlgorithm      string        // Diff algorithm: myers, minimal, patience or histogram
+	RenameThreshold    int           // Rename detection similarity percentage, 0 uses 50, negative disables it
+	NoFunctionContext  bool          // Do not show whole function around changes in staged diff
+	CompressDiff       bool          // Drop whitespace-only hunks, index lines and long unchanged runs from diffs
+	PureGo             bool          // Use go-git instead of git binary for diff, tags, push and conflict detection
+	TraceGit           bool          // Trace spawned git commands, like GIT_TRACE=1
+	ConflictMarkers    string        // Handling of conflict markers in staged changes: refuse, warn or ignore
+	Notes              bool          // Record provider, model, prompt hash and token usage in git notes of commit
+	Message            string        // Commit this message through modules and trailers instead of generating one
+	DiffFile           string        // Generate message of diff read from this file, "-" reads stdin, and only print it
+	OutputFile         string        // Write final message to this file instead of committing
+	PrintOnly          bool          // Print final message to stdout instead of committing
+	Yes                bool          // Pick top-ranked suggestion and confirm prompts without interactive mode
+	Quiet              bool          // Print only final message or hash of created commit
 }
 
 func (o *Settings) Validate() error {
@@ -30,8 +125,168 @@ func (o *Settings) Validate() error {
 	if o.Timeout <= 0 {
 		return fmt.Errorf("timeout must be greater than zero")
 	}
-	if o.Tag != "" && o.Tag != "major" && o.Tag != "minor" && o.Tag != "patch" {
-		return fmt.Errorf("invalid tag increment type: %s (must be major, minor, or patch)", o.Tag)
+	if o.MaxConcurrent < 0 {
+		return fmt.Errorf("invalid max concurrent providers: %d (must not be negative)", o.MaxConcurrent)
+	}
+	for _, provider := range o.Providers {
+		if err := checkChoice("provider", strings.ToLower(strings.TrimSpace(provider)), knownProviders...); err != nil {
+			return err
+		}
+	}
+	if _, err := parseModels(o.Models); err != nil {
+		return err
+	}
+	if _, err := parseModels(o.SummaryModels); err != nil {
+		return err
+	}
+	if _, err := parsePlatformHosts(o.PlatformHosts); err != nil {
+		return err
+	}
+	for _, patterns := range [][]string{o.ExcludePatterns, o.IncludePatterns} {
+		for _, pattern := range patterns {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid file pattern: %s (%w)", pattern, err)
+			}
+		}
+	}
+	choices := []struct {
+		field   string
+		value   string
+		choices []string
+	}{
+		{"tag increment type", o.Tag, []string{"major", "minor", "patch", "prerelease", "auto"}},
+		{"diff algorithm", o.DiffAlgorithm, []string{"myers", "minimal", "patience", "histogram"}},
+		{"conflict markers handling", o.ConflictMarkers, []string{"refuse", "warn", "ignore"}},
+		{
+			"jira task position", strings.ToLower(o.JiraTaskPosition),
+			[]string{"prefix", "infix", "suffix", "none"},
+		},
+		{
+			"jira task style", strings.ToLower(o.JiraTaskStyle),
+			[]string{"brackets", "parens", "plain-colon", "plain", "none"},
+		},
+		{"jira validation mode", o.JiraValidate, []string{"refuse", "warn", "off"}},
+		{"azure work item placement", strings.ToLower(o.AzureWorkItem), []string{"subject", "footer", "none"}},
+		{"ticket position", strings.ToLower(o.TicketPosition), []string{"prefix", "suffix", "footer"}},
+		{"scope inference", o.InferScope, []string{"missing", "override", "off"}},
+		{"subject case", o.SubjectCase, []string{"lower", "sentence", "keep"}},
+		{"imperative mode", o.Imperative, []string{"heuristic", "ai", "off"}},
+		{"translation mode", o.TranslateMode, []string{"replace", "bilingual"}},
+		{"banned words action", o.BannedWordsAction, []string{"mask", "reject", "off"}},
+		{"subject overflow", o.SubjectOverflow, []string{"truncate", "wrap"}},
+		{
+			"chat notification event", o.ChatNotifyOn,
+			[]string{modules.ChatNotifyOnCommit, modules.ChatNotifyOnPush, modules.ChatNotifyOnTag},
+		},
+	}
+	for _, c := range choices {
+		if err := checkChoice(c.field, c.value, c.choices...); err != nil {
+			return err
+		}
+	}
+	if o.CloseIssues != "" && o.CloseIssues != "off" && !modules.IsClosingKeyword(o.CloseIssues) {
+		return fmt.Errorf(
+			"invalid issue closing keyword: %s (must be e.g. closes, fixes, resolves or off)", o.CloseIssues,
+		)
+	}
+	for _, trailer := range o.Trailers {
+		if !modules.IsTrailer(strings.TrimSpace(trailer)) {
+			return fmt.Errorf("invalid trailer: %s (must be in \"Key: value\" form)", trailer)
+		}
+	}
+	if o.Message != "" && o.BranchDiff {
+		return fmt.Errorf("invalid message: cannot be combined with branch diff")
+	}
+	if o.DiffFile != "" && (o.Message != "" || o.BranchDiff) {
+		return fmt.Errorf("invalid diff file: cannot be combined with message or branch diff")
+	}
+	for _, webhook := range o.ChatWebhooks {
+		if !isWebhookURL(webhook) {
+			return fmt.Errorf("invalid chat webhook: must be http or https url")
+		}
+	}
+	for _, webhook := range o.Webhooks {
+		if !isWebhookURL(webhook) {
+			return fmt.Errorf("invalid webhook: must be http or https url")
+		}
+	}
+	for _, event := range o.WebhookEvents {
+		if err := checkChoice("webhook event", strings.ToLower(event), modules.LifecycleEvents...); err != nil {
+			return err
+		}
+	}
+	if o.CostThreshold < 0 {
+		return fmt.Errorf("invalid cost threshold: %g (must not be negative)", o.CostThreshold)
+	}
+	if o.SubjectLimit < 0 || o.BodyWidth < 0 {
+		return fmt.Errorf("invalid message wrapping: subject limit and body width must not be negative")
+	}
+	if o.RenameThreshold > 100 {
+		return fmt.Errorf("invalid rename threshold: %d (must not exceed 100)", o.RenameThreshold)
+	}
+	if o.TicketPattern != "" {
+		_, err := modules.NewTicketDetector(
+			o.TicketPattern, o.TicketFormat, modules.TicketPosition(strings.ToLower(o.TicketPosition)),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if o.Author != "" {
+		if _, _, err := gitops.ParseIdentity(o.Author); err != nil {
+			return err
+		}
+	}
+	if o.Date != "" {
+		if _, err := gitops.ParseGitDate(o.Date); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// parseModels returns models by provider from provider=model pairs
+func parseModels(pairs []string) (map[string]string, error) {
+	models := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		provider, model, ok := strings.Cut(pair, "=")
+		provider, model = strings.ToLower(strings.TrimSpace(provider)), strings.TrimSpace(model)
+		if !ok || model == "" {
+			return nil, fmt.Errorf("invalid model: %s (must be in provider=model form)", pair)
+		}
+		if err := checkChoice("model provider", provider, knownProviders...); err != nil {
+			return nil, err
+		}
+		models[provider] = model
+	}
+	return models, nil
+}
+
+// parsePlatformHosts returns platforms by lowercase host from host=platform pairs
+func parsePlatformHosts(pairs []string) (map[string]gitops.Platform, error) {
+	var platforms []string
+	for _, platform := range gitops.Platforms() {
+		platforms = append(platforms, string(platform))
+	}
+
+	hosts := make(map[string]gitops.Platform, len(pairs))
+	for _, pair := range pairs {
+		host, platform, ok := strings.Cut(pair, "=")
+		host, platform = strings.ToLower(strings.TrimSpace(host)), strings.ToLower(strings.TrimSpace(platform))
+		if !ok || host == "" || platform == "" {
+			return nil, fmt.Errorf("invalid platform host: %s (must be in host=platform form)", pair)
+		}
+		if err := checkChoice("platform", platform, platforms...); err != nil {
+			return nil, err
+		}
+		hosts[host] = gitops.Platform(platform)
+	}
+	return hosts, nil
+}
+
+// isWebhookURL reports whether webhook is absolute http or https url,
+// url is not part of errors as it usually carries a token
+func isWebhookURL(webhook string) bool {
+	u, err := url.Parse(webhook)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
@@ -0,0 +1,220 @@
+package commit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+func TestParseModels(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			pairs: nil,
+			want:  map[string]string{},
+		},
+		{
+			name:  "several providers",
+			pairs: []string{"openai=gpt-4.1-mini", " Claude = claude-sonnet-4-5 "},
+			want:  map[string]string{"openai": "gpt-4.1-mini", "claude": "claude-sonnet-4-5"},
+		},
+		{
+			name:    "missing model",
+			pairs:   []string{"gemini="},
+			wantErr: true,
+		},
+		{
+			name:    "unknown provider",
+			pairs:   []string{"llama=llama-3"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseModels(tt.pairs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseModels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseModels() = %v, want %v", got, tt.want)
+			}
+			for provider, model := range tt.want {
+				if got[provider] != model {
+					t.Errorf("parseModels()[%s] = %q, want %q", provider, got[provider], model)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePlatformHosts(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    map[string]gitops.Platform
+		wantErr bool
+	}{
+		{
+			name:  "several hosts",
+			pairs: []string{"git.mycorp.com=gitlab", " Code.Example.org = GitHub "},
+			want: map[string]gitops.Platform{
+				"git.mycorp.com": gitops.PlatformGitLab, "code.example.org": gitops.PlatformGitHub,
+			},
+		},
+		{
+			name:  "azure devops server",
+			pairs: []string{"tfs.mycorp.com=azure"},
+			want:  map[string]gitops.Platform{"tfs.mycorp.com": gitops.PlatformAzure},
+		},
+		{
+			name:  "codecommit endpoint",
+			pairs: []string{"git-codecommit.vpce.mycorp.com=CodeCommit"},
+			want:  map[string]gitops.Platform{"git-codecommit.vpce.mycorp.com": gitops.PlatformCodeCommit},
+		},
+		{
+			name:    "missing platform",
+			pairs:   []string{"git.mycorp.com"},
+			wantErr: true,
+		},
+		{
+			name:    "empty platform",
+			pairs:   []string{"git.mycorp.com="},
+			wantErr: true,
+		},
+		{
+			name:    "unknown platform",
+			pairs:   []string{"git.mycorp.com=gitea"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePlatformHosts(tt.pairs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePlatformHosts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parsePlatformHosts() = %v, want %v", got, tt.want)
+			}
+			for host, platform := range tt.want {
+				if got[host] != platform {
+					t.Errorf("parsePlatformHosts()[%s] = %q, want %q", host, got[host], platform)
+				}
+			}
+		})
+	}
+}
+
+func TestSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(s *Settings)
+		wantErr  string
+		wantHint string
+	}{
+		{
+			name:   "defaults",
+			modify: func(s *Settings) {},
+		},
+		{
+			name:     "jira position typo",
+			modify:   func(s *Settings) { s.JiraTaskPosition = "sufix" },
+			wantErr:  "invalid jira task position: sufix",
+			wantHint: "did you mean 'suffix'?",
+		},
+		{
+			name:    "jira style unknown",
+			modify:  func(s *Settings) { s.JiraTaskStyle = "curly" },
+			wantErr: "invalid jira task style: curly",
+		},
+		{
+			name:     "provider typo",
+			modify:   func(s *Settings) { s.Providers = []string{"claude", "opanai"} },
+			wantErr:  "invalid provider: opanai",
+			wantHint: "did you mean 'openai'?",
+		},
+		{
+			name:    "bad exclude pattern",
+			modify:  func(s *Settings) { s.ExcludePatterns = []string{"[abc"} },
+			wantErr: "invalid file pattern: [abc",
+		},
+		{
+			name:     "diff algorithm typo",
+			modify:   func(s *Settings) { s.DiffAlgorithm = "histogrm" },
+			wantErr:  "invalid diff algorithm: histogrm",
+			wantHint: "did you mean 'histogram'?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &Settings{Timeout: time.Minute, JiraTaskStyle: "none", TicketPosition: "footer"}
+			tt.modify(settings)
+			err := settings.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantHint != "" && !strings.Contains(err.Error(), tt.wantHint) {
+				t.Errorf("Validate() error = %v, want hint %q", err, tt.wantHint)
+			}
+			if tt.wantHint == "" && strings.Contains(err.Error(), "did you mean") {
+				t.Errorf("Validate() error = %v, want no hint", err)
+			}
+		})
+	}
+}
+
+func TestNewDiffOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		want     gitops.DiffOptions
+	}{
+		{
+			name:     "zero settings keep defaults",
+			settings: Settings{},
+			want:     gitops.DiffOptions{Algorithm: "patience", RenameThreshold: 50, FunctionContext: true},
+		},
+		{
+			name: "configured options",
+			settings: Settings{
+				DiffAlgorithm: "histogram", RenameThreshold: 90, NoFunctionContext: true, CompressDiff: true,
+			},
+			want: gitops.DiffOptions{Algorithm: "histogram", RenameThreshold: 90, Compress: true},
+		},
+		{
+			name:     "negative threshold disables renames",
+			settings: Settings{RenameThreshold: -1},
+			want:     gitops.DiffOptions{Algorithm: "patience", FunctionContext: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newDiffOptions(&tt.settings); got != tt.want {
+				t.Errorf("newDiffOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
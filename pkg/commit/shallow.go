@@ -0,0 +1,48 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+// ensureFullHistory makes sure operations depending on complete history (tags, merge bases)
+// do not silently produce wrong results in a shallow clone: history is fetched when
+// user allowed it, otherwise operation is refused
+func (s *Service) ensureFullHistory(ctx context.Context, operation string) error {
+	shallow, err := s.gitOps.IsShallowRepository()
+	if err != nil {
+		// best effort check, do not block on it
+		s.logger.WarnContext(ctx, "Failed to check for shallow clone", "error", err)
+		return nil
+	}
+	if !shallow {
+		return nil
+	}
+
+	if !s.settings.Deepen {
+		remote := s.settings.PushRemote
+		if remote == "" {
+			remote = gitops.DefaultRemote
+		}
+		s.logger.ErrorContext(
+			ctx, "Repository is a shallow clone, history may be incomplete",
+			"operation", operation,
+		)
+		return fmt.Errorf(
+			"%s requires full history, repository is a shallow clone: "+
+				"rerun with --deepen or run 'git fetch --unshallow --tags %s'",
+			operation, remote,
+		)
+	}
+
+	s.logger.InfoContext(ctx, "Fetching full history of shallow clone...", "operation", operation)
+
+	if err := s.gitOps.Deepen(s.settings.PushRemote); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to deepen repository", "error", err)
+		return fmt.Errorf("failed to deepen repository: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package commit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hasansino/commit/pkg/commit/ui"
+
+	_ "embed"
+)
+
+//go:embed prompt-split.md
+var splitPrompt string
+
+// commitGroup is a set of files committed together with a single message
+type commitGroup struct {
+	Files   []string `json:"files"`
+	Message string   `json:"message"`
+}
+
+// Split groups staged changes into logical change sets and creates one commit per group
+func (s *Service) Split(ctx context.Context) error {
+	if err := s.checkRepository(ctx); err != nil {
+		return err
+	}
+
+	stagedFiles, err := s.stageChanges(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(stagedFiles) == 0 {
+		s.logger.WarnContext(ctx, "No files to commit")
+		return nil
+	}
+
+	diff, err := s.gitOps.GetStagedDiff(s.settings.MaxDiffSizeBytes, s.settings.NewFileHeadLines)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get staged diff", "error", err)
+		return fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	branch, promptBranch, err := s.currentBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.logger.DebugContext(ctx, "Requesting split plan...")
+
+	responses, err := s.aiService.Ask(
+		ctx, s.settings.Providers,
+		buildSplitPrompt(diff, promptBranch, stagedFiles, s.settings.MultiLine),
+		true,
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to generate split plan", "error", err)
+		return fmt.Errorf("failed to generate split plan: %w", err)
+	}
+
+	groups, err := parseSplitPlan(s.getRandomMessage(responses), stagedFiles)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to parse split plan", "error", err)
+		return fmt.Errorf("failed to parse split plan: %w", err)
+	}
+
+	if !s.settings.Auto && !s.settings.Yes && !s.settings.DryRun {
+		uiGroups := make([]ui.SplitGroup, 0, len(groups))
+		for _, group := range groups {
+			uiGroups = append(uiGroups, ui.SplitGroup{Message: group.Message, Files: group.Files})
+		}
+
+		var (
+			confirmed bool
+			err       error
+		)
+		if s.settings.Accessible {
+			confirmed, err = ui.ConfirmSplitAccessible(os.Stdin, os.Stdout, uiGroups)
+		} else {
+			confirmed, err = ui.RenderSplitConfirmation(ctx, uiGroups)
+		}
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				s.logger.WarnContext(ctx, "Interactive mode canceled by user")
+				return nil
+			}
+			s.logger.ErrorContext(ctx, "Failed to enter interactive mode", "error", err)
+			return fmt.Errorf("failed to run interactive ui: %w", err)
+		}
+		if !confirmed {
+			s.logger.WarnContext(ctx, "Split canceled by user")
+			return nil
+		}
+	}
+
+	// messages are finalized before the first commit, so rejected message does not leave split half done
+	commitMessages := make([]string, len(groups))
+	for i, group := range groups {
+		commitMessage, err := s.applyModules(ctx, branch, group.Message)
+		if err != nil {
+			return err
+		}
+		commitMessage = s.appendTrailers(ctx, branch, commitMessage)
+		commitMessages[i] = strings.TrimSpace(commitMessage)
+	}
+
+	for i, group := range groups {
+		commitMessage := commitMessages[i]
+
+		if s.settings.DryRun {
+			s.logger.InfoContext(
+				ctx, "Planned commit",
+				"index", i+1,
+				"files", group.Files,
+				"message", commitMessage,
+			)
+			continue
+		}
+
+		if err := s.gitOps.UnstageAll(); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to unstage files", "error", err)
+			return fmt.Errorf("failed to unstage files: %w", err)
+		}
+
+		if err := s.gitOps.StagePaths(group.Files); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to stage files", "error", err)
+			return fmt.Errorf("failed to stage files: %w", err)
+		}
+
+		err := s.gitOps.CreateCommit(
+			commitMessage, s.settings.NoVerify, s.settings.AllowEmpty,
+			s.settings.Author, s.settings.Date,
+		)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to create commit", "error", err)
+			return fmt.Errorf("failed to create commit %d of %d: %w", i+1, len(groups), err)
+		}
+
+		s.logger.InfoContext(
+			ctx, "Commit created",
+			"index", i+1,
+			"files", len(group.Files),
+			"commit_message", commitMessage,
+		)
+	}
+
+	if s.settings.DryRun {
+		s.logger.WarnContext(ctx, "Dry run enabled, no side effects created")
+	}
+
+	return nil
+}
+
+func buildSplitPrompt(diff, branch string, files []string, multiLine bool) string {
+	injectFormat := promptFormatSingle
+	if multiLine {
+		injectFormat = promptFormatMulti
+	}
+	result := splitPrompt
+	result = strings.ReplaceAll(result, "{format}", injectFormat)
+	result = strings.ReplaceAll(result, "{branch}", branch)
+	result = strings.ReplaceAll(result, "{files}", strings.Join(files, ", "))
+	result = strings.ReplaceAll(result, "{diff}", diff)
+	return result
+}
+
+// parseSplitPlan decodes provider response and reconciles it with actually staged files:
+// unknown files are dropped, duplicates keep their first group, and files
+// the provider forgot about are added to the last group
+func parseSplitPlan(response string, stagedFiles []string) ([]commitGroup, error) {
+	start := strings.Index(response, "[")
+	end := strings.LastIndex(response, "]")
+	if start == -1 || end == -1 || end < start {
+		return nil, fmt.Errorf("no json array found in response")
+	}
+
+	var planned []commitGroup
+	if err := json.Unmarshal([]byte(response[start:end+1]), &planned); err != nil {
+		return nil, fmt.Errorf("failed to decode split plan: %w", err)
+	}
+
+	staged := make(map[string]bool, len(stagedFiles))
+	for _, file := range stagedFiles {
+		staged[file] = true
+	}
+
+	assigned := make(map[string]bool, len(stagedFiles))
+	groups := make([]commitGroup, 0, len(planned))
+
+	for _, group := range planned {
+		message := strings.TrimSpace(group.Message)
+		if message == "" {
+			continue
+		}
+
+		var files []string
+		for _, file := range group.Files {
+			if !staged[file] || assigned[file] {
+				continue
+			}
+			assigned[file] = true
+			files = append(files, file)
+		}
+
+		if len(files) == 0 {
+			continue
+		}
+
+		groups = append(groups, commitGroup{Files: files, Message: message})
+	}
+
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("split plan contains no valid groups")
+	}
+
+	for _, file := range stagedFiles {
+		if !assigned[file] {
+			groups[len(groups)-1].Files = append(groups[len(groups)-1].Files, file)
+		}
+	}
+
+	return groups, nil
+}
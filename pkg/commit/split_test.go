@@ -0,0 +1,99 @@
+package commit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSplitPlan(t *testing.T) {
+	staged := []string{"api/handler.go", "api/handler_test.go", "docs/README.md", "go.mod"}
+
+	tests := []struct {
+		name     string
+		response string
+		expected []commitGroup
+		wantErr  bool
+	}{
+		{
+			name: "valid plan",
+			response: `[{"files": ["api/handler.go", "api/handler_test.go"], "message": "feat(api): add handler"},` +
+				`{"files": ["docs/README.md", "go.mod"], "message": "docs: update readme"}]`,
+			expected: []commitGroup{
+				{Files: []string{"api/handler.go", "api/handler_test.go"}, Message: "feat(api): add handler"},
+				{Files: []string{"docs/README.md", "go.mod"}, Message: "docs: update readme"},
+			},
+		},
+		{
+			name:     "surrounding text is ignored",
+			response: "Here is the plan:\njson\n[{\"files\": [\"go.mod\"], \"message\": \"chore: bump\"}]\nDone.",
+			expected: []commitGroup{
+				{
+					Files:   []string{"go.mod", "api/handler.go", "api/handler_test.go", "docs/README.md"},
+					Message: "chore: bump",
+				},
+			},
+		},
+		{
+			name: "unknown and duplicate files are dropped, leftovers go to last group",
+			response: `[{"files": ["api/handler.go", "missing.go"], "message": "feat: handler"},` +
+				`{"files": ["api/handler.go", "docs/README.md"], "message": "docs: readme"}]`,
+			expected: []commitGroup{
+				{Files: []string{"api/handler.go"}, Message: "feat: handler"},
+				{Files: []string{"docs/README.md", "api/handler_test.go", "go.mod"}, Message: "docs: readme"},
+			},
+		},
+		{
+			name: "groups without message or files are skipped",
+			response: `[{"files": ["go.mod"], "message": "  "},` +
+				`{"files": ["missing.go"], "message": "fix: nothing"},` +
+				`{"files": ["go.mod"], "message": "chore: deps"}]`,
+			expected: []commitGroup{
+				{
+					Files:   []string{"go.mod", "api/handler.go", "api/handler_test.go", "docs/README.md"},
+					Message: "chore: deps",
+				},
+			},
+		},
+		{
+			name:     "no json",
+			response: "feat: something",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid json",
+			response: `[{"files": "go.mod"}]`,
+			wantErr:  true,
+		},
+		{
+			name:     "empty plan",
+			response: `[]`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseSplitPlan(tt.response, staged)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSplitPlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseSplitPlan() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildSplitPrompt(t *testing.T) {
+	result := buildSplitPrompt("diff content", "main", []string{"a.go", "b.go"}, false)
+
+	for _, expected := range []string{"diff content", "main", "a.go, b.go"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("buildSplitPrompt() does not contain %q", expected)
+		}
+	}
+	if strings.Contains(result, "{format}") || strings.Contains(result, "{diff}") {
+		t.Errorf("buildSplitPrompt() contains unreplaced placeholders")
+	}
+}
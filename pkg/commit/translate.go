@@ -0,0 +1,35 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	_ "embed"
+)
+
+//go:embed prompt-translate.md
+var translatePrompt string
+
+// translateMessage asks providers to translate commit message, used by translator module
+func (s *Service) translateMessage(ctx context.Context, text, language string) (string, error) {
+	s.logger.DebugContext(ctx, "Requesting commit message translation...", "language", language)
+
+	prompt := strings.ReplaceAll(translatePrompt, "{language}", language)
+	prompt = strings.ReplaceAll(prompt, "{message}", text)
+
+	responses, err := s.aiService.Ask(ctx, s.settings.Providers, prompt, true)
+	if err != nil {
+		return "", err
+	}
+
+	response := strings.TrimSpace(s.getRandomMessage(responses))
+	if response == "" {
+		return "", fmt.Errorf("no translation received from providers")
+	}
+
+	// providers tend to wrap answers into code blocks
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	return strings.TrimSpace(response), nil
+}
@@ -0,0 +1,39 @@
+package commit
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/commit/pkg/commit/mocks"
+)
+
+func TestService_translateMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ai := mocks.NewMockaiServiceAccessor(ctrl)
+	ai.EXPECT().
+		Ask(gomock.Any(), []string{"openai"}, gomock.Any(), true).
+		DoAndReturn(func(_ context.Context, _ []string, prompt string, _ bool) (map[string]string, error) {
+			if !strings.Contains(prompt, "into Spanish") || !strings.Contains(prompt, "add login") {
+				t.Errorf("prompt does not contain language and message: %q", prompt)
+			}
+			return map[string]string{"openai": "```\nañadir inicio de sesión\n```"}, nil
+		})
+
+	service := &Service{
+		logger:    slog.New(slog.DiscardHandler),
+		settings:  &Settings{Providers: []string{"openai"}},
+		aiService: ai,
+	}
+
+	got, err := service.translateMessage(context.Background(), "add login", "Spanish")
+	if err != nil {
+		t.Fatalf("translateMessage() error = %v", err)
+	}
+	if got != "añadir inicio de sesión" {
+		t.Errorf("translateMessage() = %q, want %q", got, "añadir inicio de sesión")
+	}
+}
@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// accessibleIO is line based input and output used instead of full screen UI
+type accessibleIO struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// DetectAccessible reports whether environment asks for screen reader friendly output:
+// ACCESSIBLE is set, as recognized by other charm tools, or terminal is dumb
+func DetectAccessible() bool {
+	return os.Getenv("ACCESSIBLE") != "" || os.Getenv("TERM") == "dumb"
+}
+
+// WithAccessible replaces full screen UI with linear prompts read from in and written to out
+func WithAccessible(in io.Reader, out io.Writer) Option {
+	return func(m *Model) {
+		m.accessible = &accessibleIO{in: bufio.NewReader(in), out: out}
+	}
+}
+
+// readLine returns next input line without line break, io.EOF when input is closed
+func (a *accessibleIO) readLine() (string, error) {
+	line, err := a.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (a *accessibleIO) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(a.out, format, args...)
+}
+
+// runAccessible asks user to choose suggestion with numbered prompts, without colors or animation
+func (m *Model) runAccessible() error {
+	a := m.accessible
+	m.printAccessibleSuggestions()
+
+	for {
+		a.printf("%s", AccessiblePrompt)
+		line, err := a.readLine()
+		if err != nil {
+			return fmt.Errorf("failed to read choice: %w", err)
+		}
+		line = strings.ToLower(strings.TrimSpace(line))
+
+		switch {
+		case line == KeyQuit:
+			m.done = true
+			return nil
+		case line == "l":
+			m.printAccessibleSuggestions()
+		case strings.HasPrefix(line, "o"):
+			checkboxID := m.accessibleCheckbox(strings.TrimPrefix(line, "o"))
+			if checkboxID == "" {
+				a.printf("Unknown option %q.\n", strings.TrimPrefix(line, "o"))
+				continue
+			}
+			m.toggleCheckbox(checkboxID)
+			m.printAccessibleOptions()
+		case strings.HasPrefix(line, KeyEdit):
+			item, ok := m.accessibleItem(strings.TrimPrefix(line, KeyEdit))
+			if !ok || item.provider == ProviderManual {
+				a.printf("Unknown suggestion %q.\n", strings.TrimPrefix(line, KeyEdit))
+				continue
+			}
+			a.printf("Current message:\n%s\n", strings.TrimSpace(item.message))
+			message, err := m.readAccessibleMessage()
+			if err != nil {
+				return err
+			}
+			if message != "" {
+				m.finalChoice = message
+				m.done = true
+				return nil
+			}
+		default:
+			item, ok := m.accessibleItem(line)
+			if !ok {
+				a.printf("Unknown choice %q.\n", line)
+				continue
+			}
+			if item.provider != ProviderManual {
+				m.finalChoice = item.message
+				m.done = true
+				return nil
+			}
+			message, err := m.readAccessibleMessage()
+			if err != nil {
+				return err
+			}
+			if message != "" {
+				m.finalChoice = message
+				m.done = true
+				return nil
+			}
+		}
+	}
+}
+
+// accessibleItem returns list item by its number
+func (m *Model) accessibleItem(number string) (CommitItem, bool) {
+	index, err := strconv.Atoi(strings.TrimSpace(number))
+	if err != nil || index < 1 || index > len(m.choices) {
+		return CommitItem{}, false
+	}
+	item, ok := m.choices[index-1].(CommitItem)
+	return item, ok
+}
+
+// accessibleCheckbox returns shown option by its key
+func (m *Model) accessibleCheckbox(key string) string {
+	for _, opt := range footerCheckboxes {
+		if _, exists := m.checkboxes[opt.id]; exists && opt.key == strings.TrimSpace(key) {
+			return opt.id
+		}
+	}
+	return ""
+}
+
+// readAccessibleMessage reads message typed by user, finished by a line with single dot
+func (m *Model) readAccessibleMessage() (string, error) {
+	m.accessible.printf("%s\n", AccessibleInputHelp)
+
+	var lines []string
+	for {
+		line, err := m.accessible.readLine()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read message: %w", err)
+		}
+		if line == "." {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
+
+func (m *Model) printAccessibleSuggestions() {
+	a := m.accessible
+	a.printf("%s. %d choices.\n\n", ListTitle, len(m.choices))
+	for i, item := range m.choices {
+		commit, ok := item.(CommitItem)
+		if !ok {
+			continue
+		}
+		if commit.provider == ProviderManual {
+			a.printf("Choice %d: %s.\n\n", i+1, ManualOptionDesc)
+			continue
+		}
+		a.printf("Choice %d, suggested by %s:\n%s\n\n", i+1, commit.provider, strings.TrimSpace(commit.message))
+	}
+	m.printAccessibleOptions()
+}
+
+func (m *Model) printAccessibleOptions() {
+	var options []string
+	for _, opt := range footerCheckboxes {
+		if _, exists := m.checkboxes[opt.id]; !exists {
+			continue
+		}
+		state := "off"
+		if m.checkboxes[opt.id] {
+			state = "on"
+		}
+		options = append(options, fmt.Sprintf("option %s, %s, is %s", opt.key, opt.label, state))
+	}
+	if len(options) > 0 {
+		m.accessible.printf("Options: %s.\n", strings.Join(options, "; "))
+	}
+}
+
+// ConfirmSplitAccessible lists planned commits and asks user to confirm them with linear prompts
+func ConfirmSplitAccessible(in io.Reader, out io.Writer, groups []SplitGroup) (bool, error) {
+	a := &accessibleIO{in: bufio.NewReader(in), out: out}
+	a.printf("%s. %d commits planned.\n\n", SplitTitle, len(groups))
+	for i, group := range groups {
+		a.printf("Commit %d:\n%s\nFiles: %s.\n\n",
+			i+1, strings.TrimSpace(group.Message), strings.Join(group.Files, ", "))
+	}
+	a.printf("%s", AccessibleSplitPrompt)
+
+	line, err := a.readLine()
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
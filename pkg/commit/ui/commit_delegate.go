@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // Custom delegate for multi-line commit messages
@@ -17,6 +18,7 @@ type commitDelegate struct {
 	spacing    int
 	showDetail bool
 	width      int
+	stats      StatsFunc // nil when stats are not shown
 }
 
 func newCommitDelegate() commitDelegate {
@@ -84,6 +86,14 @@ func (d *commitDelegate) getMaxDescriptionLen() int {
 	return availableWidth
 }
 
+// selectedWidth returns width of selected item inside list of listWidth, accounting for:
+// - List's internal padding/margins (approx 4)
+// - Border (1)
+// - Our padding (3)
+func selectedWidth(listWidth int) int {
+	return max(listWidth-8, 20) // Minimum width
+}
+
 func (d commitDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd {
 	return nil
 }
@@ -100,31 +110,26 @@ func (d *commitDelegate) Render(w io.Writer, m list.Model, index int, item list.
 
 	// Build title without arrow indicator
 	title := commit.Title()
+	statsLine := d.renderStats(commit.provider)
 
 	// Build description
 	var desc string
 	if commit.provider == ProviderManual {
 		desc = commit.Description()
-	} else if isSelected && len(commit.lines) > 1 {
-		// Show full multi-line message when selected
-		var descLines []string
-		for i, line := range commit.lines {
-			descLines = append(descLines, line)
-			if i >= MaxDisplayLines { // Limit display lines
-				descLines = append(descLines, "...")
-				break
-			}
+	} else if isSelected && len(commit.lines) > 0 {
+		// Show message soft-wrapped to item width when selected, long ones continue in detail view
+		wrapped := softWrap(strings.Join(commit.lines, "\n"), selectedWidth(m.Width())-3)
+		descLines := strings.Split(wrapped, "\n")
+		if len(descLines) > MaxDisplayLines {
+			hidden := len(descLines) - MaxDisplayLines
+			descLines = append(descLines[:MaxDisplayLines], fmt.Sprintf(DetailMoreLines, hidden))
 		}
 		desc = strings.Join(descLines, "\n")
 	} else {
 		// Show only first line when not selected
 		firstLine := ""
 		if len(commit.lines) > 0 {
-			firstLine = commit.lines[0]
-			maxLen := d.getMaxDescriptionLen()
-			if len(firstLine) > maxLen {
-				firstLine = firstLine[:maxLen-3] + "..."
-			}
+			firstLine = ansi.Truncate(commit.lines[0], d.getMaxDescriptionLen(), "...")
 		}
 		desc = firstLine
 		if len(commit.lines) > 1 {
@@ -135,14 +140,7 @@ func (d *commitDelegate) Render(w io.Writer, m list.Model, index int, item list.
 	// Apply styles based on selection state
 	if isSelected {
 		// Highlight with left border only, no background
-		// Calculate width accounting for:
-		// - List's internal padding/margins (approx 4)
-		// - Border (1)
-		// - Our padding (3)
-		calculatedWidth := m.Width() - 8
-		if calculatedWidth < 20 {
-			calculatedWidth = 20 // Minimum width
-		}
+		calculatedWidth := selectedWidth(m.Width())
 
 		selectedStyle := lipgloss.NewStyle().
 			BorderLeft(true).
@@ -159,7 +157,7 @@ func (d *commitDelegate) Render(w io.Writer, m list.Model, index int, item list.
 		descStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorSecondary))
 
-		content.WriteString(titleStyle.Render(title))
+		content.WriteString(titleStyle.Render(title) + statsLine)
 		if desc != "" {
 			content.WriteString("\n")
 			content.WriteString(descStyle.Render(desc))
@@ -193,7 +191,7 @@ func (d *commitDelegate) Render(w io.Writer, m list.Model, index int, item list.
 			titleStyle := d.styles.NormalTitle
 			descStyle := d.styles.NormalDesc
 
-			content.WriteString(titleStyle.Render(title))
+			content.WriteString(titleStyle.Render(title) + statsLine)
 			if desc != "" {
 				content.WriteString("\n")
 				content.WriteString(descStyle.Render(desc))
@@ -203,3 +201,25 @@ func (d *commitDelegate) Render(w io.Writer, m list.Model, index int, item list.
 		_, _ = fmt.Fprint(w, itemStyle.Render(content.String()))
 	}
 }
+
+// renderStats formats latency, tokens and cost of provider, empty when unknown
+func (d *commitDelegate) renderStats(provider string) string {
+	if d.stats == nil || provider == ProviderManual {
+		return ""
+	}
+	stats, ok := d.stats(provider)
+	if !ok {
+		return ""
+	}
+
+	parts := []string{
+		fmt.Sprintf("%.1fs", stats.Latency.Seconds()),
+		fmt.Sprintf("%d→%d tokens", stats.InputTokens, stats.OutputTokens),
+	}
+	if stats.Cost >= 0 {
+		parts = append(parts, fmt.Sprintf("~$%.4f", stats.Cost))
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorMuted)).
+		Render("  " + strings.Join(parts, " · "))
+}
@@ -9,6 +9,7 @@ type CommitItem struct {
 	provider string
 	message  string
 	lines    []string
+	marked   bool // selected for merge
 }
 
 // Title returns the title of the item (provider name)
@@ -16,6 +17,9 @@ func (i CommitItem) Title() string {
 	if i.provider == ProviderManual {
 		return ManualOptionTitle
 	}
+	if i.marked {
+		return CheckboxChecked + " " + strings.ToTitle(i.provider)
+	}
 	return strings.ToTitle(i.provider)
 }
 
@@ -8,6 +8,10 @@ const (
 	CheckboxIDCreateTagMajor = "create_tag_major"
 	CheckboxIDCreateTagMinor = "create_tag_minor"
 	CheckboxIDCreateTagPatch = "create_tag_patch"
+	CheckboxIDCreateTagAuto  = "create_tag_auto"
+	CheckboxIDCreateTagPre   = "create_tag_prerelease"
+	CheckboxIDSignOff        = "sign_off"
+	CheckboxIDTrailers       = "trailers"
 )
 
 const (
@@ -16,6 +20,10 @@ const (
 	CheckboxLabelCreateTagMajor = "Tag (major)"
 	CheckboxLabelCreateTagMinor = "Tag (minor)"
 	CheckboxLabelCreateTagPatch = "Tag (patch)"
+	CheckboxLabelCreateTagAuto  = "Tag (auto)"
+	CheckboxLabelCreateTagPre   = "Tag (prerelease)"
+	CheckboxLabelSignOff        = "Sign-off"
+	CheckboxLabelTrailers       = "Trailers"
 )
 
 const (
@@ -24,6 +32,10 @@ const (
 	CheckboxKeymap3 = "3"
 	CheckboxKeymap4 = "4"
 	CheckboxKeymap5 = "5"
+	CheckboxKeymap6 = "6"
+	CheckboxKeymap7 = "7"
+	CheckboxKeymap8 = "8"
+	CheckboxKeymap9 = "9"
 )
 
 var checkboxKeymaps = map[string]string{
@@ -32,6 +44,10 @@ var checkboxKeymaps = map[string]string{
 	CheckboxIDCreateTagMajor: CheckboxKeymap3,
 	CheckboxIDCreateTagMinor: CheckboxKeymap4,
 	CheckboxIDCreateTagPatch: CheckboxKeymap5,
+	CheckboxIDCreateTagAuto:  CheckboxKeymap6,
+	CheckboxIDCreateTagPre:   CheckboxKeymap7,
+	CheckboxIDSignOff:        CheckboxKeymap8,
+	CheckboxIDTrailers:       CheckboxKeymap9,
 }
 
 var checkboxDefaults = map[string]bool{
@@ -40,6 +56,10 @@ var checkboxDefaults = map[string]bool{
 	CheckboxIDCreateTagMajor: false,
 	CheckboxIDCreateTagMinor: false,
 	CheckboxIDCreateTagPatch: false,
+	CheckboxIDCreateTagAuto:  false,
+	CheckboxIDCreateTagPre:   false,
+	CheckboxIDSignOff:        false,
+	CheckboxIDTrailers:       false,
 }
 
 type Checkbox struct {
@@ -54,12 +74,27 @@ var footerCheckboxes = []Checkbox{
 	{CheckboxIDCreateTagMajor, CheckboxKeymap3, CheckboxLabelCreateTagMajor},
 	{CheckboxIDCreateTagMinor, CheckboxKeymap4, CheckboxLabelCreateTagMinor},
 	{CheckboxIDCreateTagPatch, CheckboxKeymap5, CheckboxLabelCreateTagPatch},
+	{CheckboxIDCreateTagAuto, CheckboxKeymap6, CheckboxLabelCreateTagAuto},
+	{CheckboxIDCreateTagPre, CheckboxKeymap7, CheckboxLabelCreateTagPre},
+	{CheckboxIDSignOff, CheckboxKeymap8, CheckboxLabelSignOff},
+	{CheckboxIDTrailers, CheckboxKeymap9, CheckboxLabelTrailers},
+}
+
+// tagIncrements are semver increments of tag checkboxes
+var tagIncrements = map[string]string{
+	CheckboxIDCreateTagMajor: "major",
+	CheckboxIDCreateTagMinor: "minor",
+	CheckboxIDCreateTagPatch: "patch",
+	CheckboxIDCreateTagAuto:  "auto",
+	CheckboxIDCreateTagPre:   "prerelease",
 }
 
 func IsTagCheckbox(id string) bool {
 	return id == CheckboxIDCreateTagMajor ||
 		id == CheckboxIDCreateTagMinor ||
-		id == CheckboxIDCreateTagPatch
+		id == CheckboxIDCreateTagPatch ||
+		id == CheckboxIDCreateTagAuto ||
+		id == CheckboxIDCreateTagPre
 }
 
 // ----
@@ -71,8 +106,34 @@ const (
 	ManualOptionDesc  = "Enter your own commit message"
 	ManualInputTitle  = "Write Your Commit Message"
 	ManualInputHelp   = "Enter: new line • Ctrl+D: finish • Esc: cancel"
-	FooterHelp        = "Press 1-5 to toggle options"
+	EditInputTitle    = "Edit Commit Message"
+	InstructionPrompt = "Extra instruction (optional): "
+	InstructionHelp   = "Enter: regenerate • Esc: cancel"
+	RegeneratingText  = "Regenerating suggestions..."
+	RestagingText     = "Restaging files..."
+	StaleSuggestions  = "Staged files changed, press r to regenerate suggestions"
+	FilesHelp         = "j/k: move • Space: toggle file • f/Esc: back to suggestions"
+	DetailHelp        = "j/k: scroll • Enter: select • v/Esc: back to suggestions"
+	DetailMoreLines   = "... %d more lines, press v to view all"
+	FooterHelp        = "Press 1-9 to toggle options"
 	ProviderManual    = "manual"
+	HistoryPrefix     = "history/" // provider prefix of suggestions recalled from earlier runs
+	ProviderMerged    = "merged"
+	MergingText       = "Merging selected suggestions..."
+	MergeHint         = "Mark at least two suggestions with m to merge them"
+	SplitTitle        = "Confirm Commit Split"
+	SplitHelp         = "Enter: create commits • Esc/q: cancel"
+	ProgressTitle     = "Waiting for providers"
+	ProgressHelp      = "1-9: cancel provider • Enter: continue with arrived • q: abort"
+	CostPrompt        = "Estimated total ~$%.4f exceeds threshold, send requests? [y/N]: "
+)
+
+// Accessible mode text, read by screen readers
+const (
+	AccessiblePrompt = "Type choice number to commit it, e and number to edit it, " +
+		"o and number to toggle option, l to list again, or q to quit: "
+	AccessibleInputHelp   = "Type commit message, finish with a line containing only a dot."
+	AccessibleSplitPrompt = "Create these commits? Type y to confirm, anything else cancels: "
 )
 
 // Unicode Characters
@@ -95,6 +156,11 @@ const (
 	ColorBright       = "230"
 	ColorMuted        = "241"
 	ColorWarning      = "214"
+	ColorDiffAdded    = "71"
+	ColorDiffRemoved  = "167"
+	ColorDiffHunk     = "73"
+	ColorSuccess      = "71"
+	ColorError        = "167"
 )
 
 // Layout Constants
@@ -110,6 +176,8 @@ const (
 	MaxDescriptionLen  = 60 // Max length for single-line description
 	ManualInputWidth   = 80
 	ManualInputHeight  = 1
+	MinDiffPaneWidth   = 120 // Narrower terminals hide diff pane until it is toggled
+	MaxFileLines       = 8   // Staged files shown at once, list scrolls with cursor
 )
 
 // Keybindings
@@ -122,6 +190,20 @@ const (
 	KeyBackspace   = "backspace"
 	KeySpace       = " "
 	KeyInterrupt   = "ctrl+c"
+	KeyEdit        = "e"
+	KeyEditor      = "E"
+	KeyRegenerate  = "r"
+	KeyDiff        = "d"
+	KeyDiffDown    = "J"
+	KeyDiffUp      = "K"
+	KeyFiles       = "f"
+	KeyDetail      = "v"
+	KeyCopy        = "y"
+	KeyHistory     = "h"
+	KeyMark        = "m"
+	KeyMerge       = "M"
+	KeyUp          = "up"
+	KeyDown        = "down"
 )
 
 const minCommitMessageLength = 3
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// detailPane is scrollable full message of selected suggestion, soft-wrapped to pane width
+type detailPane struct {
+	viewport viewport.Model
+	message  string
+}
+
+func newDetailPane(message string) *detailPane {
+	return &detailPane{viewport: viewport.New(0, 0), message: strings.TrimSpace(message)}
+}
+
+// softWrap wraps lines of text at word boundaries to fit width, words longer than width are broken
+func softWrap(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	return ansi.Wrap(text, width, "")
+}
+
+// setSize sizes pane including its border and rewraps message to new width
+func (p *detailPane) setSize(width, height int) {
+	p.viewport.Width = max(width-2, 0)
+	p.viewport.Height = max(height-2, 0)
+	p.viewport.SetContent(softWrap(p.message, p.viewport.Width))
+}
+
+func (p *detailPane) View() string {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorPrimary)).
+		Render(p.viewport.View())
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// diffPane is scrollable staged diff shown next to suggestions
+type diffPane struct {
+	viewport viewport.Model
+	visible  bool
+}
+
+func newDiffPane(diff string) *diffPane {
+	pane := &diffPane{viewport: viewport.New(0, 0), visible: true}
+	pane.viewport.SetContent(highlightDiff(diff))
+	return pane
+}
+
+// highlightDiff colors unified diff lines by their kind
+func highlightDiff(diff string) string {
+	var (
+		added   = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDiffAdded))
+		removed = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDiffRemoved))
+		hunk    = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDiffHunk))
+		header  = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondary)).Bold(true)
+		context = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorNormal))
+	)
+
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+			lines[i] = header.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunk.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = added.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = removed.Render(line)
+		default:
+			lines[i] = context.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// setSize sizes pane including its border
+func (p *diffPane) setSize(width, height int) {
+	p.viewport.Width = max(width-2, 0)
+	p.viewport.Height = max(height-2, 0)
+}
+
+func (p *diffPane) View() string {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorder)).
+		Render(p.viewport.View())
+}
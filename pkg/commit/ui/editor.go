@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultEditor is used when neither VISUAL nor EDITOR is set, like git does
+const defaultEditor = "vi"
+
+// editorFinishedMsg carries message edited in external editor
+type editorFinishedMsg struct {
+	message string
+	err     error
+}
+
+// openEditor suspends UI and edits message in $VISUAL or $EDITOR, editor may be given with arguments
+func openEditor(message string) tea.Cmd {
+	file, err := os.CreateTemp("", "COMMIT_EDITMSG-*")
+	if err != nil {
+		return func() tea.Msg { return editorFinishedMsg{err: err} }
+	}
+	path := file.Name()
+	_, err = file.WriteString(message)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return func() tea.Msg { return editorFinishedMsg{err: err} }
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = defaultEditor
+	}
+	args := strings.Fields(editor)
+
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer func() { _ = os.Remove(path) }()
+		if err != nil {
+			return editorFinishedMsg{err: err}
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return editorFinishedMsg{err: err}
+		}
+		return editorFinishedMsg{message: stripComments(string(content))}
+	})
+}
+
+// stripComments removes comment lines git would remove from edited message
+func stripComments(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CostEstimate describes request to provider before it is sent
+type CostEstimate struct {
+	Provider     string
+	Model        string
+	InputTokens  int64
+	OutputTokens int64   // expected, actual size is known only after response
+	Cost         float64 // estimated, in USD, negative when price of model is unknown
+}
+
+// ConfirmCost prints estimated cost of requests and asks user whether to send them, default is no
+func ConfirmCost(in io.Reader, out io.Writer, estimates []CostEstimate) (bool, error) {
+	a := &accessibleIO{in: bufio.NewReader(in), out: out}
+	var total float64
+	for _, estimate := range estimates {
+		cost := "unknown price"
+		if estimate.Cost >= 0 {
+			cost = fmt.Sprintf("~$%.4f", estimate.Cost)
+			total += estimate.Cost
+		}
+		a.printf("%s (%s): ~%d→%d tokens, %s\n",
+			estimate.Provider, estimate.Model, estimate.InputTokens, estimate.OutputTokens, cost)
+	}
+	a.printf(CostPrompt, total)
+
+	line, err := a.readLine()
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
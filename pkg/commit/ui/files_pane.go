@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filesPane lists staged files, files can be toggled to be left out of the commit
+type filesPane struct {
+	files   []string
+	enabled map[string]bool
+	cursor  int
+	focused bool
+}
+
+func newFilesPane(files []string) *filesPane {
+	enabled := make(map[string]bool, len(files))
+	for _, file := range files {
+		enabled[file] = true
+	}
+	return &filesPane{files: files, enabled: enabled}
+}
+
+// selected returns enabled files in original order
+func (p *filesPane) selected() []string {
+	var files []string
+	for _, file := range p.files {
+		if p.enabled[file] {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// height returns number of lines pane takes, title included
+func (p *filesPane) height() int {
+	return min(len(p.files), MaxFileLines) + 1
+}
+
+func (p *filesPane) View() string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorMuted))
+	if p.focused {
+		titleStyle = titleStyle.Foreground(lipgloss.Color(ColorPrimary)).Bold(true)
+	}
+
+	selected := len(p.selected())
+	lines := []string{titleStyle.Render(fmt.Sprintf("Staged files (%d/%d)", selected, len(p.files)))}
+
+	// window of files around cursor
+	start := max(0, min(p.cursor-MaxFileLines/2, len(p.files)-MaxFileLines))
+	end := min(len(p.files), start+MaxFileLines)
+	for i := start; i < end; i++ {
+		file := p.files[i]
+
+		box, style := CheckboxUnchecked, lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDimmed))
+		if p.enabled[file] {
+			box, style = CheckboxChecked, lipgloss.NewStyle().Foreground(lipgloss.Color(ColorNormal))
+		}
+		prefix := "  "
+		if p.focused && i == p.cursor {
+			prefix = Cursor + " "
+			style = style.Foreground(lipgloss.Color(ColorPrimary))
+		}
+		lines = append(lines, style.Render(prefix+box+" "+file))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -18,15 +20,59 @@ type Model struct {
 	choices     []list.Item
 	manualMode  bool
 	manualInput string
+	editing     bool // manual input was prefilled with selected suggestion
+	warning     string
 	finalChoice string
+	copied      bool // final choice goes to clipboard instead of commit
 	done        bool
 	width       int
 	height      int
 	checkboxes  map[string]bool
+
+	ctx              context.Context
+	regenerate       RegenerateFunc
+	instructionMode  bool
+	instructionInput string
+	busy             string // status of running background work, keys are ignored meanwhile
+	stale            bool   // staged files changed after suggestions were generated
+
+	merge       MergeFunc
+	marked      map[string]bool   // providers of suggestions selected for merge
+	tagPreview  TagPreviewFunc    // nil when versions are not previewed
+	pushTarget  *PushTarget       // nil when push target is unknown
+	accessible  *accessibleIO     // nil unless linear screen reader friendly mode is used
+	history     map[string]string // suggestions of earlier runs for the same changes
+	showHistory bool
+	prefs       Layout // arrangement chosen by user, kept for panes missing in this run
+
+	diff    *diffPane   // nil when diff is not shown
+	files   *filesPane  // nil when files are not shown
+	detail  *detailPane // nil unless full message of selected suggestion is shown
+	restage RestageFunc
+}
+
+// mergedMsg carries suggestion merged from marked ones
+type mergedMsg struct {
+	message string
+	err     error
+}
+
+// restagedMsg carries staged diff after files were toggled
+type restagedMsg struct {
+	diff string
+	err  error
+}
+
+// regeneratedMsg carries suggestions generated again on user request
+type regeneratedMsg struct {
+	suggestions map[string]string
+	err         error
 }
 
 // newModel creates a new UI model with fancy list
-func newModel(suggestions map[string]string, checkboxStates map[string]bool) Model {
+func newModel(
+	ctx context.Context, suggestions map[string]string, checkboxStates map[string]bool, opts ...Option,
+) Model {
 	items := buildListItems(suggestions)
 
 	// Create custom delegate for multi-line support
@@ -73,20 +119,24 @@ func newModel(suggestions map[string]string, checkboxStates map[string]bool) Mod
 	l.AdditionalShortHelpKeys = func() []key.Binding {
 		return []key.Binding{
 			key.NewBinding(key.WithKeys(KeySelect), key.WithHelp(KeySelect, "select")),
+			key.NewBinding(key.WithKeys(KeyEdit), key.WithHelp(KeyEdit, "edit")),
+			key.NewBinding(key.WithKeys(KeyEditor), key.WithHelp(KeyEditor, "$EDITOR")),
+			key.NewBinding(key.WithKeys(KeyCopy), key.WithHelp(KeyCopy, "copy")),
+			key.NewBinding(key.WithKeys(KeyDetail), key.WithHelp(KeyDetail, "view")),
 			key.NewBinding(key.WithKeys(KeyQuit), key.WithHelp(KeyQuit, "quit")),
 		}
 	}
 
-	// Initialize checkboxes with default values
-	checkboxes := checkboxDefaults
+	// Only checkboxes passed by caller are shown, unknown keys are ignored
+	checkboxes := make(map[string]bool, len(checkboxStates))
 	for k, v := range checkboxStates {
-		if _, exists := checkboxes[k]; !exists {
+		if _, exists := checkboxDefaults[k]; !exists {
 			continue // Ignore unknown keys
 		}
 		checkboxes[k] = v
 	}
 
-	return Model{
+	m := Model{
 		list:        l,
 		delegate:    delegate,
 		suggestions: suggestions,
@@ -95,15 +145,180 @@ func newModel(suggestions map[string]string, checkboxStates map[string]bool) Mod
 		manualInput: "",
 		done:        false,
 		checkboxes:  checkboxes,
+		ctx:         ctx,
+	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+
+	if m.history != nil && m.prefs.ShowHistory {
+		m.showHistory = true
+		m.choices = m.listItems()
+		m.list.SetItems(m.choices)
+	}
+
+	if m.diff != nil {
+		shortHelp := m.list.AdditionalShortHelpKeys
+		m.list.AdditionalShortHelpKeys = func() []key.Binding {
+			return append(shortHelp(),
+				key.NewBinding(key.WithKeys(KeyDiff), key.WithHelp(KeyDiff, "diff")),
+				key.NewBinding(
+					key.WithKeys(KeyDiffDown, KeyDiffUp), key.WithHelp(KeyDiffDown+"/"+KeyDiffUp, "scroll diff"),
+				),
+			)
+		}
+	}
+
+	if m.merge != nil {
+		shortHelp := m.list.AdditionalShortHelpKeys
+		m.list.AdditionalShortHelpKeys = func() []key.Binding {
+			return append(shortHelp(),
+				key.NewBinding(key.WithKeys(KeyMark), key.WithHelp(KeyMark, "mark")),
+				key.NewBinding(key.WithKeys(KeyMerge), key.WithHelp(KeyMerge, "merge marked")),
+			)
+		}
+	}
+
+	if m.history != nil {
+		shortHelp := m.list.AdditionalShortHelpKeys
+		m.list.AdditionalShortHelpKeys = func() []key.Binding {
+			return append(shortHelp(), key.NewBinding(key.WithKeys(KeyHistory), key.WithHelp(KeyHistory, "history")))
+		}
+	}
+
+	if m.files != nil && m.restage != nil {
+		shortHelp := m.list.AdditionalShortHelpKeys
+		m.list.AdditionalShortHelpKeys = func() []key.Binding {
+			return append(shortHelp(), key.NewBinding(key.WithKeys(KeyFiles), key.WithHelp(KeyFiles, "files")))
+		}
+	}
+
+	if m.regenerate != nil {
+		shortHelp := m.list.AdditionalShortHelpKeys
+		m.list.AdditionalShortHelpKeys = func() []key.Binding {
+			return append(shortHelp(),
+				key.NewBinding(key.WithKeys(KeyRegenerate), key.WithHelp(KeyRegenerate, "regenerate")),
+			)
+		}
 	}
+
+	return m
+}
+
+// renderPushTarget describes where commit is pushed, empty while push is disabled
+func (m *Model) renderPushTarget() string {
+	if m.pushTarget == nil || !m.checkboxes[CheckboxIDPush] || m.checkboxes[CheckboxIDDryRun] {
+		return ""
+	}
+
+	text := fmt.Sprintf("Push → %s/%s", m.pushTarget.Remote, m.pushTarget.Branch)
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorMuted))
+	if m.pushTarget.NewBranch {
+		text += " (new remote branch)"
+		style = style.Foreground(lipgloss.Color(ColorWarning))
+	}
+	return style.Render(text)
+}
+
+// markedMessages returns messages of suggestions marked for merge, in list order
+func (m *Model) markedMessages() []string {
+	var messages []string
+	for _, item := range m.choices {
+		if commit, ok := item.(CommitItem); ok && commit.marked {
+			messages = append(messages, commit.message)
+		}
+	}
+	return messages
+}
+
+// toggleCheckbox toggles option, keeping tag options exclusive and other options off during dry run
+func (m *Model) toggleCheckbox(checkboxID string) {
+	if _, exists := m.checkboxes[checkboxID]; !exists {
+		return // Ignore unknown checkbox IDs
+	}
+
+	// Check if dry-run is enabled and prevent toggling other checkboxes
+	if m.checkboxes[CheckboxIDDryRun] && checkboxID != CheckboxIDDryRun {
+		return // Don't allow toggling when dry-run is active
+	}
+
+	// Handle mutually exclusive tag checkboxes
+	if IsTagCheckbox(checkboxID) {
+		// Store current state before clearing
+		wasChecked := m.checkboxes[checkboxID]
+
+		// Clear all tag checkboxes
+		for id := range m.checkboxes {
+			if IsTagCheckbox(id) {
+				m.checkboxes[id] = false
+			}
+		}
+
+		// Toggle the selected one (allow unchecking)
+		m.checkboxes[checkboxID] = !wasChecked
+	} else if checkboxID == CheckboxIDDryRun {
+		// Toggle dry-run
+		m.checkboxes[checkboxID] = !m.checkboxes[checkboxID]
+
+		// If enabling dry-run, disable all other checkboxes
+		if m.checkboxes[CheckboxIDDryRun] {
+			for id := range m.checkboxes {
+				if id != CheckboxIDDryRun {
+					m.checkboxes[id] = false
+				}
+			}
+		}
+	} else {
+		// Normal toggle for other checkboxes
+		m.checkboxes[checkboxID] = !m.checkboxes[checkboxID]
+	}
+}
+
+// listItems returns current suggestions, with recalled ones when history is shown,
+// suggestions marked for merge are flagged
+func (m *Model) listItems() []list.Item {
+	items := m.suggestionItems()
+	for i, item := range items {
+		if commit, ok := item.(CommitItem); ok && m.marked[commit.provider] {
+			commit.marked = true
+			items[i] = commit
+		}
+	}
+	return items
+}
+
+// suggestionItems returns current suggestions, with recalled ones when history is shown
+func (m *Model) suggestionItems() []list.Item {
+	if !m.showHistory {
+		return buildListItems(m.suggestions)
+	}
+
+	current := make(map[string]bool, len(m.suggestions))
+	merged := make(map[string]string, len(m.suggestions)+len(m.history))
+	for provider, message := range m.suggestions {
+		merged[provider] = message
+		current[strings.TrimSpace(message)] = true
+	}
+	for provider, message := range m.history {
+		if !current[strings.TrimSpace(message)] {
+			merged[HistoryPrefix+provider] = message
+		}
+	}
+	return buildListItems(merged)
 }
 
 // buildListItems converts suggestions to list items
 func buildListItems(suggestions map[string]string) []list.Item {
 	var items []list.Item
 
-	// Add AI suggestions
-	for provider, message := range suggestions {
+	// Add AI suggestions, sorted so rebuilt list keeps its order
+	providers := make([]string, 0, len(suggestions))
+	for provider := range suggestions {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		message := suggestions[provider]
 		lines := strings.Split(strings.TrimSpace(message), "\n")
 		// Clean up empty lines at the end
 		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
@@ -135,35 +350,91 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		// diff pane fits wide terminals only unless user chose otherwise, later it is up to user
+		if m.diff != nil && m.width == 0 {
+			m.diff.visible = msg.Width >= MinDiffPaneWidth
+			if m.prefs.ShowDiff != nil {
+				m.diff.visible = *m.prefs.ShowDiff
+			}
+		}
 		m.width = msg.Width
 		m.height = msg.Height
-
-		// Update delegate width for dynamic description length
-		if m.delegate != nil {
-			m.delegate.SetWidth(msg.Width)
+		m.layout()
+		return m, nil
+	case editorFinishedMsg:
+		m.warning = ""
+		if msg.err != nil {
+			m.warning = fmt.Sprintf("Editor failed: %v", msg.err)
 		}
-
-		// Calculate available width accounting for padding
-		availableWidth := msg.Width - (PaddingHorizontal * 2)
-		if availableWidth > 0 {
-			m.list.SetWidth(availableWidth)
+		if msg.err == nil && len(msg.message) >= minCommitMessageLength {
+			m.finalChoice = msg.message
+			m.done = true
+			return m, tea.Quit
 		}
-
-		// Calculate available height accounting for:
-		// - Top padding
-		// - Footer (border + checkboxes + help text)
-		// - Bottom margin
-		availableHeight := msg.Height - PaddingTop - FooterHeightApprox - 1
-		if availableHeight > MinListHeight {
-			m.list.SetHeight(availableHeight)
-		} else {
-			m.list.SetHeight(MinListHeight)
+		return m, nil
+	case restagedMsg:
+		m.busy = ""
+		if msg.err != nil {
+			m.warning = fmt.Sprintf("Restaging failed: %v", msg.err)
+			return m, nil
+		}
+		m.warning = ""
+		m.stale = true
+		if m.diff != nil {
+			m.diff.viewport.SetContent(highlightDiff(msg.diff))
+			m.diff.viewport.GotoTop()
 		}
 		return m, nil
+	case mergedMsg:
+		m.busy = ""
+		if msg.err != nil {
+			m.warning = fmt.Sprintf("Merge failed: %v", msg.err)
+			return m, nil
+		}
+		m.warning = ""
+		m.marked = nil
+		suggestions := make(map[string]string, len(m.suggestions)+1)
+		for provider, message := range m.suggestions {
+			suggestions[provider] = message
+		}
+		suggestions[ProviderMerged] = msg.message
+		m.suggestions = suggestions
+		m.choices = m.listItems()
+		cmd := m.list.SetItems(m.choices)
+		for i, item := range m.choices {
+			if commit, ok := item.(CommitItem); ok && commit.provider == ProviderMerged {
+				m.list.Select(i)
+			}
+		}
+		return m, cmd
+	case regeneratedMsg:
+		m.busy = ""
+		if msg.err != nil {
+			m.warning = fmt.Sprintf("Regeneration failed: %v", msg.err)
+			return m, nil
+		}
+		m.warning = ""
+		m.stale = false
+		m.suggestions = msg.suggestions
+		m.marked = nil
+		m.choices = m.listItems()
+		return m, m.list.SetItems(m.choices)
 	case tea.KeyMsg:
 		if m.manualMode {
 			return m.updateManualMode(msg)
 		}
+		if m.instructionMode {
+			return m.updateInstructionMode(msg)
+		}
+		if m.busy != "" && msg.String() != KeyInterrupt {
+			return m, nil
+		}
+		if m.files != nil && m.files.focused {
+			return m.updateFilesMode(msg)
+		}
+		if m.detail != nil {
+			return m.updateDetailMode(msg)
+		}
 
 		// Handle selection mode
 		switch msg.String() {
@@ -176,6 +447,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if item.provider == ProviderManual {
 					m.manualMode = true
 					m.manualInput = ""
+					m.editing = false
 				} else {
 					m.finalChoice = item.message
 					m.done = true
@@ -183,47 +455,105 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return m, nil
+		case KeyCopy:
+			if item, ok := m.list.SelectedItem().(CommitItem); ok && item.provider != ProviderManual {
+				m.finalChoice = item.message
+				m.copied = true
+				m.done = true
+				return m, tea.Quit
+			}
+			return m, nil
+		case KeyEdit:
+			if item, ok := m.list.SelectedItem().(CommitItem); ok && item.provider != ProviderManual {
+				m.manualMode = true
+				m.manualInput = strings.TrimSpace(item.message)
+				m.editing = true
+			}
+			return m, nil
+		case KeyEditor:
+			if item, ok := m.list.SelectedItem().(CommitItem); ok {
+				m.warning = ""
+				return m, openEditor(strings.TrimSpace(item.message))
+			}
+			return m, nil
+		case KeyDetail:
+			if item, ok := m.list.SelectedItem().(CommitItem); ok && item.provider != ProviderManual {
+				m.detail = newDetailPane(item.message)
+				m.layout()
+			}
+			return m, nil
+		case KeyDiff:
+			if m.diff != nil {
+				m.diff.visible = !m.diff.visible
+				visible := m.diff.visible
+				m.prefs.ShowDiff = &visible
+				m.layout()
+			}
+			return m, nil
+		case KeyDiffDown:
+			if m.diff != nil && m.diff.visible {
+				m.diff.viewport.ScrollDown(1)
+			}
+			return m, nil
+		case KeyDiffUp:
+			if m.diff != nil && m.diff.visible {
+				m.diff.viewport.ScrollUp(1)
+			}
+			return m, nil
+		case KeyMark:
+			item, ok := m.list.SelectedItem().(CommitItem)
+			if m.merge == nil || !ok || item.provider == ProviderManual {
+				return m, nil
+			}
+			if m.marked == nil {
+				m.marked = make(map[string]bool)
+			}
+			if m.marked[item.provider] {
+				delete(m.marked, item.provider)
+			} else {
+				m.marked[item.provider] = true
+			}
+			m.choices = m.listItems()
+			return m, m.list.SetItems(m.choices)
+		case KeyMerge:
+			if m.merge == nil {
+				return m, nil
+			}
+			messages := m.markedMessages()
+			if len(messages) < 2 {
+				m.warning = MergeHint
+				return m, nil
+			}
+			m.busy = MergingText
+			m.warning = ""
+			ctx, merge := m.ctx, m.merge
+			return m, func() tea.Msg {
+				message, err := merge(ctx, messages)
+				return mergedMsg{message: message, err: err}
+			}
+		case KeyHistory:
+			if m.history != nil {
+				m.showHistory = !m.showHistory
+				m.prefs.ShowHistory = m.showHistory
+				m.choices = m.listItems()
+				return m, m.list.SetItems(m.choices)
+			}
+			return m, nil
+		case KeyFiles:
+			if m.files != nil {
+				m.files.focused = true
+			}
+			return m, nil
+		case KeyRegenerate:
+			if m.regenerate != nil {
+				m.instructionMode = true
+				m.instructionInput = ""
+			}
+			return m, nil
 		default:
 			for checkboxID, checkboxKey := range checkboxKeymaps {
 				if msg.String() == checkboxKey {
-					if _, exists := m.checkboxes[checkboxID]; !exists {
-						return m, nil // Ignore unknown checkbox IDs
-					}
-
-					// Check if dry-run is enabled and prevent toggling other checkboxes
-					if m.checkboxes[CheckboxIDDryRun] && checkboxID != CheckboxIDDryRun {
-						return m, nil // Don't allow toggling when dry-run is active
-					}
-
-					// Handle mutually exclusive tag checkboxes
-					if IsTagCheckbox(checkboxID) {
-						// Store current state before clearing
-						wasChecked := m.checkboxes[checkboxID]
-
-						// Clear all tag checkboxes
-						m.checkboxes[CheckboxIDCreateTagMajor] = false
-						m.checkboxes[CheckboxIDCreateTagMinor] = false
-						m.checkboxes[CheckboxIDCreateTagPatch] = false
-
-						// Toggle the selected one (allow unchecking)
-						m.checkboxes[checkboxID] = !wasChecked
-					} else if checkboxID == CheckboxIDDryRun {
-						// Toggle dry-run
-						m.checkboxes[checkboxID] = !m.checkboxes[checkboxID]
-
-						// If enabling dry-run, disable all other checkboxes
-						if m.checkboxes[CheckboxIDDryRun] {
-							for id := range m.checkboxes {
-								if id != CheckboxIDDryRun {
-									m.checkboxes[id] = false
-								}
-							}
-						}
-					} else {
-						// Normal toggle for other checkboxes
-						m.checkboxes[checkboxID] = !m.checkboxes[checkboxID]
-					}
-
+					m.toggleCheckbox(checkboxID)
 					return m, nil
 				}
 			}
@@ -240,6 +570,101 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// layout sizes list and diff pane to terminal, diff pane takes half of the width when visible
+func (m *Model) layout() {
+	// Calculate available width accounting for padding
+	availableWidth := m.width - (PaddingHorizontal * 2)
+
+	// Calculate available height accounting for:
+	// - Top padding
+	// - Footer (border + checkboxes + help text)
+	// - Bottom margin
+	// - Staged files
+	// - Push target, reserved as push can be toggled any time
+	availableHeight := m.height - PaddingTop - FooterHeightApprox - 1
+	if m.files != nil {
+		availableHeight -= m.files.height() + 1
+	}
+	if m.pushTarget != nil {
+		availableHeight--
+	}
+	availableHeight = max(availableHeight, MinListHeight)
+
+	listWidth := availableWidth
+	if m.diff != nil && m.diff.visible {
+		listWidth = availableWidth / 2
+		m.diff.setSize(availableWidth-listWidth, availableHeight)
+	}
+
+	// Update delegate width for dynamic description length
+	if m.delegate != nil {
+		m.delegate.SetWidth(listWidth + PaddingHorizontal*2)
+	}
+	if listWidth > 0 {
+		m.list.SetWidth(listWidth)
+	}
+	m.list.SetHeight(availableHeight)
+	if m.detail != nil {
+		m.detail.setSize(listWidth, availableHeight)
+	}
+}
+
+// updateDetailMode scrolls full message of selected suggestion, it can be selected from there
+func (m Model) updateDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case KeyInterrupt:
+		m.done = true
+		return m, tea.Quit
+	case KeyDetail, KeyCancel:
+		m.detail = nil
+	case KeySelect:
+		m.finalChoice = m.detail.message
+		m.done = true
+		return m, tea.Quit
+	default:
+		var cmd tea.Cmd
+		m.detail.viewport, cmd = m.detail.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// updateFilesMode moves through staged files and toggles them, every toggle restages files
+func (m Model) updateFilesMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case KeyInterrupt:
+		m.done = true
+		return m, tea.Quit
+	case KeyFiles, KeyCancel:
+		m.files.focused = false
+	case KeyUp, "k":
+		m.files.cursor = max(m.files.cursor-1, 0)
+	case KeyDown, "j":
+		m.files.cursor = min(m.files.cursor+1, len(m.files.files)-1)
+	case KeySpace:
+		if m.restage == nil {
+			return m, nil
+		}
+		file := m.files.files[m.files.cursor]
+		m.files.enabled[file] = !m.files.enabled[file]
+
+		selected := m.files.selected()
+		if len(selected) == 0 {
+			// commit needs at least one file
+			m.files.enabled[file] = true
+			return m, nil
+		}
+
+		m.busy = RestagingText
+		ctx, restage := m.ctx, m.restage
+		return m, func() tea.Msg {
+			diff, err := restage(ctx, selected)
+			return restagedMsg{diff: diff, err: err}
+		}
+	}
+	return m, nil
+}
+
 // updateManualMode handles input in manual entry mode
 func (m Model) updateManualMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -278,6 +703,37 @@ func (m Model) updateManualMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateInstructionMode handles single line input of extra instruction for regeneration
+func (m Model) updateInstructionMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case KeyInterrupt:
+		m.done = true
+		return m, tea.Quit
+	case KeyCancel:
+		m.instructionMode = false
+	case KeySelect:
+		m.instructionMode = false
+		m.busy = RegeneratingText
+		m.warning = ""
+		ctx, regenerate, instruction := m.ctx, m.regenerate, strings.TrimSpace(m.instructionInput)
+		return m, func() tea.Msg {
+			suggestions, err := regenerate(ctx, instruction)
+			return regeneratedMsg{suggestions: suggestions, err: err}
+		}
+	case KeyBackspace:
+		if runes := []rune(m.instructionInput); len(runes) > 0 {
+			m.instructionInput = string(runes[:len(runes)-1])
+		}
+	case KeySpace:
+		m.instructionInput += " "
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.instructionInput += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
 // View renders the UI
 func (m Model) View() string {
 	if m.done {
@@ -291,13 +747,49 @@ func (m Model) View() string {
 		return paddedStyle.Render(m.renderManualMode())
 	}
 
-	return paddedStyle.Render(
-		lipgloss.JoinVertical(
-			lipgloss.Left,
-			m.list.View(),
-			m.renderFooter(),
-		),
-	)
+	content := m.list.View()
+	if m.detail != nil {
+		content = m.detail.View()
+	}
+	if m.diff != nil && m.diff.visible {
+		content = lipgloss.JoinHorizontal(lipgloss.Top, content, m.diff.View())
+	}
+
+	sections := []string{content}
+	if m.files != nil {
+		sections = append(sections, m.files.View())
+		if m.files.focused {
+			sections = append(sections, lipgloss.NewStyle().Foreground(lipgloss.Color(ColorMuted)).Render(FilesHelp))
+		}
+	}
+	if m.detail != nil {
+		sections = append(sections, lipgloss.NewStyle().Foreground(lipgloss.Color(ColorMuted)).Render(DetailHelp))
+	}
+	switch {
+	case m.instructionMode:
+		sections = append(sections,
+			lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimary)).
+				Render(InstructionPrompt+m.instructionInput+Cursor),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(ColorMuted)).Render(InstructionHelp),
+		)
+	case m.busy != "":
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(ColorMuted)).
+			Italic(true).
+			Render(m.busy))
+	case m.stale:
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(ColorWarning)).
+			Render(StaleSuggestions))
+	}
+	if m.warning != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(ColorWarning)).
+			Render(m.warning))
+	}
+	sections = append(sections, m.renderFooter())
+
+	return paddedStyle.Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
 }
 
 // renderFooter renders the checkbox footer
@@ -319,6 +811,10 @@ func (m Model) renderFooter() string {
 
 	var checkboxes []string
 	for _, opt := range footerCheckboxes {
+		if _, exists := m.checkboxes[opt.id]; !exists {
+			continue
+		}
+
 		// Determine checkbox symbol based on type
 		var checkbox string
 		var boxStyle lipgloss.Style
@@ -382,10 +878,22 @@ func (m Model) renderFooter() string {
 				Foreground(lipgloss.Color(ColorDimmedDarker))
 		}
 
+		label := opt.label
+		if increment, ok := tagIncrements[opt.id]; ok && m.tagPreview != nil {
+			// auto increment depends on message which would be committed
+			var message string
+			if item, ok := m.list.SelectedItem().(CommitItem); ok {
+				message = item.message
+			}
+			if tag := m.tagPreview(increment, message); tag != "" {
+				label = increment + " → " + tag
+			}
+		}
+
 		// Format: 1 ▢ Label
 		item := keyStyle.Render(opt.key) + " " +
 			boxStyle.Render(checkbox) + " " +
-			labelStyle.Render(opt.label)
+			labelStyle.Render(label)
 
 		checkboxes = append(checkboxes, item)
 	}
@@ -410,6 +918,9 @@ func (m Model) renderFooter() string {
 
 	// Combine checkbox line and help
 	content := lipgloss.JoinVertical(lipgloss.Left, checkboxLine, helpText)
+	if target := m.renderPushTarget(); target != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, checkboxLine, target, helpText)
+	}
 
 	return footerStyle.Render(content)
 }
@@ -451,7 +962,11 @@ func (m Model) renderManualMode() string {
 
 	var b strings.Builder
 
-	b.WriteString(titleStyle.Render(ManualInputTitle))
+	title := ManualInputTitle
+	if m.editing {
+		title = EditInputTitle
+	}
+	b.WriteString(titleStyle.Render(title))
 	b.WriteString("\n\n")
 
 	// Show the input with cursor at the correct position
@@ -495,11 +1010,26 @@ func min(a, b int) int {
 	return b
 }
 
+// GetSuggestions returns suggestions shown last, they differ from initial ones after regeneration
+func (m Model) GetSuggestions() map[string]string {
+	return m.suggestions
+}
+
 // GetFinalChoice returns the selected commit message
 func (m Model) GetFinalChoice() string {
 	return m.finalChoice
 }
 
+// GetLayout returns arrangement chosen by user, to be restored in next run
+func (m Model) GetLayout() Layout {
+	return m.prefs
+}
+
+// IsCopied returns whether the user asked to copy final choice instead of committing it
+func (m Model) IsCopied() bool {
+	return m.copied
+}
+
 // IsDone returns whether the user has made a selection
 func (m Model) IsDone() bool {
 	return m.done
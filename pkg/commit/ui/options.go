@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"context"
+	"time"
+)
+
+// Option configures interactive UI
+type Option func(m *Model)
+
+// RegenerateFunc generates new suggestions, instruction is extra guidance typed by user, may be empty
+type RegenerateFunc func(ctx context.Context, instruction string) (map[string]string, error)
+
+// RestageFunc stages exactly given files and returns new staged diff
+type RestageFunc func(ctx context.Context, files []string) (string, error)
+
+// ProviderStats describes latest generation of provider
+type ProviderStats struct {
+	Latency      time.Duration
+	InputTokens  int64
+	OutputTokens int64
+	Cost         float64 // estimated, in USD, negative when price of model is unknown
+}
+
+// StatsFunc returns stats of provider, ok is false when there are none
+type StatsFunc func(provider string) (ProviderStats, bool)
+
+// TagPreviewFunc returns tag created for increment when message is committed, empty when unknown
+type TagPreviewFunc func(increment, message string) string
+
+// MergeFunc combines several suggestions into one message
+type MergeFunc func(ctx context.Context, messages []string) (string, error)
+
+// WithRegenerate enables regeneration of suggestions from the UI
+func WithRegenerate(regenerate RegenerateFunc) Option {
+	return func(m *Model) {
+		m.regenerate = regenerate
+	}
+}
+
+// WithDiff shows staged diff in a pane next to suggestions
+func WithDiff(diff string) Option {
+	return func(m *Model) {
+		if diff != "" {
+			m.diff = newDiffPane(diff)
+		}
+	}
+}
+
+// WithFiles shows staged files, which can be toggled when restage is not nil
+func WithFiles(files []string, restage RestageFunc) Option {
+	return func(m *Model) {
+		if len(files) > 0 {
+			m.files = newFilesPane(files)
+			m.restage = restage
+		}
+	}
+}
+
+// WithStats shows latency, tokens and cost next to provider of every suggestion
+func WithStats(stats StatsFunc) Option {
+	return func(m *Model) {
+		m.delegate.stats = stats
+	}
+}
+
+// WithHistory allows recalling suggestions of earlier runs for the same changes
+func WithHistory(previous map[string]string) Option {
+	return func(m *Model) {
+		if len(previous) > 0 {
+			m.history = previous
+		}
+	}
+}
+
+// WithTagPreview shows resulting version next to tag checkboxes
+func WithTagPreview(preview TagPreviewFunc) Option {
+	return func(m *Model) {
+		m.tagPreview = preview
+	}
+}
+
+// PushTarget describes where commit is pushed
+type PushTarget struct {
+	Remote    string
+	Branch    string
+	NewBranch bool // branch does not exist on remote yet
+}
+
+// WithPushTarget shows push target while push is enabled
+func WithPushTarget(target PushTarget) Option {
+	return func(m *Model) {
+		m.pushTarget = &target
+	}
+}
+
+// WithMerge enables marking several suggestions and merging them into one
+func WithMerge(merge MergeFunc) Option {
+	return func(m *Model) {
+		m.merge = merge
+	}
+}
+
+// Layout is arrangement of interactive mode chosen by user, remembered between runs
+type Layout struct {
+	ShowDiff    *bool // nil leaves diff pane to terminal width
+	ShowHistory bool
+}
+
+// WithLayout restores arrangement chosen in earlier run
+func WithLayout(layout Layout) Option {
+	return func(m *Model) {
+		m.prefs = layout
+	}
+}
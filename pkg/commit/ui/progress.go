@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProviderStatus is state of request to provider
+type ProviderStatus string
+
+const (
+	StatusPending  ProviderStatus = "pending"
+	StatusDone     ProviderStatus = "done"
+	StatusFailed   ProviderStatus = "failed"
+	StatusCanceled ProviderStatus = "canceled"
+)
+
+// ProgressFunc reports status change of provider
+type ProgressFunc func(provider string, status ProviderStatus)
+
+// providerStatusMsg carries status change reported while generating
+type providerStatusMsg struct {
+	provider string
+	status   ProviderStatus
+}
+
+// generatedMsg carries result of generation
+type generatedMsg struct {
+	suggestions map[string]string
+	err         error
+}
+
+// progressModel shows status of every provider until generation is finished
+type progressModel struct {
+	providers []string
+	statuses  map[string]ProviderStatus
+	cancel    func(provider string)
+	spinner   spinner.Model
+
+	suggestions map[string]string
+	err         error
+	aborted     bool
+}
+
+// RenderProgress runs generate while showing status of providers, slow providers can be
+// canceled one by one, or all at once to continue with suggestions which already arrived
+func RenderProgress(
+	ctx context.Context,
+	providers []string,
+	cancel func(provider string),
+	generate func(progress ProgressFunc) (map[string]string, error),
+) (map[string]string, error) {
+	statuses := make(map[string]ProviderStatus, len(providers))
+	for _, provider := range providers {
+		statuses[provider] = StatusPending
+	}
+
+	program := tea.NewProgram(
+		progressModel{
+			providers: providers,
+			statuses:  statuses,
+			cancel:    cancel,
+			spinner:   spinner.New(spinner.WithSpinner(spinner.Dot)),
+		},
+		tea.WithContext(ctx),
+	)
+
+	go func() {
+		suggestions, err := generate(func(provider string, status ProviderStatus) {
+			program.Send(providerStatusMsg{provider: provider, status: status})
+		})
+		program.Send(generatedMsg{suggestions: suggestions, err: err})
+	}()
+
+	runResult, err := program.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run progress ui: %w", err)
+	}
+
+	finalState, ok := runResult.(progressModel)
+	if !ok {
+		return nil, fmt.Errorf("invalid model type returned from ui")
+	}
+	if finalState.aborted {
+		return nil, context.Canceled
+	}
+
+	return finalState.suggestions, finalState.err
+}
+
+func (m progressModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case providerStatusMsg:
+		m.statuses[msg.provider] = msg.status
+		return m, nil
+	case generatedMsg:
+		m.suggestions, m.err = msg.suggestions, msg.err
+		return m, tea.Quit
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case tea.KeyMsg:
+		switch msg.String() {
+		case KeyInterrupt, KeyQuit, KeyCancel:
+			m.cancelPending()
+			m.aborted = true
+			return m, tea.Quit
+		case KeySelect:
+			// generation returns as soon as canceled providers give up
+			m.cancelPending()
+			return m, nil
+		default:
+			if index, err := strconv.Atoi(msg.String()); err == nil && index >= 1 && index <= len(m.providers) {
+				if provider := m.providers[index-1]; m.statuses[provider] == StatusPending {
+					m.cancel(provider)
+				}
+			}
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// cancelPending cancels providers which did not respond yet
+func (m progressModel) cancelPending() {
+	for _, provider := range m.providers {
+		if m.statuses[provider] == StatusPending {
+			m.cancel(provider)
+		}
+	}
+}
+
+func (m progressModel) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorPrimary)).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorMuted))
+
+	lines := []string{titleStyle.Render(ProgressTitle), ""}
+	for i, provider := range m.providers {
+		var indicator string
+		status := m.statuses[provider]
+		switch status {
+		case StatusPending:
+			indicator = m.spinner.View()
+		case StatusDone:
+			indicator = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSuccess)).Render("✓")
+		case StatusFailed:
+			indicator = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render("✗")
+		case StatusCanceled:
+			indicator = mutedStyle.Render("-")
+		}
+		lines = append(lines, fmt.Sprintf(
+			"%s %s %-10s %s",
+			mutedStyle.Render(strconv.Itoa(i+1)), indicator,
+			strings.ToTitle(provider), mutedStyle.Render(string(status)),
+		))
+	}
+	lines = append(lines, "", mutedStyle.Render(ProgressHelp))
+
+	return lipgloss.NewStyle().
+		PaddingTop(1).
+		PaddingLeft(2).
+		Render(strings.Join(lines, "\n")) + "\n"
+}
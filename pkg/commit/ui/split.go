@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SplitGroup is a single planned commit shown in split confirmation screen
+type SplitGroup struct {
+	Message string
+	Files   []string
+}
+
+// splitModel is a minimal confirmation screen for commit split plan
+type splitModel struct {
+	groups    []SplitGroup
+	confirmed bool
+	done      bool
+}
+
+func (m splitModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m splitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case KeySelect:
+			m.confirmed = true
+			m.done = true
+			return m, tea.Quit
+		case KeyInterrupt, KeyQuit, KeyCancel:
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m splitModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color(ColorAccent)).
+		Foreground(lipgloss.Color(ColorBright)).
+		Bold(true).
+		Italic(true).
+		Padding(0, 2).
+		MarginBottom(1)
+
+	messageStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorPrimary)).
+		Bold(true)
+
+	fileStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorMuted))
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorDimmed)).
+		MarginTop(1)
+
+	var b strings.Builder
+	for i, group := range m.groups {
+		b.WriteString(messageStyle.Render(fmt.Sprintf("%d. %s", i+1, group.Message)))
+		b.WriteString("\n")
+		for _, file := range group.Files {
+			b.WriteString(fileStyle.Render("   " + file))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	return lipgloss.NewStyle().
+		Padding(PaddingTop, PaddingHorizontal).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			titleStyle.Render(SplitTitle),
+			strings.TrimSuffix(b.String(), "\n"),
+			helpStyle.Render(SplitHelp),
+		))
+}
+
+// RenderSplitConfirmation shows planned commits and asks user to confirm them
+func RenderSplitConfirmation(ctx context.Context, groups []SplitGroup) (bool, error) {
+	program := tea.NewProgram(
+		splitModel{groups: groups},
+		tea.WithContext(ctx),
+		tea.WithAltScreen(),
+	)
+
+	runResult, err := program.Run()
+	if err != nil {
+		return false, fmt.Errorf("failed to run interactive ui: %w", err)
+	}
+
+	finalState, ok := runResult.(splitModel)
+	if !ok {
+		return false, fmt.Errorf("invalid model type returned from ui")
+	}
+
+	return finalState.confirmed, nil
+}
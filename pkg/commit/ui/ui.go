@@ -12,9 +12,18 @@ func RenderInteractiveUI(
 	ctx context.Context,
 	suggestions map[string]string,
 	checkboxStates map[string]bool,
+	opts ...Option,
 ) (*Model, error) {
+	model := newModel(ctx, suggestions, checkboxStates, opts...)
+	if model.accessible != nil {
+		if err := model.runAccessible(); err != nil {
+			return nil, err
+		}
+		return &model, nil
+	}
+
 	program := tea.NewProgram(
-		newModel(suggestions, checkboxStates),
+		model,
 		tea.WithContext(ctx),
 		tea.WithAltScreen(), // keeps the terminal clean after exiting
 	)
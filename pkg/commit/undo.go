@@ -0,0 +1,82 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hasansino/commit/pkg/gitops"
+)
+
+// Undo soft-resets HEAD commit keeping its changes staged, so it can be regenerated with
+// different options, commits without generation note or already pushed are rejected unless force is set
+func (s *Service) Undo(ctx context.Context, force bool) error {
+	// providers are not needed, so checkRepository is not used
+	if !s.gitOps.IsGitRepository() {
+		return fmt.Errorf("not a git repository")
+	}
+
+	repoState, err := s.gitOps.GetRepoState()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get repository state", "error", err)
+		return fmt.Errorf("failed to get repository state: %w", err)
+	}
+	if repoState != gitops.RepoStateNormal {
+		s.logger.ErrorContext(ctx, "Repository not in normal state", "state", repoState)
+		return fmt.Errorf("repository is in %s state, cannot undo commit", repoState)
+	}
+
+	sha, err := s.gitOps.ResolveCommit("HEAD")
+	if err != nil {
+		s.logger.ErrorContext(ctx, "No commit to undo", "error", err)
+		return fmt.Errorf("no commit to undo: %w", err)
+	}
+
+	if !strings.HasPrefix(s.gitOps.GetNote(notesRef, sha), generatedNoteMarker) {
+		if !force {
+			s.logger.ErrorContext(
+				ctx, "HEAD commit has no generation note, use --force to undo it anyway",
+				"commit", gitops.ShortSHA(sha),
+			)
+			return fmt.Errorf("commit %s was not created by this tool", gitops.ShortSHA(sha))
+		}
+		s.logger.WarnContext(ctx, "Undoing commit without generation note", "commit", gitops.ShortSHA(sha))
+	}
+
+	pushed, err := s.gitOps.IsCommitPushed(sha)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to check if commit is pushed", "error", err)
+		return fmt.Errorf("failed to check if commit is pushed: %w", err)
+	}
+	if pushed {
+		if !force {
+			s.logger.ErrorContext(
+				ctx, "Commit is already pushed, use --force to undo it anyway",
+				"commit", gitops.ShortSHA(sha),
+			)
+			return fmt.Errorf("commit %s is already pushed", gitops.ShortSHA(sha))
+		}
+		s.logger.WarnContext(ctx, "Undoing pushed commit, force push will be required", "commit", gitops.ShortSHA(sha))
+	}
+
+	message, err := s.gitOps.GetCommitMessage(sha)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to get commit message", "error", err)
+	}
+	subject, _, _ := strings.Cut(message, "\n")
+
+	if s.settings.DryRun {
+		s.logger.InfoContext(ctx, "Dry run: commit not undone", "commit", gitops.ShortSHA(sha), "subject", subject)
+		return nil
+	}
+
+	if err := s.gitOps.UndoLastCommit(); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to undo commit", "error", err)
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "Commit undone, its changes are staged",
+		"commit", gitops.ShortSHA(sha), "subject", subject)
+
+	return nil
+}
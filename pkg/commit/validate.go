@@ -0,0 +1,71 @@
+package commit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// knownProviders lists provider names accepted in settings.
+var knownProviders = []string{"claude", "openai", "gemini"}
+
+// maxSuggestionDistance is the largest edit distance for a "did you mean" hint.
+const maxSuggestionDistance = 2
+
+// checkChoice returns an error when value is not empty and not one of choices.
+// The error lists accepted values and suggests the closest one when it looks like a typo.
+func checkChoice(field, value string, choices ...string) error {
+	if value == "" {
+		return nil
+	}
+	for _, choice := range choices {
+		if value == choice {
+			return nil
+		}
+	}
+	msg := fmt.Sprintf("invalid %s: %s (must be %s", field, value, joinChoices(choices))
+	if suggestion := closestChoice(value, choices); suggestion != "" {
+		msg += fmt.Sprintf(", did you mean '%s'?", suggestion)
+	}
+	return fmt.Errorf("%s)", msg)
+}
+
+// joinChoices formats choices as "a, b or c".
+func joinChoices(choices []string) string {
+	if len(choices) < 2 {
+		return strings.Join(choices, "")
+	}
+	return strings.Join(choices[:len(choices)-1], ", ") + " or " + choices[len(choices)-1]
+}
+
+// closestChoice returns the choice nearest to value, or empty if none is close enough.
+func closestChoice(value string, choices []string) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, choice := range choices {
+		if d := levenshtein(strings.ToLower(value), choice); d < bestDistance {
+			best, bestDistance = choice, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
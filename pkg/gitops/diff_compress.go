@@ -0,0 +1,124 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxContextRun    = 12 // longest run of unchanged lines kept whole in compressed diff
+	keptContextLines = 3  // unchanged lines kept at each end of collapsed run
+)
+
+// noiseHeaderPrefixes start diff header lines which say nothing about intent of a change
+var noiseHeaderPrefixes = []string{"index ", "similarity index ", "dissimilarity index "}
+
+// compressDiff reduces size of unified diff without losing what changed, it drops noise
+// header lines and hunks changing only whitespace, and collapses long runs of unchanged lines
+func compressDiff(diff string) string {
+	var b strings.Builder
+	b.Grow(len(diff))
+	for _, section := range splitBefore(diff, "diff --git ") {
+		compressFileDiff(&b, section)
+	}
+	return b.String()
+}
+
+// compressFileDiff writes compressed diff of a single file, file with only whitespace
+// changes keeps its header and a note instead of hunks
+func compressFileDiff(b *strings.Builder, section string) {
+	var (
+		hunks  [][]string
+		inHunk bool
+	)
+	for _, line := range strings.SplitAfter(section, "\n") {
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "@@"):
+			hunks = append(hunks, []string{line})
+			inHunk = true
+		case inHunk:
+			hunks[len(hunks)-1] = append(hunks[len(hunks)-1], line)
+		case !isNoiseHeader(line):
+			b.WriteString(line)
+		}
+	}
+
+	kept := 0
+	for _, hunk := range hunks {
+		if isWhitespaceOnlyHunk(hunk) {
+			continue
+		}
+		kept++
+		b.WriteString(hunk[0])
+		writeCollapsedContext(b, hunk[1:])
+	}
+	if kept == 0 && len(hunks) > 0 {
+		b.WriteString("(whitespace-only changes)\n")
+	}
+}
+
+func isNoiseHeader(line string) bool {
+	for _, prefix := range noiseHeaderPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWhitespaceOnlyHunk reports whether removed and added lines of hunk differ only in whitespace
+func isWhitespaceOnlyHunk(hunk []string) bool {
+	var removed, added strings.Builder
+	changed := false
+	for _, line := range hunk[1:] {
+		switch {
+		case strings.HasPrefix(line, "-"):
+			removed.WriteString(stripSpace(line[1:]))
+			changed = true
+		case strings.HasPrefix(line, "+"):
+			added.WriteString(stripSpace(line[1:]))
+			changed = true
+		}
+	}
+	return changed && removed.String() == added.String()
+}
+
+func stripSpace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// writeCollapsedContext writes hunk body replacing middle of long runs of unchanged lines with a note
+func writeCollapsedContext(b *strings.Builder, body []string) {
+	var run []string
+	flush := func() {
+		if len(run) > maxContextRun {
+			for _, line := range run[:keptContextLines] {
+				b.WriteString(line)
+			}
+			fmt.Fprintf(b, " ... %d unchanged lines\n", len(run)-2*keptContextLines)
+			run = run[len(run)-keptContextLines:]
+		}
+		for _, line := range run {
+			b.WriteString(line)
+		}
+		run = run[:0]
+	}
+
+	for _, line := range body {
+		if strings.HasPrefix(line, " ") {
+			run = append(run, line)
+			continue
+		}
+		flush()
+		b.WriteString(line)
+	}
+	flush()
+}
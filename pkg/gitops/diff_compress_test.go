@@ -0,0 +1,65 @@
+package gitops
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompressDiff(t *testing.T) {
+	context := func(n int) string {
+		var b strings.Builder
+		for i := range n {
+			b.WriteString(" line" + string(rune('a'+i)) + "\n")
+		}
+		return b.String()
+	}
+
+	tests := []struct {
+		name string
+		diff string
+		want string
+	}{
+		{
+			name: "strips index and similarity lines",
+			diff: "diff --git a.go b.go\nsimilarity index 90%\nrename from a.go\nrename to b.go\n" +
+				"index 1234567..89abcde 100644\n--- a.go\n+++ b.go\n@@ -1 +1 @@\n-a\n+b\n",
+			want: "diff --git a.go b.go\nrename from a.go\nrename to b.go\n--- a.go\n+++ b.go\n@@ -1 +1 @@\n-a\n+b\n",
+		},
+		{
+			name: "drops whitespace-only hunk",
+			diff: "diff --git a.go a.go\n--- a.go\n+++ a.go\n" +
+				"@@ -1 +1 @@\n-if x {\n+if x  {\n" +
+				"@@ -9 +9 @@\n-a\n+b\n",
+			want: "diff --git a.go a.go\n--- a.go\n+++ a.go\n@@ -9 +9 @@\n-a\n+b\n",
+		},
+		{
+			name: "notes file with only whitespace changes",
+			diff: "diff --git a.go a.go\n--- a.go\n+++ a.go\n@@ -1,2 +1,2 @@\n-\tx := 1\n+    x := 1\n \ty\n",
+			want: "diff --git a.go a.go\n--- a.go\n+++ a.go\n(whitespace-only changes)\n",
+		},
+		{
+			name: "collapses long unchanged run",
+			diff: "diff --git a.go a.go\n@@ -1,21 +1,21 @@\n" + context(20) + "-a\n+b\n",
+			want: "diff --git a.go a.go\n@@ -1,21 +1,21 @@\n linea\n lineb\n linec\n ... 14 unchanged lines\n" +
+				" liner\n lines\n linet\n-a\n+b\n",
+		},
+		{
+			name: "keeps short unchanged run",
+			diff: "diff --git a.go a.go\n@@ -1,5 +1,5 @@\n" + context(4) + "-a\n+b\n",
+			want: "diff --git a.go a.go\n@@ -1,5 +1,5 @@\n" + context(4) + "-a\n+b\n",
+		},
+		{
+			name: "keeps binary file",
+			diff: "diff --git a.png a.png\nindex 1234567..89abcde 100644\nBinary files a.png and a.png differ\n",
+			want: "diff --git a.png a.png\nBinary files a.png and a.png differ\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compressDiff(tt.diff); got != tt.want {
+				t.Errorf("compressDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
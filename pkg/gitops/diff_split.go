@@ -0,0 +1,135 @@
+package gitops
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// SplitDiff splits unified diff into chunks of at most maxSizeBytes, on file boundaries when possible,
+// large files are split on hunk boundaries and keep their header, single hunks which are still too large
+// are cut on line boundary
+func SplitDiff(diff string, maxSizeBytes int) []string {
+	if len(diff) <= maxSizeBytes {
+		return []string{diff}
+	}
+
+	var pieces []string
+	for _, section := range splitBefore(diff, "diff --git ") {
+		if len(section) <= maxSizeBytes {
+			pieces = append(pieces, section)
+			continue
+		}
+		header, hunks := "", splitBefore(section, "@@ ")
+		if !strings.HasPrefix(hunks[0], "@@ ") {
+			header, hunks = hunks[0], hunks[1:]
+		}
+		if len(header) >= maxSizeBytes || len(hunks) == 0 {
+			pieces = append(pieces, truncateLines(section, maxSizeBytes))
+			continue
+		}
+		for _, hunk := range hunks {
+			pieces = append(pieces, header+truncateLines(hunk, maxSizeBytes-len(header)))
+		}
+	}
+
+	// pack small pieces together, so there are as few requests as possible
+	var (
+		chunks  []string
+		current strings.Builder
+	)
+	for _, piece := range pieces {
+		if current.Len() > 0 && current.Len()+len(piece) > maxSizeBytes {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		current.WriteString(piece)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+
+	return chunks
+}
+
+// splitBefore splits text into sections starting with lines which have given prefix,
+// text before first such line is the first section
+func splitBefore(text, prefix string) []string {
+	var (
+		sections []string
+		start    int
+	)
+	for offset := 0; offset < len(text); {
+		end := strings.IndexByte(text[offset:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += offset + 1
+		}
+		if offset > start && strings.HasPrefix(text[offset:], prefix) {
+			sections = append(sections, text[start:offset])
+			start = offset
+		}
+		offset = end
+	}
+	if start < len(text) || len(sections) == 0 {
+		sections = append(sections, text[start:])
+	}
+	return sections
+}
+
+// truncateLines cuts text to at most maxSizeBytes without splitting lines,
+// single line longer than the limit is cut on rune boundary
+func truncateLines(text string, maxSizeBytes int) string {
+	if len(text) <= maxSizeBytes {
+		return text
+	}
+	if cut := strings.LastIndexByte(text[:maxSizeBytes], '\n'); cut > 0 {
+		return text[:cut+1]
+	}
+	cut := max(maxSizeBytes, 0)
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
+
+// DiffFiles returns paths of files changed in unified diff, taken from "diff --git" headers
+// with or without a/ b/ prefixes, or from "+++" lines of plain diffs
+func DiffFiles(diff string) []string {
+	var (
+		files []string
+		seen  = make(map[string]bool)
+		git   bool
+	)
+	add := func(file string) {
+		if file != "" && file != "/dev/null" && !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+
+	for _, line := range strings.Split(diff, "\n") {
+		if header, ok := strings.CutPrefix(line, "diff --git "); ok {
+			git = true
+			fields := strings.Fields(header)
+			if len(fields) < 2 {
+				continue
+			}
+			file := fields[len(fields)-1]
+			if strings.HasPrefix(fields[0], "a/") {
+				file = strings.TrimPrefix(file, "b/")
+			}
+			add(file)
+			continue
+		}
+		if git {
+			continue
+		}
+		if target, ok := strings.CutPrefix(line, "+++ "); ok {
+			target, _, _ = strings.Cut(target, "\t")
+			add(strings.TrimPrefix(target, "b/"))
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,140 @@
+package gitops
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitDiff(t *testing.T) {
+	fileA := "diff --git a.go a.go\n--- a.go\n+++ a.go\n@@ -1 +1 @@\n-a\n+b\n"
+	fileB := "diff --git b.go b.go\n--- b.go\n+++ b.go\n@@ -1 +1 @@\n-c\n+d\n"
+	header := "diff --git c.go c.go\n--- c.go\n+++ c.go\n"
+	hunk1 := "@@ -1,2 +1,2 @@\n-one\n+uno\n-two\n+dos\n"
+	hunk2 := "@@ -10,2 +10,2 @@\n-ten\n+diez\n-eleven\n+once\n"
+
+	tests := []struct {
+		name string
+		diff string
+		max  int
+		want []string
+	}{
+		{
+			name: "fits",
+			diff: fileA + fileB,
+			max:  1024,
+			want: []string{fileA + fileB},
+		},
+		{
+			name: "file per chunk",
+			diff: fileA + fileB,
+			max:  len(fileA) + 10,
+			want: []string{fileA, fileB},
+		},
+		{
+			name: "large file split by hunks with header",
+			diff: fileA + header + hunk1 + hunk2,
+			max:  len(header) + len(hunk2),
+			want: []string{fileA, header + hunk1, header + hunk2},
+		},
+		{
+			name: "large hunk cut on line boundary",
+			diff: header + hunk1,
+			max:  len(header) + len("@@ -1,2 +1,2 @@\n-one\n+uno\n") + 3,
+			want: []string{header + "@@ -1,2 +1,2 @@\n-one\n+uno\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitDiff(tt.diff, tt.max)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SplitDiff() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("SplitDiff()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+				if len(got[i]) > tt.max {
+					t.Errorf("SplitDiff()[%d] has %d bytes, max %d", i, len(got[i]), tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncateDiff(t *testing.T) {
+	diff := "line one\nline two\nline three\nline four\nline five\n"
+
+	if got := truncateDiff(diff, len(diff)); got != diff {
+		t.Errorf("truncateDiff() of fitting diff = %q, want %q", got, diff)
+	}
+
+	max := len("line one\n") + len(DiffTruncatedMarker) + 4
+	got := truncateDiff(diff, max)
+	if want := "line one\n" + DiffTruncatedMarker; got != want {
+		t.Errorf("truncateDiff() = %q, want %q", got, want)
+	}
+}
+
+func TestTruncateLines(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		max  int
+		want string
+	}{
+		{name: "fitting text", text: "a\nb\n", max: 4, want: "a\nb\n"},
+		{name: "cut on line boundary", text: "first\nsecond\n", max: 10, want: "first\n"},
+		{name: "long line cut on rune boundary", text: "ключ: значение", max: 5, want: "кл"},
+		{name: "multibyte rune is not split", text: "日本語", max: 4, want: "日"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateLines(tt.text, tt.max)
+			if got != tt.want || !utf8.ValidString(got) {
+				t.Errorf("truncateLines(%q, %d) = %q, want %q", tt.text, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		diff string
+		want []string
+	}{
+		{
+			name: "git diff with prefixes",
+			diff: "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n" +
+				"diff --git a/old.go b/new.go\nrename from old.go\nrename to new.go\n",
+			want: []string{"main.go", "new.go"},
+		},
+		{
+			name: "git diff without prefixes",
+			diff: "diff --git pkg/a.go pkg/a.go\n--- pkg/a.go\n+++ pkg/a.go\n",
+			want: []string{"pkg/a.go"},
+		},
+		{
+			name: "plain unified diff",
+			diff: "--- a/readme.md\t2024-01-01\n+++ b/readme.md\t2024-01-02\n@@ -1 +1 @@\n" +
+				"--- a/gone.txt\n+++ /dev/null\n",
+			want: []string{"readme.md"},
+		},
+		{
+			name: "no files",
+			diff: "random text",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffFiles(tt.diff); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package gitops
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// diffReadMargin is read past size limit of diff, it leaves room for compression
+// and for choosing whole files to keep
+const diffReadMargin = 1 << 20
+
+// errDiffLimit stops writes to diffBuffer past its limit
+var errDiffLimit = errors.New("diff exceeds read limit")
+
+// diffBuffer keeps diff output up to its limit and fails writes past it, so git and
+// diff encoder stop producing output instead of it being buffered whole
+type diffBuffer struct {
+	buf      bytes.Buffer
+	limit    int
+	exceeded bool
+}
+
+func newDiffBuffer(maxSizeBytes int) *diffBuffer {
+	return &diffBuffer{limit: maxSizeBytes + diffReadMargin}
+}
+
+func (b *diffBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - b.buf.Len()
+	if len(p) > remaining {
+		b.exceeded = true
+		n, _ := b.buf.Write(p[:max(remaining, 0)])
+		return n, errDiffLimit
+	}
+	return b.buf.Write(p)
+}
+
+// String returns read diff, last file of diff cut by the limit is dropped as it is incomplete,
+// unless it is the only one, then it is kept up to its last whole line
+func (b *diffBuffer) String() string {
+	diff := b.buf.String()
+	if !b.exceeded {
+		return diff
+	}
+	if i := strings.LastIndex(diff, "\ndiff --git "); i >= 0 {
+		return diff[:i+1]
+	}
+	if i := strings.LastIndexByte(diff, '\n'); i >= 0 {
+		return diff[:i+1]
+	}
+	return ""
+}
+
+// runDiff runs git diff with output read up to limit of maxSizeBytes, complete is false when
+// git was stopped because its output exceeded the limit
+func (g *Operations) runDiff(args []string, maxSizeBytes int) (diff string, complete bool, err error) {
+	buf := newDiffBuffer(maxSizeBytes)
+	cmd := g.gitCommand(args...)
+	cmd.Stdout = buf
+	err = cmd.Run()
+	if buf.exceeded {
+		// git is stopped by closed pipe, its exit status is expected
+		return buf.String(), false, nil
+	}
+	return buf.String(), true, err
+}
+
+// fitDiff fits diff into maxSizeBytes by priority of its files, incomplete diff cut
+// by read limit is marked as truncated even when the rest fits
+func fitDiff(diff string, complete bool, maxSizeBytes int) string {
+	diff = PrioritizeDiff(diff, maxSizeBytes)
+	if complete || strings.Contains(diff, DiffTruncatedMarker) || maxSizeBytes <= len(DiffTruncatedMarker) {
+		return diff
+	}
+	return truncateLines(diff, maxSizeBytes-len(DiffTruncatedMarker)) + DiffTruncatedMarker
+}
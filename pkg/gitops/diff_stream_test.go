@@ -0,0 +1,94 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiffBuffer(t *testing.T) {
+	fileA := "diff --git a.go a.go\n@@ -1 +1 @@\n-a\n+b\n"
+	fileB := "diff --git b.go b.go\n@@ -1,2 +1,2 @@\n-c\n+d\n"
+
+	tests := []struct {
+		name         string
+		limit        int
+		writes       []string
+		want         string
+		wantExceeded bool
+	}{
+		{name: "within limit", limit: 100, writes: []string{fileA, fileB}, want: fileA + fileB},
+		{
+			name: "drops cut file", limit: len(fileA) + 10, writes: []string{fileA, fileB},
+			want: fileA, wantExceeded: true,
+		},
+		{
+			name: "keeps whole lines of only file", limit: 36, writes: []string{fileA},
+			want: fileA[:36], wantExceeded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &diffBuffer{limit: tt.limit}
+			for _, write := range tt.writes {
+				if _, err := buf.Write([]byte(write)); err != nil {
+					break
+				}
+			}
+			if buf.exceeded != tt.wantExceeded {
+				t.Errorf("exceeded = %v, want %v", buf.exceeded, tt.wantExceeded)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitDiff(t *testing.T) {
+	diff := "diff --git a.go a.go\n@@ -1 +1 @@\n-a\n+b\n"
+
+	if got := fitDiff(diff, true, 1024); got != diff {
+		t.Errorf("fitDiff() of complete diff = %q, want it unchanged", got)
+	}
+	if got := fitDiff(diff, false, 1024); got != diff+DiffTruncatedMarker {
+		t.Errorf("fitDiff() of incomplete diff = %q, want it marked as truncated", got)
+	}
+	if got := fitDiff(diff, false, len(diff)); len(got) > len(diff) || !strings.HasSuffix(got, DiffTruncatedMarker) {
+		t.Errorf("fitDiff() of incomplete diff at limit = %q, want marked diff within limit", got)
+	}
+}
+
+func TestGitOperations_GetStagedDiff_ReadLimit(t *testing.T) {
+	for _, pureGo := range []bool{false, true} {
+		t.Run(fmt.Sprintf("pure go %v", pureGo), func(t *testing.T) {
+			g, dir := newTestGitOperations(t)
+			g.pureGo = pureGo
+			t.Chdir(dir)
+			commitTestFile(t, dir, "main.go", "package main\n", "init")
+
+			// staged diff is far over read limit
+			var huge strings.Builder
+			for i := 0; huge.Len() < 2*diffReadMargin; i++ {
+				fmt.Fprintf(&huge, "line %d of generated content\n", i)
+			}
+			writeTestFiles(t, dir, map[string]string{
+				"a.go":   "package main\n\nfunc a() {}\n",
+				"big.go": huge.String(),
+			})
+			runTestGit(t, dir, "add", "a.go", "big.go")
+
+			diff, err := g.GetStagedDiff(4096, 0)
+			if err != nil {
+				t.Fatalf("GetStagedDiff() unexpected error = %v", err)
+			}
+			if len(diff) > 4096 {
+				t.Errorf("GetStagedDiff() length = %d, want at most 4096", len(diff))
+			}
+			if !strings.Contains(diff, "a.go") || !strings.Contains(diff, DiffTruncatedMarker) {
+				t.Errorf("GetStagedDiff() = %q, want a.go kept and diff marked as truncated", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,1539 @@
+package gitops
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// Operations runs git operations on one repository, spawning git or using go-git
+type Operations struct {
+	repo        *git.Repository
+	diffOptions DiffOptions
+	pureGo      bool // use go-git instead of spawning git where possible
+	trace       bool // spawn git with GIT_TRACE=1 unless tracing is configured by environment
+	// platforms of hosts not named after them, for pull request links
+	platformHosts map[string]Platform
+	root          string // worktree root, spawned commands run in it
+	gitDir        string // absolute GIT_DIR the repository was opened with, empty when discovered
+}
+
+// DiffOptions tune diffs used as AI input
+type DiffOptions struct {
+	Algorithm       string // myers, minimal, patience or histogram, empty uses git default
+	RenameThreshold int    // similarity percentage for rename detection, 0 disables it
+	FunctionContext bool   // show whole function around changes in staged diff
+	Compress        bool   // drop whitespace-only hunks, noise headers and long unchanged runs
+}
+
+// DefaultDiffOptions are tuned for code with many similar lines
+var DefaultDiffOptions = DiffOptions{
+	Algorithm:       "patience",
+	RenameThreshold: 50,
+	FunctionContext: true,
+	Compress:        true,
+}
+
+// prepare compresses diff shown to AI when enabled
+func (o DiffOptions) prepare(diff string) string {
+	if !o.Compress {
+		return diff
+	}
+	return compressDiff(diff)
+}
+
+// args returns diff arguments shared by all diffs shown to AI
+func (o DiffOptions) args() []string {
+	var args []string
+	if o.Algorithm != "" {
+		args = append(args, "--diff-algorithm="+o.Algorithm)
+	}
+	if o.RenameThreshold > 0 {
+		args = append(args, fmt.Sprintf("--find-renames=%d", o.RenameThreshold))
+	} else {
+		args = append(args, "--no-renames")
+	}
+	return args
+}
+
+type gitConfig struct {
+	UserName   string
+	UserEmail  string
+	GPGSign    bool
+	TagGPGSign bool
+	SigningKey string
+	GPGFormat  string
+	GPGProgram string
+}
+
+// conventionalHeaderPattern splits conventional commit header into type, scope and breaking mark
+var conventionalHeaderPattern = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!?): `)
+
+// breakingChangeFooterPattern matches BREAKING CHANGE footer in commit body
+var breakingChangeFooterPattern = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+
+// DefaultRemote is used for pushing when no remote is configured
+const DefaultRemote = "origin"
+
+// semVer represents a semantic version
+type semVer struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string // e.g. rc.1, empty for releases
+	Build      string // build metadata, ignored in precedence
+}
+
+// semVerPattern matches semver tags with optional prerelease and build metadata
+var semVerPattern = regexp.MustCompile(
+	`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`,
+)
+
+// defaultPrereleaseID is used when starting a new prerelease series
+const defaultPrereleaseID = "rc"
+
+// Open opens repository containing repoPath, or the one pointed to by GIT_DIR,
+// in which case repoPath is its worktree, like git does
+func Open(repoPath string, options ...Option) (*Operations, error) {
+	repo, gitDir, err := openRepository(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Operations{repo: repo, diffOptions: DefaultDiffOptions, gitDir: gitDir}
+	// bare repositories have no worktree, commands run in working directory of the process
+	if wt, err := repo.Worktree(); err == nil {
+		g.root = wt.Filesystem.Root()
+	}
+	for _, option := range options {
+		option(g)
+	}
+	return g, nil
+}
+
+// ErrNotRepository is returned by Open when path is not in a repository
+var ErrNotRepository = git.ErrRepositoryNotExists
+
+// ErrRequiresGit is returned in pure go mode by operations go-git cannot perform
+var ErrRequiresGit = errors.New("requires git binary, unavailable in pure go mode")
+
+// OpenEmpty returns operations of empty in-memory repository, spawned commands run in working
+// directory of the process, for work which needs no repository, e.g. describing supplied diff
+func OpenEmpty(options ...Option) (*Operations, error) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize empty repository: %w", err)
+	}
+
+	g := &Operations{repo: repo, diffOptions: DefaultDiffOptions}
+	for _, option := range options {
+		option(g)
+	}
+	return g, nil
+}
+
+func openRepository(repoPath string) (*git.Repository, string, error) {
+	gitDir := os.Getenv("GIT_DIR")
+	if gitDir == "" {
+		repo, err := git.PlainOpenWithOptions(repoPath, &git.PlainOpenOptions{
+			DetectDotGit: true,
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to open git repository: %w", err)
+		}
+		return repo, "", nil
+	}
+
+	// Git commands are spawned from worktree root, relative paths would no longer resolve
+	absGitDir, err := filepath.Abs(gitDir)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve GIT_DIR %s: %w", gitDir, err)
+	}
+	absWorkTree, err := filepath.Abs(repoPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve work tree %s: %w", repoPath, err)
+	}
+
+	storage := filesystem.NewStorage(osfs.New(absGitDir), cache.NewObjectLRUDefault())
+	repo, err := git.Open(storage, osfs.New(absWorkTree))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open git repository %s: %w", absGitDir, err)
+	}
+
+	return repo, absGitDir, nil
+}
+
+// Root returns worktree root, paths of the repository are relative to it
+func (g *Operations) Root() string {
+	return g.root
+}
+
+// command prepares program run in worktree root, git spawned by it
+// uses repository the operations were opened with
+func (g *Operations) command(name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = g.root
+
+	var env []string
+	if g.gitDir != "" {
+		env = append(env, "GIT_DIR="+g.gitDir, "GIT_WORK_TREE="+g.root)
+	}
+	if g.trace && os.Getenv("GIT_TRACE") == "" {
+		env = append(env, "GIT_TRACE=1")
+	}
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	return cmd
+}
+
+// gitCommand prepares git command run in worktree root
+func (g *Operations) gitCommand(args ...string) *exec.Cmd {
+	return g.command("git", args...)
+}
+
+// getConfig reads git configuration - fails if user.name or user.email not configured
+func (g *Operations) getConfig() (*gitConfig, error) {
+	config := &gitConfig{
+		GPGSign:    false,
+		GPGProgram: "gpg",
+	}
+
+	// Get required user configuration
+	userName := g.GetConfigValue("user.name")
+	if userName == "" {
+		return nil, fmt.Errorf("git user.name not configured. Run: git config user.name \"Your Name\"")
+	}
+	config.UserName = userName
+
+	userEmail := g.GetConfigValue("user.email")
+	if userEmail == "" {
+		return nil, fmt.Errorf("git user.email not configured. Run: git config user.email \"your.email@example.com\"")
+	}
+	config.UserEmail = userEmail
+
+	// Read optional GPG configuration
+	if gpgSign := g.GetConfigValue("commit.gpgsign"); gpgSign != "" {
+		config.GPGSign = strings.ToLower(gpgSign) == "true"
+	}
+	// Tags follow commit signing unless tag.gpgSign is set explicitly
+	config.TagGPGSign = config.GPGSign
+	if tagGPGSign := g.GetConfigValue("tag.gpgsign"); tagGPGSign != "" {
+		config.TagGPGSign = strings.ToLower(tagGPGSign) == "true"
+	}
+	if gpgFormat := g.GetConfigValue("gpg.format"); gpgFormat != "" {
+		config.GPGFormat = strings.ToLower(gpgFormat)
+	}
+	if signingKey := g.GetConfigValue("user.signingkey"); signingKey != "" {
+		config.SigningKey = signingKey
+	}
+	if gpgProgram := g.GetConfigValue("gpg.program"); gpgProgram != "" {
+		config.GPGProgram = gpgProgram
+	}
+
+	return config, nil
+}
+
+// GetConfigValue reads a specific git config value using git command
+func (g *Operations) GetConfigValue(key string) string {
+	if g.pureGo {
+		return g.getConfigValueNative(key)
+	}
+	cmd := g.gitCommand("config", key)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// getGlobalGitignoreFile reads core.excludesFile from git config and returns the absolute path
+func (g *Operations) getGlobalGitignoreFile() (string, error) {
+	excludesFile := g.GetConfigValue("core.excludesFile")
+	if excludesFile == "" {
+		return "", nil // No global gitignore configured
+	}
+
+	// Expand ~ to home directory if needed
+	if strings.HasPrefix(excludesFile, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		excludesFile = filepath.Join(homeDir, excludesFile[2:])
+	}
+
+	// Convert to absolute path if not already
+	if !filepath.IsAbs(excludesFile) {
+		absPath, err := filepath.Abs(excludesFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to get absolute path for %s: %w", excludesFile, err)
+		}
+		excludesFile = absPath
+	}
+
+	return excludesFile, nil
+}
+
+// parseGitignoreFile parses a gitignore file and returns its patterns, including negations
+func parseGitignoreFile(filePath string) ([]gitignore.Pattern, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []gitignore.Pattern{}, nil // File doesn't exist, return empty patterns
+		}
+		return nil, fmt.Errorf("failed to open gitignore file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	var patterns []gitignore.Pattern
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		// Trailing spaces are handled by the pattern parser (they can be escaped)
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		// Skip empty lines and comments
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read gitignore file: %w", err)
+	}
+
+	return patterns, nil
+}
+
+// DetachedHeadError is returned by GetCurrentBranch when HEAD points to a commit, not a branch
+type DetachedHeadError struct {
+	SHA string
+}
+
+func (e *DetachedHeadError) Error() string {
+	return "HEAD is detached at " + ShortSHA(e.SHA)
+}
+
+// GetCurrentBranch returns short name of checked out branch, including unborn branch
+// of an empty repository, detached HEAD is reported as *DetachedHeadError
+func (g *Operations) GetCurrentBranch() (string, error) {
+	head, err := g.repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	if head.Type() == plumbing.SymbolicReference {
+		return head.Target().Short(), nil
+	}
+
+	return "", &DetachedHeadError{SHA: head.Hash().String()}
+}
+
+// GetCommitTemplate reads the file configured in commit.template, stripping comment lines
+func (g *Operations) GetCommitTemplate() (string, error) {
+	templateFile := g.GetConfigValue("commit.template")
+	if templateFile == "" {
+		return "", nil // No commit template configured
+	}
+
+	// Expand ~ to home directory if needed
+	if strings.HasPrefix(templateFile, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		templateFile = filepath.Join(homeDir, templateFile[2:])
+	}
+
+	// Relative paths are resolved against the worktree root
+	if !filepath.IsAbs(templateFile) {
+		wt, err := g.repo.Worktree()
+		if err != nil {
+			return "", fmt.Errorf("failed to get worktree: %w", err)
+		}
+		templateFile = filepath.Join(wt.Filesystem.Root(), templateFile)
+	}
+
+	content, err := os.ReadFile(templateFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read commit template %s: %w", templateFile, err)
+	}
+
+	return parseCommitTemplate(string(content)), nil
+}
+
+// GetRecentCommits returns subjects of up to n latest non-merge commits reachable from HEAD
+func (g *Operations) GetRecentCommits(n int) ([]string, error) {
+	head, err := g.repo.Head()
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, nil // No commits yet
+		}
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	iter, err := g.repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+	defer iter.Close()
+
+	var subjects []string
+	err = iter.ForEach(func(c *object.Commit) error {
+		if len(subjects) >= n {
+			return storer.ErrStop
+		}
+		if c.NumParents() > 1 {
+			return nil
+		}
+		subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+		subjects = append(subjects, strings.TrimSpace(subject))
+		return nil
+	})
+	// History of shallow clones ends with missing parents, use what is available
+	if err != nil && !errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, fmt.Errorf("failed to read commit log: %w", err)
+	}
+
+	return subjects, nil
+}
+
+// parseCommitTemplate removes git comment lines and surrounding whitespace from a template
+func parseCommitTemplate(content string) string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t\r"))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func (g *Operations) GetWorkingTreeStatus() (git.Status, error) {
+	worktree, err := g.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	return status, nil
+}
+
+func (g *Operations) UnstageAll() error {
+	// there is nothing to reset to on unborn branch, index is emptied instead
+	if _, err := g.repo.Head(); errors.Is(err, plumbing.ErrReferenceNotFound) {
+		idx, err := g.repo.Storer.Index()
+		if err != nil {
+			return fmt.Errorf("failed to read index: %w", err)
+		}
+		idx.Entries = nil
+		if err := g.repo.Storer.SetIndex(idx); err != nil {
+			return fmt.Errorf("failed to reset index: %w", err)
+		}
+		return nil
+	}
+
+	worktree, err := g.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	// Single reset operation instead of per-file operations
+	err = worktree.Reset(&git.ResetOptions{
+		Mode: git.MixedReset,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset: %w", err)
+	}
+
+	return nil
+}
+
+func (g *Operations) StageFiles(
+	excludePatterns []string,
+	includePatterns []string,
+	useGlobalGitignore bool,
+	scopeDir string,
+) ([]string, error) {
+	worktree, err := g.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	// Load global gitignore patterns if requested
+	var globalPatterns []gitignore.Pattern
+	if useGlobalGitignore {
+		globalGitignoreFile, err := g.getGlobalGitignoreFile()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get global gitignore file: %w", err)
+		}
+
+		if globalGitignoreFile != "" {
+			patterns, err := parseGitignoreFile(globalGitignoreFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse global gitignore: %w", err)
+			}
+			globalPatterns = patterns
+		}
+	}
+
+	// Repository ignore files have higher priority and can re-include globally ignored files
+	var ignoreMatcher gitignore.Matcher
+	if len(globalPatterns) > 0 {
+		repoPatterns, err := gitignore.ReadPatterns(worktree.Filesystem, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read repository gitignore: %w", err)
+		}
+		ignoreMatcher = newIgnoreMatcher(globalPatterns, repoPatterns)
+	}
+
+	// Status walks the whole worktree, it is computed once and shared by all staging strategies
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	// Optimization: if no patterns specified, stage everything modified
+	if len(excludePatterns) == 0 && len(includePatterns) == 0 && len(globalPatterns) == 0 && scopeDir == "" {
+		return g.stageAllModified(worktree, status)
+	}
+
+	// If we have simple include patterns (glob-compatible) and no global patterns, match them directly
+	if len(excludePatterns) == 0 && len(includePatterns) == 1 && len(globalPatterns) == 0 && scopeDir == "" &&
+		isSimpleGlobPattern(includePatterns[0]) {
+		return g.stageWithGlob(worktree, status, includePatterns[0])
+	}
+
+	// Fall back to filtered staging for complex patterns
+	return g.stageFiltered(worktree, status, excludePatterns, includePatterns, ignoreMatcher, scopeDir)
+}
+
+// addBatchSize limits paths passed to a single git add, to stay below argument size limits
+const addBatchSize = 500
+
+// addFiles stages given files, files missing from worktree are staged as deletions,
+// callers filtered files already, so ignore rules are not applied again
+func (g *Operations) addFiles(worktree *git.Worktree, files []string) error {
+	if g.pureGo {
+		return addFilesNative(worktree, files)
+	}
+
+	// git writes index once per call, go-git rewrites it for every added file
+	for start := 0; start < len(files); start += addBatchSize {
+		batch := files[start:min(start+addBatchSize, len(files))]
+		args := append([]string{
+			"--literal-pathspecs", "add", "--all", "--force", "--",
+		}, batch...)
+		if output, err := g.gitCommand(args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to stage files: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
+
+// addFilesNative stages given files with go-git, skipping status check which it runs on every plain Add
+func addFilesNative(worktree *git.Worktree, files []string) error {
+	for _, file := range files {
+		if _, err := worktree.Filesystem.Lstat(file); err != nil {
+			if !os.IsNotExist(err) {
+				return fmt.Errorf("failed to stat file %s: %w", file, err)
+			}
+			if _, err := worktree.Remove(file); err != nil && !errors.Is(err, index.ErrEntryNotFound) {
+				return fmt.Errorf("failed to stage deletion of %s: %w", file, err)
+			}
+			continue
+		}
+		if err := worktree.AddWithOptions(&git.AddOptions{Path: file, SkipStatus: true}); err != nil {
+			return fmt.Errorf("failed to stage file %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// newIgnoreMatcher combines ignore patterns in ascending order of priority (last wins)
+func newIgnoreMatcher(patternSets ...[]gitignore.Pattern) gitignore.Matcher {
+	var patterns []gitignore.Pattern
+	for _, set := range patternSets {
+		patterns = append(patterns, set...)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	return gitignore.NewMatcher(patterns)
+}
+
+// StagePaths stages exactly the given paths, including deletions
+func (g *Operations) StagePaths(paths []string) error {
+	worktree, err := g.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	return g.addFiles(worktree, paths)
+}
+
+// Fast path: stage all modified files
+func (g *Operations) stageAllModified(worktree *git.Worktree, status git.Status) ([]string, error) {
+	var modifiedFiles []string
+	for file := range status {
+		fileStatus := status.File(file)
+		if fileStatus.Worktree != git.Unmodified {
+			modifiedFiles = append(modifiedFiles, file)
+		}
+	}
+
+	if len(modifiedFiles) == 0 {
+		return []string{}, nil
+	}
+
+	// go-git stages everything with a single index write, unlike file by file
+	var err error
+	if g.pureGo {
+		err = worktree.AddWithOptions(&git.AddOptions{All: true})
+	} else {
+		err = g.addFiles(worktree, modifiedFiles)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage all files: %w", err)
+	}
+
+	return modifiedFiles, nil
+}
+
+// Fast path: use glob patterns when possible
+func (g *Operations) stageWithGlob(worktree *git.Worktree, status git.Status, pattern string) ([]string, error) {
+	var matchingFiles []string
+	for file := range status {
+		fileStatus := status.File(file)
+		if fileStatus.Worktree == git.Unmodified {
+			continue
+		}
+		if matched, _ := filepath.Match(pattern, file); matched {
+			matchingFiles = append(matchingFiles, file)
+		}
+	}
+
+	if len(matchingFiles) == 0 {
+		return []string{}, nil
+	}
+
+	if err := g.addFiles(worktree, matchingFiles); err != nil {
+		return nil, fmt.Errorf("failed to stage files with pattern %s: %w", pattern, err)
+	}
+
+	return matchingFiles, nil
+}
+
+// Fallback: filtered staging for complex patterns
+func (g *Operations) stageFiltered(
+	worktree *git.Worktree,
+	status git.Status,
+	excludePatterns, includePatterns []string,
+	ignoreMatcher gitignore.Matcher,
+	scopeDir string,
+) ([]string, error) {
+	// Build list of files to stage (filtering phase)
+	var filesToStage []string
+	for file := range status {
+		fileStatus := status.File(file)
+		if fileStatus.Worktree == git.Unmodified {
+			continue
+		}
+
+		if !isInScopeDir(file, scopeDir) {
+			continue
+		}
+
+		if shouldExcludeFile(file, excludePatterns, ignoreMatcher) {
+			continue
+		}
+
+		if len(includePatterns) > 0 && !shouldIncludeFile(file, includePatterns) {
+			continue
+		}
+
+		filesToStage = append(filesToStage, file)
+	}
+
+	// Early return if no files to stage
+	if len(filesToStage) == 0 {
+		return []string{}, nil
+	}
+
+	// Stage files individually (necessary for complex filtering)
+	if err := g.addFiles(worktree, filesToStage); err != nil {
+		return nil, err
+	}
+
+	return filesToStage, nil
+}
+
+// Helper function to check if pattern is simple glob (no complex logic needed)
+func isSimpleGlobPattern(pattern string) bool {
+	// Simple check: if it contains only *, ?, and regular chars, it's probably a simple glob
+	// Exclude patterns with path separators or complex logic
+	return !strings.Contains(pattern, "/") &&
+		(strings.Contains(pattern, "*") || strings.Contains(pattern, "?"))
+}
+
+var contextLevels = []int{5, 3, 2, 1, 0}
+
+// DiffTruncatedMarker ends diffs which were cut to fit size limit even without context
+const DiffTruncatedMarker = "(diff truncated)\n"
+
+// truncateDiff cuts diff to at most maxSizeBytes on line boundary and marks it as truncated
+func truncateDiff(diff string, maxSizeBytes int) string {
+	if len(diff) <= maxSizeBytes {
+		return diff
+	}
+	if maxSizeBytes <= len(DiffTruncatedMarker) {
+		return truncateLines(diff, maxSizeBytes)
+	}
+	return truncateLines(diff, maxSizeBytes-len(DiffTruncatedMarker)) + DiffTruncatedMarker
+}
+
+// GetStagedFiles returns files already staged, excluding pre-defined patterns
+func (g *Operations) GetStagedFiles() ([]string, error) {
+	return g.GetFilteredStagedFiles()
+}
+
+// GetIndexHash identifies staged content, it changes with path, mode or content of any index entry
+func (g *Operations) GetIndexHash() (string, error) {
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return "", fmt.Errorf("failed to read index: %w", err)
+	}
+	sum := sha256.New()
+	for _, entry := range idx.Entries {
+		_, _ = fmt.Fprintf(sum, "%o %s %d %s\n", entry.Mode, entry.Hash, entry.Stage, entry.Name)
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// GetFilteredStagedFiles returns list of staged files excluding pre-defined patterns
+func (g *Operations) GetFilteredStagedFiles() ([]string, error) {
+	if g.pureGo {
+		return g.getStagedFilesNative()
+	}
+
+	cmd := g.gitCommand("diff", "--cached", "--name-only")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	files := strings.Split(strings.TrimSpace(string(output)), "\n")
+
+	filtered := make([]string, 0, len(files))
+	for _, file := range files {
+		if len(file) > 0 { // nothing yet
+			filtered = append(filtered, file)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (g *Operations) GetStagedDiff(maxSizeBytes int, newFileHeadLines int) (string, error) {
+	diffFiles, err := g.GetFilteredStagedFiles()
+	if err != nil {
+		return "", fmt.Errorf("failed to get staged files: %w", err)
+	}
+
+	if len(diffFiles) == 0 {
+		return "", nil // No files to diff after filtering
+	}
+
+	if g.pureGo {
+		return g.getStagedDiffNative(diffFiles, maxSizeBytes)
+	}
+
+	// Binary, lock and generated files are noise in prompts, describe them with a single line
+	opaque, err := g.summarizeOpaqueFiles(diffFiles)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize opaque files: %w", err)
+	}
+
+	if len(opaque) == 0 {
+		return g.getSummarizedStagedDiff(diffFiles, maxSizeBytes, newFileHeadLines)
+	}
+
+	omitted := make(map[string]bool, len(opaque))
+	for _, summary := range opaque {
+		omitted[summary.Path] = true
+	}
+
+	remainingFiles := make([]string, 0, len(diffFiles))
+	for _, file := range diffFiles {
+		if !omitted[file] {
+			remainingFiles = append(remainingFiles, file)
+		}
+	}
+
+	opaqueSection := formatOpaqueSummaries(opaque)
+	if len(opaqueSection) >= maxSizeBytes {
+		return truncateLines(opaqueSection, maxSizeBytes), nil
+	}
+
+	if len(remainingFiles) == 0 {
+		return opaqueSection, nil
+	}
+
+	diff, err := g.getSummarizedStagedDiff(remainingFiles, maxSizeBytes-len(opaqueSection), newFileHeadLines)
+	if err != nil {
+		return "", err
+	}
+
+	return diff + opaqueSection, nil
+}
+
+// getSummarizedStagedDiff returns staged diff of given files with large new files summarized
+func (g *Operations) getSummarizedStagedDiff(
+	diffFiles []string, maxSizeBytes, newFileHeadLines int,
+) (string, error) {
+	// Large new files would dominate the diff, replace them with a head and declarations summary
+	summaries, err := g.summarizeNewFiles(diffFiles, newFileHeadLines)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize new files: %w", err)
+	}
+
+	if len(summaries) == 0 {
+		return g.getStagedDiffForFiles(diffFiles, maxSizeBytes)
+	}
+
+	summarized := make(map[string]bool, len(summaries))
+	for _, summary := range summaries {
+		summarized[summary.Path] = true
+	}
+
+	remainingFiles := make([]string, 0, len(diffFiles))
+	for _, file := range diffFiles {
+		if !summarized[file] {
+			remainingFiles = append(remainingFiles, file)
+		}
+	}
+
+	summarySection := formatNewFileSummaries(summaries)
+	if len(summarySection) >= maxSizeBytes {
+		return truncateLines(summarySection, maxSizeBytes), nil
+	}
+
+	if len(remainingFiles) == 0 {
+		return summarySection, nil
+	}
+
+	diff, err := g.getStagedDiffForFiles(remainingFiles, maxSizeBytes-len(summarySection))
+	if err != nil {
+		return "", err
+	}
+
+	return diff + summarySection, nil
+}
+
+// getStagedDiffForFiles returns staged diff of given files, reducing context to fit within maxSizeBytes
+func (g *Operations) getStagedDiffForFiles(diffFiles []string, maxSizeBytes int) (string, error) {
+
+	// Common diff options optimized for AI consumption
+	baseDiffOpts := []string{
+		"diff",
+		"--cached",
+		"--no-color",            // Remove ANSI color codes that confuse AI
+		"--no-ext-diff",         // Disable external diff drivers
+		"--no-prefix",           // Remove a/ b/ prefixes for cleaner output
+		"--ignore-space-at-eol", // Ignore trailing whitespace changes
+		"--ignore-cr-at-eol",    // Ignore carriage return differences
+	}
+	baseDiffOpts = append(baseDiffOpts, g.diffOptions.args()...)
+	if g.diffOptions.FunctionContext {
+		// Include entire function in diff for better AI understanding
+		baseDiffOpts = append(baseDiffOpts, "--function-context")
+	}
+
+	// Try different context levels to fit within maxSize, output over read limit is not buffered whole
+	var (
+		diff     string
+		complete bool
+	)
+	for _, contextLevel := range contextLevels {
+		contextOpts := append([]string{}, baseDiffOpts...)
+		contextOpts = append(contextOpts, fmt.Sprintf("-U%d", contextLevel))
+		contextOpts = append(contextOpts, "--")
+		contextOpts = append(contextOpts, diffFiles...)
+
+		output, ok, err := g.runDiff(contextOpts, maxSizeBytes)
+		if err != nil {
+			// If the command fails, it might be because no files match - return empty diff
+			if strings.Contains(err.Error(), "exit status 128") {
+				return "", nil
+			}
+			return "", fmt.Errorf("failed to get staged diff: %w", err)
+		}
+
+		diff, complete = g.diffOptions.prepare(output), ok
+		if complete && len(diff) <= maxSizeBytes {
+			return diff, nil
+		}
+	}
+
+	return fitDiff(diff, complete, maxSizeBytes), nil
+}
+
+func (g *Operations) CreateCommit(message string, noVerify, allowEmpty bool, author, date string) error {
+	// Get git configuration
+	config, err := g.getConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	worktree, err := g.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	authorSig, committerSig, err := resolveSignatures(config, author, date, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to resolve commit identity: %w", err)
+	}
+
+	// Create commit options with real user identity, unless overridden
+	commitOptions := &git.CommitOptions{
+		Author:            authorSig,
+		Committer:         committerSig,
+		AllowEmptyCommits: allowEmpty,
+	}
+
+	// Add GPG signing if enabled
+	if config.GPGSign {
+		if config.SigningKey == "" {
+			return fmt.Errorf("commit.gpgsign=true but user.signingkey not configured")
+		}
+
+		signer, signKey, err := g.getSigner(config)
+		if err != nil {
+			return err
+		}
+		if signer != nil {
+			commitOptions.Signer = signer
+		} else {
+			commitOptions.SignKey = signKey
+		}
+	}
+
+	// Run pre-commit and commit-msg hooks, like git commit does
+	if !noVerify {
+		message, err = g.runCommitHooks(message)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = worktree.Commit(message, commitOptions)
+	if err != nil {
+		return fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	return nil
+}
+
+func shouldExcludeFile(file string, excludePatterns []string, ignoreMatcher gitignore.Matcher) bool {
+	// First check gitignore rules, negations and anchoring are handled by the matcher
+	if ignoreMatcher != nil && ignoreMatcher.Match(strings.Split(file, "/"), false) {
+		return true
+	}
+
+	// Then check local exclude patterns (existing logic)
+	if len(excludePatterns) == 0 {
+		return false
+	}
+
+	basename := filepath.Base(file)
+	for _, pattern := range excludePatterns {
+		// Fast string containment check first (most common case)
+		if strings.Contains(file, pattern) || strings.Contains(basename, pattern) {
+			return true
+		}
+		// Expensive glob matching only if simple checks fail
+		if matched, _ := filepath.Match(pattern, file); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, basename); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Operations) GetRemoteURL(remoteName string) (string, error) {
+	remote, err := g.repo.Remote(remoteName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get remote '%s': %w", remoteName, err)
+	}
+
+	config := remote.Config()
+	if len(config.URLs) == 0 {
+		return "", fmt.Errorf("remote '%s' has no URLs", remoteName)
+	}
+
+	// Return the first URL (usually there's only one)
+	return config.URLs[0], nil
+}
+
+func (g *Operations) GetDefaultBranch(remote string) string {
+	if g.pureGo {
+		return g.getDefaultBranchNative(remote)
+	}
+
+	remoteHead := "refs/remotes/" + remote + "/"
+	cmd := g.gitCommand("symbolic-ref", remoteHead+"HEAD")
+	output, err := cmd.Output()
+	if err == nil {
+		branch := strings.TrimSpace(string(output))
+		if strings.HasPrefix(branch, remoteHead) {
+			return strings.TrimPrefix(branch, remoteHead)
+		}
+	}
+	return "master"
+}
+
+// hasUpstream checks whether branch has an upstream tracking branch configured
+func (g *Operations) hasUpstream(branch string) bool {
+	if g.pureGo {
+		return g.hasUpstreamNative(branch)
+	}
+	cmd := g.gitCommand("rev-parse", "--abbrev-ref", "--symbolic-full-name", branch+"@{upstream}")
+	return cmd.Run() == nil
+}
+
+// Push pushes current branch to the matching branch on remote,
+// upstream is configured when requested and branch has none yet
+func (g *Operations) Push(remote string, forceWithLease, setUpstream bool) (string, error) {
+	if remote == "" {
+		remote = DefaultRemote
+	}
+
+	// Get the current branch name
+	branch, err := g.GetCurrentBranch()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	if g.pureGo {
+		setUpstream = setUpstream && !g.hasUpstream(branch)
+		refSpec := plumbing.NewBranchReferenceName(branch).String()
+		if err := g.pushNative(remote, forceWithLease, refSpec+":"+refSpec); err != nil {
+			return "", fmt.Errorf("failed to push to %s/%s: %w", remote, branch, err)
+		}
+		if setUpstream {
+			if err := g.setUpstreamNative(remote, branch); err != nil {
+				return "", fmt.Errorf("failed to set upstream of %s: %w", branch, err)
+			}
+		}
+	} else {
+		args := []string{"push"}
+		if forceWithLease {
+			args = append(args, "--force-with-lease")
+		}
+		if setUpstream && !g.hasUpstream(branch) {
+			args = append(args, "--set-upstream")
+		}
+		args = append(args, remote, branch)
+
+		// Push to the matching branch on the remote
+		cmd := g.gitCommand(args...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return "", fmt.Errorf("failed to push to %s/%s: %w\nOutput: %s", remote, branch, err, string(output))
+		}
+	}
+
+	// Generate MR/PR URL if possible
+	remoteURL, err := g.GetRemoteURL(remote)
+	if err != nil {
+		// Don't fail the push, just log that we couldn't get the URL
+		return "", nil
+	}
+
+	remoteInfo, err := ParseRemoteURL(remoteURL, g.platformHosts)
+	if err != nil {
+		// Don't fail the push, just return empty URL
+		return "", nil
+	}
+
+	// Get the default/target branch for MR/PR
+	targetBranch := g.GetDefaultBranch(remote)
+
+	if branch != targetBranch {
+		return MergeRequestURL(remoteInfo, branch, targetBranch), nil
+	}
+
+	return "", nil
+}
+
+// GetLatestTag retrieves the latest semver tag with given prefix from the repository,
+// pattern is a glob used to list tags and defaults to prefix followed by wildcard
+func (g *Operations) GetLatestTag(prefix, pattern string) (string, error) {
+	if pattern == "" {
+		pattern = prefix + "*"
+	}
+
+	tags, err := g.listTags(pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	if len(tags) == 0 || tags[0] == "" {
+		// No tags found, return default
+		return "", nil
+	}
+
+	// Filter valid semver tags and sort them
+	var validTags []string
+	for _, tag := range tags {
+		if version, ok := strings.CutPrefix(tag, prefix); ok && semVerPattern.MatchString(version) {
+			validTags = append(validTags, tag)
+		}
+	}
+
+	if len(validTags) == 0 {
+		return "", nil
+	}
+
+	// Sort tags by semver precedence, highest first
+	sort.SliceStable(validTags, func(i, j int) bool {
+		vi := parseSemVer(strings.TrimPrefix(validTags[i], prefix))
+		vj := parseSemVer(strings.TrimPrefix(validTags[j], prefix))
+		return compareSemVer(vi, vj) > 0
+	})
+
+	return validTags[0], nil
+}
+
+// listTags returns names of tags matching glob pattern
+func (g *Operations) listTags(pattern string) ([]string, error) {
+	if g.pureGo {
+		return g.listTagsNative(pattern)
+	}
+
+	cmd := g.gitCommand("tag", "-l", pattern)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strings.TrimSpace(string(output)), "\n"), nil
+}
+
+// parseSemVer parses a version string like "v1.2.3-rc.1+build.5" into a semVer struct
+func parseSemVer(version string) semVer {
+	matches := semVerPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return semVer{}
+	}
+
+	major, _ := strconv.Atoi(matches[1])
+	minor, _ := strconv.Atoi(matches[2])
+	patch, _ := strconv.Atoi(matches[3])
+
+	return semVer{
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		Prerelease: matches[4],
+		Build:      matches[5],
+	}
+}
+
+// tag formats version as a tag name, build metadata is not carried over to new tags
+func (v semVer) tag(prefix string) string {
+	tag := fmt.Sprintf("%s%d.%d.%d", prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		tag += "-" + v.Prerelease
+	}
+	return tag
+}
+
+// compareSemVer compares versions according to semver precedence rules,
+// returns negative if a < b, positive if a > b and zero if equal
+func compareSemVer(a, b semVer) int {
+	if a.Major != b.Major {
+		return a.Major - b.Major
+	}
+	if a.Minor != b.Minor {
+		return a.Minor - b.Minor
+	}
+	if a.Patch != b.Patch {
+		return a.Patch - b.Patch
+	}
+
+	// Release has higher precedence than any of its prereleases
+	switch {
+	case a.Prerelease == b.Prerelease:
+		return 0
+	case a.Prerelease == "":
+		return 1
+	case b.Prerelease == "":
+		return -1
+	}
+
+	aParts := strings.Split(a.Prerelease, ".")
+	bParts := strings.Split(b.Prerelease, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if c := comparePrereleaseIdentifier(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+	return len(aParts) - len(bParts)
+}
+
+// comparePrereleaseIdentifier compares numeric identifiers numerically,
+// numeric identifiers always have lower precedence than alphanumeric ones
+func comparePrereleaseIdentifier(a, b string) int {
+	aNum, aErr := strconv.Atoi(a)
+	bNum, bErr := strconv.Atoi(b)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return aNum - bNum
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// incrementPrerelease bumps the last numeric identifier of prerelease (rc.1 -> rc.2),
+// appending a counter when there is none (beta -> beta.1)
+func incrementPrerelease(prerelease string) string {
+	parts := strings.Split(prerelease, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if n, err := strconv.Atoi(parts[i]); err == nil {
+			parts[i] = strconv.Itoa(n + 1)
+			return strings.Join(parts, ".")
+		}
+	}
+	return prerelease + ".1"
+}
+
+// IncrementVersion increments the version based on the increment type
+func (g *Operations) IncrementVersion(currentTag string, incrementType string, prefix string) (string, error) {
+	var version semVer
+
+	if currentTag != "" {
+		version = parseSemVer(strings.TrimPrefix(currentTag, prefix))
+	}
+
+	incrementType = strings.ToLower(incrementType)
+	if incrementType == "auto" {
+		commits, err := g.GetCommitsSince(currentTag)
+		if err != nil {
+			return "", fmt.Errorf("failed to get commits since %s: %w", currentTag, err)
+		}
+		incrementType = DetectIncrementType(commits)
+	}
+
+	// Prerelease of a version precedes it, so incrementing finalizes the version
+	// when it already satisfies requested increment (v1.1.0-rc.1 + minor = v1.1.0)
+	isPrerelease := version.Prerelease != ""
+
+	switch incrementType {
+	case "major":
+		if !isPrerelease || version.Minor != 0 || version.Patch != 0 {
+			version.Major++
+		}
+		version.Minor = 0
+		version.Patch = 0
+	case "minor":
+		if !isPrerelease || version.Patch != 0 {
+			version.Minor++
+		}
+		version.Patch = 0
+	case "patch":
+		if !isPrerelease {
+			version.Patch++
+		}
+	case "prerelease":
+		if isPrerelease {
+			version.Prerelease = incrementPrerelease(version.Prerelease)
+		} else {
+			version.Patch++
+			version.Prerelease = defaultPrereleaseID + ".1"
+		}
+		version.Build = ""
+		return version.tag(prefix), nil
+	default:
+		return "", fmt.Errorf(
+			"invalid increment type: %s (must be major, minor, patch, prerelease or auto)", incrementType,
+		)
+	}
+
+	version.Prerelease = ""
+	version.Build = ""
+
+	return version.tag(prefix), nil
+}
+
+// DetectIncrementType picks semver increment from conventional commit messages:
+// breaking changes bump major, features bump minor, anything else bumps patch
+func DetectIncrementType(commits []string) string {
+	incrementType := "patch"
+	for _, message := range commits {
+		header, body, _ := strings.Cut(message, "\n")
+		matches := conventionalHeaderPattern.FindStringSubmatch(header)
+
+		if (matches != nil && matches[3] == "!") || breakingChangeFooterPattern.MatchString(body) {
+			return "major"
+		}
+		if matches != nil && matches[1] == "feat" {
+			incrementType = "minor"
+		}
+	}
+	return incrementType
+}
+
+// CreateTag creates a new annotated tag, signed according to tag.gpgSign or commit.gpgsign
+func (g *Operations) CreateTag(tagName string, message string) error {
+	config, err := g.getConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	nonOpenPGP := config.GPGFormat != "" && config.GPGFormat != "openpgp"
+
+	if g.pureGo && !config.TagGPGSign {
+		return g.createTagNative(tagName, message, config)
+	}
+	if g.pureGo && nonOpenPGP {
+		return fmt.Errorf("signing tags with gpg.format=%s requires git binary", config.GPGFormat)
+	}
+
+	// Non-openpgp formats (ssh, x509) are delegated to git itself
+	if !config.TagGPGSign || nonOpenPGP {
+		args := []string{"tag", "-a", tagName, "-m", message}
+		if config.TagGPGSign {
+			args = []string{"tag", "-s", tagName, "-m", message}
+		}
+		cmd := g.gitCommand(args...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to create tag %s: %w\nOutput: %s", tagName, err, string(output))
+		}
+		return nil
+	}
+
+	// Reference is stored directly, it must not replace existing tag like git tag refuses to
+	tagRef := plumbing.NewTagReferenceName(tagName)
+	if _, err := g.repo.Reference(tagRef, false); err == nil {
+		return fmt.Errorf("tag %s already exists", tagName)
+	} else if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return fmt.Errorf("failed to check tag %s: %w", tagName, err)
+	}
+
+	if config.SigningKey == "" {
+		return fmt.Errorf("tag signing enabled but user.signingkey not configured")
+	}
+
+	signer, signKey, err := g.getSigner(config)
+	if err != nil {
+		return err
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	tagger := &object.Signature{
+		Name:  config.UserName,
+		Email: config.UserEmail,
+		When:  time.Now(),
+	}
+
+	sign := func(message io.Reader) ([]byte, error) {
+		if signer != nil {
+			return signer.Sign(message)
+		}
+		var b bytes.Buffer
+		if err := openpgp.ArmoredDetachSign(&b, signKey, message, nil); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	}
+
+	tag, err := buildSignedTag(tagName, head.Hash(), tagger, message, sign)
+	if err != nil {
+		return fmt.Errorf("failed to sign tag %s: %w", tagName, err)
+	}
+
+	obj := g.repo.Storer.NewEncodedObject()
+	if err := tag.Encode(obj); err != nil {
+		return fmt.Errorf("failed to encode tag %s: %w", tagName, err)
+	}
+
+	hash, err := g.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return fmt.Errorf("failed to store tag %s: %w", tagName, err)
+	}
+
+	ref := plumbing.NewHashReference(tagRef, hash)
+	if err := g.repo.Storer.CheckAndSetReference(ref, nil); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", tagName, err)
+	}
+
+	return nil
+}
+
+// buildSignedTag creates annotated tag object pointing to a commit and signs its canonical encoding
+func buildSignedTag(
+	name string, target plumbing.Hash,
+	tagger *object.Signature, message string,
+	sign func(io.Reader) ([]byte, error),
+) (*object.Tag, error) {
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+
+	tag := &object.Tag{
+		Name:       name,
+		Tagger:     *tagger,
+		Message:    message,
+		TargetType: plumbing.CommitObject,
+		Target:     target,
+	}
+
+	unsigned := &plumbing.MemoryObject{}
+	if err := tag.EncodeWithoutSignature(unsigned); err != nil {
+		return nil, err
+	}
+
+	reader, err := unsigned.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+
+	signature, err := sign(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	tag.PGPSignature = string(signature)
+
+	return tag, nil
+}
+
+// DeleteTag deletes a local tag
+func (g *Operations) DeleteTag(tagName string) error {
+	if g.pureGo {
+		if err := g.repo.DeleteTag(tagName); err != nil {
+			return fmt.Errorf("failed to delete tag %s: %w", tagName, err)
+		}
+		return nil
+	}
+
+	cmd := g.gitCommand("tag", "--delete", tagName)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to delete tag %s: %w\nOutput: %s", tagName, err, string(output))
+	}
+	return nil
+}
+
+// UndoLastCommit removes HEAD commit keeping its changes staged
+func (g *Operations) UndoLastCommit() error {
+	args := []string{"reset", "--soft", "HEAD~1"}
+	if err := g.gitCommand("rev-parse", "--verify", "--quiet", "HEAD~1").Run(); err != nil {
+		// Root commit has no parent to reset to, drop the branch ref instead
+		args = []string{"update-ref", "-d", "HEAD"}
+	}
+
+	cmd := g.gitCommand(args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to undo last commit: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
+
+// PushTag pushes the tag to the remote repository
+func (g *Operations) PushTag(tagName string, remote string) error {
+	if remote == "" {
+		remote = DefaultRemote
+	}
+	if g.pureGo {
+		refSpec := plumbing.NewTagReferenceName(tagName).String()
+		if err := g.pushNative(remote, false, refSpec+":"+refSpec); err != nil {
+			return fmt.Errorf("failed to push tag %s: %w", tagName, err)
+		}
+		return nil
+	}
+	cmd := g.gitCommand("push", remote, tagName)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to push tag %s: %w\nOutput: %s", tagName, err, string(output))
+	}
+	return nil
+}
+
+func shouldIncludeFile(file string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+
+	basename := filepath.Base(file)
+	for _, pattern := range patterns {
+		// Fast string containment check first (most common case)
+		if strings.Contains(file, pattern) || strings.Contains(basename, pattern) {
+			return true
+		}
+		// Expensive glob matching only if simple checks fail
+		if matched, _ := filepath.Match(pattern, file); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, basename); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGitRepository reports whether repository has HEAD, which may point to an unborn branch
+func (g *Operations) IsGitRepository() bool {
+	_, err := g.repo.Reference(plumbing.HEAD, false)
+	return err == nil
+}
@@ -1,4 +1,4 @@
-package commit
+package gitops
 
 import (
 	"fmt"
@@ -39,7 +39,7 @@ func (g *gpgSigner) Sign(message io.Reader) ([]byte, error) {
 }
 
 // isGPGAgentAvailable checks if gpg-agent is running
-func (g *gitOperations) isGPGAgentAvailable(gpgProgram string) bool {
+func (g *Operations) isGPGAgentAvailable(gpgProgram string) bool {
 	// Check if GPG_AGENT_INFO is set (older GPG versions)
 	if os.Getenv("GPG_AGENT_INFO") != "" {
 		return true
@@ -51,8 +51,28 @@ func (g *gitOperations) isGPGAgentAvailable(gpgProgram string) bool {
 	return err == nil
 }
 
+// getSigner returns gpg-agent backed signer when agent is available,
+// otherwise falls back to a key loaded directly from keyring
+func (g *Operations) getSigner(config *gitConfig) (*gpgSigner, *openpgp.Entity, error) {
+	// First try to use gpg-agent if available (preferred method)
+	if g.isGPGAgentAvailable(config.GPGProgram) {
+		signer, err := g.createGPGSigner(config)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create GPG signer %s: %w", config.SigningKey, err)
+		}
+		return signer, nil, nil
+	}
+
+	// Fallback to direct keyring access with manual passphrase
+	signKey, err := g.loadKeyDirectly(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load GPG signing key %s: %w", config.SigningKey, err)
+	}
+	return nil, signKey, nil
+}
+
 // createGPGSigner creates a GPG signer that uses gpg-agent's cached credentials
-func (g *gitOperations) createGPGSigner(config *gitConfig) (*gpgSigner, error) {
+func (g *Operations) createGPGSigner(config *gitConfig) (*gpgSigner, error) {
 	// Verify that the key exists and is available
 	cmd := exec.Command(config.GPGProgram, "--list-secret-keys", config.SigningKey)
 	err := cmd.Run()
@@ -67,7 +87,7 @@ func (g *gitOperations) createGPGSigner(config *gitConfig) (*gpgSigner, error) {
 }
 
 // loadKeyDirectly loads key directly from keyring (fallback method)
-func (g *gitOperations) loadKeyDirectly(config *gitConfig) (*openpgp.Entity, error) {
+func (g *Operations) loadKeyDirectly(config *gitConfig) (*openpgp.Entity, error) {
 	// Try to get GPG key from user's keyring
 	keyring, err := g.getGPGKeyring(config.GPGProgram)
 	if err != nil {
@@ -93,7 +113,7 @@ func (g *gitOperations) loadKeyDirectly(config *gitConfig) (*openpgp.Entity, err
 }
 
 // getGPGKeyring reads the user's GPG keyring
-func (g *gitOperations) getGPGKeyring(gpgProgram string) (openpgp.EntityList, error) {
+func (g *Operations) getGPGKeyring(gpgProgram string) (openpgp.EntityList, error) {
 	// Get GPG home directory
 	gpgHome := os.Getenv("GNUPGHOME")
 	if gpgHome == "" {
@@ -121,7 +141,7 @@ func (g *gitOperations) getGPGKeyring(gpgProgram string) (openpgp.EntityList, er
 }
 
 // exportGPGKeys exports GPG keys using the gpg command
-func (g *gitOperations) exportGPGKeys(gpgProgram string) (openpgp.EntityList, error) {
+func (g *Operations) exportGPGKeys(gpgProgram string) (openpgp.EntityList, error) {
 	// Export secret keys in ASCII armor format
 	cmd := exec.Command(gpgProgram, "--export-secret-keys", "--armor")
 	output, err := cmd.Output()
@@ -134,7 +154,7 @@ func (g *gitOperations) exportGPGKeys(gpgProgram string) (openpgp.EntityList, er
 }
 
 // matchesSigningKey checks if a GPG entity matches the signing key identifier
-func (g *gitOperations) matchesSigningKey(entity *openpgp.Entity, signingKey string) bool {
+func (g *Operations) matchesSigningKey(entity *openpgp.Entity, signingKey string) bool {
 	// Check primary key ID (full or short form)
 	primaryKeyID := fmt.Sprintf("%016X", entity.PrimaryKey.KeyId)
 	if strings.HasSuffix(primaryKeyID, strings.ToUpper(signingKey)) {
@@ -160,7 +180,7 @@ func (g *gitOperations) matchesSigningKey(entity *openpgp.Entity, signingKey str
 }
 
 // decryptPrivateKey prompts for passphrase and decrypts the GPG private key
-func (g *gitOperations) decryptPrivateKey(entity *openpgp.Entity, keyID string) error {
+func (g *Operations) decryptPrivateKey(entity *openpgp.Entity, keyID string) error {
 	fmt.Printf("Enter passphrase for GPG key %s: ", keyID)
 
 	// Read passphrase securely (without echoing to terminal)
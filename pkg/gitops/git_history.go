@@ -0,0 +1,126 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveCommit returns full hash of commit ref points to
+func (g *Operations) ResolveCommit(ref string) (string, error) {
+	sha, err := g.runGit("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown commit %s", ref)
+	}
+	return sha, nil
+}
+
+// GetCommitMessage returns full message of commit
+func (g *Operations) GetCommitMessage(sha string) (string, error) {
+	message, err := g.runGit("log", "-1", "--format=%B", sha)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit message: %w", err)
+	}
+	return message, nil
+}
+
+// logCommitSeparator separates commits in git log output, bodies can contain empty lines
+const logCommitSeparator = "---commit---"
+
+// GetCommitsSince returns messages of non-merge commits between tag and HEAD,
+// all reachable commits are returned when tag is empty
+func (g *Operations) GetCommitsSince(tag string) ([]string, error) {
+	args := []string{"log", "--no-merges", "--format=%B" + logCommitSeparator}
+	if tag != "" {
+		args = append(args, tag+"..HEAD")
+	}
+
+	output, err := g.gitCommand(args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+
+	var commits []string
+	for _, message := range strings.Split(string(output), logCommitSeparator) {
+		message = strings.TrimSpace(message)
+		if message != "" {
+			commits = append(commits, message)
+		}
+	}
+
+	return commits, nil
+}
+
+// GetBranchDiff returns diff and changed files of current branch against its merge base with base,
+// empty base resolves to default branch of remote
+func (g *Operations) GetBranchDiff(base, remote string, maxSizeBytes int) (string, []string, error) {
+	if base == "" {
+		if remote == "" {
+			remote = DefaultRemote
+		}
+		base = remote + "/" + g.GetDefaultBranch(remote)
+	}
+
+	revRange := base + "...HEAD"
+
+	output, err := g.runGit("diff", "--name-only", revRange)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get branch files against %s: %w", base, err)
+	}
+
+	var files []string
+	for _, file := range strings.Split(output, "\n") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	var diff string
+	for _, contextLevel := range contextLevels {
+		args := []string{"diff", "--no-color", "--no-ext-diff", "--no-prefix"}
+		args = append(args, g.diffOptions.args()...)
+		args = append(args, fmt.Sprintf("-U%d", contextLevel), revRange)
+		diff, err = g.runGit(args...)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get branch diff against %s: %w", base, err)
+		}
+		diff = g.diffOptions.prepare(diff)
+		if len(diff) <= maxSizeBytes {
+			return diff, files, nil
+		}
+	}
+
+	return PrioritizeDiff(diff, maxSizeBytes), files, nil
+}
+
+// IsShallowRepository reports whether repository history is truncated by a shallow clone,
+// partial (blobless) clones have complete history and are fetched on demand by git itself
+func (g *Operations) IsShallowRepository() (bool, error) {
+	if g.pureGo {
+		shallow, err := g.repo.Storer.Shallow()
+		if err != nil {
+			return false, fmt.Errorf("failed to check shallow repository: %w", err)
+		}
+		return len(shallow) > 0, nil
+	}
+
+	output, err := g.runGit("rev-parse", "--is-shallow-repository")
+	if err != nil {
+		return false, fmt.Errorf("failed to check shallow repository: %w", err)
+	}
+	return output == "true", nil
+}
+
+// Deepen fetches complete history and tags of a shallow clone from remote
+func (g *Operations) Deepen(remote string) error {
+	if remote == "" {
+		remote = DefaultRemote
+	}
+
+	cmd := g.gitCommand("fetch", "--unshallow", "--tags", remote)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to deepen repository from %s: %w\nOutput: %s", remote, err, string(output))
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package gitops
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestGitOperations_GetCommitMessage(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	first := commitTestFile(t, dir, "a.txt", "a\n", "fix stuff\n\nlonger body")
+	commitTestFile(t, dir, "b.txt", "b\n", "wip")
+
+	sha, err := g.ResolveCommit("HEAD~1")
+	if err != nil {
+		t.Fatalf("ResolveCommit() error = %v", err)
+	}
+	if sha != first {
+		t.Errorf("ResolveCommit() = %s, want %s", sha, first)
+	}
+
+	if _, err := g.ResolveCommit("no-such-ref"); err == nil {
+		t.Error("ResolveCommit() for unknown ref, want error")
+	}
+
+	message, err := g.GetCommitMessage(sha)
+	if err != nil {
+		t.Fatalf("GetCommitMessage() error = %v", err)
+	}
+	if message != "fix stuff\n\nlonger body" {
+		t.Errorf("GetCommitMessage() = %q", message)
+	}
+}
+
+func TestGitOperations_GetCommitsSince(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	commitTestFile(t, dir, "a.txt", "a\n", "feat: first")
+	runTestGit(t, dir, "tag", "v0.1.0")
+	commitTestFile(t, dir, "b.txt", "b\n", "fix: second\n\nwith body")
+	commitTestFile(t, dir, "c.txt", "c\n", "docs: third")
+
+	commits, err := g.GetCommitsSince("v0.1.0")
+	if err != nil {
+		t.Fatalf("GetCommitsSince() error = %v", err)
+	}
+	expected := []string{"docs: third", "fix: second\n\nwith body"}
+	if !reflect.DeepEqual(commits, expected) {
+		t.Errorf("GetCommitsSince() = %q, want %q", commits, expected)
+	}
+
+	all, err := g.GetCommitsSince("")
+	if err != nil {
+		t.Fatalf("GetCommitsSince() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("GetCommitsSince(\"\") returned %d commits, want 3", len(all))
+	}
+}
+
+func TestGitOperations_GetBranchDiff(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	commitTestFile(t, dir, "base.txt", "base\n", "init")
+	runTestGit(t, dir, "branch", "-M", "main")
+	runTestGit(t, dir, "checkout", "-b", "feature")
+	commitTestFile(t, dir, "a.txt", "a\n", "feat: a")
+	commitTestFile(t, dir, "b.txt", "b\n", "feat: b")
+
+	// changes on base after branching must not show up
+	runTestGit(t, dir, "checkout", "main")
+	commitTestFile(t, dir, "main.txt", "main\n", "chore: main")
+	runTestGit(t, dir, "checkout", "feature")
+
+	diff, files, err := g.GetBranchDiff("main", "", 64*1024)
+	if err != nil {
+		t.Fatalf("GetBranchDiff() error = %v", err)
+	}
+	if strings.Join(files, ",") != "a.txt,b.txt" {
+		t.Errorf("files = %v, want [a.txt b.txt]", files)
+	}
+	if !strings.Contains(diff, "+a") || !strings.Contains(diff, "+b") {
+		t.Errorf("diff does not contain branch changes: %q", diff)
+	}
+	if strings.Contains(diff, "main.txt") {
+		t.Errorf("diff contains changes from base branch: %q", diff)
+	}
+
+	if _, _, err := g.GetBranchDiff("missing", "", 64*1024); err == nil {
+		t.Error("GetBranchDiff() expected error for unknown base")
+	}
+}
+
+func TestGitOperations_ShallowClone(t *testing.T) {
+	_, origin := newTestGitOperations(t)
+	commitTestFile(t, origin, "a.txt", "a\n", "feat: first")
+	runTestGit(t, origin, "tag", "v1.0.0")
+	commitTestFile(t, origin, "b.txt", "b\n", "feat: second")
+	commitTestFile(t, origin, "c.txt", "c\n", "feat: third")
+
+	dir := t.TempDir()
+	runTestGit(t, dir, "clone", "--quiet", "--depth", "1", "file://"+origin, ".")
+	t.Chdir(dir)
+
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("Failed to open clone: %v", err)
+	}
+	g := &Operations{repo: repo, root: dir}
+
+	shallow, err := g.IsShallowRepository()
+	if err != nil {
+		t.Fatalf("IsShallowRepository() error = %v", err)
+	}
+	if !shallow {
+		t.Fatal("IsShallowRepository() = false, want true")
+	}
+
+	// truncated history is fine for style context
+	commits, err := g.GetRecentCommits(10)
+	if err != nil {
+		t.Fatalf("GetRecentCommits() in shallow clone error = %v", err)
+	}
+	if len(commits) != 1 || commits[0] != "feat: third" {
+		t.Errorf("GetRecentCommits() in shallow clone = %v, want [feat: third]", commits)
+	}
+
+	if err := g.Deepen("origin"); err != nil {
+		t.Fatalf("Deepen() error = %v", err)
+	}
+
+	shallow, err = g.IsShallowRepository()
+	if err != nil {
+		t.Fatalf("IsShallowRepository() error = %v", err)
+	}
+	if shallow {
+		t.Error("IsShallowRepository() after Deepen() = true, want false")
+	}
+
+	tag, err := g.GetLatestTag("v", "")
+	if err != nil {
+		t.Fatalf("GetLatestTag() error = %v", err)
+	}
+	if tag != "v1.0.0" {
+		t.Errorf("GetLatestTag() after Deepen() = %q, want %q", tag, "v1.0.0")
+	}
+}
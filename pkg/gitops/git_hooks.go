@@ -0,0 +1,159 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Names of repository hooks, as files in hooks directory
+const (
+	// HookPreCommit runs before commit is created, failure aborts the commit
+	HookPreCommit = "pre-commit"
+	// HookCommitMsg gets file with commit message, may rewrite it or reject the commit
+	HookCommitMsg = "commit-msg"
+	// HookPrepareCommitMsg gets file with message before editor opens, installed by hook command
+	HookPrepareCommitMsg = "prepare-commit-msg"
+)
+
+// commitEditMsgFile is the file git uses to pass the message to commit-msg hooks
+const commitEditMsgFile = "COMMIT_EDITMSG"
+
+// getHooksDir returns the directory containing repository hooks, honoring core.hooksPath
+func (g *Operations) getHooksDir() (string, error) {
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if hooksPath := g.GetConfigValue("core.hooksPath"); hooksPath != "" {
+		// Expand ~ to home directory if needed
+		if strings.HasPrefix(hooksPath, "~/") {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to get home directory: %w", err)
+			}
+			hooksPath = filepath.Join(homeDir, hooksPath[2:])
+		}
+		// Relative paths are resolved against the worktree root, like git does
+		if !filepath.IsAbs(hooksPath) {
+			hooksPath = filepath.Join(wt.Filesystem.Root(), hooksPath)
+		}
+		return hooksPath, nil
+	}
+
+	gitDir, err := g.getCommonGitDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(gitDir, "hooks"), nil
+}
+
+// findHook returns the path to an executable hook, or empty string if it is not installed
+func (g *Operations) findHook(name string) (string, error) {
+	hooksDir, err := g.getHooksDir()
+	if err != nil {
+		return "", err
+	}
+
+	hookPath := filepath.Join(hooksDir, name)
+	info, err := os.Stat(hookPath)
+	if err != nil || info.IsDir() {
+		return "", nil
+	}
+
+	// Git silently ignores hooks which are not executable
+	if info.Mode()&0o111 == 0 {
+		return "", nil
+	}
+
+	return hookPath, nil
+}
+
+// InstallHook writes executable hook script and returns its path, existing different hook is kept
+func (g *Operations) InstallHook(name, script string) (string, error) {
+	hooksDir, err := g.getHooksDir()
+	if err != nil {
+		return "", err
+	}
+
+	hookPath := filepath.Join(hooksDir, name)
+	if existing, err := os.ReadFile(hookPath); err == nil {
+		if string(existing) == script {
+			return hookPath, nil
+		}
+		return "", fmt.Errorf("%s hook already exists: %s", name, hookPath)
+	}
+
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(hookPath, []byte(script), 0o755); err != nil {
+		return "", fmt.Errorf("failed to write %s hook: %w", name, err)
+	}
+	return hookPath, nil
+}
+
+// runHook executes a repository hook from the worktree root, if it is installed
+func (g *Operations) runHook(name string, args ...string) error {
+	hookPath, err := g.findHook(name)
+	if err != nil {
+		return fmt.Errorf("failed to locate %s hook: %w", name, err)
+	}
+	if hookPath == "" {
+		return nil
+	}
+
+	cmd := g.command(hookPath, args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s hook failed: %w\nOutput: %s", name, err, string(output))
+	}
+
+	return nil
+}
+
+// runCommitHooks runs pre-commit and commit-msg hooks and returns the
+// (possibly rewritten by commit-msg) commit message
+func (g *Operations) runCommitHooks(message string) (string, error) {
+	if err := g.runHook(HookPreCommit); err != nil {
+		return "", err
+	}
+
+	hookPath, err := g.findHook(HookCommitMsg)
+	if err != nil {
+		return "", fmt.Errorf("failed to locate %s hook: %w", HookCommitMsg, err)
+	}
+	if hookPath == "" {
+		return message, nil
+	}
+
+	gitDir, err := g.getGitDir()
+	if err != nil {
+		return "", err
+	}
+
+	msgFile := filepath.Join(gitDir, commitEditMsgFile)
+	if err := os.WriteFile(msgFile, []byte(message+"\n"), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", commitEditMsgFile, err)
+	}
+
+	if err := g.runHook(HookCommitMsg, msgFile); err != nil {
+		return "", err
+	}
+
+	// commit-msg hooks are allowed to edit the message in place
+	updated, err := os.ReadFile(msgFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", commitEditMsgFile, err)
+	}
+
+	updatedMessage := strings.TrimSpace(string(updated))
+	if updatedMessage == "" {
+		return "", fmt.Errorf("%s hook produced an empty commit message", HookCommitMsg)
+	}
+
+	return updatedMessage, nil
+}
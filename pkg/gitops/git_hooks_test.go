@@ -0,0 +1,176 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func newTestGitOperations(t *testing.T) (*Operations, string) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	repo, err := git.PlainInit(tmpDir, false)
+	if err != nil {
+		t.Fatalf("Failed to init repository: %v", err)
+	}
+
+	return &Operations{repo: repo, root: tmpDir}, tmpDir
+}
+
+func writeTestHook(t *testing.T, repoDir, name, script string, mode os.FileMode) {
+	t.Helper()
+
+	hooksDir := filepath.Join(repoDir, ".git", "hooks")
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatalf("Failed to create hooks directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(hooksDir, name), []byte(script), mode); err != nil {
+		t.Fatalf("Failed to write hook %s: %v", name, err)
+	}
+}
+
+func TestGitOperations_findHook(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     os.FileMode
+		install  bool
+		expected bool
+	}{
+		{
+			name:     "hook not installed",
+			install:  false,
+			expected: false,
+		},
+		{
+			name:     "hook not executable",
+			mode:     0644,
+			install:  true,
+			expected: false,
+		},
+		{
+			name:     "executable hook",
+			mode:     0755,
+			install:  true,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, repoDir := newTestGitOperations(t)
+			if tt.install {
+				writeTestHook(t, repoDir, HookPreCommit, "#!/bin/sh\nexit 0\n", tt.mode)
+			}
+
+			hookPath, err := g.findHook(HookPreCommit)
+			if err != nil {
+				t.Fatalf("findHook() unexpected error = %v", err)
+			}
+			if (hookPath != "") != tt.expected {
+				t.Errorf("findHook() = %q, want found = %v", hookPath, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitOperations_runCommitHooks(t *testing.T) {
+	tests := []struct {
+		name        string
+		hooks       map[string]string
+		message     string
+		expected    string
+		expectErr   bool
+		errContains string
+	}{
+		{
+			name:     "no hooks installed",
+			hooks:    map[string]string{},
+			message:  "feat: add feature",
+			expected: "feat: add feature",
+		},
+		{
+			name: "pre-commit hook rejects commit",
+			hooks: map[string]string{
+				HookPreCommit: "#!/bin/sh\necho 'lint failed'\nexit 1\n",
+			},
+			message:     "feat: add feature",
+			expectErr:   true,
+			errContains: "lint failed",
+		},
+		{
+			name: "commit-msg hook rewrites message",
+			hooks: map[string]string{
+				HookPreCommit: "#!/bin/sh\nexit 0\n",
+				HookCommitMsg: "#!/bin/sh\necho 'Signed-off-by: Test' >> \"$1\"\n",
+			},
+			message:  "feat: add feature",
+			expected: "feat: add feature\nSigned-off-by: Test",
+		},
+		{
+			name: "commit-msg hook rejects message",
+			hooks: map[string]string{
+				HookCommitMsg: "#!/bin/sh\necho 'bad message'\nexit 1\n",
+			},
+			message:     "wip",
+			expectErr:   true,
+			errContains: "commit-msg hook failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, repoDir := newTestGitOperations(t)
+			for name, script := range tt.hooks {
+				writeTestHook(t, repoDir, name, script, 0755)
+			}
+
+			result, err := g.runCommitHooks(tt.message)
+
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("runCommitHooks() expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("runCommitHooks() error = %q, want to contain %q", err.Error(), tt.errContains)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("runCommitHooks() unexpected error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("runCommitHooks() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitOperations_InstallHook(t *testing.T) {
+	g, repoDir := newTestGitOperations(t)
+	script := "#!/bin/sh\nexit 0\n"
+
+	path, err := g.InstallHook(HookPrepareCommitMsg, script)
+	if err != nil {
+		t.Fatalf("InstallHook() unexpected error = %v", err)
+	}
+	if found, _ := g.findHook(HookPrepareCommitMsg); found != path {
+		t.Errorf("InstallHook() path = %q, found executable hook %q", path, found)
+	}
+
+	if _, err := g.InstallHook(HookPrepareCommitMsg, script); err != nil {
+		t.Errorf("InstallHook() of identical hook unexpected error = %v", err)
+	}
+
+	writeTestHook(t, repoDir, HookCommitMsg, "#!/bin/sh\necho custom\n", 0755)
+	if _, err := g.InstallHook(HookCommitMsg, script); err == nil {
+		t.Error("InstallHook() over different hook expected error")
+	}
+	if content, _ := os.ReadFile(filepath.Join(repoDir, ".git", "hooks", HookCommitMsg)); string(content) == script {
+		t.Error("InstallHook() replaced existing hook")
+	}
+}
@@ -0,0 +1,118 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var identityPattern = regexp.MustCompile(`^\s*([^<>]*?)\s*<([^<>]*)>\s*$`)
+
+// gitDateLayouts are date formats accepted by --date and GIT_*_DATE, besides git internal format
+var gitDateLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// resolveSignatures builds author and committer signatures the way git does:
+// explicit author and date take precedence over GIT_AUTHOR_* and GIT_COMMITTER_*
+// environment variables, which take precedence over user config
+func resolveSignatures(
+	config *gitConfig, author, date string, now time.Time,
+) (*object.Signature, *object.Signature, error) {
+	authorSig := &object.Signature{Name: config.UserName, Email: config.UserEmail, When: now}
+	committerSig := &object.Signature{Name: config.UserName, Email: config.UserEmail, When: now}
+
+	if err := applyIdentityEnv(authorSig, "GIT_AUTHOR"); err != nil {
+		return nil, nil, err
+	}
+	if err := applyIdentityEnv(committerSig, "GIT_COMMITTER"); err != nil {
+		return nil, nil, err
+	}
+
+	if author != "" {
+		name, email, err := ParseIdentity(author)
+		if err != nil {
+			return nil, nil, err
+		}
+		authorSig.Name = name
+		authorSig.Email = email
+	}
+
+	if date != "" {
+		when, err := ParseGitDate(date)
+		if err != nil {
+			return nil, nil, err
+		}
+		authorSig.When = when
+	}
+
+	return authorSig, committerSig, nil
+}
+
+// applyIdentityEnv overrides signature fields from <prefix>_NAME, <prefix>_EMAIL and <prefix>_DATE
+func applyIdentityEnv(sig *object.Signature, prefix string) error {
+	if name := os.Getenv(prefix + "_NAME"); name != "" {
+		sig.Name = name
+	}
+	if email := os.Getenv(prefix + "_EMAIL"); email != "" {
+		sig.Email = email
+	}
+	if date := os.Getenv(prefix + "_DATE"); date != "" {
+		when, err := ParseGitDate(date)
+		if err != nil {
+			return fmt.Errorf("invalid %s_DATE: %w", prefix, err)
+		}
+		sig.When = when
+	}
+	return nil
+}
+
+// ParseIdentity splits "Name <email>" into its parts
+func ParseIdentity(identity string) (string, string, error) {
+	matches := identityPattern.FindStringSubmatch(identity)
+	if matches == nil || matches[1] == "" || matches[2] == "" {
+		return "", "", fmt.Errorf("invalid identity %q, expected \"Name <email>\"", identity)
+	}
+	return matches[1], matches[2], nil
+}
+
+// ParseGitDate parses git internal format ("<unix> <tz>" or "@<unix>") and common date formats
+func ParseGitDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	// Like git, bare numbers are only treated as timestamps when they are large enough
+	fields := strings.Fields(strings.TrimPrefix(value, "@"))
+	if len(fields) > 0 && len(fields) <= 2 {
+		seconds, err := strconv.ParseInt(fields[0], 10, 64)
+		if err == nil && (strings.HasPrefix(value, "@") || seconds > 100000000) {
+			when := time.Unix(seconds, 0)
+			if len(fields) == 2 {
+				zone, err := time.Parse("-0700", fields[1])
+				if err != nil {
+					return time.Time{}, fmt.Errorf("invalid timezone in date %q", value)
+				}
+				when = when.In(zone.Location())
+			}
+			return when, nil
+		}
+	}
+
+	for _, layout := range gitDateLayouts {
+		if when, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return when, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported date format %q", value)
+}
@@ -0,0 +1,148 @@
+package gitops
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseIdentity(t *testing.T) {
+	tests := []struct {
+		name      string
+		identity  string
+		wantName  string
+		wantEmail string
+		wantErr   bool
+	}{
+		{
+			name:      "name and email",
+			identity:  "Jane Doe <jane@example.com>",
+			wantName:  "Jane Doe",
+			wantEmail: "jane@example.com",
+		},
+		{
+			name:      "surrounding whitespace",
+			identity:  "  Jane Doe   <jane@example.com>  ",
+			wantName:  "Jane Doe",
+			wantEmail: "jane@example.com",
+		},
+		{
+			name:     "missing email",
+			identity: "Jane Doe",
+			wantErr:  true,
+		},
+		{
+			name:     "missing name",
+			identity: "<jane@example.com>",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, email, err := ParseIdentity(tt.identity)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIdentity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if name != tt.wantName || email != tt.wantEmail {
+				t.Errorf("ParseIdentity() = %q, %q, want %q, %q", name, email, tt.wantName, tt.wantEmail)
+			}
+		})
+	}
+}
+
+func TestParseGitDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "internal format", value: "1700000000 +0200", want: 1700000000},
+		{name: "at timestamp", value: "@1700000000", want: 1700000000},
+		{name: "rfc3339", value: "2023-11-14T22:13:20Z", want: 1700000000},
+		{name: "rfc2822", value: "Tue, 14 Nov 2023 22:13:20 +0000", want: 1700000000},
+		{name: "iso with zone", value: "2023-11-14 22:13:20 +0000", want: 1700000000},
+		{name: "small number", value: "12345", wantErr: true},
+		{name: "garbage", value: "yesterday-ish", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGitDate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGitDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Unix() != tt.want {
+				t.Errorf("ParseGitDate() = %d, want %d", got.Unix(), tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSignatures(t *testing.T) {
+	config := &gitConfig{UserName: "Config", UserEmail: "config@example.com"}
+	now := time.Unix(1700000000, 0)
+
+	t.Run("defaults to config", func(t *testing.T) {
+		t.Setenv("GIT_AUTHOR_NAME", "")
+		t.Setenv("GIT_AUTHOR_EMAIL", "")
+		t.Setenv("GIT_AUTHOR_DATE", "")
+		t.Setenv("GIT_COMMITTER_NAME", "")
+		t.Setenv("GIT_COMMITTER_EMAIL", "")
+		t.Setenv("GIT_COMMITTER_DATE", "")
+
+		author, committer, err := resolveSignatures(config, "", "", now)
+		if err != nil {
+			t.Fatalf("resolveSignatures() error = %v", err)
+		}
+		if author.Name != "Config" || committer.Email != "config@example.com" || !author.When.Equal(now) {
+			t.Errorf("unexpected signatures: %+v, %+v", author, committer)
+		}
+	})
+
+	t.Run("environment overrides config", func(t *testing.T) {
+		t.Setenv("GIT_AUTHOR_NAME", "Env Author")
+		t.Setenv("GIT_AUTHOR_EMAIL", "author@example.com")
+		t.Setenv("GIT_AUTHOR_DATE", "@1600000000")
+		t.Setenv("GIT_COMMITTER_NAME", "Env Committer")
+		t.Setenv("GIT_COMMITTER_EMAIL", "")
+		t.Setenv("GIT_COMMITTER_DATE", "")
+
+		author, committer, err := resolveSignatures(config, "", "", now)
+		if err != nil {
+			t.Fatalf("resolveSignatures() error = %v", err)
+		}
+		if author.Name != "Env Author" || author.Email != "author@example.com" || author.When.Unix() != 1600000000 {
+			t.Errorf("unexpected author: %+v", author)
+		}
+		if committer.Name != "Env Committer" || committer.Email != "config@example.com" || !committer.When.Equal(now) {
+			t.Errorf("unexpected committer: %+v", committer)
+		}
+	})
+
+	t.Run("flags override environment", func(t *testing.T) {
+		t.Setenv("GIT_AUTHOR_NAME", "Env Author")
+		t.Setenv("GIT_AUTHOR_EMAIL", "author@example.com")
+		t.Setenv("GIT_AUTHOR_DATE", "@1600000000")
+		t.Setenv("GIT_COMMITTER_NAME", "")
+		t.Setenv("GIT_COMMITTER_EMAIL", "")
+		t.Setenv("GIT_COMMITTER_DATE", "")
+
+		author, committer, err := resolveSignatures(config, "Flag <flag@example.com>", "@1500000000", now)
+		if err != nil {
+			t.Fatalf("resolveSignatures() error = %v", err)
+		}
+		if author.Name != "Flag" || author.Email != "flag@example.com" || author.When.Unix() != 1500000000 {
+			t.Errorf("unexpected author: %+v", author)
+		}
+		if committer.Name != "Config" || !committer.When.Equal(now) {
+			t.Errorf("unexpected committer: %+v", committer)
+		}
+	})
+
+	t.Run("invalid author", func(t *testing.T) {
+		if _, _, err := resolveSignatures(config, "nobody", "", now); err == nil {
+			t.Error("expected error for invalid author")
+		}
+	})
+}
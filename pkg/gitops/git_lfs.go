@@ -0,0 +1,55 @@
+package gitops
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// GetLargeBinaries returns staged binary files not tracked by Git LFS
+// whose size exceeds thresholdBytes, mapped to their size
+func (g *Operations) GetLargeBinaries(thresholdBytes int64) (map[string]int64, error) {
+	if g.pureGo {
+		return g.getLargeBinariesNative(thresholdBytes)
+	}
+
+	stats, err := g.getStagedNumstat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged numstat: %w", err)
+	}
+
+	var binaries []string
+	for file, stat := range stats {
+		if stat.binary {
+			binaries = append(binaries, file)
+		}
+	}
+
+	attributes, err := g.getFileAttributes(binaries, "filter")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check file attributes: %w", err)
+	}
+
+	large := make(map[string]int64)
+	for _, file := range binaries {
+		if attributes[file]["filter"] == "lfs" {
+			continue
+		}
+
+		// Deleted files are not in the index anymore
+		output, err := g.runGit("cat-file", "-s", ":"+file)
+		if err != nil {
+			continue
+		}
+
+		size, err := strconv.ParseInt(output, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse size of %s: %w", file, err)
+		}
+
+		if size > thresholdBytes {
+			large[file] = size
+		}
+	}
+
+	return large, nil
+}
@@ -0,0 +1,48 @@
+package gitops
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGitOperations_GetLargeBinaries(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	binary := func(size int) []byte {
+		return append([]byte{0x00}, bytes.Repeat([]byte{0x01}, size-1)...)
+	}
+
+	files := map[string][]byte{
+		".gitattributes": []byte("*.psd filter=lfs diff=lfs merge=lfs -text\n"),
+		"large.bin":      binary(2048),
+		"small.bin":      binary(16),
+		"design.psd":     binary(4096),
+		"large.txt":      bytes.Repeat([]byte("text\n"), 1024),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	runTestGit(t, dir, "add", "-A")
+
+	large, err := g.GetLargeBinaries(1024)
+	if err != nil {
+		t.Fatalf("GetLargeBinaries() error = %v", err)
+	}
+	if len(large) != 1 || large["large.bin"] != 2048 {
+		t.Errorf("GetLargeBinaries() = %v, want map[large.bin:2048]", large)
+	}
+
+	diff, err := g.GetStagedDiff(64*1024, 40)
+	if err != nil {
+		t.Fatalf("GetStagedDiff() error = %v", err)
+	}
+	if !strings.Contains(diff, "design.psd: Git LFS object updated") {
+		t.Errorf("diff does not summarize LFS file:\n%s", diff)
+	}
+}
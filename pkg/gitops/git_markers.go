@@ -0,0 +1,97 @@
+package gitops
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// conflictMarkerSuffix is how git diff --check reports leftover conflict markers
+const conflictMarkerSuffix = ": leftover conflict marker"
+
+// GetConflictMarkers returns "path:line" locations of conflict markers added by staged changes,
+// markers already present in committed content are not reported
+func (g *Operations) GetConflictMarkers() ([]string, error) {
+	if g.pureGo {
+		return g.getConflictMarkersNative()
+	}
+
+	// --check honors conflict-marker-size attribute and skips binary files
+	output, err := g.gitCommand("diff", "--cached", "--check", "--no-color").Output()
+	if err != nil {
+		// problems found are reported with non-zero exit code
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || len(output) == 0 {
+			return nil, fmt.Errorf("failed to check staged changes: %w", err)
+		}
+	}
+
+	var markers []string
+	for _, line := range strings.Split(string(output), "\n") {
+		// whitespace errors are reported too, they are none of our business
+		if location, ok := strings.CutSuffix(line, conflictMarkerSuffix); ok {
+			markers = append(markers, location)
+		}
+	}
+
+	return markers, nil
+}
+
+// getConflictMarkersNative scans lines added by staged changes for conflict markers
+func (g *Operations) getConflictMarkersNative() ([]string, error) {
+	changes, err := g.getStagedChangesNative()
+	if err != nil {
+		return nil, err
+	}
+
+	var markers []string
+	for _, change := range changes {
+		if change.to == nil {
+			continue
+		}
+		src, err := g.readBlob(change.from)
+		if err != nil {
+			return nil, err
+		}
+		dst, err := g.readBlob(change.to)
+		if err != nil {
+			return nil, err
+		}
+		if isBinaryContent(dst) {
+			continue
+		}
+
+		line := 0
+		for _, d := range diff.Do(string(src), string(dst)) {
+			if d.Type == diffmatchpatch.DiffDelete {
+				continue
+			}
+			for _, text := range strings.SplitAfter(d.Text, "\n") {
+				if text == "" {
+					continue
+				}
+				line++
+				if d.Type == diffmatchpatch.DiffInsert && isConflictMarker(text) {
+					markers = append(markers, fmt.Sprintf("%s:%d", change.path, line))
+				}
+			}
+		}
+	}
+
+	return markers, nil
+}
+
+// isConflictMarker matches lines git writes around conflicting hunks
+func isConflictMarker(line string) bool {
+	line = strings.TrimRight(line, "\r\n")
+	for _, marker := range []string{"<<<<<<<", "|||||||", ">>>>>>>"} {
+		if line == marker || strings.HasPrefix(line, marker+" ") {
+			return true
+		}
+	}
+	return line == "======="
+}
@@ -0,0 +1,64 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGitOperations_GetConflictMarkers(t *testing.T) {
+	for _, pureGo := range []bool{false, true} {
+		t.Run(map[bool]string{false: "git", true: "pure go"}[pureGo], func(t *testing.T) {
+			g, dir := newTestGitOperations(t)
+			g.pureGo = pureGo
+			t.Chdir(dir)
+
+			// markers committed before, e.g. in docs, are not reported
+			commitTestFile(t, dir, "docs.md", "example:\n<<<<<<< HEAD\n", "init")
+			commitTestFile(t, dir, "main.go", "package main\n", "add main")
+
+			files := map[string]string{
+				"main.go":  "package main\n<<<<<<< HEAD\nvar a = 1\n=======\nvar a = 2\n>>>>>>> feature\n",
+				"docs.md":  "example:\n<<<<<<< HEAD\nmore text\n",
+				"clean.go": "package clean\n",
+			}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+			runTestGit(t, dir, "add", "-A")
+
+			markers, err := g.GetConflictMarkers()
+			if err != nil {
+				t.Fatalf("GetConflictMarkers() error = %v", err)
+			}
+			want := []string{"main.go:2", "main.go:4", "main.go:6"}
+			if !reflect.DeepEqual(markers, want) {
+				t.Errorf("GetConflictMarkers() = %v, want %v", markers, want)
+			}
+		})
+	}
+}
+
+func TestIsConflictMarker(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{line: "<<<<<<< HEAD\n", want: true},
+		{line: "=======\n", want: true},
+		{line: ">>>>>>> feature\r\n", want: true},
+		{line: "||||||| base\n", want: true},
+		{line: "<<<<<<<<\n", want: false},
+		{line: "========\n", want: false},
+		{line: "// <<<<<<< HEAD\n", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isConflictMarker(tt.line); got != tt.want {
+			t.Errorf("isConflictMarker(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,566 @@
+package gitops
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/utils/binary"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/hasansino/commit/pkg/commit/modules"
+)
+
+// Implementations below are used instead of spawning git when running in pure go mode,
+// they cover what is needed to commit, tag and push, without hooks-like extras of git itself
+
+// nativeFile is one side of a staged change
+type nativeFile struct {
+	hash plumbing.Hash
+	mode filemode.FileMode
+	path string
+}
+
+func (f *nativeFile) Hash() plumbing.Hash     { return f.hash }
+func (f *nativeFile) Mode() filemode.FileMode { return f.mode }
+func (f *nativeFile) Path() string            { return f.path }
+
+type nativeChunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c nativeChunk) Content() string       { return c.content }
+func (c nativeChunk) Type() fdiff.Operation { return c.op }
+
+type nativeFilePatch struct {
+	from, to fdiff.File
+	chunks   []fdiff.Chunk
+}
+
+func (p nativeFilePatch) IsBinary() bool                  { return false }
+func (p nativeFilePatch) Files() (fdiff.File, fdiff.File) { return p.from, p.to }
+func (p nativeFilePatch) Chunks() []fdiff.Chunk           { return p.chunks }
+
+type nativePatch []fdiff.FilePatch
+
+func (p nativePatch) FilePatches() []fdiff.FilePatch { return p }
+func (p nativePatch) Message() string                { return "" }
+
+// stagedChange is a path whose index entry differs from HEAD, from is nil for added
+// files and to is nil for deleted ones
+type stagedChange struct {
+	path     string
+	from, to *nativeFile
+}
+
+// getStagedChangesNative compares index with HEAD tree
+func (g *Operations) getStagedChangesNative() ([]stagedChange, error) {
+	head := make(map[string]*nativeFile)
+
+	ref, err := g.repo.Head()
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		// unborn branch, everything in index is new
+	case err != nil:
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	default:
+		commit, err := g.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get HEAD commit: %w", err)
+		}
+		tree, err := commit.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get HEAD tree: %w", err)
+		}
+		walker := object.NewTreeWalker(tree, true, nil)
+		defer walker.Close()
+		for {
+			name, entry, err := walker.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to walk HEAD tree: %w", err)
+			}
+			if entry.Mode == filemode.Dir {
+				continue
+			}
+			head[name] = &nativeFile{hash: entry.Hash, mode: entry.Mode, path: name}
+		}
+	}
+
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	staged := make(map[string]*nativeFile, len(idx.Entries))
+	for _, entry := range idx.Entries {
+		// stage 0 is a merged entry, others are sides of a conflict
+		if entry.Stage > 0 {
+			continue
+		}
+		staged[entry.Name] = &nativeFile{hash: entry.Hash, mode: entry.Mode, path: entry.Name}
+	}
+
+	var changes []stagedChange
+	for name, to := range staged {
+		from := head[name]
+		if from != nil && from.hash == to.hash && from.mode == to.mode {
+			continue
+		}
+		changes = append(changes, stagedChange{path: name, from: from, to: to})
+	}
+	for name, from := range head {
+		if _, ok := staged[name]; !ok {
+			changes = append(changes, stagedChange{path: name, from: from})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].path < changes[j].path
+	})
+
+	return changes, nil
+}
+
+// getStagedFilesNative returns paths of staged changes
+func (g *Operations) getStagedFilesNative() ([]string, error) {
+	changes, err := g.getStagedChangesNative()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(changes))
+	for _, change := range changes {
+		files = append(files, change.path)
+	}
+
+	return files, nil
+}
+
+// readBlob returns content of a blob, submodule entries have no content
+func (g *Operations) readBlob(file *nativeFile) ([]byte, error) {
+	if file == nil || file.mode == filemode.Submodule {
+		return nil, nil
+	}
+
+	blob, err := g.repo.BlobObject(file.hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.path, err)
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.path, err)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// GetStagedGoFiles returns content of staged Go files before and after change, for API comparison
+func (g *Operations) GetStagedGoFiles() ([]modules.ChangedFile, error) {
+	return g.GetStagedFileContents(func(file string) bool {
+		return strings.HasSuffix(file, ".go")
+	})
+}
+
+// GetStagedFileContents returns content of matching staged files before and after change
+func (g *Operations) GetStagedFileContents(match func(file string) bool) ([]modules.ChangedFile, error) {
+	changes, err := g.getStagedChangesNative()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []modules.ChangedFile
+	for _, change := range changes {
+		if !match(change.path) {
+			continue
+		}
+		before, err := g.readBlob(change.from)
+		if err != nil {
+			return nil, err
+		}
+		after, err := g.readBlob(change.to)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, modules.ChangedFile{Path: change.path, Before: before, After: after})
+	}
+
+	return files, nil
+}
+
+// getStagedDiffNative renders staged diff of given files with go-git, reducing context
+// to fit within maxSizeBytes, binary and lock files are summarized with a single line
+func (g *Operations) getStagedDiffNative(files []string, maxSizeBytes int) (string, error) {
+	changes, err := g.getStagedChangesNative()
+	if err != nil {
+		return "", err
+	}
+
+	selected := make(map[string]bool, len(files))
+	for _, file := range files {
+		selected[file] = true
+	}
+
+	var (
+		patch  nativePatch
+		opaque []opaqueFileSummary
+	)
+	for _, change := range changes {
+		if !selected[change.path] {
+			continue
+		}
+
+		if description := lockFiles[path.Base(change.path)]; description != "" {
+			opaque = append(opaque, opaqueFileSummary{Path: change.path, Description: description})
+			continue
+		}
+
+		src, err := g.readBlob(change.from)
+		if err != nil {
+			return "", err
+		}
+		dst, err := g.readBlob(change.to)
+		if err != nil {
+			return "", err
+		}
+		if isBinaryContent(src) || isBinaryContent(dst) {
+			opaque = append(opaque, opaqueFileSummary{Path: change.path, Description: "binary file updated"})
+			continue
+		}
+
+		filePatch := nativeFilePatch{}
+		if change.from != nil {
+			filePatch.from = change.from
+		}
+		if change.to != nil {
+			filePatch.to = change.to
+		}
+		for _, d := range diff.Do(string(src), string(dst)) {
+			op := fdiff.Equal
+			switch d.Type {
+			case diffmatchpatch.DiffInsert:
+				op = fdiff.Add
+			case diffmatchpatch.DiffDelete:
+				op = fdiff.Delete
+			}
+			filePatch.chunks = append(filePatch.chunks, nativeChunk{content: d.Text, op: op})
+		}
+		patch = append(patch, filePatch)
+	}
+
+	opaqueSection := formatOpaqueSummaries(opaque)
+	if len(opaqueSection) >= maxSizeBytes {
+		return truncateLines(opaqueSection, maxSizeBytes), nil
+	}
+	if len(patch) == 0 {
+		return opaqueSection, nil
+	}
+
+	maxSizeBytes -= len(opaqueSection)
+
+	var (
+		output   string
+		complete bool
+	)
+	for _, contextLevel := range contextLevels {
+		buf := newDiffBuffer(maxSizeBytes)
+		encoder := fdiff.NewUnifiedEncoder(buf, contextLevel).SetSrcPrefix("").SetDstPrefix("")
+		if err := encoder.Encode(patch); err != nil && !buf.exceeded {
+			return "", fmt.Errorf("failed to encode staged diff: %w", err)
+		}
+		output, complete = g.diffOptions.prepare(buf.String()), !buf.exceeded
+		if complete && len(output) <= maxSizeBytes {
+			return output + opaqueSection, nil
+		}
+	}
+
+	return fitDiff(output, complete, maxSizeBytes) + opaqueSection, nil
+}
+
+func isBinaryContent(content []byte) bool {
+	isBinary, _ := binary.IsBinary(bytes.NewReader(content))
+	return isBinary
+}
+
+// getLargeBinariesNative is GetLargeBinaries without git, LFS files are staged as small
+// text pointers and never reported
+func (g *Operations) getLargeBinariesNative(thresholdBytes int64) (map[string]int64, error) {
+	changes, err := g.getStagedChangesNative()
+	if err != nil {
+		return nil, err
+	}
+
+	large := make(map[string]int64)
+	for _, change := range changes {
+		if change.to == nil || change.to.mode == filemode.Submodule {
+			continue
+		}
+		blob, err := g.repo.BlobObject(change.to.hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", change.path, err)
+		}
+		if blob.Size <= thresholdBytes {
+			continue
+		}
+		content, err := g.readBlob(change.to)
+		if err != nil {
+			return nil, err
+		}
+		if isBinaryContent(content) {
+			large[change.path] = blob.Size
+		}
+	}
+
+	return large, nil
+}
+
+// createTagNative creates unsigned annotated tag pointing to HEAD
+func (g *Operations) createTagNative(tagName, message string, config *gitConfig) error {
+	head, err := g.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	_, err = g.repo.CreateTag(tagName, head.Hash(), &git.CreateTagOptions{
+		Tagger: &object.Signature{
+			Name:  config.UserName,
+			Email: config.UserEmail,
+			When:  time.Now(),
+		},
+		Message: message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", tagName, err)
+	}
+
+	return nil
+}
+
+// getConflictedFilesNative returns paths having unmerged index entries
+func (g *Operations) getConflictedFilesNative() ([]string, error) {
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var conflicted []string
+	for _, entry := range idx.Entries {
+		if entry.Stage > 0 && !seen[entry.Name] {
+			seen[entry.Name] = true
+			conflicted = append(conflicted, entry.Name)
+		}
+	}
+
+	return conflicted, nil
+}
+
+// listTagsNative returns names of tags matching glob pattern
+func (g *Operations) listTagsNative(pattern string) ([]string, error) {
+	refs, err := g.repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if ok, _ := path.Match(pattern, name); ok {
+			tags = append(tags, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(tags)
+
+	return tags, nil
+}
+
+// getConfigValueNative reads a config value from repository, user or system configuration,
+// in this order of precedence
+func (g *Operations) getConfigValueNative(key string) string {
+	section, option, ok := strings.Cut(key, ".")
+	if !ok {
+		return ""
+	}
+
+	local, err := g.repo.Storer.Config()
+	if err != nil {
+		return ""
+	}
+
+	configs := []*config.Config{local}
+	for _, scope := range []config.Scope{config.GlobalScope, config.SystemScope} {
+		if cfg, err := config.LoadConfig(scope); err == nil {
+			configs = append(configs, cfg)
+		}
+	}
+
+	for _, cfg := range configs {
+		if value := cfg.Raw.Section(section).Option(option); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// getDefaultBranchNative follows refs/remotes/<remote>/HEAD
+func (g *Operations) getDefaultBranchNative(remote string) string {
+	ref, err := g.repo.Reference(plumbing.NewRemoteHEADReferenceName(remote), false)
+	if err == nil && ref.Type() == plumbing.SymbolicReference {
+		if branch, ok := strings.CutPrefix(ref.Target().Short(), remote+"/"); ok {
+			return branch
+		}
+	}
+	return "master"
+}
+
+// hasUpstreamNative checks branch configuration for upstream tracking branch
+func (g *Operations) hasUpstreamNative(branch string) bool {
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return false
+	}
+	b, ok := cfg.Branches[branch]
+	return ok && b.Remote != "" && b.Merge != ""
+}
+
+// pushNative pushes refspecs to remote with go-git transports, authentication relies
+// on ssh-agent for ssh remotes
+func (g *Operations) pushNative(remote string, forceWithLease bool, refSpecs ...string) error {
+	options := &git.PushOptions{RemoteName: remote}
+	for _, refSpec := range refSpecs {
+		options.RefSpecs = append(options.RefSpecs, config.RefSpec(refSpec))
+	}
+	if forceWithLease {
+		// empty lease protects refs by their remote tracking counterparts
+		options.ForceWithLease = &git.ForceWithLease{}
+	}
+
+	err := g.repo.Push(options)
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+
+	return nil
+}
+
+// setUpstreamNative configures remote branch with the same name as upstream of branch
+func (g *Operations) setUpstreamNative(remote, branch string) error {
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return err
+	}
+
+	cfg.Branches[branch] = &config.Branch{
+		Name:   branch,
+		Remote: remote,
+		Merge:  plumbing.NewBranchReferenceName(branch),
+	}
+
+	return g.repo.SetConfig(cfg)
+}
+
+// getSubmoduleChangesNative returns staged changes having submodule on either side
+func (g *Operations) getSubmoduleChangesNative() ([]submoduleChange, error) {
+	changes, err := g.getStagedChangesNative()
+	if err != nil {
+		return nil, err
+	}
+
+	var submodules []submoduleChange
+	for _, change := range changes {
+		fromSubmodule := change.from != nil && change.from.mode == filemode.Submodule
+		toSubmodule := change.to != nil && change.to.mode == filemode.Submodule
+		if !fromSubmodule && !toSubmodule {
+			continue
+		}
+
+		submodule := submoduleChange{Path: change.path}
+		if fromSubmodule {
+			submodule.OldSHA = change.from.hash.String()
+		}
+		if toSubmodule {
+			submodule.NewSHA = change.to.hash.String()
+		}
+		submodules = append(submodules, submodule)
+	}
+
+	return submodules, nil
+}
+
+// getSubmoduleLogNative is getSubmoduleLog without git, walk stops at old revision
+// instead of excluding everything reachable from it
+func getSubmoduleLogNative(submodulePath, oldSHA, newSHA string) []string {
+	repo, err := git.PlainOpen(submodulePath)
+	if err != nil {
+		return nil
+	}
+	commits, err := repo.Log(&git.LogOptions{From: plumbing.NewHash(newSHA)})
+	if err != nil {
+		return nil
+	}
+	defer commits.Close()
+
+	var lines []string
+	_ = commits.ForEach(func(commit *object.Commit) error {
+		if commit.Hash.String() == oldSHA || len(lines) >= submoduleMaxLogLines {
+			return storer.ErrStop
+		}
+		subject, _, _ := strings.Cut(commit.Message, "\n")
+		lines = append(lines, ShortSHA(commit.Hash.String())+" "+subject)
+		return nil
+	})
+	return lines
+}
+
+// getStagedNewFilesNative returns paths added to the index since HEAD
+func (g *Operations) getStagedNewFilesNative() (map[string]bool, error) {
+	changes, err := g.getStagedChangesNative()
+	if err != nil {
+		return nil, err
+	}
+
+	newFiles := make(map[string]bool)
+	for _, change := range changes {
+		if change.from == nil {
+			newFiles[change.path] = true
+		}
+	}
+
+	return newFiles, nil
+}
+
+// readStagedFileNative returns content of a merged index entry
+func (g *Operations) readStagedFileNative(file string) ([]byte, error) {
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	entry, err := idx.Entry(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s in index: %w", file, err)
+	}
+	return g.readBlob(&nativeFile{hash: entry.Hash, mode: entry.Mode, path: entry.Name})
+}
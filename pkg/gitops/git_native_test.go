@@ -0,0 +1,203 @@
+package gitops
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGitOperations_GetStagedDiff_PureGo(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	g.pureGo = true
+	t.Chdir(dir)
+
+	commitTestFile(t, dir, "main.go", "package main\n\nfunc main() {\n\tprintln(1)\n}\n", "init")
+	commitTestFile(t, dir, "removed.txt", "gone\n", "add file to remove")
+
+	files := map[string]string{
+		"main.go":    "package main\n\nfunc main() {\n\tprintln(2)\n}\n",
+		"new.txt":    "hello\n",
+		"image.bin":  "\x00\x01\x02",
+		"go.sum":     "example.com/mod v1.0.0 h1:abc=\n",
+		"ignore.txt": "not staged\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	runTestGit(t, dir, "add", "main.go", "new.txt", "image.bin", "go.sum")
+	runTestGit(t, dir, "rm", "--quiet", "removed.txt")
+
+	staged, err := g.GetFilteredStagedFiles()
+	if err != nil {
+		t.Fatalf("GetFilteredStagedFiles() error = %v", err)
+	}
+	want := []string{"go.sum", "image.bin", "main.go", "new.txt", "removed.txt"}
+	if !reflect.DeepEqual(staged, want) {
+		t.Errorf("GetFilteredStagedFiles() = %v, want %v", staged, want)
+	}
+
+	diff, err := g.GetStagedDiff(64*1024, 0)
+	if err != nil {
+		t.Fatalf("GetStagedDiff() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"--- main.go\n+++ main.go\n",
+		"-\tprintln(1)\n+\tprintln(2)\n",
+		"new file mode 100644\n",
+		"+hello\n",
+		"deleted file mode 100644\n",
+		"-gone\n",
+		"image.bin: binary file updated\n",
+		"go.sum: dependency checksums updated\n",
+	} {
+		if !strings.Contains(diff, expected) {
+			t.Errorf("GetStagedDiff() missing %q in:\n%s", expected, diff)
+		}
+	}
+	if strings.Contains(diff, "ignore.txt") || strings.Contains(diff, "h1:abc=") {
+		t.Errorf("GetStagedDiff() contains unstaged or omitted content:\n%s", diff)
+	}
+}
+
+func TestGitOperations_GetLatestTag_PureGo(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	g.pureGo = true
+
+	commitTestFile(t, dir, "a.txt", "a\n", "init")
+	for _, tag := range []string{"v1.2.0", "v1.10.0", "app/v3.0.0", "latest"} {
+		runTestGit(t, dir, "tag", tag)
+	}
+
+	tests := []struct {
+		name    string
+		prefix  string
+		pattern string
+		want    string
+	}{
+		{name: "default pattern", prefix: "v", want: "v1.10.0"},
+		{name: "component prefix", prefix: "app/v", want: "app/v3.0.0"},
+		{name: "no matching tags", prefix: "release-", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.GetLatestTag(tt.prefix, tt.pattern)
+			if err != nil {
+				t.Fatalf("GetLatestTag() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetLatestTag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitOperations_HasConflicts_PureGo(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	g.pureGo = true
+	setTestGitIdentity(t)
+
+	commitTestFile(t, dir, "a.txt", "base\n", "init")
+	runTestGit(t, dir, "checkout", "--quiet", "-b", "other")
+	commitTestFile(t, dir, "a.txt", "other\n", "other change")
+	runTestGit(t, dir, "checkout", "--quiet", "-")
+	commitTestFile(t, dir, "a.txt", "main\n", "main change")
+
+	hasConflicts, files, err := g.HasConflicts()
+	if err != nil || hasConflicts {
+		t.Fatalf("HasConflicts() before merge = %v, %v, %v", hasConflicts, files, err)
+	}
+
+	// merge fails with conflict, which is what we want
+	if err := exec.Command("git", "-C", dir, "merge", "other").Run(); err == nil {
+		t.Fatalf("merge succeeded, expected conflict")
+	}
+
+	hasConflicts, files, err = g.HasConflicts()
+	if err != nil {
+		t.Fatalf("HasConflicts() error = %v", err)
+	}
+	if !hasConflicts || !reflect.DeepEqual(files, []string{"a.txt"}) {
+		t.Errorf("HasConflicts() = %v, %v, want true, [a.txt]", hasConflicts, files)
+	}
+}
+
+func TestGitOperations_Push_PureGo(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	g.pureGo = true
+
+	remoteDir := t.TempDir()
+	runTestGit(t, remoteDir, "init", "--bare")
+	runTestGit(t, dir, "remote", "add", "upstream", remoteDir)
+
+	commitTestFile(t, dir, "a.txt", "a\n", "init")
+	runTestGit(t, dir, "checkout", "-b", "feature")
+
+	if g.hasUpstream("feature") {
+		t.Fatalf("hasUpstream() = true before first push")
+	}
+
+	if _, err := g.Push("upstream", false, true); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	if !g.hasUpstream("feature") {
+		t.Errorf("hasUpstream() = false after push with setUpstream")
+	}
+
+	remoteHead := runTestGit(t, remoteDir, "rev-parse", "refs/heads/feature")
+	localHead := runTestGit(t, dir, "rev-parse", "HEAD")
+	if remoteHead != localHead {
+		t.Errorf("remote head = %s, want %s", remoteHead, localHead)
+	}
+
+	// Rewritten history is accepted with lease, since remote did not move
+	runTestGit(t, dir, "commit", "--amend", "-m", "amended")
+	if _, err := g.Push("upstream", true, false); err != nil {
+		t.Fatalf("Push() with force-with-lease error = %v", err)
+	}
+
+	runTestGit(t, dir, "tag", "v1.0.0")
+	if err := g.PushTag("v1.0.0", "upstream"); err != nil {
+		t.Fatalf("PushTag() error = %v", err)
+	}
+	runTestGit(t, remoteDir, "rev-parse", "refs/tags/v1.0.0")
+
+	if err := g.DeleteTag("v1.0.0"); err != nil {
+		t.Fatalf("DeleteTag() error = %v", err)
+	}
+	if tags := runTestGit(t, dir, "tag", "-l"); tags != "" {
+		t.Errorf("tags after DeleteTag() = %q, want none", tags)
+	}
+}
+
+func TestGitOperations_getStagedGoFiles(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	commitTestFile(t, dir, "api.go", "package api\n\nfunc Old() {}\n", "init")
+	commitTestFile(t, dir, "notes.txt", "notes\n", "add notes")
+
+	if err := os.WriteFile(filepath.Join(dir, "api.go"), []byte("package api\n\nfunc New() {}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write api.go: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("changed\n"), 0644); err != nil {
+		t.Fatalf("Failed to write notes.txt: %v", err)
+	}
+	runTestGit(t, dir, "add", "-A")
+
+	files, err := g.GetStagedGoFiles()
+	if err != nil {
+		t.Fatalf("GetStagedGoFiles() error = %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "api.go" ||
+		!strings.Contains(string(files[0].Before), "Old") || !strings.Contains(string(files[0].After), "New") {
+		t.Errorf("GetStagedGoFiles() = %+v, want api.go before and after change", files)
+	}
+}
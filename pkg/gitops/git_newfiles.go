@@ -0,0 +1,176 @@
+package gitops
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxDeclarationsInSummary limits how many declarations are listed for a single new file
+const maxDeclarationsInSummary = 30
+
+// declarationPatterns detect top-level declarations for common languages,
+// first capture group is the declaration kind, second is its name
+var declarationPatterns = map[string][]*regexp.Regexp{
+	".go": {
+		regexp.MustCompile(`^(func)\s+(?:\([^)]*\)\s*)?([A-Za-z_]\w*)`),
+		regexp.MustCompile(`^(type)\s+([A-Za-z_]\w*)`),
+	},
+	".py": {
+		regexp.MustCompile(`^(def|class)\s+([A-Za-z_]\w*)`),
+		regexp.MustCompile(`^(async def)\s+([A-Za-z_]\w*)`),
+	},
+	".js":  jsDeclarationPatterns,
+	".ts":  jsDeclarationPatterns,
+	".jsx": jsDeclarationPatterns,
+	".tsx": jsDeclarationPatterns,
+	".java": {
+		regexp.MustCompile(`^\s*(?:(?:public|protected|private|abstract|final|static)\s+)*` +
+			`(class|interface|enum|record)\s+([A-Za-z_]\w*)`),
+	},
+	".rb": {
+		regexp.MustCompile(`^\s*(def|class|module)\s+([A-Za-z_][\w.]*)`),
+	},
+	".rs": {
+		regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(fn|struct|enum|trait|mod)\s+([A-Za-z_]\w*)`),
+	},
+}
+
+var jsDeclarationPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:async\s+)?` +
+		`(function|class|interface|type)\s+([A-Za-z_$][\w$]*)`),
+}
+
+// newFileSummary is a compact representation of a large newly added file
+type newFileSummary struct {
+	Path         string
+	TotalLines   int
+	Declarations []string
+	Head         []string
+}
+
+// getStagedNewFiles returns staged files which are newly added to the index
+func (g *Operations) getStagedNewFiles() (map[string]bool, error) {
+	if g.pureGo {
+		return g.getStagedNewFilesNative()
+	}
+
+	cmd := g.gitCommand("diff", "--cached", "--name-only", "--diff-filter=A")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	newFiles := make(map[string]bool)
+	for _, file := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if len(file) > 0 {
+			newFiles[file] = true
+		}
+	}
+
+	return newFiles, nil
+}
+
+// summarizeNewFiles builds summaries for staged new files longer than headLines,
+// small and binary files are left to the regular diff
+func (g *Operations) summarizeNewFiles(files []string, headLines int) ([]newFileSummary, error) {
+	if headLines <= 0 {
+		return nil, nil
+	}
+
+	newFiles, err := g.getStagedNewFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get new files: %w", err)
+	}
+
+	var summaries []newFileSummary
+	for _, file := range files {
+		if !newFiles[file] {
+			continue
+		}
+
+		content, err := g.readStagedFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read staged content of %s: %w", file, err)
+		}
+
+		if bytes.IndexByte(content, 0) != -1 {
+			continue // binary content
+		}
+
+		summary := summarizeNewFile(file, string(content), headLines)
+		if summary.TotalLines <= headLines {
+			continue
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// readStagedFile returns staged content of a file from the index, not from the worktree
+func (g *Operations) readStagedFile(file string) ([]byte, error) {
+	if g.pureGo {
+		return g.readStagedFileNative(file)
+	}
+	return g.gitCommand("show", ":"+file).Output()
+}
+
+// summarizeNewFile extracts the head and top-level declarations of a file
+func summarizeNewFile(path, content string, headLines int) newFileSummary {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	summary := newFileSummary{
+		Path:       path,
+		TotalLines: len(lines),
+	}
+
+	if len(lines) > headLines {
+		summary.Head = lines[:headLines]
+	} else {
+		summary.Head = lines
+	}
+
+	patterns := declarationPatterns[strings.ToLower(filepath.Ext(path))]
+	for _, line := range lines {
+		for _, pattern := range patterns {
+			if matches := pattern.FindStringSubmatch(line); len(matches) > 2 {
+				summary.Declarations = append(summary.Declarations, matches[1]+" "+matches[2])
+				break
+			}
+		}
+		if len(summary.Declarations) >= maxDeclarationsInSummary {
+			break
+		}
+	}
+
+	return summary
+}
+
+// formatNewFileSummaries renders summaries as a diff-like section for prompts
+func formatNewFileSummaries(summaries []newFileSummary) string {
+	if len(summaries) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, summary := range summaries {
+		b.WriteString(fmt.Sprintf("new file %s (%d lines, showing first %d)\n",
+			summary.Path, summary.TotalLines, len(summary.Head)))
+		if len(summary.Declarations) > 0 {
+			b.WriteString("declarations: " + strings.Join(summary.Declarations, ", ") + "\n")
+		}
+		for _, line := range summary.Head {
+			b.WriteString("+" + line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
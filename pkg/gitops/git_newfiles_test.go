@@ -0,0 +1,154 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeNewFile(t *testing.T) {
+	tests := []struct {
+		name                 string
+		path                 string
+		content              string
+		headLines            int
+		expectedTotal        int
+		expectedHead         int
+		expectedDeclarations []string
+	}{
+		{
+			name:          "empty file",
+			path:          "empty.go",
+			content:       "",
+			headLines:     5,
+			expectedTotal: 0,
+			expectedHead:  0,
+		},
+		{
+			name: "go declarations",
+			path: "service.go",
+			content: "package service\n\ntype Service struct{}\n\nfunc New() *Service {\n\treturn nil\n}\n\n" +
+				"func (s *Service) Run() error {\n\treturn nil\n}\n",
+			headLines:            3,
+			expectedTotal:        11,
+			expectedHead:         3,
+			expectedDeclarations: []string{"type Service", "func New", "func Run"},
+		},
+		{
+			name: "python declarations",
+			path: "app.py",
+			content: "import os\n\nclass App:\n    def run(self):\n        pass\n\n" +
+				"def main():\n    pass\n",
+			headLines:            2,
+			expectedTotal:        8,
+			expectedHead:         2,
+			expectedDeclarations: []string{"class App", "def main"},
+		},
+		{
+			name:                 "typescript declarations",
+			path:                 "index.ts",
+			content:              "export default class Store {}\nexport async function load() {}\ninterface Item {}\n",
+			headLines:            10,
+			expectedTotal:        3,
+			expectedHead:         3,
+			expectedDeclarations: []string{"class Store", "function load", "interface Item"},
+		},
+		{
+			name:          "unknown extension",
+			path:          "notes.txt",
+			content:       "func not code\nclass neither\n",
+			headLines:     1,
+			expectedTotal: 2,
+			expectedHead:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarizeNewFile(tt.path, tt.content, tt.headLines)
+
+			if summary.Path != tt.path {
+				t.Errorf("summarizeNewFile() path = %q, want %q", summary.Path, tt.path)
+			}
+			if summary.TotalLines != tt.expectedTotal {
+				t.Errorf("summarizeNewFile() total lines = %d, want %d", summary.TotalLines, tt.expectedTotal)
+			}
+			if len(summary.Head) != tt.expectedHead {
+				t.Errorf("summarizeNewFile() head lines = %d, want %d", len(summary.Head), tt.expectedHead)
+			}
+			if !reflect.DeepEqual(summary.Declarations, tt.expectedDeclarations) {
+				t.Errorf("summarizeNewFile() declarations = %v, want %v", summary.Declarations, tt.expectedDeclarations)
+			}
+		})
+	}
+}
+
+func TestGitOperations_SummarizeNewFiles(t *testing.T) {
+	for _, pureGo := range []bool{false, true} {
+		t.Run(map[bool]string{false: "git", true: "pure go"}[pureGo], func(t *testing.T) {
+			g, dir := newTestGitOperations(t)
+			g.pureGo = pureGo
+			t.Chdir(dir)
+
+			commitTestFile(t, dir, "old.go", "package old\n\nfunc A() {}\n\nfunc B() {}\n", "init")
+
+			files := map[string]string{
+				"old.go":   "package old\n\nfunc A() {}\n\nfunc B() {}\n\nfunc C() {}\n",
+				"new.go":   "package main\n\nfunc main() {}\n\nfunc run() {}\n",
+				"small.go": "package small\n",
+			}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+			// staged content is summarized, not the worktree one
+			runTestGit(t, dir, "add", "-A")
+			if err := os.WriteFile(filepath.Join(dir, "new.go"), []byte("package changed\n"), 0644); err != nil {
+				t.Fatalf("Failed to write new.go: %v", err)
+			}
+
+			summaries, err := g.summarizeNewFiles([]string{"new.go", "old.go", "small.go"}, 2)
+			if err != nil {
+				t.Fatalf("summarizeNewFiles() error = %v", err)
+			}
+			want := []newFileSummary{{
+				Path:         "new.go",
+				TotalLines:   5,
+				Declarations: []string{"func main", "func run"},
+				Head:         []string{"package main", ""},
+			}}
+			if !reflect.DeepEqual(summaries, want) {
+				t.Errorf("summarizeNewFiles() = %+v, want %+v", summaries, want)
+			}
+		})
+	}
+}
+
+func TestFormatNewFileSummaries(t *testing.T) {
+	if result := formatNewFileSummaries(nil); result != "" {
+		t.Errorf("formatNewFileSummaries(nil) = %q, want empty string", result)
+	}
+
+	result := formatNewFileSummaries([]newFileSummary{
+		{
+			Path:         "service.go",
+			TotalLines:   120,
+			Declarations: []string{"type Service", "func New"},
+			Head:         []string{"package service", ""},
+		},
+	})
+
+	expectations := []string{
+		"new file service.go (120 lines, showing first 2)",
+		"declarations: type Service, func New",
+		"+package service\n+\n",
+	}
+	for _, expected := range expectations {
+		if !strings.Contains(result, expected) {
+			t.Errorf("formatNewFileSummaries() result should contain %q, got: %s", expected, result)
+		}
+	}
+}
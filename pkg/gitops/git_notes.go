@@ -0,0 +1,157 @@
+package gitops
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// AddNote attaches note to HEAD commit under notesRef, replacing existing one
+func (g *Operations) AddNote(notesRef, message string) error {
+	if g.pureGo {
+		return g.addNoteNative(notesRef, message)
+	}
+
+	cmd := g.gitCommand("notes", "--ref", notesRef, "add", "--force", "--message", message, "HEAD")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to add note: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
+
+// GetNote returns note attached to commit under notesRef, empty when commit has no note
+func (g *Operations) GetNote(notesRef, sha string) string {
+	// git notes show fails both for missing notes ref and for commits without note
+	note, err := g.runGit("notes", "--ref", notesRef, "show", sha)
+	if err != nil {
+		return ""
+	}
+	return note
+}
+
+// PushNotes pushes notesRef to remote, notes are not pushed together with branches
+func (g *Operations) PushNotes(notesRef, remote string) error {
+	if remote == "" {
+		remote = DefaultRemote
+	}
+
+	refSpec := notesRef + ":" + notesRef
+	if g.pureGo {
+		if err := g.pushNative(remote, false, refSpec); err != nil {
+			return fmt.Errorf("failed to push notes %s: %w", notesRef, err)
+		}
+		return nil
+	}
+
+	cmd := g.gitCommand("push", remote, refSpec)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to push notes %s: %w\nOutput: %s", notesRef, err, string(output))
+	}
+	return nil
+}
+
+// addNoteNative writes note the way git notes does: a commit on notesRef whose tree maps
+// annotated commit hashes to note blobs
+func (g *Operations) addNoteNative(notesRef, message string) error {
+	config, err := g.getConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	target := head.Hash().String()
+
+	var (
+		entries []object.TreeEntry
+		parents []plumbing.Hash
+	)
+	ref, err := g.repo.Reference(plumbing.ReferenceName(notesRef), true)
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to read notes %s: %w", notesRef, err)
+	default:
+		notes, err := g.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to read notes %s: %w", notesRef, err)
+		}
+		tree, err := notes.Tree()
+		if err != nil {
+			return fmt.Errorf("failed to read notes %s: %w", notesRef, err)
+		}
+		for _, entry := range tree.Entries {
+			if entry.Name != target {
+				entries = append(entries, entry)
+			}
+		}
+		parents = append(parents, ref.Hash())
+	}
+
+	blob := g.repo.Storer.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+	writer, err := blob.Writer()
+	if err != nil {
+		return fmt.Errorf("failed to write note: %w", err)
+	}
+	if _, err := writer.Write([]byte(message + "\n")); err != nil {
+		return fmt.Errorf("failed to write note: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write note: %w", err)
+	}
+	blobHash, err := g.repo.Storer.SetEncodedObject(blob)
+	if err != nil {
+		return fmt.Errorf("failed to store note: %w", err)
+	}
+
+	entries = append(entries, object.TreeEntry{Name: target, Mode: filemode.Regular, Hash: blobHash})
+	// plain name order matches git tree order, fanout directory names are prefixes of note names
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	treeHash, err := g.storeObject(&object.Tree{Entries: entries})
+	if err != nil {
+		return fmt.Errorf("failed to store notes tree: %w", err)
+	}
+
+	signature := object.Signature{Name: config.UserName, Email: config.UserEmail, When: time.Now()}
+	commitHash, err := g.storeObject(&object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      "Notes added by 'commit'\n",
+		TreeHash:     treeHash,
+		ParentHashes: parents,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store notes commit: %w", err)
+	}
+
+	newRef := plumbing.NewHashReference(plumbing.ReferenceName(notesRef), commitHash)
+	if err := g.repo.Storer.CheckAndSetReference(newRef, ref); err != nil {
+		return fmt.Errorf("failed to update notes %s: %w", notesRef, err)
+	}
+
+	return nil
+}
+
+// storeObject encodes object into repository storage
+func (g *Operations) storeObject(o interface {
+	Encode(plumbing.EncodedObject) error
+}) (plumbing.Hash, error) {
+	obj := g.repo.Storer.NewEncodedObject()
+	if err := o.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return g.repo.Storer.SetEncodedObject(obj)
+}
@@ -0,0 +1,68 @@
+package gitops
+
+import (
+	"strings"
+	"testing"
+)
+
+const testNotesRef = "refs/notes/commit-ai"
+
+func TestGitOperations_AddNote(t *testing.T) {
+	for _, pureGo := range []bool{false, true} {
+		t.Run(map[bool]string{false: "git", true: "pure go"}[pureGo], func(t *testing.T) {
+			g, dir := newTestGitOperations(t)
+			g.pureGo = pureGo
+			t.Chdir(dir)
+			runTestGit(t, dir, "config", "user.name", "Test")
+			runTestGit(t, dir, "config", "user.email", "test@example.com")
+
+			first := commitTestFile(t, dir, "a.txt", "a\n", "first")
+			if err := g.AddNote(testNotesRef, "Provider: claude"); err != nil {
+				t.Fatalf("AddNote() error = %v", err)
+			}
+
+			commitTestFile(t, dir, "b.txt", "b\n", "second")
+			if err := g.AddNote(testNotesRef, "Provider: openai"); err != nil {
+				t.Fatalf("AddNote() error = %v", err)
+			}
+			// replacing note keeps a single entry per commit
+			if err := g.AddNote(testNotesRef, "Provider: gemini"); err != nil {
+				t.Fatalf("AddNote() replace error = %v", err)
+			}
+
+			if got := runTestGit(t, dir, "notes", "--ref", testNotesRef, "show", first); got != "Provider: claude" {
+				t.Errorf("note of first commit = %q, want %q", got, "Provider: claude")
+			}
+			if got := runTestGit(t, dir, "notes", "--ref", testNotesRef, "show", "HEAD"); got != "Provider: gemini" {
+				t.Errorf("note of HEAD = %q, want %q", got, "Provider: gemini")
+			}
+			if got := runTestGit(t, dir, "notes", "--ref", testNotesRef, "list"); len(strings.Split(got, "\n")) != 2 {
+				t.Errorf("notes list = %q, want 2 notes", got)
+			}
+		})
+	}
+}
+
+func TestGitOperations_GetNote(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+	runTestGit(t, dir, "config", "user.name", "Test")
+	runTestGit(t, dir, "config", "user.email", "test@example.com")
+
+	first := commitTestFile(t, dir, "a.txt", "a\n", "first")
+	if got := g.GetNote(testNotesRef, first); got != "" {
+		t.Errorf("GetNote() without notes ref = %q, want empty", got)
+	}
+
+	if err := g.AddNote(testNotesRef, "Generated-By: commit"); err != nil {
+		t.Fatalf("AddNote() error = %v", err)
+	}
+	second := commitTestFile(t, dir, "b.txt", "b\n", "second")
+
+	if got := g.GetNote(testNotesRef, first); got != "Generated-By: commit" {
+		t.Errorf("GetNote() = %q, want %q", got, "Generated-By: commit")
+	}
+	if got := g.GetNote(testNotesRef, second); got != "" {
+		t.Errorf("GetNote() of commit without note = %q, want empty", got)
+	}
+}
@@ -0,0 +1,152 @@
+package gitops
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// lockFiles maps well known dependency lockfiles to a description of their change
+var lockFiles = map[string]string{
+	"go.sum":            "dependency checksums updated",
+	"go.work.sum":       "dependency checksums updated",
+	"package-lock.json": "npm lockfile updated",
+	"yarn.lock":         "yarn lockfile updated",
+	"pnpm-lock.yaml":    "pnpm lockfile updated",
+	"bun.lockb":         "bun lockfile updated",
+	"Cargo.lock":        "cargo lockfile updated",
+	"Gemfile.lock":      "bundler lockfile updated",
+	"composer.lock":     "composer lockfile updated",
+	"poetry.lock":       "poetry lockfile updated",
+	"uv.lock":           "uv lockfile updated",
+	"Pipfile.lock":      "pipenv lockfile updated",
+}
+
+// IsLockFile reports whether file name is one of well known dependency lockfiles
+func IsLockFile(name string) bool {
+	return lockFiles[name] != ""
+}
+
+// opaqueFileSummary describes a staged file whose raw diff is useless in prompts
+type opaqueFileSummary struct {
+	Path        string
+	Description string
+}
+
+// summarizeOpaqueFiles finds LFS and binary files, lockfiles and files marked linguist-generated
+// among staged files and describes each of them with a single line
+func (g *Operations) summarizeOpaqueFiles(files []string) ([]opaqueFileSummary, error) {
+	stats, err := g.getStagedNumstat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged numstat: %w", err)
+	}
+
+	attributes, err := g.getFileAttributes(files, "linguist-generated", "filter")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check file attributes: %w", err)
+	}
+
+	var summaries []opaqueFileSummary
+	for _, file := range files {
+		stat, ok := stats[file]
+		switch {
+		case attributes[file]["filter"] == "lfs":
+			// diff of LFS files is only a pointer to the object
+			summaries = append(summaries, opaqueFileSummary{Path: file, Description: "Git LFS object updated"})
+		case ok && stat.binary:
+			summaries = append(summaries, opaqueFileSummary{Path: file, Description: "binary file updated"})
+		case lockFiles[path.Base(file)] != "":
+			summaries = append(summaries, opaqueFileSummary{Path: file, Description: lockFiles[path.Base(file)]})
+		case isAttributeSet(attributes[file]["linguist-generated"]):
+			summaries = append(summaries, opaqueFileSummary{
+				Path:        file,
+				Description: fmt.Sprintf("generated file updated (+%d -%d lines)", stat.added, stat.deleted),
+			})
+		}
+	}
+
+	return summaries, nil
+}
+
+type numstat struct {
+	added   int
+	deleted int
+	binary  bool
+}
+
+// getStagedNumstat returns added and deleted line counts per staged file, binary files have no counts
+func (g *Operations) getStagedNumstat() (map[string]numstat, error) {
+	// -z keeps paths unquoted, renames are reported as old and new path
+	output, err := g.gitCommand("diff", "--cached", "--numstat", "-z", "--no-renames").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]numstat)
+	for _, record := range strings.Split(string(output), "\x00") {
+		fields := strings.SplitN(record, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		var stat numstat
+		if fields[0] == "-" && fields[1] == "-" {
+			stat.binary = true
+		} else {
+			_, _ = fmt.Sscanf(fields[0]+" "+fields[1], "%d %d", &stat.added, &stat.deleted)
+		}
+		stats[fields[2]] = stat
+	}
+
+	return stats, nil
+}
+
+// getFileAttributes returns values of requested gitattributes for files, as seen in the index,
+// unspecified attributes are omitted
+func (g *Operations) getFileAttributes(files []string, attributes ...string) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string)
+	if len(files) == 0 {
+		return result, nil
+	}
+
+	args := append([]string{"check-attr", "-z", "--cached"}, attributes...)
+	args = append(args, "--")
+	args = append(args, files...)
+	output, err := g.gitCommand(args...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	// -z output is a sequence of path, attribute, value triplets
+	fields := strings.Split(string(output), "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		if fields[i+2] == "unspecified" {
+			continue
+		}
+		if result[fields[i]] == nil {
+			result[fields[i]] = make(map[string]string)
+		}
+		result[fields[i]][fields[i+1]] = fields[i+2]
+	}
+
+	return result, nil
+}
+
+func isAttributeSet(value string) bool {
+	return value == "set" || value == "true"
+}
+
+// formatOpaqueSummaries renders one line per opaque file for prompts
+func formatOpaqueSummaries(summaries []opaqueFileSummary) string {
+	if len(summaries) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("other changed files (content omitted):\n")
+	for _, summary := range summaries {
+		b.WriteString(summary.Path + ": " + summary.Description + "\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGitOperations_GetStagedDiff_OpaqueFiles(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	commitTestFile(t, dir, "main.go", "package main\n", "init")
+
+	files := map[string][]byte{
+		"main.go":            []byte("package main\n\nfunc main() {}\n"),
+		"go.sum":             []byte("example.com/mod v1.0.0 h1:abc=\n"),
+		"web/yarn.lock":      []byte("lodash@^4:\n  version \"4.17.21\"\n"),
+		"logo.png":           {0x89, 'P', 'N', 'G', 0x00, 0x01, 0x02},
+		"api/api.pb.go":      []byte("// Code generated. DO NOT EDIT.\npackage api\n"),
+		".gitattributes":     []byte("*.pb.go linguist-generated\n"),
+		"docs/not_generated": []byte("plain text\n"),
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	runTestGit(t, dir, "add", "-A")
+
+	diff, err := g.GetStagedDiff(64*1024, 40)
+	if err != nil {
+		t.Fatalf("GetStagedDiff() error = %v", err)
+	}
+
+	expectedLines := []string{
+		"go.sum: dependency checksums updated",
+		"web/yarn.lock: yarn lockfile updated",
+		"logo.png: binary file updated",
+		"api/api.pb.go: generated file updated (+2 -0 lines)",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(diff, line) {
+			t.Errorf("diff does not contain %q:\n%s", line, diff)
+		}
+	}
+
+	for _, content := range []string{"h1:abc=", "lodash", "package api"} {
+		if strings.Contains(diff, content) {
+			t.Errorf("diff contains raw content %q of opaque file:\n%s", content, diff)
+		}
+	}
+
+	for _, content := range []string{"+func main() {}", "+plain text"} {
+		if !strings.Contains(diff, content) {
+			t.Errorf("diff does not contain regular change %q:\n%s", content, diff)
+		}
+	}
+}
+
+func TestFormatOpaqueSummaries(t *testing.T) {
+	if got := formatOpaqueSummaries(nil); got != "" {
+		t.Errorf("formatOpaqueSummaries(nil) = %q, want empty", got)
+	}
+
+	got := formatOpaqueSummaries([]opaqueFileSummary{
+		{Path: "go.sum", Description: "dependency checksums updated"},
+	})
+	expected := "other changed files (content omitted):\ngo.sum: dependency checksums updated\n\n"
+	if got != expected {
+		t.Errorf("formatOpaqueSummaries() = %q, want %q", got, expected)
+	}
+}
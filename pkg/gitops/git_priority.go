@@ -0,0 +1,95 @@
+package gitops
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// lowPriorityDirs hold vendored, built or generated code, their diffs say least about intent of a change
+var lowPriorityDirs = []string{
+	"vendor/", "third_party/", "node_modules/", "dist/", "build/", "mocks/", "testdata/", "__snapshots__/",
+}
+
+// lowPrioritySuffixes mark generated, minified and snapshot files
+var lowPrioritySuffixes = []string{
+	".pb.go", "_gen.go", ".gen.go", "_generated.go", "_mock.go",
+	".min.js", ".min.css", ".map", ".snap", ".svg", ".lock",
+}
+
+// isLowPriorityPath reports whether diff of file is first to drop when diff does not fit
+func isLowPriorityPath(file string) bool {
+	if lockFiles[path.Base(file)] != "" {
+		return true
+	}
+	for _, dir := range lowPriorityDirs {
+		if strings.HasPrefix(file, dir) || strings.Contains(file, "/"+dir) {
+			return true
+		}
+	}
+	for _, suffix := range lowPrioritySuffixes {
+		if strings.HasSuffix(file, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrioritizeDiff fits diff into maxSizeBytes by keeping whole files, source files before
+// vendored and generated ones and smaller files before larger ones, omitted files are listed
+// after truncation marker, diff which has no complete file fitting is cut on line boundary
+func PrioritizeDiff(diff string, maxSizeBytes int) string {
+	if len(diff) <= maxSizeBytes {
+		return diff
+	}
+
+	type section struct {
+		index int
+		text  string
+		file  string
+		low   bool
+	}
+	var sections []section
+	for i, text := range splitBefore(diff, "diff --git ") {
+		var file string
+		if files := DiffFiles(text); len(files) > 0 {
+			file = files[0]
+		}
+		sections = append(sections, section{index: i, text: text, file: file, low: isLowPriorityPath(file)})
+	}
+
+	ordered := append([]section(nil), sections...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].low != ordered[j].low {
+			return !ordered[i].low
+		}
+		return len(ordered[i].text) < len(ordered[j].text)
+	})
+
+	budget := maxSizeBytes - len(DiffTruncatedMarker)
+	included := make(map[int]bool, len(sections))
+	for _, s := range ordered {
+		if len(s.text) <= budget {
+			included[s.index] = true
+			budget -= len(s.text)
+		}
+	}
+	if len(included) == 0 {
+		return truncateDiff(diff, maxSizeBytes)
+	}
+
+	// included files keep their order, omitted ones are named so providers know about them
+	var b strings.Builder
+	var omitted strings.Builder
+	for _, s := range sections {
+		if included[s.index] {
+			b.WriteString(s.text)
+		} else if s.file != "" {
+			omitted.WriteString("omitted: " + s.file + "\n")
+		}
+	}
+	b.WriteString(DiffTruncatedMarker)
+	b.WriteString(omitted.String())
+
+	return truncateLines(b.String(), maxSizeBytes)
+}
@@ -0,0 +1,91 @@
+package gitops
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsLowPriorityPath(t *testing.T) {
+	tests := []struct {
+		file string
+		want bool
+	}{
+		{"main.go", false},
+		{"pkg/commit/git.go", false},
+		{"vendor/github.com/x/y.go", true},
+		{"web/node_modules/lib/index.js", true},
+		{"api/v1/service.pb.go", true},
+		{"pkg/commit/mocks/mocks.go", true},
+		{"static/app.min.js", true},
+		{"yarn.lock", true},
+		{"docs/build.md", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			if got := isLowPriorityPath(tt.file); got != tt.want {
+				t.Errorf("isLowPriorityPath(%q) = %v, want %v", tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrioritizeDiff(t *testing.T) {
+	small := "diff --git a.go a.go\n@@ -1 +1 @@\n-a\n+b\n"
+	large := "diff --git b.go b.go\n@@ -1,3 +1,3 @@\n-one\n+uno\n-two\n+dos\n-three\n+tres\n"
+	vendored := "diff --git vendor/x.go vendor/x.go\n@@ -1 +1 @@\n-x\n+y\n"
+
+	tests := []struct {
+		name        string
+		diff        string
+		max         int
+		want        []string
+		wantMissing []string
+	}{
+		{
+			name: "fits",
+			diff: small + large,
+			max:  1024,
+			want: []string{small + large},
+		},
+		{
+			name:        "source before vendored",
+			diff:        vendored + small,
+			max:         len(small) + len(DiffTruncatedMarker) + len("omitted: vendor/x.go\n"),
+			want:        []string{small, DiffTruncatedMarker, "omitted: vendor/x.go"},
+			wantMissing: []string{"-x\n"},
+		},
+		{
+			name:        "smaller files first, order kept",
+			diff:        large + small + vendored,
+			max:         len(small) + len(vendored) + len(DiffTruncatedMarker) + len("omitted: b.go\n"),
+			want:        []string{small + vendored + DiffTruncatedMarker + "omitted: b.go\n"},
+			wantMissing: []string{"uno"},
+		},
+		{
+			name: "single file cut",
+			diff: large,
+			max:  len(large) - 5,
+			want: []string{"diff --git b.go b.go\n", DiffTruncatedMarker},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PrioritizeDiff(tt.diff, tt.max)
+			if len(got) > tt.max {
+				t.Errorf("PrioritizeDiff() has %d bytes, max %d", len(got), tt.max)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("PrioritizeDiff() = %q, want it to contain %q", got, want)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(got, missing) {
+					t.Errorf("PrioritizeDiff() = %q, want it not to contain %q", got, missing)
+				}
+			}
+		})
+	}
+}
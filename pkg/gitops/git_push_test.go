@@ -0,0 +1,166 @@
+package gitops
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestGitOperations_Push(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	remoteDir := t.TempDir()
+	runTestGit(t, remoteDir, "init", "--bare")
+	runTestGit(t, dir, "remote", "add", "upstream", remoteDir)
+
+	commitTestFile(t, dir, "a.txt", "a\n", "init")
+	runTestGit(t, dir, "checkout", "-b", "feature")
+
+	if g.hasUpstream("feature") {
+		t.Fatalf("hasUpstream() = true before first push")
+	}
+
+	if _, err := g.Push("upstream", false, true); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	if !g.hasUpstream("feature") {
+		t.Errorf("hasUpstream() = false after push with setUpstream")
+	}
+
+	remoteHead := runTestGit(t, remoteDir, "rev-parse", "refs/heads/feature")
+	localHead := runTestGit(t, dir, "rev-parse", "HEAD")
+	if remoteHead != localHead {
+		t.Errorf("remote head = %s, want %s", remoteHead, localHead)
+	}
+
+	// Rewritten history is accepted with lease, since remote did not move
+	runTestGit(t, dir, "commit", "--amend", "-m", "amended")
+	if _, err := g.Push("upstream", true, false); err != nil {
+		t.Fatalf("Push() with force-with-lease error = %v", err)
+	}
+
+	runTestGit(t, dir, "tag", "v1.0.0")
+	if err := g.PushTag("v1.0.0", "upstream"); err != nil {
+		t.Fatalf("PushTag() error = %v", err)
+	}
+	runTestGit(t, remoteDir, "rev-parse", "refs/tags/v1.0.0")
+}
+
+func TestParseDivergence(t *testing.T) {
+	tests := []struct {
+		name           string
+		output         string
+		expectedAhead  int
+		expectedBehind int
+		wantErr        bool
+	}{
+		{name: "in sync", output: "0\t0\n", expectedAhead: 0, expectedBehind: 0},
+		{name: "ahead", output: "2\t0\n", expectedAhead: 2, expectedBehind: 0},
+		{name: "diverged", output: "1\t3\n", expectedAhead: 1, expectedBehind: 3},
+		{name: "garbage", output: "fatal", wantErr: true},
+		{name: "not a number", output: "a\tb", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ahead, behind, err := parseDivergence(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDivergence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ahead != tt.expectedAhead || behind != tt.expectedBehind {
+				t.Errorf("parseDivergence() = %d, %d, want %d, %d",
+					ahead, behind, tt.expectedAhead, tt.expectedBehind)
+			}
+		})
+	}
+}
+
+func TestGitOperations_GetRemoteDivergence(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+	setTestGitIdentity(t)
+
+	remoteDir := t.TempDir()
+	runTestGit(t, remoteDir, "init", "--bare")
+	runTestGit(t, dir, "remote", "add", "origin", remoteDir)
+
+	commitTestFile(t, dir, "a.txt", "a\n", "init")
+
+	// Branch does not exist on remote yet
+	ahead, behind, err := g.GetRemoteDivergence("")
+	if err != nil || ahead != 0 || behind != 0 {
+		t.Fatalf("GetRemoteDivergence() = %d, %d, %v, want 0, 0, nil", ahead, behind, err)
+	}
+
+	branch := runTestGit(t, dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if g.HasRemoteBranch("origin", branch) {
+		t.Errorf("HasRemoteBranch() = true before push, want false")
+	}
+	runTestGit(t, dir, "push", "origin", branch)
+	if !g.HasRemoteBranch("origin", branch) {
+		t.Errorf("HasRemoteBranch() = false after push, want true")
+	}
+
+	// Another clone pushes a commit
+	otherDir := t.TempDir()
+	runTestGit(t, otherDir, "clone", remoteDir, ".")
+	commitTestFile(t, otherDir, "b.txt", "b\n", "remote change")
+	runTestGit(t, otherDir, "push", "origin", branch)
+
+	commitTestFile(t, dir, "c.txt", "c\n", "local change")
+
+	ahead, behind, err = g.GetRemoteDivergence("")
+	if err != nil {
+		t.Fatalf("GetRemoteDivergence() error = %v", err)
+	}
+	if ahead != 1 || behind != 1 {
+		t.Errorf("GetRemoteDivergence() = %d, %d, want 1, 1", ahead, behind)
+	}
+
+	if err := g.PullRebase(""); err != nil {
+		t.Fatalf("PullRebase() error = %v", err)
+	}
+
+	ahead, behind, err = g.GetRemoteDivergence("")
+	if err != nil {
+		t.Fatalf("GetRemoteDivergence() error = %v", err)
+	}
+	if ahead != 1 || behind != 0 {
+		t.Errorf("GetRemoteDivergence() after rebase = %d, %d, want 1, 0", ahead, behind)
+	}
+}
+
+func TestGitOperations_Rollback(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	commitTestFile(t, dir, "a.txt", "a\n", "first")
+	commitTestFile(t, dir, "b.txt", "b\n", "second")
+	runTestGit(t, dir, "tag", "v1.0.0")
+
+	if err := g.DeleteTag("v1.0.0"); err != nil {
+		t.Fatalf("DeleteTag() error = %v", err)
+	}
+	if tags := runTestGit(t, dir, "tag", "--list"); tags != "" {
+		t.Errorf("tags after DeleteTag() = %q, want none", tags)
+	}
+
+	if err := g.UndoLastCommit(); err != nil {
+		t.Fatalf("UndoLastCommit() error = %v", err)
+	}
+	if subject := runTestGit(t, dir, "log", "-1", "--format=%s"); subject != "first" {
+		t.Errorf("HEAD after UndoLastCommit() = %q, want %q", subject, "first")
+	}
+	if staged := runTestGit(t, dir, "diff", "--cached", "--name-only"); staged != "b.txt" {
+		t.Errorf("staged files after UndoLastCommit() = %q, want %q", staged, "b.txt")
+	}
+
+	// Root commit
+	if err := g.UndoLastCommit(); err != nil {
+		t.Fatalf("UndoLastCommit() on root commit error = %v", err)
+	}
+	if err := exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD").Run(); err == nil {
+		t.Errorf("HEAD still exists after undoing root commit")
+	}
+}
@@ -0,0 +1,90 @@
+package gitops
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// GetRemoteDivergence fetches current branch from remote and returns how many commits
+// local branch is ahead and behind of it, missing remote branch is not an error
+func (g *Operations) GetRemoteDivergence(remote string) (int, int, error) {
+	if remote == "" {
+		remote = DefaultRemote
+	}
+
+	branch, err := g.GetCurrentBranch()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	// Check if branch exists on remote first, ls-remote exits with 2 when nothing matched
+	cmd := g.gitCommand("ls-remote", "--exit-code", "--heads", remote, branch)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 2 {
+			return 0, 0, nil
+		}
+		return 0, 0, fmt.Errorf("failed to query %s: %w\nOutput: %s", remote, err, string(output))
+	}
+
+	cmd = g.gitCommand("fetch", "--quiet", remote, branch)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch %s/%s: %w\nOutput: %s", remote, branch, err, string(output))
+	}
+
+	cmd = g.gitCommand("rev-list", "--left-right", "--count", "HEAD...FETCH_HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compare with %s/%s: %w", remote, branch, err)
+	}
+
+	return parseDivergence(string(output))
+}
+
+// HasRemoteBranch reports whether remote-tracking branch exists locally, as of the last fetch
+func (g *Operations) HasRemoteBranch(remote, branch string) bool {
+	_, err := g.repo.Reference(plumbing.NewRemoteReferenceName(remote, branch), true)
+	return err == nil
+}
+
+// parseDivergence parses `git rev-list --left-right --count` output
+func parseDivergence(output string) (int, int, error) {
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+
+	ahead, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+	behind, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+
+	return ahead, behind, nil
+}
+
+// PullRebase rebases local commits on top of remote branch, aborting on conflicts
+func (g *Operations) PullRebase(remote string) error {
+	if remote == "" {
+		remote = DefaultRemote
+	}
+
+	branch, err := g.GetCurrentBranch()
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	cmd := g.gitCommand("pull", "--rebase", "--autostash", remote, branch)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		_ = g.gitCommand("rebase", "--abort").Run()
+		return fmt.Errorf("failed to rebase onto %s/%s: %w\nOutput: %s", remote, branch, err, string(output))
+	}
+
+	return nil
+}
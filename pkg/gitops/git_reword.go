@@ -0,0 +1,170 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ResolveRewordTarget resolves ref to a full commit hash and verifies it can be reworded,
+// target must be reachable from HEAD and history after it must be linear
+func (g *Operations) ResolveRewordTarget(ref string) (string, error) {
+	if g.pureGo {
+		return "", fmt.Errorf("rewording commits %w", ErrRequiresGit)
+	}
+
+	sha, err := g.runGit("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown commit %s", ref)
+	}
+
+	if _, err := g.runGit("merge-base", "--is-ancestor", sha, "HEAD"); err != nil {
+		return "", fmt.Errorf("commit %s is not an ancestor of HEAD", ShortSHA(sha))
+	}
+
+	head, err := g.runGit("rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	// Rebase would flatten merges between target and HEAD
+	if sha != head {
+		merges, err := g.runGit("rev-list", "--merges", sha+"..HEAD")
+		if err == nil && merges != "" {
+			return "", fmt.Errorf("history after %s contains merge commits, cannot reword safely", ShortSHA(sha))
+		}
+	}
+
+	return sha, nil
+}
+
+// IsCommitPushed reports whether commit is reachable from any remote-tracking branch
+func (g *Operations) IsCommitPushed(sha string) (bool, error) {
+	output, err := g.runGit("branch", "--remotes", "--contains", sha)
+	if err != nil {
+		return false, fmt.Errorf("failed to check remote branches: %w", err)
+	}
+	return output != "", nil
+}
+
+// GetCommitDiff returns diff and changed files of a single commit,
+// reducing context to fit within maxSizeBytes
+func (g *Operations) GetCommitDiff(sha string, maxSizeBytes int) (string, []string, error) {
+	output, err := g.runGit("diff-tree", "--root", "--no-commit-id", "--name-only", "-r", sha)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get commit files: %w", err)
+	}
+
+	var files []string
+	for _, file := range strings.Split(output, "\n") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	var diff string
+	for _, contextLevel := range contextLevels {
+		args := []string{
+			"diff-tree", "--root", "--no-commit-id", "-p",
+			"--no-color", "--no-ext-diff", "--no-prefix",
+		}
+		args = append(args, g.diffOptions.args()...)
+		args = append(args, fmt.Sprintf("-U%d", contextLevel), sha)
+		diff, err = g.runGit(args...)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get commit diff: %w", err)
+		}
+		diff = g.diffOptions.prepare(diff)
+		if len(diff) <= maxSizeBytes {
+			return diff, files, nil
+		}
+	}
+
+	return PrioritizeDiff(diff, maxSizeBytes), files, nil
+}
+
+// RewordCommit replaces message of a commit, HEAD is amended in place,
+// older commits are rewritten with a non-interactive rebase
+func (g *Operations) RewordCommit(sha, message string, noVerify bool) error {
+	if g.pureGo {
+		return fmt.Errorf("rewording commits %w", ErrRequiresGit)
+	}
+
+	messageFile, err := os.CreateTemp("", "commit-reword-*.txt")
+	if err != nil {
+		return fmt.Errorf("failed to create message file: %w", err)
+	}
+	defer func() { _ = os.Remove(messageFile.Name()) }()
+
+	if _, err := messageFile.WriteString(message + "\n"); err != nil {
+		_ = messageFile.Close()
+		return fmt.Errorf("failed to write message file: %w", err)
+	}
+	if err := messageFile.Close(); err != nil {
+		return fmt.Errorf("failed to write message file: %w", err)
+	}
+
+	head, err := g.runGit("rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	if sha == head {
+		args := []string{"commit", "--amend", "--only", "--allow-empty", "-F", messageFile.Name()}
+		if noVerify {
+			args = append(args, "--no-verify")
+		}
+		if output, err := g.gitCommand(args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to amend commit: %w\nOutput: %s", err, string(output))
+		}
+		return nil
+	}
+
+	args := []string{"rebase", "--interactive", "--autostash", "--no-autosquash"}
+	if noVerify {
+		args = append(args, "--no-verify")
+	}
+	if _, err := g.runGit("rev-parse", "--verify", "--quiet", sha+"^"); err != nil {
+		args = append(args, "--root")
+	} else {
+		args = append(args, sha+"^")
+	}
+
+	// Sequence editor marks target commit for reword, editor replaces its message with prepared one.
+	// Todo list shows full hashes, abbreviated ones could match other commits.
+	cmd := g.gitCommand(append([]string{"-c", "core.abbrev=no"}, args...)...)
+	cmd.Env = append(
+		cmd.Environ(),
+		"GIT_SEQUENCE_EDITOR="+rewordSequenceEditor(sha),
+		"GIT_EDITOR=cp "+shellQuote(messageFile.Name()),
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		_ = g.gitCommand("rebase", "--abort").Run()
+		return fmt.Errorf("failed to rebase: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// rewordSequenceEditor returns shell function which switches target commit from pick to reword,
+// backup needed by BSD sed is removed and missing target fails the rebase instead of doing nothing
+func rewordSequenceEditor(sha string) string {
+	return fmt.Sprintf(
+		`reword_todo() { sed -i.bak -e 's/^pick %[1]s /reword %[1]s /' "$1" && rm -f "$1.bak" && `+
+			`grep -q '^reword %[1]s ' "$1"; }; reword_todo`,
+		sha,
+	)
+}
+
+// runGit runs git command and returns its trimmed standard output
+func (g *Operations) runGit(args ...string) (string, error) {
+	output, err := g.gitCommand(args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,150 @@
+package gitops
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runTestGit(t testing.TB, dir string, args ...string) string {
+	t.Helper()
+
+	args = append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// setTestGitIdentity provides identity for git commands which create commits internally
+func setTestGitIdentity(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+}
+
+func commitTestFile(t *testing.T, dir, name, content, message string) string {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	runTestGit(t, dir, "add", name)
+	runTestGit(t, dir, "commit", "--no-verify", "-m", message)
+	return runTestGit(t, dir, "rev-parse", "HEAD")
+}
+
+func TestGitOperations_RewordCommit(t *testing.T) {
+	tests := []struct {
+		name   string
+		target int // index of commit to reword
+	}{
+		{name: "root commit", target: 0},
+		{name: "middle commit", target: 1},
+		{name: "head commit", target: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, dir := newTestGitOperations(t)
+			t.Chdir(dir)
+			setTestGitIdentity(t)
+
+			shas := []string{
+				commitTestFile(t, dir, "a.txt", "a\n", "first"),
+				commitTestFile(t, dir, "b.txt", "b\n", "second"),
+				commitTestFile(t, dir, "c.txt", "c\n", "third"),
+			}
+
+			sha, err := g.ResolveRewordTarget(shas[tt.target])
+			if err != nil {
+				t.Fatalf("ResolveRewordTarget() error = %v", err)
+			}
+
+			if err := g.RewordCommit(sha, "feat: reworded", true); err != nil {
+				t.Fatalf("RewordCommit() error = %v", err)
+			}
+
+			subjects := strings.Split(runTestGit(t, dir, "log", "--reverse", "--format=%s"), "\n")
+			expected := []string{"first", "second", "third"}
+			expected[tt.target] = "feat: reworded"
+
+			if strings.Join(subjects, ",") != strings.Join(expected, ",") {
+				t.Errorf("history = %v, want %v", subjects, expected)
+			}
+		})
+	}
+}
+
+func TestGitOperations_RewordCommit_PureGo(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	g.pureGo = true
+	t.Chdir(dir)
+
+	sha := commitTestFile(t, dir, "a.txt", "a\n", "first")
+
+	if _, err := g.ResolveRewordTarget(sha); !errors.Is(err, ErrRequiresGit) {
+		t.Errorf("ResolveRewordTarget() error = %v, want %v", err, ErrRequiresGit)
+	}
+	if err := g.RewordCommit(sha, "feat: reworded", true); !errors.Is(err, ErrRequiresGit) {
+		t.Errorf("RewordCommit() error = %v, want %v", err, ErrRequiresGit)
+	}
+}
+
+func TestRewordSequenceEditor(t *testing.T) {
+	target := "abcdef1234567890abcdef1234567890abcdef12"
+	similar := "abcdef1999999999999999999999999999999999"
+	todo := filepath.Join(t.TempDir(), "git-rebase-todo")
+	content := "pick " + similar + " other\npick " + target + " target\n"
+	if err := os.WriteFile(todo, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write todo: %v", err)
+	}
+
+	// git runs editors with shell the same way
+	editor := rewordSequenceEditor(target)
+	if output, err := exec.Command("sh", "-c", editor+` "$@"`, editor, todo).CombinedOutput(); err != nil {
+		t.Fatalf("sequence editor failed: %v\n%s", err, output)
+	}
+
+	got, err := os.ReadFile(todo)
+	if err != nil {
+		t.Fatalf("Failed to read todo: %v", err)
+	}
+	if want := "pick " + similar + " other\nreword " + target + " target\n"; string(got) != want {
+		t.Errorf("todo = %q, want %q", got, want)
+	}
+	if _, err := os.Stat(todo + ".bak"); !os.IsNotExist(err) {
+		t.Errorf("backup of todo is left, stat error = %v", err)
+	}
+
+	// missing target must fail rebase instead of leaving history unchanged
+	missing := rewordSequenceEditor("0123456789012345678901234567890123456789")
+	if err := exec.Command("sh", "-c", missing+` "$@"`, missing, todo).Run(); err == nil {
+		t.Error("sequence editor of missing target succeeded, want error")
+	}
+}
+
+func TestGitOperations_GetCommitDiff(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	sha := commitTestFile(t, dir, "main.go", "package main\n", "init")
+
+	diff, files, err := g.GetCommitDiff(sha, 64*1024)
+	if err != nil {
+		t.Fatalf("GetCommitDiff() error = %v", err)
+	}
+	if len(files) != 1 || files[0] != "main.go" {
+		t.Errorf("files = %v, want [main.go]", files)
+	}
+	if !strings.Contains(diff, "+package main") {
+		t.Errorf("diff does not contain added line: %q", diff)
+	}
+}
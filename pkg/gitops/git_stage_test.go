@@ -0,0 +1,183 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestGitOperations_StageFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		exclude []string
+		include []string
+		want    []string
+	}{
+		{
+			name: "all modified",
+			want: []string{"gone.go", "main.go", "new.go", "notes.txt"},
+		},
+		{
+			name:    "simple glob",
+			include: []string{"*.go"},
+			want:    []string{"gone.go", "main.go", "new.go"},
+		},
+		{
+			name:    "filtered",
+			exclude: []string{"*.txt", "new.go"},
+			want:    []string{"gone.go", "main.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, pureGo := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/pure go %v", tt.name, pureGo), func(t *testing.T) {
+				g, dir := newTestGitOperations(t)
+				g.pureGo = pureGo
+				commitTestFile(t, dir, "main.go", "package main\n", "init")
+				commitTestFile(t, dir, "gone.go",
+					"package gone\n\nfunc Gone() string {\n\treturn \"gone\"\n}\n", "add file to delete")
+				commitTestFile(t, dir, "same.go", "package main\n", "add unchanged file")
+
+				writeTestFiles(t, dir, map[string]string{
+					"main.go":    "package main\n\nfunc main() {}\n",
+					"new.go":     "// Package fresh is new\npackage fresh\n",
+					"notes.txt":  "notes\n",
+					"ignored.go": "package main\n",
+					".gitignore": "ignored.go\n",
+				})
+				runTestGit(t, dir, "add", ".gitignore")
+				runTestGit(t, dir, "commit", "--no-verify", "-m", "ignore")
+				if err := os.Remove(filepath.Join(dir, "gone.go")); err != nil {
+					t.Fatal(err)
+				}
+
+				got, err := g.StageFiles(tt.exclude, tt.include, false, "")
+				if err != nil {
+					t.Fatalf("StageFiles() error = %v", err)
+				}
+				sort.Strings(got)
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("StageFiles() = %v, want %v", got, tt.want)
+				}
+
+				t.Chdir(dir)
+				staged, err := g.GetFilteredStagedFiles()
+				if err != nil {
+					t.Fatalf("GetFilteredStagedFiles() error = %v", err)
+				}
+				if !reflect.DeepEqual(staged, tt.want) {
+					t.Errorf("staged files = %v, want %v", staged, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestGitOperations_GetIndexHash(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	commitTestFile(t, dir, "main.go", "package main\n", "init")
+
+	hash := func() string {
+		t.Helper()
+		got, err := g.GetIndexHash()
+		if err != nil {
+			t.Fatalf("GetIndexHash() unexpected error = %v", err)
+		}
+		return got
+	}
+
+	initial := hash()
+	if again := hash(); again != initial {
+		t.Errorf("GetIndexHash() of unchanged index = %s, want %s", again, initial)
+	}
+
+	// unstaged changes do not affect staged content
+	writeTestFiles(t, dir, map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+	if got := hash(); got != initial {
+		t.Errorf("GetIndexHash() after unstaged change = %s, want %s", got, initial)
+	}
+
+	runTestGit(t, dir, "add", "main.go")
+	if got := hash(); got == initial {
+		t.Error("GetIndexHash() did not change after staging a change")
+	}
+}
+
+func writeTestFiles(t testing.TB, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// newBenchmarkRepo creates repository with files committed in nested directories,
+// and modifies every tenth of them
+func newBenchmarkRepo(b *testing.B, files int) (*Operations, string) {
+	b.Helper()
+
+	dir := b.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		b.Fatalf("Failed to init repository: %v", err)
+	}
+
+	contents := make(map[string]string, files)
+	for i := 0; i < files; i++ {
+		contents[fmt.Sprintf("pkg%02d/file%05d.go", i%50, i)] = fmt.Sprintf("package pkg\n\nconst v%d = %d\n", i, i)
+	}
+	writeTestFiles(b, dir, contents)
+	runTestGit(b, dir, "add", ".")
+	runTestGit(b, dir, "commit", "--no-verify", "-q", "-m", "init")
+
+	modified := make(map[string]string, files/10)
+	for i := 0; i < files; i += 10 {
+		modified[fmt.Sprintf("pkg%02d/file%05d.go", i%50, i)] = fmt.Sprintf("package pkg\n\nconst v%d = %d\n", i, -i)
+	}
+	writeTestFiles(b, dir, modified)
+
+	return &Operations{repo: repo, root: dir}, dir
+}
+
+func BenchmarkGitOperations_StageFiles(b *testing.B) {
+	benchmarks := []struct {
+		name    string
+		exclude []string
+		include []string
+	}{
+		{name: "all modified"},
+		{name: "simple glob", include: []string{"*.go"}},
+		{name: "filtered", exclude: []string{"pkg01/*"}},
+	}
+
+	for _, size := range []int{1000, 5000} {
+		g, _ := newBenchmarkRepo(b, size)
+		for _, bm := range benchmarks {
+			b.Run(fmt.Sprintf("%s/%d files", bm.name, size), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					if err := g.UnstageAll(); err != nil {
+						b.Fatalf("UnstageAll() error = %v", err)
+					}
+					b.StartTimer()
+
+					if _, err := g.StageFiles(bm.exclude, bm.include, false, ""); err != nil {
+						b.Fatalf("StageFiles() error = %v", err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stashMessage marks stash entries created by this tool
+const stashMessage = "commit: unrelated worktree changes"
+
+// StashUnstaged moves unstaged and untracked changes aside, keeping the index and staged
+// files in the worktree intact, returns false when there was nothing to stash
+func (g *Operations) StashUnstaged() (bool, error) {
+	if g.pureGo {
+		return false, fmt.Errorf("stashing changes %w", ErrRequiresGit)
+	}
+
+	before, _ := g.runGit("rev-parse", "--quiet", "--verify", "refs/stash")
+
+	cmd := g.gitCommand(
+		"stash", "push",
+		"--keep-index", "--include-untracked",
+		"--message", stashMessage,
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("failed to stash changes: %w\nOutput: %s", err, string(output))
+	}
+
+	after, _ := g.runGit("rev-parse", "--quiet", "--verify", "refs/stash")
+
+	return after != "" && after != before, nil
+}
+
+// RestoreStash brings back changes saved by StashUnstaged
+func (g *Operations) RestoreStash() error {
+	if g.pureGo {
+		return fmt.Errorf("restoring stash %w", ErrRequiresGit)
+	}
+
+	// Verify that the latest entry is ours, never pop somebody else's stash
+	subject, err := g.runGit("log", "-1", "--format=%s", "refs/stash")
+	if err != nil {
+		return fmt.Errorf("failed to read stash: %w", err)
+	}
+	if !strings.HasSuffix(subject, stashMessage) {
+		return fmt.Errorf("latest stash entry %q was not created by commit", subject)
+	}
+
+	cmd := g.gitCommand("stash", "pop")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to restore stashed changes: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
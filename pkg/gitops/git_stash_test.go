@@ -0,0 +1,99 @@
+package gitops
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitOperations_StashUnstaged(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+	setTestGitIdentity(t)
+
+	commitTestFile(t, dir, "selected.txt", "v1\n", "init")
+	commitTestFile(t, dir, "unrelated.txt", "v1\n", "second")
+
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	readFile := func(name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		return string(content)
+	}
+
+	writeFile("selected.txt", "v2\n")
+	writeFile("unrelated.txt", "v2\n")
+	writeFile("untracked.txt", "new\n")
+	runTestGit(t, dir, "add", "selected.txt")
+
+	stashed, err := g.StashUnstaged()
+	if err != nil {
+		t.Fatalf("StashUnstaged() error = %v", err)
+	}
+	if !stashed {
+		t.Fatal("StashUnstaged() = false, want true")
+	}
+
+	if got := readFile("selected.txt"); got != "v2\n" {
+		t.Errorf("selected.txt = %q, staged change must stay in worktree", got)
+	}
+	if got := readFile("unrelated.txt"); got != "v1\n" {
+		t.Errorf("unrelated.txt = %q, want unstaged change stashed", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "untracked.txt")); !os.IsNotExist(err) {
+		t.Error("untracked.txt should be stashed")
+	}
+
+	runTestGit(t, dir, "commit", "--no-verify", "-m", "selected")
+
+	if err := g.RestoreStash(); err != nil {
+		t.Fatalf("RestoreStash() error = %v", err)
+	}
+
+	if got := readFile("unrelated.txt"); got != "v2\n" {
+		t.Errorf("unrelated.txt = %q after restore, want %q", got, "v2\n")
+	}
+	if got := readFile("untracked.txt"); got != "new\n" {
+		t.Errorf("untracked.txt = %q after restore, want %q", got, "new\n")
+	}
+
+	if err := g.RestoreStash(); err == nil {
+		t.Error("RestoreStash() expected error when there is no stash left")
+	}
+}
+
+func TestGitOperations_RestoreStash_ForeignStash(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	commitTestFile(t, dir, "a.txt", "v1\n", "init")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("v2\n"), 0644); err != nil {
+		t.Fatalf("Failed to write a.txt: %v", err)
+	}
+	runTestGit(t, dir, "stash", "push", "--message", "my work")
+
+	if err := g.RestoreStash(); err == nil {
+		t.Error("RestoreStash() must refuse to pop stash it did not create")
+	}
+}
+
+func TestGitOperations_StashUnstaged_PureGo(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	g.pureGo = true
+	t.Chdir(dir)
+
+	commitTestFile(t, dir, "a.txt", "a\n", "init")
+
+	if _, err := g.StashUnstaged(); !errors.Is(err, ErrRequiresGit) {
+		t.Errorf("StashUnstaged() error = %v, want %v", err, ErrRequiresGit)
+	}
+}
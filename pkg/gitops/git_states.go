@@ -1,11 +1,14 @@
-package commit
+package gitops
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/go-git/go-git/v5/storage/filesystem"
 )
 
 const (
@@ -18,32 +21,10 @@ const (
 )
 
 // GetRepoState determines the current state of the repository
-func (g *gitOperations) GetRepoState() (string, error) {
-	// Get the worktree path
-	wt, err := g.repo.Worktree()
+func (g *Operations) GetRepoState() (string, error) {
+	gitDir, err := g.getGitDir()
 	if err != nil {
-		return RepoStateNormal, fmt.Errorf("failed to get worktree: %w", err)
-	}
-
-	gitDir := filepath.Join(wt.Filesystem.Root(), ".git")
-
-	// For worktrees, .git might be a file pointing to the actual git directory
-	if info, err := os.Stat(gitDir); err == nil && !info.IsDir() {
-		// Read the gitdir path from the file
-		content, err := os.ReadFile(gitDir)
-		if err != nil {
-			return RepoStateNormal, fmt.Errorf("failed to read .git file: %w", err)
-		}
-		// Parse "gitdir: /path/to/.git/worktrees/name"
-		gitDirLine := strings.TrimSpace(string(content))
-		if strings.HasPrefix(gitDirLine, "gitdir: ") {
-			gitDir = strings.TrimPrefix(gitDirLine, "gitdir: ")
-			// Get the common dir for worktrees
-			gitDir = filepath.Dir(gitDir)
-			if strings.Contains(gitDir, "/worktrees/") {
-				gitDir = filepath.Dir(filepath.Dir(gitDir))
-			}
-		}
+		return RepoStateNormal, err
 	}
 
 	// Check for rebase
@@ -75,15 +56,51 @@ func (g *gitOperations) GetRepoState() (string, error) {
 	return RepoStateNormal, nil
 }
 
-// HasConflicts checks if there are any unresolved merge conflicts
-func (g *gitOperations) HasConflicts() (bool, []string, error) {
-	// Get the worktree path
-	wt, err := g.repo.Worktree()
+// GetGitDir returns the common .git directory of the repository
+func (g *Operations) GetGitDir() (string, error) {
+	return g.getCommonGitDir()
+}
+
+// getGitDir resolves git directory the repository is stored in, it is the one given by GIT_DIR
+// or the per-worktree directory of linked worktrees, where state of operations in progress is kept
+func (g *Operations) getGitDir() (string, error) {
+	storage, ok := g.repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return "", fmt.Errorf("repository is not stored on filesystem")
+	}
+	return storage.Filesystem().Root(), nil
+}
+
+// getCommonGitDir resolves git directory shared by all worktrees, which holds hooks
+func (g *Operations) getCommonGitDir() (string, error) {
+	gitDir, err := g.getGitDir()
 	if err != nil {
-		return false, nil, fmt.Errorf("failed to get worktree: %w", err)
+		return "", err
 	}
 
-	cmd := exec.Command("git", "-C", wt.Filesystem.Root(), "diff", "--name-only", "--diff-filter=U")
+	// Linked worktrees name the common directory in commondir file, relative to their own
+	content, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return gitDir, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read commondir: %w", err)
+	}
+	commonDir := strings.TrimSpace(string(content))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+
+	return filepath.Clean(commonDir), nil
+}
+
+// HasConflicts checks if there are any unresolved merge conflicts
+func (g *Operations) HasConflicts() (bool, []string, error) {
+	if g.pureGo {
+		return g.hasConflictsViaStatus()
+	}
+
+	cmd := g.gitCommand("diff", "--name-only", "--diff-filter=U")
 	output, err := cmd.Output()
 	if err != nil {
 		// If the command fails, it might mean no conflicts or git error
@@ -108,7 +125,7 @@ func (g *gitOperations) HasConflicts() (bool, []string, error) {
 }
 
 // hasConflictsViaStatus is a fallback method using git status
-func (g *gitOperations) hasConflictsViaStatus() (bool, []string, error) {
+func (g *Operations) hasConflictsViaStatus() (bool, []string, error) {
 	files, err := g.GetConflictedFiles()
 	if err != nil {
 		return false, nil, err
@@ -117,14 +134,12 @@ func (g *gitOperations) hasConflictsViaStatus() (bool, []string, error) {
 }
 
 // GetConflictedFiles returns detailed information about conflicted files
-func (g *gitOperations) GetConflictedFiles() ([]string, error) {
-	// Get the worktree path
-	wt, err := g.repo.Worktree()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get worktree: %w", err)
+func (g *Operations) GetConflictedFiles() ([]string, error) {
+	if g.pureGo {
+		return g.getConflictedFilesNative()
 	}
 
-	cmd := exec.Command("git", "-C", wt.Filesystem.Root(), "status", "--porcelain")
+	cmd := g.gitCommand("status", "--porcelain")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git status: %w", err)
@@ -1,4 +1,4 @@
-package commit
+package gitops
 
 import (
 	"os"
@@ -51,12 +51,8 @@ func TestGetRepoState(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create temporary directory
-			tmpDir := t.TempDir()
+			g, tmpDir := newTestGitOperations(t)
 			gitDir := filepath.Join(tmpDir, ".git")
-			if err := os.MkdirAll(gitDir, 0755); err != nil {
-				t.Fatalf("Failed to create .git directory: %v", err)
-			}
 
 			// Setup test files
 			for _, file := range tt.setupFiles {
@@ -73,14 +69,7 @@ func TestGetRepoState(t *testing.T) {
 				}
 			}
 
-			// Test GetRepoState  - we need to mock the repo for testing
-			// For now, we'll skip the actual test execution since it requires
-			// a proper git.Repository mock
-			t.Skip("Requires proper git.Repository mock")
-
-			// Skip the actual execution - would need mock
-			state := tt.expectedState
-			err := error(nil)
+			state, err := g.GetRepoState()
 			if err != nil {
 				t.Errorf("GetRepoState() error = %v", err)
 				return
@@ -0,0 +1,167 @@
+package gitops
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	submoduleMode        = "160000"
+	submoduleShortSHALen = 7
+	submoduleMaxLogLines = 20
+)
+
+// submoduleChange describes a staged submodule pointer update
+type submoduleChange struct {
+	Path   string
+	OldSHA string // empty when submodule was added
+	NewSHA string // empty when submodule was removed
+}
+
+// GetSubmoduleSummary returns a human-readable summary of staged submodule pointer changes,
+// optionally including commit subjects between the old and new revisions
+func (g *Operations) GetSubmoduleSummary(includeLog bool) (string, error) {
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	root := wt.Filesystem.Root()
+
+	var changes []submoduleChange
+	if g.pureGo {
+		changes, err = g.getSubmoduleChangesNative()
+		if err != nil {
+			return "", fmt.Errorf("failed to get staged submodule changes: %w", err)
+		}
+	} else {
+		cmd := g.gitCommand("diff", "--cached", "--raw", "--no-abbrev")
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to get staged raw diff: %w", err)
+		}
+		changes = parseSubmoduleChanges(string(output))
+	}
+
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	for _, change := range changes {
+		var log []string
+		if includeLog && change.OldSHA != "" && change.NewSHA != "" {
+			// Submodule might not be checked out or fetched, log is best effort
+			submodulePath := filepath.Join(root, change.Path)
+			if g.pureGo {
+				log = getSubmoduleLogNative(submodulePath, change.OldSHA, change.NewSHA)
+			} else {
+				log = getSubmoduleLog(submodulePath, change.OldSHA, change.NewSHA)
+			}
+		}
+		b.WriteString(formatSubmoduleChange(change, log))
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// parseSubmoduleChanges extracts submodule entries from `git diff --raw` output
+func parseSubmoduleChanges(rawDiff string) []submoduleChange {
+	var changes []submoduleChange
+
+	scanner := bufio.NewScanner(strings.NewReader(rawDiff))
+	for scanner.Scan() {
+		// Format: ":<old mode> <new mode> <old sha> <new sha> <status>\t<path>"
+		line := scanner.Text()
+		if !strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		meta, path, found := strings.Cut(line[1:], "\t")
+		if !found {
+			continue
+		}
+
+		fields := strings.Fields(meta)
+		if len(fields) < 5 {
+			continue
+		}
+
+		oldMode, newMode, oldSHA, newSHA := fields[0], fields[1], fields[2], fields[3]
+		if oldMode != submoduleMode && newMode != submoduleMode {
+			continue
+		}
+
+		change := submoduleChange{Path: path}
+		if oldMode == submoduleMode && !isNullSHA(oldSHA) {
+			change.OldSHA = oldSHA
+		}
+		if newMode == submoduleMode && !isNullSHA(newSHA) {
+			change.NewSHA = newSHA
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// getSubmoduleLog returns commit subjects in old..new range of a checked out submodule
+func getSubmoduleLog(submodulePath, oldSHA, newSHA string) []string {
+	cmd := exec.Command(
+		"git", "-C", submodulePath,
+		"log", "--oneline", "--no-decorate",
+		fmt.Sprintf("-n%d", submoduleMaxLogLines),
+		oldSHA+".."+newSHA,
+	)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// formatSubmoduleChange renders a submodule change in a format similar to `git diff --submodule=log`
+func formatSubmoduleChange(change submoduleChange, log []string) string {
+	var b strings.Builder
+
+	switch {
+	case change.OldSHA == "":
+		b.WriteString(fmt.Sprintf("Submodule %s added at %s\n", change.Path, ShortSHA(change.NewSHA)))
+	case change.NewSHA == "":
+		b.WriteString(fmt.Sprintf("Submodule %s removed (was %s)\n", change.Path, ShortSHA(change.OldSHA)))
+	default:
+		b.WriteString(fmt.Sprintf(
+			"Submodule %s updated %s..%s\n",
+			change.Path, ShortSHA(change.OldSHA), ShortSHA(change.NewSHA),
+		))
+	}
+
+	for _, line := range log {
+		b.WriteString("  > " + line + "\n")
+	}
+
+	return b.String()
+}
+
+// ShortSHA abbreviates commit hash like git does by default
+func ShortSHA(sha string) string {
+	if len(sha) > submoduleShortSHALen {
+		return sha[:submoduleShortSHALen]
+	}
+	return sha
+}
+
+func isNullSHA(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
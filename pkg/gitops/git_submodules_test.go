@@ -0,0 +1,142 @@
+package gitops
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSubmoduleChanges(t *testing.T) {
+	const (
+		oldSHA  = "1111111111111111111111111111111111111111"
+		newSHA  = "2222222222222222222222222222222222222222"
+		nullSHA = "0000000000000000000000000000000000000000"
+	)
+
+	tests := []struct {
+		name     string
+		rawDiff  string
+		expected []submoduleChange
+	}{
+		{
+			name:     "empty diff",
+			rawDiff:  "",
+			expected: nil,
+		},
+		{
+			name:     "regular file change is ignored",
+			rawDiff:  ":100644 100644 " + oldSHA + " " + newSHA + " M\tmain.go\n",
+			expected: nil,
+		},
+		{
+			name:    "submodule updated",
+			rawDiff: ":160000 160000 " + oldSHA + " " + newSHA + " M\tlibs/core\n",
+			expected: []submoduleChange{
+				{Path: "libs/core", OldSHA: oldSHA, NewSHA: newSHA},
+			},
+		},
+		{
+			name:    "submodule added",
+			rawDiff: ":000000 160000 " + nullSHA + " " + newSHA + " A\tlibs/new\n",
+			expected: []submoduleChange{
+				{Path: "libs/new", NewSHA: newSHA},
+			},
+		},
+		{
+			name:    "submodule removed",
+			rawDiff: ":160000 000000 " + oldSHA + " " + nullSHA + " D\tlibs/old\n",
+			expected: []submoduleChange{
+				{Path: "libs/old", OldSHA: oldSHA},
+			},
+		},
+		{
+			name: "mixed changes",
+			rawDiff: ":100644 100644 " + oldSHA + " " + newSHA + " M\tgo.mod\n" +
+				":160000 160000 " + oldSHA + " " + newSHA + " M\tvendor/lib\n",
+			expected: []submoduleChange{
+				{Path: "vendor/lib", OldSHA: oldSHA, NewSHA: newSHA},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseSubmoduleChanges(tt.rawDiff)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseSubmoduleChanges() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatSubmoduleChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   submoduleChange
+		log      []string
+		expected string
+	}{
+		{
+			name:     "updated without log",
+			change:   submoduleChange{Path: "libs/core", OldSHA: "abcdef0123", NewSHA: "0123abcdef"},
+			expected: "Submodule libs/core updated abcdef0..0123abc\n",
+		},
+		{
+			name:     "updated with log",
+			change:   submoduleChange{Path: "libs/core", OldSHA: "abcdef0123", NewSHA: "0123abcdef"},
+			log:      []string{"0123abc fix parser", "9876543 add option"},
+			expected: "Submodule libs/core updated abcdef0..0123abc\n  > 0123abc fix parser\n  > 9876543 add option\n",
+		},
+		{
+			name:     "added",
+			change:   submoduleChange{Path: "libs/new", NewSHA: "0123abcdef"},
+			expected: "Submodule libs/new added at 0123abc\n",
+		},
+		{
+			name:     "removed",
+			change:   submoduleChange{Path: "libs/old", OldSHA: "abcdef0123"},
+			expected: "Submodule libs/old removed (was abcdef0)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatSubmoduleChange(tt.change, tt.log)
+			if result != tt.expected {
+				t.Errorf("formatSubmoduleChange() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitOperations_GetSubmoduleSummary(t *testing.T) {
+	for _, pureGo := range []bool{false, true} {
+		t.Run(map[bool]string{false: "git", true: "pure go"}[pureGo], func(t *testing.T) {
+			library := t.TempDir()
+			runTestGit(t, library, "init", "-q")
+			first := commitTestFile(t, library, "lib.go", "package lib\n", "first")
+			second := commitTestFile(t, library, "lib.go", "package lib\n\nvar A = 1\n", "add a")
+
+			g, dir := newTestGitOperations(t)
+			g.pureGo = pureGo
+			t.Chdir(dir)
+			runTestGit(t, dir, "-c", "protocol.file.allow=always", "submodule", "add", "-q", library, "libs/core")
+			runTestGit(t, filepath.Join(dir, "libs/core"), "checkout", "-q", first)
+			runTestGit(t, dir, "add", "libs/core")
+			runTestGit(t, dir, "commit", "--no-verify", "-m", "add library")
+
+			runTestGit(t, filepath.Join(dir, "libs/core"), "checkout", "-q", second)
+			runTestGit(t, dir, "add", "libs/core")
+
+			summary, err := g.GetSubmoduleSummary(true)
+			if err != nil {
+				t.Fatalf("GetSubmoduleSummary() error = %v", err)
+			}
+			want := "Submodule libs/core updated " + ShortSHA(first) + ".." + ShortSHA(second) + "\n" +
+				"  > " + ShortSHA(second) + " add a"
+			if summary != want {
+				t.Errorf("GetSubmoduleSummary() = %q, want %q", summary, want)
+			}
+		})
+	}
+}
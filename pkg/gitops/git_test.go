@@ -0,0 +1,1015 @@
+package gitops
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestSemVer_Parsing(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		expected semVer
+	}{
+		{
+			name:     "standard version",
+			version:  "v1.2.3",
+			expected: semVer{Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			name:     "version without v prefix",
+			version:  "1.2.3",
+			expected: semVer{Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			name:     "zero version",
+			version:  "v0.0.0",
+			expected: semVer{Major: 0, Minor: 0, Patch: 0},
+		},
+		{
+			name:     "large numbers",
+			version:  "v10.20.30",
+			expected: semVer{Major: 10, Minor: 20, Patch: 30},
+		},
+		{
+			name:     "invalid version",
+			version:  "invalid",
+			expected: semVer{Major: 0, Minor: 0, Patch: 0},
+		},
+		{
+			name:     "partial version",
+			version:  "v1.2",
+			expected: semVer{Major: 0, Minor: 0, Patch: 0},
+		},
+		{
+			name:     "empty version",
+			version:  "",
+			expected: semVer{Major: 0, Minor: 0, Patch: 0},
+		},
+		{
+			name:     "prerelease version",
+			version:  "v1.2.3-rc.1",
+			expected: semVer{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"},
+		},
+		{
+			name:     "prerelease with build metadata",
+			version:  "v1.2.3-beta.2+build.5",
+			expected: semVer{Major: 1, Minor: 2, Patch: 3, Prerelease: "beta.2", Build: "build.5"},
+		},
+		{
+			name:     "build metadata only",
+			version:  "v1.2.3+20240101",
+			expected: semVer{Major: 1, Minor: 2, Patch: 3, Build: "20240101"},
+		},
+		{
+			name:     "invalid prerelease",
+			version:  "v1.2.3-",
+			expected: semVer{Major: 0, Minor: 0, Patch: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseSemVer(tt.version)
+			if result != tt.expected {
+				t.Errorf("parseSemVer(%q) = %+v, want %+v", tt.version, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitOperations_IncrementVersion(t *testing.T) {
+	git := &Operations{}
+
+	tests := []struct {
+		name          string
+		currentTag    string
+		incrementType string
+		expected      string
+		expectErr     bool
+	}{
+		{
+			name:          "increment patch from existing version",
+			currentTag:    "v1.2.3",
+			incrementType: "patch",
+			expected:      "v1.2.4",
+			expectErr:     false,
+		},
+		{
+			name:          "increment minor from existing version",
+			currentTag:    "v1.2.3",
+			incrementType: "minor",
+			expected:      "v1.3.0",
+			expectErr:     false,
+		},
+		{
+			name:          "increment major from existing version",
+			currentTag:    "v1.2.3",
+			incrementType: "major",
+			expected:      "v2.0.0",
+			expectErr:     false,
+		},
+		{
+			name:          "increment patch from empty tag",
+			currentTag:    "",
+			incrementType: "patch",
+			expected:      "v0.0.1",
+			expectErr:     false,
+		},
+		{
+			name:          "increment minor from empty tag",
+			currentTag:    "",
+			incrementType: "minor",
+			expected:      "v0.1.0",
+			expectErr:     false,
+		},
+		{
+			name:          "increment major from empty tag",
+			currentTag:    "",
+			incrementType: "major",
+			expected:      "v1.0.0",
+			expectErr:     false,
+		},
+		{
+			name:          "invalid increment type",
+			currentTag:    "v1.2.3",
+			incrementType: "invalid",
+			expected:      "",
+			expectErr:     true,
+		},
+		{
+			name:          "case insensitive increment type",
+			currentTag:    "v1.2.3",
+			incrementType: "PATCH",
+			expected:      "v1.2.4",
+			expectErr:     false,
+		},
+		{
+			name:          "increment from zero version",
+			currentTag:    "v0.0.0",
+			incrementType: "patch",
+			expected:      "v0.0.1",
+			expectErr:     false,
+		},
+		{
+			name:          "start prerelease series",
+			currentTag:    "v1.2.3",
+			incrementType: "prerelease",
+			expected:      "v1.2.4-rc.1",
+			expectErr:     false,
+		},
+		{
+			name:          "increment prerelease counter",
+			currentTag:    "v1.2.4-rc.1",
+			incrementType: "prerelease",
+			expected:      "v1.2.4-rc.2",
+			expectErr:     false,
+		},
+		{
+			name:          "increment prerelease without counter",
+			currentTag:    "v1.2.4-beta+build.7",
+			incrementType: "prerelease",
+			expected:      "v1.2.4-beta.1",
+			expectErr:     false,
+		},
+		{
+			name:          "patch finalizes prerelease",
+			currentTag:    "v1.2.4-rc.2",
+			incrementType: "patch",
+			expected:      "v1.2.4",
+			expectErr:     false,
+		},
+		{
+			name:          "minor finalizes minor prerelease",
+			currentTag:    "v1.3.0-rc.1",
+			incrementType: "minor",
+			expected:      "v1.3.0",
+			expectErr:     false,
+		},
+		{
+			name:          "minor from patch prerelease",
+			currentTag:    "v1.2.4-rc.1",
+			incrementType: "minor",
+			expected:      "v1.3.0",
+			expectErr:     false,
+		},
+		{
+			name:          "major finalizes major prerelease",
+			currentTag:    "v2.0.0-rc.3",
+			incrementType: "major",
+			expected:      "v2.0.0",
+			expectErr:     false,
+		},
+		{
+			name:          "build metadata is dropped",
+			currentTag:    "v1.2.3+build.1",
+			incrementType: "patch",
+			expected:      "v1.2.4",
+			expectErr:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := git.IncrementVersion(tt.currentTag, tt.incrementType, "v")
+
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("IncrementVersion(%q, %q) expected error but got none", tt.currentTag, tt.incrementType)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("IncrementVersion(%q, %q) unexpected error = %v", tt.currentTag, tt.incrementType, err)
+				}
+				if result != tt.expected {
+					t.Errorf(
+						"IncrementVersion(%q, %q) = %q, want %q", tt.currentTag, tt.incrementType, result, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestGitOperations_GetLatestTag(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	commitTestFile(t, dir, "a.txt", "a\n", "init")
+	for _, tag := range []string{"v1.2.0", "v1.10.0-rc.1", "v1.9.0", "app/v2.0.0", "app/v2.1.0", "release-3.0.0"} {
+		runTestGit(t, dir, "tag", tag)
+	}
+
+	tests := []struct {
+		name     string
+		prefix   string
+		pattern  string
+		expected string
+	}{
+		{name: "default prefix", prefix: "v", expected: "v1.10.0-rc.1"},
+		{name: "component prefix", prefix: "app/v", expected: "app/v2.1.0"},
+		{name: "dash prefix", prefix: "release-", expected: "release-3.0.0"},
+		{name: "custom pattern", prefix: "v", pattern: "v1.9*", expected: "v1.9.0"},
+		{name: "no matching tags", prefix: "lib/v", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := g.GetLatestTag(tt.prefix, tt.pattern)
+			if err != nil {
+				t.Fatalf("GetLatestTag() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("GetLatestTag(%q, %q) = %q, want %q", tt.prefix, tt.pattern, result, tt.expected)
+			}
+		})
+	}
+
+	next, err := g.IncrementVersion("app/v2.1.0", "minor", "app/v")
+	if err != nil {
+		t.Fatalf("IncrementVersion() error = %v", err)
+	}
+	if next != "app/v2.2.0" {
+		t.Errorf("IncrementVersion() = %q, want %q", next, "app/v2.2.0")
+	}
+}
+
+func TestGitOperations_GetRecentCommits(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+
+	commits, err := g.GetRecentCommits(5)
+	if err != nil {
+		t.Fatalf("GetRecentCommits() on empty repository error = %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("GetRecentCommits() on empty repository = %v, want none", commits)
+	}
+
+	commitTestFile(t, dir, "a.txt", "a\n", "feat: first")
+	commitTestFile(t, dir, "b.txt", "b\n", "fix(api): second\n\nbody")
+	commitTestFile(t, dir, "c.txt", "c\n", "chore: third")
+
+	commits, err = g.GetRecentCommits(2)
+	if err != nil {
+		t.Fatalf("GetRecentCommits() error = %v", err)
+	}
+	expected := []string{"chore: third", "fix(api): second"}
+	if strings.Join(commits, "|") != strings.Join(expected, "|") {
+		t.Errorf("GetRecentCommits() = %v, want %v", commits, expected)
+	}
+}
+
+func TestGitOperations_GetCurrentBranch(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	t.Chdir(dir)
+
+	runTestGit(t, dir, "symbolic-ref", "HEAD", "refs/heads/trunk")
+
+	branch, err := g.GetCurrentBranch()
+	if err != nil {
+		t.Fatalf("GetCurrentBranch() on unborn branch error = %v", err)
+	}
+	if branch != "trunk" {
+		t.Errorf("GetCurrentBranch() on unborn branch = %q, want %q", branch, "trunk")
+	}
+
+	sha := commitTestFile(t, dir, "a.txt", "a\n", "init")
+	runTestGit(t, dir, "checkout", "--detach")
+
+	_, err = g.GetCurrentBranch()
+	var detached *DetachedHeadError
+	if !errors.As(err, &detached) {
+		t.Fatalf("GetCurrentBranch() on detached HEAD error = %v, want DetachedHeadError", err)
+	}
+	if detached.SHA != sha {
+		t.Errorf("detached sha = %q, want %q", detached.SHA, sha)
+	}
+}
+
+func TestNewGitOperations_GitDirEnv(t *testing.T) {
+	_, source := newTestGitOperations(t)
+	commitTestFile(t, source, "a.txt", "a\n", "feat: separate git dir")
+
+	// Move repository metadata away from its worktree
+	gitDir := filepath.Join(t.TempDir(), "repo.git")
+	if err := os.Rename(filepath.Join(source, ".git"), gitDir); err != nil {
+		t.Fatalf("Failed to move git dir: %v", err)
+	}
+
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+	t.Setenv("GIT_DIR", gitDir)
+	t.Setenv("GIT_WORK_TREE", "")
+
+	g, err := Open(source)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if g.Root() != source {
+		t.Errorf("Root() = %q, want %q", g.Root(), source)
+	}
+
+	// exercises both go-git and spawned git commands
+	commits, err := g.GetRecentCommits(1)
+	if err != nil || len(commits) != 1 || commits[0] != "feat: separate git dir" {
+		t.Errorf("GetRecentCommits() = %v, %v", commits, err)
+	}
+	shallow, err := g.IsShallowRepository()
+	if err != nil || shallow {
+		t.Errorf("IsShallowRepository() = %v, %v", shallow, err)
+	}
+
+	// state and hooks are read from the separate git dir, not from worktree
+	if err := os.WriteFile(filepath.Join(gitDir, "MERGE_HEAD"), []byte("0\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write MERGE_HEAD: %v", err)
+	}
+	if state, err := g.GetRepoState(); err != nil || state != RepoStateMerging {
+		t.Errorf("GetRepoState() = %v, %v, want %v", state, err, RepoStateMerging)
+	}
+	if hooksDir, err := g.getHooksDir(); err != nil || hooksDir != filepath.Join(gitDir, "hooks") {
+		t.Errorf("getHooksDir() = %v, %v, want %v", hooksDir, err, filepath.Join(gitDir, "hooks"))
+	}
+
+	// process state is left to the program using the package
+	if wd, _ := os.Getwd(); wd != cwd {
+		t.Errorf("working directory = %q, want %q", wd, cwd)
+	}
+	if env := os.Getenv("GIT_WORK_TREE"); env != "" {
+		t.Errorf("GIT_WORK_TREE = %q, want it unchanged", env)
+	}
+}
+
+func TestOperations_command_Trace(t *testing.T) {
+	hasTrace := func(env []string) bool {
+		for _, value := range env {
+			if value == "GIT_TRACE=1" {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Setenv("GIT_TRACE", "")
+	g, _ := newTestGitOperations(t)
+	if hasTrace(g.gitCommand("status").Env) {
+		t.Error("gitCommand() traces git without WithTrace")
+	}
+
+	WithTrace(true)(g)
+	if !hasTrace(g.gitCommand("status").Env) {
+		t.Error("gitCommand() does not trace git with WithTrace")
+	}
+	if env := os.Getenv("GIT_TRACE"); env != "" {
+		t.Errorf("GIT_TRACE = %q, want process environment unchanged", env)
+	}
+
+	// tracing configured by environment, e.g. to a file, is kept
+	t.Setenv("GIT_TRACE", "/tmp/trace.log")
+	if hasTrace(g.gitCommand("status").Env) {
+		t.Error("gitCommand() overrides GIT_TRACE from environment")
+	}
+}
+
+func TestDiffOptions_args(t *testing.T) {
+	tests := []struct {
+		name    string
+		options DiffOptions
+		want    []string
+	}{
+		{
+			name:    "defaults",
+			options: DefaultDiffOptions,
+			want:    []string{"--diff-algorithm=patience", "--find-renames=50"},
+		},
+		{
+			name:    "histogram with high threshold",
+			options: DiffOptions{Algorithm: "histogram", RenameThreshold: 90},
+			want:    []string{"--diff-algorithm=histogram", "--find-renames=90"},
+		},
+		{
+			name:    "git default algorithm without renames",
+			options: DiffOptions{},
+			want:    []string{"--no-renames"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.options.args()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("args() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSemVer(t *testing.T) {
+	// Ordered by ascending precedence, as in semver specification examples
+	ordered := []string{
+		"v1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-alpha.beta",
+		"v1.0.0-beta",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"v1.0.1",
+		"v1.1.0",
+		"v2.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		a, b := parseSemVer(ordered[i]), parseSemVer(ordered[i+1])
+		if compareSemVer(a, b) >= 0 {
+			t.Errorf("compareSemVer(%s, %s) should be negative", ordered[i], ordered[i+1])
+		}
+		if compareSemVer(b, a) <= 0 {
+			t.Errorf("compareSemVer(%s, %s) should be positive", ordered[i+1], ordered[i])
+		}
+	}
+
+	if compareSemVer(parseSemVer("v1.0.0+build.1"), parseSemVer("v1.0.0+build.2")) != 0 {
+		t.Errorf("build metadata must not affect precedence")
+	}
+}
+
+func TestDetectIncrementType(t *testing.T) {
+	tests := []struct {
+		name     string
+		commits  []string
+		expected string
+	}{
+		{
+			name:     "no commits",
+			commits:  nil,
+			expected: "patch",
+		},
+		{
+			name:     "fixes only",
+			commits:  []string{"fix: handle nil", "chore(deps): bump lib"},
+			expected: "patch",
+		},
+		{
+			name:     "feature",
+			commits:  []string{"fix: handle nil", "feat(api): add endpoint"},
+			expected: "minor",
+		},
+		{
+			name:     "breaking mark",
+			commits:  []string{"feat(api): add endpoint", "refactor(api)!: drop v1"},
+			expected: "major",
+		},
+		{
+			name:     "breaking change footer",
+			commits:  []string{"fix: rename option\n\nBREAKING CHANGE: --foo is now --bar"},
+			expected: "major",
+		},
+		{
+			name:     "non conventional commits",
+			commits:  []string{"Update readme", "featuring new stuff"},
+			expected: "patch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DetectIncrementType(tt.commits); result != tt.expected {
+				t.Errorf("DetectIncrementType() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitOperations_shouldExcludeFile(t *testing.T) {
+	tests := []struct {
+		name            string
+		file            string
+		excludePatterns []string
+		globalPatterns  []string
+		expected        bool
+	}{
+		{
+			name:            "no patterns",
+			file:            "test.go",
+			excludePatterns: []string{},
+			globalPatterns:  []string{},
+			expected:        false,
+		},
+		{
+			name:            "exact match exclude",
+			file:            "test.log",
+			excludePatterns: []string{"test.log"},
+			globalPatterns:  []string{},
+			expected:        true,
+		},
+		{
+			name:            "glob pattern exclude",
+			file:            "test.log",
+			excludePatterns: []string{"*.log"},
+			globalPatterns:  []string{},
+			expected:        true,
+		},
+		{
+			name:            "basename match exclude",
+			file:            "dir/test.log",
+			excludePatterns: []string{"test.log"},
+			globalPatterns:  []string{},
+			expected:        true,
+		},
+		{
+			name:            "no match exclude",
+			file:            "test.go",
+			excludePatterns: []string{"*.log"},
+			globalPatterns:  []string{},
+			expected:        false,
+		},
+		{
+			name:            "global pattern exclude",
+			file:            "node_modules/package.json",
+			excludePatterns: []string{},
+			globalPatterns:  []string{"node_modules"},
+			expected:        true,
+		},
+		{
+			name:            "directory pattern exclude",
+			file:            "build/output.js",
+			excludePatterns: []string{},
+			globalPatterns:  []string{"build/"},
+			expected:        true,
+		},
+		{
+			name:            "multiple patterns - first match",
+			file:            "test.log",
+			excludePatterns: []string{"*.log", "*.tmp"},
+			globalPatterns:  []string{},
+			expected:        true,
+		},
+		{
+			name:            "multiple patterns - second match",
+			file:            "temp.tmp",
+			excludePatterns: []string{"*.log", "*.tmp"},
+			globalPatterns:  []string{},
+			expected:        true,
+		},
+		{
+			name:            "global and local patterns",
+			file:            "node_modules/test.log",
+			excludePatterns: []string{"*.log"},
+			globalPatterns:  []string{"node_modules"},
+			expected:        true,
+		},
+		{
+			name:            "global negation re-includes file",
+			file:            "logs/keep.log",
+			excludePatterns: []string{},
+			globalPatterns:  []string{"*.log", "!keep.log"},
+			expected:        false,
+		},
+		{
+			name:            "global negation does not affect other files",
+			file:            "logs/debug.log",
+			excludePatterns: []string{},
+			globalPatterns:  []string{"*.log", "!keep.log"},
+			expected:        true,
+		},
+		{
+			name:            "later pattern overrides negation",
+			file:            "keep.log",
+			excludePatterns: []string{},
+			globalPatterns:  []string{"*.log", "!keep.log", "keep.*"},
+			expected:        true,
+		},
+		{
+			name:            "anchored pattern matches root only",
+			file:            "src/config.json",
+			excludePatterns: []string{},
+			globalPatterns:  []string{"/config.json"},
+			expected:        false,
+		},
+		{
+			name:            "anchored pattern matches root file",
+			file:            "config.json",
+			excludePatterns: []string{},
+			globalPatterns:  []string{"/config.json"},
+			expected:        true,
+		},
+		{
+			name:            "double star pattern",
+			file:            "a/b/c/cache/data.bin",
+			excludePatterns: []string{},
+			globalPatterns:  []string{"**/cache/**"},
+			expected:        true,
+		},
+		{
+			name:            "pattern is not a substring match",
+			file:            "builder.go",
+			excludePatterns: []string{},
+			globalPatterns:  []string{"build"},
+			expected:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns := make([]gitignore.Pattern, 0, len(tt.globalPatterns))
+			for _, p := range tt.globalPatterns {
+				patterns = append(patterns, gitignore.ParsePattern(p, nil))
+			}
+			result := shouldExcludeFile(tt.file, tt.excludePatterns, newIgnoreMatcher(patterns))
+			if result != tt.expected {
+				t.Errorf("shouldExcludeFile(%q, %v, %v) = %v, want %v",
+					tt.file, tt.excludePatterns, tt.globalPatterns, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitOperations_shouldIncludeFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		patterns []string
+		expected bool
+	}{
+		{
+			name:     "no patterns",
+			file:     "test.go",
+			patterns: []string{},
+			expected: false,
+		},
+		{
+			name:     "exact match include",
+			file:     "test.go",
+			patterns: []string{"test.go"},
+			expected: true,
+		},
+		{
+			name:     "glob pattern include",
+			file:     "test.go",
+			patterns: []string{"*.go"},
+			expected: true,
+		},
+		{
+			name:     "basename match include",
+			file:     "src/test.go",
+			patterns: []string{"test.go"},
+			expected: true,
+		},
+		{
+			name:     "no match include",
+			file:     "test.log",
+			patterns: []string{"*.go"},
+			expected: false,
+		},
+		{
+			name:     "multiple patterns - first match",
+			file:     "test.go",
+			patterns: []string{"*.go", "*.js"},
+			expected: true,
+		},
+		{
+			name:     "multiple patterns - second match",
+			file:     "script.js",
+			patterns: []string{"*.go", "*.js"},
+			expected: true,
+		},
+		{
+			name:     "multiple patterns - no match",
+			file:     "readme.txt",
+			patterns: []string{"*.go", "*.js"},
+			expected: false,
+		},
+		{
+			name:     "substring match",
+			file:     "test-file.go",
+			patterns: []string{"test"},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := shouldIncludeFile(tt.file, tt.patterns)
+			if result != tt.expected {
+				t.Errorf("shouldIncludeFile(%q, %v) = %v, want %v", tt.file, tt.patterns, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitOperations_isSimpleGlobPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		expected bool
+	}{
+		{
+			name:     "simple wildcard",
+			pattern:  "*.go",
+			expected: true,
+		},
+		{
+			name:     "question mark",
+			pattern:  "test?.go",
+			expected: true,
+		},
+		{
+			name:     "no wildcards",
+			pattern:  "test.go",
+			expected: false,
+		},
+		{
+			name:     "path separator",
+			pattern:  "src/*.go",
+			expected: false,
+		},
+		{
+			name:     "complex pattern with path",
+			pattern:  "src/**/*.go",
+			expected: false,
+		},
+		{
+			name:     "multiple wildcards",
+			pattern:  "*.test.*",
+			expected: true,
+		},
+		{
+			name:     "empty pattern",
+			pattern:  "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := isSimpleGlobPattern(tt.pattern)
+			if result != tt.expected {
+				t.Errorf("isSimpleGlobPattern(%q) = %v, want %v", tt.pattern, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseGitignoreFile_InvalidPath(t *testing.T) {
+	patterns, err := parseGitignoreFile("/nonexistent/path/.gitignore")
+
+	if err != nil {
+		t.Errorf("parseGitignoreFile() with non-existent file should return empty patterns, got error: %v", err)
+	}
+
+	if len(patterns) != 0 {
+		t.Errorf(
+			"parseGitignoreFile() with non-existent file should return empty patterns, got %d patterns",
+			len(patterns),
+		)
+	}
+}
+
+func TestParseGitignoreFile(t *testing.T) {
+	content := "# comment\n\n*.log\n!keep.log\n/dist/\r\n"
+
+	path := filepath.Join(t.TempDir(), ".gitignore")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write gitignore file: %v", err)
+	}
+
+	patterns, err := parseGitignoreFile(path)
+	if err != nil {
+		t.Fatalf("parseGitignoreFile() unexpected error = %v", err)
+	}
+	if len(patterns) != 3 {
+		t.Fatalf("parseGitignoreFile() returned %d patterns, want 3", len(patterns))
+	}
+
+	matcher := newIgnoreMatcher(patterns)
+	tests := []struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{"debug.log", false, true},
+		{"keep.log", false, false},
+		{"dist", true, true},
+		{"src/dist", true, false},
+		{"main.go", false, false},
+	}
+	for _, tt := range tests {
+		if result := matcher.Match(strings.Split(tt.path, "/"), tt.isDir); result != tt.expected {
+			t.Errorf("ignore match %q = %v, want %v", tt.path, result, tt.expected)
+		}
+	}
+}
+
+func TestGitConfig_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config gitConfig
+		valid  bool
+	}{
+		{
+			name: "valid config without GPG",
+			config: gitConfig{
+				UserName:  "Test User",
+				UserEmail: "test@example.com",
+				GPGSign:   false,
+			},
+			valid: true,
+		},
+		{
+			name: "valid config with GPG",
+			config: gitConfig{
+				UserName:   "Test User",
+				UserEmail:  "test@example.com",
+				GPGSign:    true,
+				SigningKey: "ABCD1234",
+				GPGProgram: "gpg",
+			},
+			valid: true,
+		},
+		{
+			name: "empty user name",
+			config: gitConfig{
+				UserName:  "",
+				UserEmail: "test@example.com",
+			},
+			valid: false,
+		},
+		{
+			name: "empty user email",
+			config: gitConfig{
+				UserName:  "Test User",
+				UserEmail: "",
+			},
+			valid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// This is a basic validation test for the GitConfig struct
+			// In a real scenario, you would test the actual validation logic
+			// if it existed in the GetConfig method
+			if tt.config.UserName == "" || tt.config.UserEmail == "" {
+				if tt.valid {
+					t.Error("Expected invalid config to be marked as invalid")
+				}
+			} else {
+				if !tt.valid {
+					t.Error("Expected valid config to be marked as valid")
+				}
+			}
+		})
+	}
+}
+
+func TestParseCommitTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "empty template",
+			content:  "",
+			expected: "",
+		},
+		{
+			name:     "comments only",
+			content:  "# Subject line\n# Body\n",
+			expected: "",
+		},
+		{
+			name:     "sections with comments",
+			content:  "\n# Explain why\nWhy:\n\n# Explain what\nWhat:   \n",
+			expected: "Why:\n\nWhat:",
+		},
+		{
+			name:     "indented comments",
+			content:  "Refs:\n    # ticket reference\n",
+			expected: "Refs:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseCommitTemplate(tt.content)
+			if result != tt.expected {
+				t.Errorf("parseCommitTemplate(%q) = %q, want %q", tt.content, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitOperations_CreateTag_SignedExisting(t *testing.T) {
+	g, dir := newTestGitOperations(t)
+	sha := commitTestFile(t, dir, "a.txt", "a\n", "init")
+	runTestGit(t, dir, "tag", "v1.0.0")
+	runTestGit(t, dir, "config", "user.name", "Test")
+	runTestGit(t, dir, "config", "user.email", "test@example.com")
+	runTestGit(t, dir, "config", "tag.gpgSign", "true")
+	runTestGit(t, dir, "config", "user.signingkey", "0123456789ABCDEF")
+
+	err := g.CreateTag("v1.0.0", "v1.0.0")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("CreateTag() of existing tag error = %v, want already exists", err)
+	}
+	if got := runTestGit(t, dir, "rev-parse", "v1.0.0"); got != sha {
+		t.Errorf("tag v1.0.0 = %s, want it left at %s", got, sha)
+	}
+}
+
+func TestBuildSignedTag(t *testing.T) {
+	entity, err := openpgp.NewEntity("Test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+
+	var publicKey bytes.Buffer
+	armorWriter, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("failed to armor key: %v", err)
+	}
+	if err := entity.Serialize(armorWriter); err != nil {
+		t.Fatalf("failed to serialize key: %v", err)
+	}
+	_ = armorWriter.Close()
+
+	sign := func(message io.Reader) ([]byte, error) {
+		var b bytes.Buffer
+		if err := openpgp.ArmoredDetachSign(&b, entity, message, nil); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	}
+
+	tagger := &object.Signature{Name: "Test", Email: "test@example.com", When: time.Now()}
+	target := plumbing.NewHash("0123456789abcdef0123456789abcdef01234567")
+
+	tag, err := buildSignedTag("v1.0.0", target, tagger, "v1.0.0", sign)
+	if err != nil {
+		t.Fatalf("buildSignedTag() error = %v", err)
+	}
+
+	if tag.Message != "v1.0.0\n" {
+		t.Errorf("Message = %q, want trailing newline", tag.Message)
+	}
+	if !strings.HasPrefix(tag.PGPSignature, "-----BEGIN PGP SIGNATURE-----") {
+		t.Errorf("PGPSignature = %q, want armored signature", tag.PGPSignature)
+	}
+	if _, err := tag.Verify(publicKey.String()); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
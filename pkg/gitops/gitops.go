@@ -0,0 +1,64 @@
+// Package gitops reads and records changes of a git repository the way commit does:
+// staging, staged diff sized for AI input, commits, semver tags, pushes and links
+// to merge requests of pushed branches. Git is spawned where it is faster or more
+// complete than go-git, go-git is used otherwise or when git is not installed.
+//
+//	ops, err := gitops.Open(".", gitops.WithDiffOptions(gitops.DefaultDiffOptions))
+//	if err != nil {
+//		return err
+//	}
+//	diff, err := ops.GetStagedDiff(64<<10, 0)
+//
+// Paths are relative to worktree root returned by Root, git is spawned in it, working
+// directory and environment of the process are left intact.
+package gitops
+
+// Stager stages changes and reads staged ones
+type Stager interface {
+	StageFiles(excludePatterns, includePatterns []string, useGlobalGitignore bool, scopeDir string) ([]string, error)
+	StagePaths(paths []string) error
+	UnstageAll() error
+	GetStagedDiff(maxSizeBytes int, newFileHeadLines int) (string, error)
+	GetStagedFiles() ([]string, error)
+	GetIndexHash() (string, error)
+}
+
+// Committer creates and rewrites commits
+type Committer interface {
+	CreateCommit(message string, noVerify, allowEmpty bool, author, date string) error
+	RewordCommit(sha, message string, noVerify bool) error
+	UndoLastCommit() error
+	ResolveCommit(ref string) (string, error)
+	GetCommitMessage(sha string) (string, error)
+	GetCommitDiff(sha string, maxSizeBytes int) (string, []string, error)
+}
+
+// Tagger creates semver tags of releases
+type Tagger interface {
+	GetLatestTag(prefix, pattern string) (string, error)
+	GetCommitsSince(tag string) ([]string, error)
+	IncrementVersion(currentTag, incrementType, prefix string) (string, error)
+	CreateTag(tag, message string) error
+	PushTag(tag, remote string) error
+	DeleteTag(tag string) error
+}
+
+// Pusher pushes current branch, Push returns url of merge request page of pushed branch
+type Pusher interface {
+	GetCurrentBranch() (string, error)
+	GetDefaultBranch(remote string) string
+	GetRemoteDivergence(remote string) (int, int, error)
+	GetRemoteInfo(remote string) (*RemoteInfo, error)
+	PullRebase(remote string) error
+	Push(remote string, forceWithLease, setUpstream bool) (string, error)
+}
+
+// Repository is implemented by Operations, consumers usually depend on part of it
+type Repository interface {
+	Stager
+	Committer
+	Tagger
+	Pusher
+}
+
+var _ Repository = (*Operations)(nil)
@@ -0,0 +1,33 @@
+package gitops
+
+// Option configures Operations created by Open
+type Option func(g *Operations)
+
+// WithDiffOptions tunes diffs returned for AI input, DefaultDiffOptions are used otherwise
+func WithDiffOptions(options DiffOptions) Option {
+	return func(g *Operations) {
+		g.diffOptions = options
+	}
+}
+
+// WithPureGo uses go-git instead of spawning git where possible, e.g. when git is not installed
+func WithPureGo(pureGo bool) Option {
+	return func(g *Operations) {
+		g.pureGo = pureGo
+	}
+}
+
+// WithTrace traces spawned git commands like GIT_TRACE=1 does, without changing environment of the process
+func WithTrace(trace bool) Option {
+	return func(g *Operations) {
+		g.trace = trace
+	}
+}
+
+// WithPlatformHosts maps lowercase hosts not named after their platform, e.g. git.mycorp.com,
+// to platforms for merge request and commit links
+func WithPlatformHosts(platformHosts map[string]Platform) Option {
+	return func(g *Operations) {
+		g.platformHosts = platformHosts
+	}
+}
@@ -0,0 +1,416 @@
+package gitops
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Platform is git hosting platform serving a remote
+type Platform string
+
+const (
+	PlatformGitHub     Platform = "github"
+	PlatformGitLab     Platform = "gitlab"
+	PlatformBitbucket  Platform = "bitbucket"
+	PlatformAzure      Platform = "azure"
+	PlatformCodeCommit Platform = "codecommit"
+	PlatformUnknown    Platform = "unknown"
+)
+
+// Platforms returns platforms remotes are recognized on, hosts can be mapped to any of them
+func Platforms() []Platform {
+	return []Platform{PlatformGitHub, PlatformGitLab, PlatformBitbucket, PlatformAzure, PlatformCodeCommit}
+}
+
+const bitbucketCloudHost = "bitbucket.org"
+
+// RemoteInfo describes repository of a remote on its platform
+type RemoteInfo struct {
+	Platform Platform
+	Host     string
+	Owner    string
+	Repo     string
+	Region   string // AWS region of CodeCommit repository
+	Profile  string // AWS profile given in git-remote-codecommit url
+	Mapped   bool   // platform is given by platform hosts rather than guessed from host name
+}
+
+// ParseRemoteURL parses a git remote URL and extracts platform information,
+// platformHosts name platforms of hosts which are not named after them
+func ParseRemoteURL(remoteURL string, platformHosts map[string]Platform) (*RemoteInfo, error) {
+	if remoteURL == "" {
+		return nil, fmt.Errorf("empty remote URL")
+	}
+
+	if info, ok := parseAzureRemoteURL(remoteURL); ok {
+		return info, nil
+	}
+	if info, ok := parseCodeCommitRemoteURL(remoteURL); ok {
+		return info, nil
+	}
+
+	info := &RemoteInfo{
+		Platform: PlatformUnknown,
+	}
+
+	// Check if it's an HTTP(S) URL first
+	if strings.HasPrefix(remoteURL, "http://") || strings.HasPrefix(remoteURL, "https://") {
+		// Handle HTTPS URLs
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse URL: %w", err)
+		}
+
+		info.Host = u.Host
+
+		// Extract owner and repo from path
+		pathParts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		// Bitbucket Server serves repositories over https under /scm/{project}/{repo}
+		platform := detectPlatform(info.Host, platformHosts)
+		if platform == PlatformBitbucket && len(pathParts) > 2 && pathParts[0] == "scm" {
+			pathParts = pathParts[1:]
+		}
+		if len(pathParts) >= 2 {
+			info.Owner = pathParts[0]
+			info.Repo = strings.TrimSuffix(pathParts[1], ".git")
+
+			// Handle GitLab subgroups (multiple path segments)
+			if platform == PlatformGitLab && len(pathParts) > 2 {
+				// For GitLab, owner can be a nested group
+				info.Owner = strings.Join(pathParts[:len(pathParts)-1], "/")
+				info.Repo = strings.TrimSuffix(pathParts[len(pathParts)-1], ".git")
+			}
+		} else {
+			return nil, fmt.Errorf("invalid repository path in URL")
+		}
+	} else {
+		// Handle SSH URLs (git@host:owner/repo.git or git@host:group/subgroup/repo.git)
+		sshPattern := regexp.MustCompile(`^(?:ssh://)?(?:git@)?([^:/]+)[:/](.+?)(?:\.git)?$`)
+		if matches := sshPattern.FindStringSubmatch(remoteURL); len(matches) == 3 {
+			info.Host = matches[1]
+
+			// Split the path to handle both simple and nested paths
+			pathParts := strings.Split(matches[2], "/")
+			// Drop port of ssh://git@host:7999/project/repo.git, used by Bitbucket Server
+			if strings.HasPrefix(remoteURL, "ssh://") && len(pathParts) > 2 && isPort(pathParts[0]) {
+				pathParts = pathParts[1:]
+			}
+			if len(pathParts) >= 2 {
+				// For GitLab, handle subgroups
+				if detectPlatform(info.Host, platformHosts) == PlatformGitLab && len(pathParts) > 2 {
+					info.Owner = strings.Join(pathParts[:len(pathParts)-1], "/")
+					info.Repo = strings.TrimSuffix(pathParts[len(pathParts)-1], ".git")
+				} else {
+					info.Owner = pathParts[0]
+					info.Repo = strings.TrimSuffix(strings.Join(pathParts[1:], "/"), ".git")
+				}
+			} else {
+				return nil, fmt.Errorf("invalid repository path in SSH URL")
+			}
+		} else {
+			return nil, fmt.Errorf("unsupported URL format: %s", remoteURL)
+		}
+	}
+
+	// Detect platform based on host
+	info.Platform = detectPlatform(info.Host, platformHosts)
+	_, info.Mapped = mappedPlatform(info.Host, platformHosts)
+
+	return info, nil
+}
+
+// azureRemotePattern matches Azure DevOps remotes, owner is organization and project:
+// https://dev.azure.com/{org}/{project}/_git/{repo},
+// https://{org}.visualstudio.com[/DefaultCollection]/{project}/_git/{repo},
+// git@ssh.dev.azure.com:v3/{org}/{project}/{repo} and {org}@vs-ssh.visualstudio.com:v3/{org}/{project}/{repo}
+var azureRemotePattern = regexp.MustCompile(
+	`^(?:https://(?:[^@/]+@)?(dev\.azure\.com/[^/]+|[^@/.]+\.visualstudio\.com)` +
+		`(?:/DefaultCollection)?/([^/]+)/_git/([^/]+?)` +
+		`|(?:ssh://)?[^@/]+@(?:ssh\.dev\.azure\.com|vs-ssh\.visualstudio\.com):v3/([^/]+)/([^/]+)/([^/]+?))` +
+		`(?:\.git)?/?$`,
+)
+
+// parseAzureRemoteURL parses Azure DevOps remote, which owner/repo parsing would mangle,
+// host of pull request urls is kept: dev.azure.com or {org}.visualstudio.com
+func parseAzureRemoteURL(remoteURL string) (*RemoteInfo, bool) {
+	matches := azureRemotePattern.FindStringSubmatch(remoteURL)
+	if matches == nil {
+		return nil, false
+	}
+
+	info := &RemoteInfo{Platform: PlatformAzure}
+	if matches[1] != "" {
+		host, org, _ := strings.Cut(matches[1], "/")
+		if org == "" {
+			// legacy host carries organization
+			org, _, _ = strings.Cut(host, ".")
+		} else {
+			host = "dev.azure.com"
+		}
+		info.Host, info.Owner, info.Repo = host, org+"/"+matches[2], matches[3]
+	} else {
+		info.Host = "dev.azure.com"
+		if strings.Contains(remoteURL, "visualstudio.com") {
+			info.Host = matches[4] + ".visualstudio.com"
+		}
+		info.Owner, info.Repo = matches[4]+"/"+matches[5], matches[6]
+	}
+	return info, true
+}
+
+var (
+	// codeCommitRemotePattern matches https://git-codecommit.{region}.amazonaws.com/v1/repos/{repo} and its ssh form
+	codeCommitRemotePattern = regexp.MustCompile(
+		`^(?:https|ssh)://(?:[^@/]+@)?(git-codecommit\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?)(?::\d+)?` +
+			`/v1/repos/([^/]+?)/?$`,
+	)
+	// codeCommitGRCPattern matches git-remote-codecommit urls: codecommit[::{region}]://[{profile}@]{repo}
+	codeCommitGRCPattern = regexp.MustCompile(`^codecommit(?:::([a-z0-9-]+))?://(?:([^@/]+)@)?([^@/]+?)/?$`)
+)
+
+// parseCodeCommitRemoteURL parses AWS CodeCommit remote, region of git-remote-codecommit url
+// without one is read from AWS_REGION or AWS_DEFAULT_REGION like the helper does
+func parseCodeCommitRemoteURL(remoteURL string) (*RemoteInfo, bool) {
+	if matches := codeCommitRemotePattern.FindStringSubmatch(remoteURL); matches != nil {
+		return &RemoteInfo{
+			Platform: PlatformCodeCommit,
+			Host:     matches[1],
+			Repo:     matches[3],
+			Region:   matches[2],
+		}, true
+	}
+
+	matches := codeCommitGRCPattern.FindStringSubmatch(remoteURL)
+	if matches == nil {
+		return nil, false
+	}
+	region := matches[1]
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	host := "git-codecommit.amazonaws.com"
+	if region != "" {
+		host = "git-codecommit." + region + ".amazonaws.com"
+	}
+	return &RemoteInfo{
+		Platform: PlatformCodeCommit,
+		Host:     host,
+		Repo:     matches[3],
+		Region:   region,
+		Profile:  matches[2],
+	}, true
+}
+
+// mappedPlatform returns platform of host given in platformHosts
+func mappedPlatform(host string, platformHosts map[string]Platform) (Platform, bool) {
+	lowerHost := strings.ToLower(host)
+
+	if platform, ok := platformHosts[lowerHost]; ok {
+		return platform, true
+	}
+	// https remotes carry port in host, mapping may be given without it
+	if hostname, _, ok := strings.Cut(lowerHost, ":"); ok {
+		if platform, ok := platformHosts[hostname]; ok {
+			return platform, true
+		}
+	}
+	return "", false
+}
+
+// detectPlatform identifies the git platform from the host, platformHosts take precedence
+func detectPlatform(host string, platformHosts map[string]Platform) Platform {
+	if platform, ok := mappedPlatform(host, platformHosts); ok {
+		return platform
+	}
+
+	lowerHost := strings.ToLower(host)
+
+	if strings.Contains(lowerHost, "github") {
+		return PlatformGitHub
+	}
+	if strings.Contains(lowerHost, "gitlab") {
+		return PlatformGitLab
+	}
+	if _, ok := GitLabInstanceURL(host); ok {
+		return PlatformGitLab
+	}
+	if strings.Contains(lowerHost, "bitbucket") {
+		return PlatformBitbucket
+	}
+	if strings.HasPrefix(lowerHost, "git-codecommit.") {
+		return PlatformCodeCommit
+	}
+	if strings.HasSuffix(lowerHost, "dev.azure.com") || strings.HasSuffix(lowerHost, "visualstudio.com") {
+		return PlatformAzure
+	}
+
+	return PlatformUnknown
+}
+
+// isPort reports whether URL segment is a port number
+func isPort(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, r := range segment {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MergeRequestURL generates the appropriate MR/PR URL based on platform
+func MergeRequestURL(info *RemoteInfo, branch string, targetBranch string) string {
+	if info == nil || branch == "" {
+		return ""
+	}
+
+	// URL-encode branch names to handle special characters
+	encodedBranch := url.QueryEscape(branch)
+	encodedTargetBranch := url.QueryEscape(targetBranch)
+
+	switch info.Platform {
+	case PlatformGitHub:
+		// GitHub PR URL format
+		// https://github.com/{owner}/{repo}/compare/{target}...{branch}?expand=1
+		if targetBranch != "" && targetBranch != branch {
+			return fmt.Sprintf("https://%s/%s/%s/compare/%s...%s?expand=1",
+				info.Host, info.Owner, info.Repo, encodedTargetBranch, encodedBranch)
+		}
+		// If no target branch or same as source, use simpler format
+		return fmt.Sprintf("https://%s/%s/%s/pull/new/%s",
+			info.Host, info.Owner, info.Repo, encodedBranch)
+
+	case PlatformGitLab:
+		// GitLab MR URL format
+		// https://gitlab.com/{owner}/{repo}/-/merge_requests/new?merge_request[source_branch]={branch}&merge_request[target_branch]={target}
+		baseURL := fmt.Sprintf("https://%s/%s/%s/-/merge_requests/new",
+			info.Host, info.Owner, info.Repo)
+
+		params := url.Values{}
+		params.Set("merge_request[source_branch]", branch)
+		if targetBranch != "" && targetBranch != branch {
+			params.Set("merge_request[target_branch]", targetBranch)
+		}
+
+		return fmt.Sprintf("%s?%s", baseURL, params.Encode())
+
+	case PlatformBitbucket:
+		if strings.EqualFold(info.Host, bitbucketCloudHost) {
+			// Bitbucket Cloud PR URL format
+			// https://bitbucket.org/{workspace}/{repo}/pull-requests/new?source={branch}&dest={target}
+			params := url.Values{}
+			params.Set("source", branch)
+			if targetBranch != "" && targetBranch != branch {
+				params.Set("dest", targetBranch)
+			}
+			return fmt.Sprintf("https://%s/%s/%s/pull-requests/new?%s",
+				info.Host, info.Owner, info.Repo, params.Encode())
+		}
+
+		// Bitbucket Server PR URL format, personal repositories of ~user live under /users/{user}
+		// https://{host}/projects/{project}/repos/{repo}/pull-requests?create&sourceBranch=refs/heads/{branch}
+		owner := "projects/" + info.Owner
+		if user, ok := strings.CutPrefix(info.Owner, "~"); ok {
+			owner = "users/" + user
+		}
+		params := url.Values{}
+		params.Set("sourceBranch", "refs/heads/"+branch)
+		if targetBranch != "" && targetBranch != branch {
+			params.Set("targetBranch", "refs/heads/"+targetBranch)
+		}
+		return fmt.Sprintf("https://%s/%s/repos/%s/pull-requests?create&%s",
+			info.Host, owner, info.Repo, params.Encode())
+
+	case PlatformAzure:
+		// Azure DevOps PR URL format, organization is part of owner only on dev.azure.com
+		// https://dev.azure.com/{org}/{project}/_git/{repo}/pullrequestcreate?sourceRef={branch}&targetRef={target}
+		owner := info.Owner
+		if !strings.EqualFold(info.Host, "dev.azure.com") {
+			_, owner, _ = strings.Cut(owner, "/")
+		}
+		params := url.Values{}
+		params.Set("sourceRef", branch)
+		if targetBranch != "" && targetBranch != branch {
+			params.Set("targetRef", targetBranch)
+		}
+		return fmt.Sprintf("https://%s/%s/_git/%s/pullrequestcreate?%s",
+			info.Host, owner, info.Repo, params.Encode())
+
+	case PlatformCodeCommit:
+		// CodeCommit has no web page creating pull request of a branch, they are opened with --create-pr
+		return ""
+
+	default:
+		// Unknown platform, return empty string
+		return ""
+	}
+}
+
+// CommitURL returns web page of commit on platform of remote, empty for unknown platforms
+func CommitURL(info *RemoteInfo, hash string) string {
+	if info == nil || hash == "" {
+		return ""
+	}
+
+	switch info.Platform {
+	case PlatformGitHub:
+		return fmt.Sprintf("https://%s/%s/%s/commit/%s", info.Host, info.Owner, info.Repo, hash)
+	case PlatformGitLab:
+		return fmt.Sprintf("https://%s/%s/%s/-/commit/%s", info.Host, info.Owner, info.Repo, hash)
+	case PlatformBitbucket:
+		if strings.EqualFold(info.Host, bitbucketCloudHost) {
+			return fmt.Sprintf("https://%s/%s/%s/commits/%s", info.Host, info.Owner, info.Repo, hash)
+		}
+		owner := "projects/" + info.Owner
+		if user, ok := strings.CutPrefix(info.Owner, "~"); ok {
+			owner = "users/" + user
+		}
+		return fmt.Sprintf("https://%s/%s/repos/%s/commits/%s", info.Host, owner, info.Repo, hash)
+	case PlatformAzure:
+		owner := info.Owner
+		if !strings.EqualFold(info.Host, "dev.azure.com") {
+			_, owner, _ = strings.Cut(owner, "/")
+		}
+		return fmt.Sprintf("https://%s/%s/_git/%s/commit/%s", info.Host, owner, info.Repo, hash)
+	case PlatformCodeCommit:
+		if info.Region == "" {
+			return ""
+		}
+		return fmt.Sprintf(
+			"https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/commit/%s?region=%s",
+			info.Region, info.Repo, hash, info.Region,
+		)
+	default:
+		return ""
+	}
+}
+
+// GetRemoteInfo returns parsed url of remote, with platforms of configured hosts
+func (g *Operations) GetRemoteInfo(remote string) (*RemoteInfo, error) {
+	remoteURL, err := g.GetRemoteURL(remote)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoteURL(remoteURL, g.platformHosts)
+}
+
+// GitLabInstanceURL returns GITLAB_URL without trailing slash when it points to host
+func GitLabInstanceURL(host string) (string, bool) {
+	instanceURL := strings.TrimRight(os.Getenv("GITLAB_URL"), "/")
+	if instanceURL == "" {
+		return "", false
+	}
+	u, err := url.Parse(instanceURL)
+	if err != nil || !strings.EqualFold(u.Hostname(), host) {
+		return "", false
+	}
+	return instanceURL, true
+}
@@ -0,0 +1,592 @@
+package gitops
+
+import (
+	"testing"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		remoteURL string
+		wantInfo  *RemoteInfo
+		wantErr   bool
+	}{
+		{
+			name:      "GitHub HTTPS URL",
+			remoteURL: "https://github.com/owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "GitHub HTTPS URL without .git",
+			remoteURL: "https://github.com/owner/repo",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "GitHub SSH URL",
+			remoteURL: "git@github.com:owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Bitbucket Server HTTPS URL",
+			remoteURL: "https://bitbucket.example.com/scm/PROJ/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformBitbucket,
+				Host:     "bitbucket.example.com",
+				Owner:    "PROJ",
+				Repo:     "repo",
+			},
+		},
+		{
+			name:      "Bitbucket Server SSH URL with port",
+			remoteURL: "ssh://git@bitbucket.example.com:7999/proj/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformBitbucket,
+				Host:     "bitbucket.example.com",
+				Owner:    "proj",
+				Repo:     "repo",
+			},
+		},
+		{
+			name:      "Bitbucket Cloud SSH URL",
+			remoteURL: "git@bitbucket.org:workspace/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformBitbucket,
+				Host:     "bitbucket.org",
+				Owner:    "workspace",
+				Repo:     "repo",
+			},
+		},
+		{
+			name:      "GitHub SSH URL with ssh://",
+			remoteURL: "ssh://git@github.com/owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "GitLab HTTPS URL",
+			remoteURL: "https://gitlab.com/owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "gitlab.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "GitLab SSH URL",
+			remoteURL: "git@gitlab.com:owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "gitlab.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "GitLab with subgroup HTTPS",
+			remoteURL: "https://gitlab.com/group/subgroup/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "gitlab.com",
+				Owner:    "group/subgroup",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "GitLab with subgroup SSH",
+			remoteURL: "git@gitlab.com:group/subgroup/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "gitlab.com",
+				Owner:    "group/subgroup",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Self-hosted GitLab",
+			remoteURL: "https://gitlab.example.com/owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "gitlab.example.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Self-hosted GitHub Enterprise",
+			remoteURL: "https://github.enterprise.com/owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.enterprise.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Bitbucket Cloud HTTPS URL",
+			remoteURL: "https://bitbucket.org/owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformBitbucket,
+				Host:     "bitbucket.org",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Azure DevOps HTTPS URL",
+			remoteURL: "https://org@dev.azure.com/org/project/_git/repo",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformAzure,
+				Host:     "dev.azure.com",
+				Owner:    "org/project",
+				Repo:     "repo",
+			},
+		},
+		{
+			name:      "Azure DevOps SSH URL",
+			remoteURL: "git@ssh.dev.azure.com:v3/org/project/repo",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformAzure,
+				Host:     "dev.azure.com",
+				Owner:    "org/project",
+				Repo:     "repo",
+			},
+		},
+		{
+			name:      "Azure DevOps legacy HTTPS URL",
+			remoteURL: "https://org.visualstudio.com/DefaultCollection/project/_git/repo",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformAzure,
+				Host:     "org.visualstudio.com",
+				Owner:    "org/project",
+				Repo:     "repo",
+			},
+		},
+		{
+			name:      "Azure DevOps legacy SSH URL",
+			remoteURL: "org@vs-ssh.visualstudio.com:v3/org/project/repo",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformAzure,
+				Host:     "org.visualstudio.com",
+				Owner:    "org/project",
+				Repo:     "repo",
+			},
+		},
+		{
+			name:      "CodeCommit HTTPS URL",
+			remoteURL: "https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/MyRepo",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformCodeCommit,
+				Host:     "git-codecommit.eu-west-1.amazonaws.com",
+				Repo:     "MyRepo",
+				Region:   "eu-west-1",
+			},
+		},
+		{
+			name:      "CodeCommit SSH URL",
+			remoteURL: "ssh://APKAEIBAERJR2EXAMPLE@git-codecommit.eu-west-1.amazonaws.com/v1/repos/MyRepo",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformCodeCommit,
+				Host:     "git-codecommit.eu-west-1.amazonaws.com",
+				Repo:     "MyRepo",
+				Region:   "eu-west-1",
+			},
+		},
+		{
+			name:      "CodeCommit GRC URL",
+			remoteURL: "codecommit::us-east-2://dev@MyRepo",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformCodeCommit,
+				Host:     "git-codecommit.us-east-2.amazonaws.com",
+				Repo:     "MyRepo",
+				Region:   "us-east-2",
+				Profile:  "dev",
+			},
+		},
+		{
+			name:      "Mapped self-hosted GitLab with subgroup",
+			remoteURL: "https://git.mycorp.com:8443/group/sub/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "git.mycorp.com:8443",
+				Owner:    "group/sub",
+				Repo:     "repo",
+				Mapped:   true,
+			},
+		},
+		{
+			name:      "Mapped self-hosted GitLab SSH URL",
+			remoteURL: "git@git.mycorp.com:group/sub/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "git.mycorp.com",
+				Owner:    "group/sub",
+				Repo:     "repo",
+				Mapped:   true,
+			},
+		},
+		{
+			name:      "Unknown platform",
+			remoteURL: "https://git.example.com/owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformUnknown,
+				Host:     "git.example.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Empty URL",
+			remoteURL: "",
+			wantInfo:  nil,
+			wantErr:   true,
+		},
+		{
+			name:      "Invalid URL format",
+			remoteURL: "not-a-url",
+			wantInfo:  nil,
+			wantErr:   true,
+		},
+		{
+			name:      "HTTP URL with port",
+			remoteURL: "https://github.com:8080/owner/repo.git",
+			wantInfo: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.com:8080",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ParseRemoteURL(tt.remoteURL, map[string]Platform{"git.mycorp.com": PlatformGitLab})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRemoteURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && info != nil {
+				if info.Platform != tt.wantInfo.Platform {
+					t.Errorf("Platform = %v, want %v", info.Platform, tt.wantInfo.Platform)
+				}
+				if info.Host != tt.wantInfo.Host {
+					t.Errorf("Host = %v, want %v", info.Host, tt.wantInfo.Host)
+				}
+				if info.Owner != tt.wantInfo.Owner {
+					t.Errorf("Owner = %v, want %v", info.Owner, tt.wantInfo.Owner)
+				}
+				if info.Repo != tt.wantInfo.Repo {
+					t.Errorf("Repo = %v, want %v", info.Repo, tt.wantInfo.Repo)
+				}
+				if info.Region != tt.wantInfo.Region || info.Profile != tt.wantInfo.Profile {
+					t.Errorf("Region, Profile = %v, %v, want %v, %v",
+						info.Region, info.Profile, tt.wantInfo.Region, tt.wantInfo.Profile)
+				}
+				if info.Mapped != tt.wantInfo.Mapped {
+					t.Errorf("Mapped = %v, want %v", info.Mapped, tt.wantInfo.Mapped)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateMergeRequestURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		info         *RemoteInfo
+		branch       string
+		targetBranch string
+		wantURL      string
+	}{
+		{
+			name: "GitHub PR URL with target branch",
+			info: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			branch:       "feature-branch",
+			targetBranch: "master",
+			wantURL:      "https://github.com/owner/repo/compare/master...feature-branch?expand=1",
+		},
+		{
+			name: "GitHub PR URL without target branch",
+			info: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			branch:       "feature-branch",
+			targetBranch: "",
+			wantURL:      "https://github.com/owner/repo/pull/new/feature-branch",
+		},
+		{
+			name: "GitLab MR URL with target branch",
+			info: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "gitlab.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			branch:       "feature-branch",
+			targetBranch: "master",
+			wantURL:      "https://gitlab.com/owner/repo/-/merge_requests/new?merge_request%5Bsource_branch%5D=feature-branch&merge_request%5Btarget_branch%5D=master",
+		},
+		{
+			name: "GitLab MR URL without target branch",
+			info: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "gitlab.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			branch:       "feature-branch",
+			targetBranch: "",
+			wantURL:      "https://gitlab.com/owner/repo/-/merge_requests/new?merge_request%5Bsource_branch%5D=feature-branch",
+		},
+		{
+			name: "GitLab MR URL with subgroup",
+			info: &RemoteInfo{
+				Platform: PlatformGitLab,
+				Host:     "gitlab.com",
+				Owner:    "group/subgroup",
+				Repo:     "repo",
+			},
+			branch:       "feature-branch",
+			targetBranch: "master",
+			wantURL:      "https://gitlab.com/group/subgroup/repo/-/merge_requests/new?merge_request%5Bsource_branch%5D=feature-branch&merge_request%5Btarget_branch%5D=master",
+		},
+		{
+			name: "Bitbucket Cloud PR URL",
+			info: &RemoteInfo{
+				Platform: PlatformBitbucket,
+				Host:     "bitbucket.org",
+				Owner:    "workspace",
+				Repo:     "repo",
+			},
+			branch:       "feature-branch",
+			targetBranch: "main",
+			wantURL:      "https://bitbucket.org/workspace/repo/pull-requests/new?dest=main&source=feature-branch",
+		},
+		{
+			name: "Bitbucket Server PR URL",
+			info: &RemoteInfo{
+				Platform: PlatformBitbucket,
+				Host:     "bitbucket.example.com",
+				Owner:    "PROJ",
+				Repo:     "repo",
+			},
+			branch:       "feature",
+			targetBranch: "main",
+			wantURL: "https://bitbucket.example.com/projects/PROJ/repos/repo/pull-requests?create&" +
+				"sourceBranch=refs%2Fheads%2Ffeature&targetBranch=refs%2Fheads%2Fmain",
+		},
+		{
+			name: "Bitbucket Server personal repository PR URL",
+			info: &RemoteInfo{
+				Platform: PlatformBitbucket,
+				Host:     "bitbucket.example.com",
+				Owner:    "~jdoe",
+				Repo:     "repo",
+			},
+			branch: "feature",
+			wantURL: "https://bitbucket.example.com/users/jdoe/repos/repo/pull-requests?create&" +
+				"sourceBranch=refs%2Fheads%2Ffeature",
+		},
+		{
+			name: "Azure DevOps PR URL",
+			info: &RemoteInfo{
+				Platform: PlatformAzure,
+				Host:     "dev.azure.com",
+				Owner:    "org/project",
+				Repo:     "repo",
+			},
+			branch:       "feature",
+			targetBranch: "main",
+			wantURL: "https://dev.azure.com/org/project/_git/repo/pullrequestcreate?" +
+				"sourceRef=feature&targetRef=main",
+		},
+		{
+			name: "Azure DevOps legacy PR URL",
+			info: &RemoteInfo{
+				Platform: PlatformAzure,
+				Host:     "org.visualstudio.com",
+				Owner:    "org/project",
+				Repo:     "repo",
+			},
+			branch:  "feature",
+			wantURL: "https://org.visualstudio.com/project/_git/repo/pullrequestcreate?sourceRef=feature",
+		},
+		{
+			name: "CodeCommit has no PR URL",
+			info: &RemoteInfo{
+				Platform: PlatformCodeCommit,
+				Host:     "git-codecommit.eu-west-1.amazonaws.com",
+				Repo:     "MyRepo",
+				Region:   "eu-west-1",
+			},
+			branch:       "feature",
+			targetBranch: "main",
+			wantURL:      "",
+		},
+		{
+			name: "GitHub with special characters in branch",
+			info: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			branch:       "feature/new-feature",
+			targetBranch: "develop",
+			wantURL:      "https://github.com/owner/repo/compare/develop...feature%2Fnew-feature?expand=1",
+		},
+		{
+			name: "Unknown platform returns empty",
+			info: &RemoteInfo{
+				Platform: PlatformUnknown,
+				Host:     "bitbucket.org",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			branch:       "feature-branch",
+			targetBranch: "master",
+			wantURL:      "",
+		},
+		{
+			name:         "Nil info returns empty",
+			info:         nil,
+			branch:       "feature-branch",
+			targetBranch: "master",
+			wantURL:      "",
+		},
+		{
+			name: "Empty branch returns empty",
+			info: &RemoteInfo{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+			},
+			branch:       "",
+			targetBranch: "master",
+			wantURL:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURL := MergeRequestURL(tt.info, tt.branch, tt.targetBranch)
+			if gotURL != tt.wantURL {
+				t.Errorf("MergeRequestURL() = %v, want %v", gotURL, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestDetectPlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     string
+		wantPlat Platform
+	}{
+		{"GitHub.com", "github.com", PlatformGitHub},
+		{"GitHub Enterprise", "github.enterprise.com", PlatformGitHub},
+		{"GitLab.com", "gitlab.com", PlatformGitLab},
+		{"Self-hosted GitLab", "gitlab.example.com", PlatformGitLab},
+		{"Mixed case GitHub", "GitHub.com", PlatformGitHub},
+		{"Mixed case GitLab", "GitLab.com", PlatformGitLab},
+		{"Bitbucket", "bitbucket.org", PlatformBitbucket},
+		{"Bitbucket Server", "bitbucket.example.com", PlatformBitbucket},
+		{"Azure DevOps", "dev.azure.com", PlatformAzure},
+		{"Generic Git", "git.example.com", PlatformUnknown},
+		{"GITLAB_URL instance", "code.internal", PlatformGitLab},
+	}
+
+	t.Setenv("GITLAB_URL", "http://code.internal:8080/")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectPlatform(tt.host, nil); got != tt.wantPlat {
+				t.Errorf("detectPlatform() = %v, want %v", got, tt.wantPlat)
+			}
+		})
+	}
+}
+
+func TestGenerateCommitURL(t *testing.T) {
+	hash := "0123abc"
+	tests := []struct {
+		name string
+		info *RemoteInfo
+		want string
+	}{
+		{
+			name: "GitHub",
+			info: &RemoteInfo{Platform: PlatformGitHub, Host: "github.com", Owner: "o", Repo: "r"},
+			want: "https://github.com/o/r/commit/0123abc",
+		},
+		{
+			name: "GitLab",
+			info: &RemoteInfo{Platform: PlatformGitLab, Host: "gitlab.com", Owner: "group/sub", Repo: "r"},
+			want: "https://gitlab.com/group/sub/r/-/commit/0123abc",
+		},
+		{
+			name: "Bitbucket Server",
+			info: &RemoteInfo{Platform: PlatformBitbucket, Host: "bitbucket.example.com", Owner: "PROJ", Repo: "r"},
+			want: "https://bitbucket.example.com/projects/PROJ/repos/r/commits/0123abc",
+		},
+		{
+			name: "Azure DevOps",
+			info: &RemoteInfo{Platform: PlatformAzure, Host: "dev.azure.com", Owner: "org/project", Repo: "r"},
+			want: "https://dev.azure.com/org/project/_git/r/commit/0123abc",
+		},
+		{
+			name: "Unknown",
+			info: &RemoteInfo{Platform: PlatformUnknown, Host: "git.example.com", Owner: "o", Repo: "r"},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommitURL(tt.info, hash); got != tt.want {
+				t.Errorf("CommitURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveScopeDir converts a directory given relative to current working directory
+// into a slash-separated path relative to repository root
+func (g *Operations) ResolveScopeDir(dir string) (string, error) {
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	info, err := os.Stat(absDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", dir)
+	}
+
+	root, err := filepath.EvalSymlinks(wt.Filesystem.Root())
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve repository root: %w", err)
+	}
+	absDir, err = filepath.EvalSymlinks(absDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	rel, err := filepath.Rel(root, absDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of repository", dir)
+	}
+
+	return normalizeScopeDir(filepath.ToSlash(rel)), nil
+}
+
+// normalizeScopeDir cleans up scope directory, repository root is represented by empty string
+func normalizeScopeDir(dir string) string {
+	dir = path.Clean(strings.TrimSpace(dir))
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return strings.TrimPrefix(dir, "./")
+}
+
+// isInScopeDir checks whether a repository-relative file path belongs to scope directory
+func isInScopeDir(file, scopeDir string) bool {
+	if scopeDir == "" {
+		return true
+	}
+	return file == scopeDir || strings.HasPrefix(file, scopeDir+"/")
+}
@@ -0,0 +1,46 @@
+package gitops
+
+import "testing"
+
+func TestNormalizeScopeDir(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "", expected: ""},
+		{input: ".", expected: ""},
+		{input: "./services/api", expected: "services/api"},
+		{input: "services/api/", expected: "services/api"},
+		{input: "services//api/../web", expected: "services/web"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := normalizeScopeDir(tt.input); result != tt.expected {
+				t.Errorf("normalizeScopeDir(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsInScopeDir(t *testing.T) {
+	tests := []struct {
+		file     string
+		scopeDir string
+		expected bool
+	}{
+		{file: "main.go", scopeDir: "", expected: true},
+		{file: "services/api/main.go", scopeDir: "services/api", expected: true},
+		{file: "services/api", scopeDir: "services/api", expected: true},
+		{file: "services/api-gateway/main.go", scopeDir: "services/api", expected: false},
+		{file: "services/web/main.go", scopeDir: "services/api", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file+"@"+tt.scopeDir, func(t *testing.T) {
+			if result := isInScopeDir(tt.file, tt.scopeDir); result != tt.expected {
+				t.Errorf("isInScopeDir(%q, %q) = %v, want %v", tt.file, tt.scopeDir, result, tt.expected)
+			}
+		})
+	}
+}